@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_approval_request is a generated GoMock package.
+package mock_approval_request
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateApprovalRequest mocks base method.
+func (m *MockRepository) CreateApprovalRequest(ctx context.Context, approval *entity.ApprovalRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApprovalRequest", ctx, approval)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApprovalRequest indicates an expected call of CreateApprovalRequest.
+func (mr *MockRepositoryMockRecorder) CreateApprovalRequest(ctx, approval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApprovalRequest", reflect.TypeOf((*MockRepository)(nil).CreateApprovalRequest), ctx, approval)
+}
+
+// GetApprovalRequestForUpdate mocks base method.
+func (m *MockRepository) GetApprovalRequestForUpdate(ctx context.Context, approvalRequestID int64) (*entity.ApprovalRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovalRequestForUpdate", ctx, approvalRequestID)
+	ret0, _ := ret[0].(*entity.ApprovalRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovalRequestForUpdate indicates an expected call of GetApprovalRequestForUpdate.
+func (mr *MockRepositoryMockRecorder) GetApprovalRequestForUpdate(ctx, approvalRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovalRequestForUpdate", reflect.TypeOf((*MockRepository)(nil).GetApprovalRequestForUpdate), ctx, approvalRequestID)
+}
+
+// GetListApprovalRequest mocks base method.
+func (m *MockRepository) GetListApprovalRequest(ctx context.Context, arg *domain.GetListApprovalRequest) ([]*entity.ApprovalRequest, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListApprovalRequest", ctx, arg)
+	ret0, _ := ret[0].([]*entity.ApprovalRequest)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListApprovalRequest indicates an expected call of GetListApprovalRequest.
+func (mr *MockRepositoryMockRecorder) GetListApprovalRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListApprovalRequest", reflect.TypeOf((*MockRepository)(nil).GetListApprovalRequest), ctx, arg)
+}
+
+// UpdateApprovalRequest mocks base method.
+func (m *MockRepository) UpdateApprovalRequest(ctx context.Context, approval *entity.ApprovalRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApprovalRequest", ctx, approval)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApprovalRequest indicates an expected call of UpdateApprovalRequest.
+func (mr *MockRepositoryMockRecorder) UpdateApprovalRequest(ctx, approval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApprovalRequest", reflect.TypeOf((*MockRepository)(nil).UpdateApprovalRequest), ctx, approval)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: payment_pin.go
+
+// Package mock_cache is a generated GoMock package.
+package mock_cache
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentPinAttemptInterface is a mock of PaymentPinAttemptInterface interface.
+type MockPaymentPinAttemptInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentPinAttemptInterfaceMockRecorder
+}
+
+// MockPaymentPinAttemptInterfaceMockRecorder is the mock recorder for MockPaymentPinAttemptInterface.
+type MockPaymentPinAttemptInterfaceMockRecorder struct {
+	mock *MockPaymentPinAttemptInterface
+}
+
+// NewMockPaymentPinAttemptInterface creates a new mock instance.
+func NewMockPaymentPinAttemptInterface(ctrl *gomock.Controller) *MockPaymentPinAttemptInterface {
+	mock := &MockPaymentPinAttemptInterface{ctrl: ctrl}
+	mock.recorder = &MockPaymentPinAttemptInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentPinAttemptInterface) EXPECT() *MockPaymentPinAttemptInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetFailures mocks base method.
+func (m *MockPaymentPinAttemptInterface) GetFailures(ctx context.Context, userID string) (int64, time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFailures", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFailures indicates an expected call of GetFailures.
+func (mr *MockPaymentPinAttemptInterfaceMockRecorder) GetFailures(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFailures", reflect.TypeOf((*MockPaymentPinAttemptInterface)(nil).GetFailures), ctx, userID)
+}
+
+// IncrFailures mocks base method.
+func (m *MockPaymentPinAttemptInterface) IncrFailures(ctx context.Context, userID string, window time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrFailures", ctx, userID, window)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrFailures indicates an expected call of IncrFailures.
+func (mr *MockPaymentPinAttemptInterfaceMockRecorder) IncrFailures(ctx, userID, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrFailures", reflect.TypeOf((*MockPaymentPinAttemptInterface)(nil).IncrFailures), ctx, userID, window)
+}
+
+// ResetFailures mocks base method.
+func (m *MockPaymentPinAttemptInterface) ResetFailures(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailures", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailures indicates an expected call of ResetFailures.
+func (mr *MockPaymentPinAttemptInterfaceMockRecorder) ResetFailures(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailures", reflect.TypeOf((*MockPaymentPinAttemptInterface)(nil).ResetFailures), ctx, userID)
+}
@@ -68,34 +68,49 @@ func (mr *MockRepositoryMockRecorder) ClaimNextLuckyShare(ctx, envelopeID interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNextLuckyShare", reflect.TypeOf((*MockRepository)(nil).ClaimNextLuckyShare), ctx, envelopeID)
 }
 
+// ClaimReservedShare mocks base method.
+func (m *MockRepository) ClaimReservedShare(ctx context.Context, envelopeID int64, userID string) (*entity.EnvelopeDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReservedShare", ctx, envelopeID, userID)
+	ret0, _ := ret[0].(*entity.EnvelopeDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReservedShare indicates an expected call of ClaimReservedShare.
+func (mr *MockRepositoryMockRecorder) ClaimReservedShare(ctx, envelopeID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReservedShare", reflect.TypeOf((*MockRepository)(nil).ClaimReservedShare), ctx, envelopeID, userID)
+}
+
 // CountClaimedEnvelope mocks base method.
-func (m *MockRepository) CountClaimedEnvelope(ctx context.Context, userID string, since time.Time) (int64, error) {
+func (m *MockRepository) CountClaimedEnvelope(ctx context.Context, userID string, now time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountClaimedEnvelope", ctx, userID, since)
+	ret := m.ctrl.Call(m, "CountClaimedEnvelope", ctx, userID, now)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountClaimedEnvelope indicates an expected call of CountClaimedEnvelope.
-func (mr *MockRepositoryMockRecorder) CountClaimedEnvelope(ctx, userID, since interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CountClaimedEnvelope(ctx, userID, now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClaimedEnvelope", reflect.TypeOf((*MockRepository)(nil).CountClaimedEnvelope), ctx, userID, since)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClaimedEnvelope", reflect.TypeOf((*MockRepository)(nil).CountClaimedEnvelope), ctx, userID, now)
 }
 
 // CountSentEnvelope mocks base method.
-func (m *MockRepository) CountSentEnvelope(ctx context.Context, userID string, since time.Time) (int64, error) {
+func (m *MockRepository) CountSentEnvelope(ctx context.Context, userID string, now time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountSentEnvelope", ctx, userID, since)
+	ret := m.ctrl.Call(m, "CountSentEnvelope", ctx, userID, now)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountSentEnvelope indicates an expected call of CountSentEnvelope.
-func (mr *MockRepositoryMockRecorder) CountSentEnvelope(ctx, userID, since interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CountSentEnvelope(ctx, userID, now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSentEnvelope", reflect.TypeOf((*MockRepository)(nil).CountSentEnvelope), ctx, userID, since)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSentEnvelope", reflect.TypeOf((*MockRepository)(nil).CountSentEnvelope), ctx, userID, now)
 }
 
 // CreateEnvelope mocks base method.
@@ -156,18 +171,18 @@ func (mr *MockRepositoryMockRecorder) FetchExpiredEnvelopes(ctx, ids interface{}
 }
 
 // GetAllEnvelopesByUserID mocks base method.
-func (m *MockRepository) GetAllEnvelopesByUserID(userID int64) ([]*entity.Envelope, error) {
+func (m *MockRepository) GetAllEnvelopesByUserID(ctx context.Context, userID int64) ([]*entity.Envelope, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllEnvelopesByUserID", userID)
+	ret := m.ctrl.Call(m, "GetAllEnvelopesByUserID", ctx, userID)
 	ret0, _ := ret[0].([]*entity.Envelope)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllEnvelopesByUserID indicates an expected call of GetAllEnvelopesByUserID.
-func (mr *MockRepositoryMockRecorder) GetAllEnvelopesByUserID(userID interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetAllEnvelopesByUserID(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllEnvelopesByUserID", reflect.TypeOf((*MockRepository)(nil).GetAllEnvelopesByUserID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllEnvelopesByUserID", reflect.TypeOf((*MockRepository)(nil).GetAllEnvelopesByUserID), ctx, userID)
 }
 
 // GetEnvelope mocks base method.
@@ -275,7 +290,7 @@ func (mr *MockRepositoryMockRecorder) LockEnvelopeByID(ctx, envelopeID interface
 }
 
 // RefundEnvelope mocks base method.
-func (m *MockRepository) RefundEnvelope(ctx context.Context, envelopeID int64, refundAmount float64) error {
+func (m *MockRepository) RefundEnvelope(ctx context.Context, envelopeID int64, refundAmount entity.Money) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RefundEnvelope", ctx, envelopeID, refundAmount)
 	ret0, _ := ret[0].(error)
@@ -303,7 +318,7 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, envelope, tx interface{}) *gom
 }
 
 // UpdateClaimedAmount mocks base method.
-func (m *MockRepository) UpdateClaimedAmount(ctx context.Context, envelopeID int64, amount float64) error {
+func (m *MockRepository) UpdateClaimedAmount(ctx context.Context, envelopeID int64, amount entity.Money) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateClaimedAmount", ctx, envelopeID, amount)
 	ret0, _ := ret[0].(error)
@@ -331,7 +346,7 @@ func (mr *MockRepositoryMockRecorder) UpdateEnvelopeDetail(ctx, detail, tx inter
 }
 
 // WithTransaction mocks base method.
-func (m *MockRepository) WithTransaction(ctx context.Context, fn func(envelope.Repository) error) error {
+func (m *MockRepository) WithTransaction(ctx context.Context, fn func(context.Context, envelope.Repository) error) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "WithTransaction", ctx, fn)
 	ret0, _ := ret[0].(error)
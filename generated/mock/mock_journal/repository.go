@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_journal is a generated GoMock package.
+package mock_journal
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	gorm "gorm.io/gorm"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateJournalEntry mocks base method.
+func (m *MockRepository) CreateJournalEntry(ctx context.Context, tx *gorm.DB, entry *entity.JournalEntry) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJournalEntry", ctx, tx, entry)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJournalEntry indicates an expected call of CreateJournalEntry.
+func (mr *MockRepositoryMockRecorder) CreateJournalEntry(ctx, tx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJournalEntry", reflect.TypeOf((*MockRepository)(nil).CreateJournalEntry), ctx, tx, entry)
+}
+
+// GetSystemAccountBalances mocks base method.
+func (m *MockRepository) GetSystemAccountBalances(ctx context.Context, currency entity.Currency) ([]*entity.SystemAccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSystemAccountBalances", ctx, currency)
+	ret0, _ := ret[0].([]*entity.SystemAccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSystemAccountBalances indicates an expected call of GetSystemAccountBalances.
+func (mr *MockRepositoryMockRecorder) GetSystemAccountBalances(ctx, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemAccountBalances", reflect.TypeOf((*MockRepository)(nil).GetSystemAccountBalances), ctx, currency)
+}
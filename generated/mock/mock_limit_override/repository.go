@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_limit_override is a generated GoMock package.
+package mock_limit_override
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLimitOverride mocks base method.
+func (m *MockRepository) GetLimitOverride(ctx context.Context, scope entity.LimitScope, scopeID string) (*entity.LimitOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimitOverride", ctx, scope, scopeID)
+	ret0, _ := ret[0].(*entity.LimitOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimitOverride indicates an expected call of GetLimitOverride.
+func (mr *MockRepositoryMockRecorder) GetLimitOverride(ctx, scope, scopeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitOverride", reflect.TypeOf((*MockRepository)(nil).GetLimitOverride), ctx, scope, scopeID)
+}
+
+// GetListLimitOverride mocks base method.
+func (m *MockRepository) GetListLimitOverride(ctx context.Context, arg *domain.GetListLimitOverrideRequest) ([]*entity.LimitOverride, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListLimitOverride", ctx, arg)
+	ret0, _ := ret[0].([]*entity.LimitOverride)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListLimitOverride indicates an expected call of GetListLimitOverride.
+func (mr *MockRepositoryMockRecorder) GetListLimitOverride(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListLimitOverride", reflect.TypeOf((*MockRepository)(nil).GetListLimitOverride), ctx, arg)
+}
+
+// UpsertLimitOverride mocks base method.
+func (m *MockRepository) UpsertLimitOverride(ctx context.Context, override *entity.LimitOverride) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertLimitOverride", ctx, override)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertLimitOverride indicates an expected call of UpsertLimitOverride.
+func (mr *MockRepositoryMockRecorder) UpsertLimitOverride(ctx, override interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLimitOverride", reflect.TypeOf((*MockRepository)(nil).UpsertLimitOverride), ctx, override)
+}
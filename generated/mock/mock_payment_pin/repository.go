@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_payment_pin is a generated GoMock package.
+package mock_payment_pin
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAudit mocks base method.
+func (m *MockRepository) CreateAudit(ctx context.Context, audit *entity.PaymentPinAudit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAudit", ctx, audit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAudit indicates an expected call of CreateAudit.
+func (mr *MockRepositoryMockRecorder) CreateAudit(ctx, audit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAudit", reflect.TypeOf((*MockRepository)(nil).CreateAudit), ctx, audit)
+}
+
+// CreatePaymentPin mocks base method.
+func (m *MockRepository) CreatePaymentPin(ctx context.Context, pin *entity.PaymentPin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentPin", ctx, pin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePaymentPin indicates an expected call of CreatePaymentPin.
+func (mr *MockRepositoryMockRecorder) CreatePaymentPin(ctx, pin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentPin", reflect.TypeOf((*MockRepository)(nil).CreatePaymentPin), ctx, pin)
+}
+
+// DeletePaymentPin mocks base method.
+func (m *MockRepository) DeletePaymentPin(ctx context.Context, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePaymentPin", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePaymentPin indicates an expected call of DeletePaymentPin.
+func (mr *MockRepositoryMockRecorder) DeletePaymentPin(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymentPin", reflect.TypeOf((*MockRepository)(nil).DeletePaymentPin), ctx, userID)
+}
+
+// GetListAudit mocks base method.
+func (m *MockRepository) GetListAudit(ctx context.Context, arg *domain.GetListPaymentPinAuditRequest) ([]*entity.PaymentPinAudit, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListAudit", ctx, arg)
+	ret0, _ := ret[0].([]*entity.PaymentPinAudit)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListAudit indicates an expected call of GetListAudit.
+func (mr *MockRepositoryMockRecorder) GetListAudit(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListAudit", reflect.TypeOf((*MockRepository)(nil).GetListAudit), ctx, arg)
+}
+
+// GetPaymentPin mocks base method.
+func (m *MockRepository) GetPaymentPin(ctx context.Context, userID string) (*entity.PaymentPin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentPin", ctx, userID)
+	ret0, _ := ret[0].(*entity.PaymentPin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentPin indicates an expected call of GetPaymentPin.
+func (mr *MockRepositoryMockRecorder) GetPaymentPin(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentPin", reflect.TypeOf((*MockRepository)(nil).GetPaymentPin), ctx, userID)
+}
+
+// UpdatePaymentPinHash mocks base method.
+func (m *MockRepository) UpdatePaymentPinHash(ctx context.Context, userID, pinHash, operatedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentPinHash", ctx, userID, pinHash, operatedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentPinHash indicates an expected call of UpdatePaymentPinHash.
+func (mr *MockRepositoryMockRecorder) UpdatePaymentPinHash(ctx, userID, pinHash, operatedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentPinHash", reflect.TypeOf((*MockRepository)(nil).UpdatePaymentPinHash), ctx, userID, pinHash, operatedBy)
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_payment_request is a generated GoMock package.
+package mock_payment_request
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePaymentRequest mocks base method.
+func (m *MockRepository) CreatePaymentRequest(ctx context.Context, request *entity.PaymentRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentRequest", ctx, request)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentRequest indicates an expected call of CreatePaymentRequest.
+func (mr *MockRepositoryMockRecorder) CreatePaymentRequest(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentRequest", reflect.TypeOf((*MockRepository)(nil).CreatePaymentRequest), ctx, request)
+}
+
+// ExpirePaymentRequests mocks base method.
+func (m *MockRepository) ExpirePaymentRequests(ctx context.Context, now time.Time, operatedBy string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePaymentRequests", ctx, now, operatedBy)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePaymentRequests indicates an expected call of ExpirePaymentRequests.
+func (mr *MockRepositoryMockRecorder) ExpirePaymentRequests(ctx, now, operatedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePaymentRequests", reflect.TypeOf((*MockRepository)(nil).ExpirePaymentRequests), ctx, now, operatedBy)
+}
+
+// GetListPaymentRequest mocks base method.
+func (m *MockRepository) GetListPaymentRequest(ctx context.Context, arg *domain.GetListPaymentRequestRequest) ([]*entity.PaymentRequest, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListPaymentRequest", ctx, arg)
+	ret0, _ := ret[0].([]*entity.PaymentRequest)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListPaymentRequest indicates an expected call of GetListPaymentRequest.
+func (mr *MockRepositoryMockRecorder) GetListPaymentRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListPaymentRequest", reflect.TypeOf((*MockRepository)(nil).GetListPaymentRequest), ctx, arg)
+}
+
+// GetPaymentRequestForUpdate mocks base method.
+func (m *MockRepository) GetPaymentRequestForUpdate(ctx context.Context, paymentRequestID int64) (*entity.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentRequestForUpdate", ctx, paymentRequestID)
+	ret0, _ := ret[0].(*entity.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentRequestForUpdate indicates an expected call of GetPaymentRequestForUpdate.
+func (mr *MockRepositoryMockRecorder) GetPaymentRequestForUpdate(ctx, paymentRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentRequestForUpdate", reflect.TypeOf((*MockRepository)(nil).GetPaymentRequestForUpdate), ctx, paymentRequestID)
+}
+
+// UpdatePaymentRequest mocks base method.
+func (m *MockRepository) UpdatePaymentRequest(ctx context.Context, request *entity.PaymentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentRequest", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentRequest indicates an expected call of UpdatePaymentRequest.
+func (mr *MockRepositoryMockRecorder) UpdatePaymentRequest(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentRequest", reflect.TypeOf((*MockRepository)(nil).UpdatePaymentRequest), ctx, request)
+}
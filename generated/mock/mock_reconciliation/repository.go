@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_reconciliation is a generated GoMock package.
+package mock_reconciliation
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDiscrepancies mocks base method.
+func (m *MockRepository) CreateDiscrepancies(ctx context.Context, discrepancies []*entity.ReconciliationDiscrepancy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDiscrepancies", ctx, discrepancies)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDiscrepancies indicates an expected call of CreateDiscrepancies.
+func (mr *MockRepositoryMockRecorder) CreateDiscrepancies(ctx, discrepancies interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiscrepancies", reflect.TypeOf((*MockRepository)(nil).CreateDiscrepancies), ctx, discrepancies)
+}
+
+// CreateRun mocks base method.
+func (m *MockRepository) CreateRun(ctx context.Context, run *entity.ReconciliationRun) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", ctx, run)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockRepositoryMockRecorder) CreateRun(ctx, run interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockRepository)(nil).CreateRun), ctx, run)
+}
+
+// GetBalanceChainBreaks mocks base method.
+func (m *MockRepository) GetBalanceChainBreaks(ctx context.Context, walletIDs []int64) ([]*entity.BalanceChainBreak, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceChainBreaks", ctx, walletIDs)
+	ret0, _ := ret[0].([]*entity.BalanceChainBreak)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceChainBreaks indicates an expected call of GetBalanceChainBreaks.
+func (mr *MockRepositoryMockRecorder) GetBalanceChainBreaks(ctx, walletIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceChainBreaks", reflect.TypeOf((*MockRepository)(nil).GetBalanceChainBreaks), ctx, walletIDs)
+}
+
+// GetEnvelopeClaimSums mocks base method.
+func (m *MockRepository) GetEnvelopeClaimSums(ctx context.Context, afterEnvelopeID int64, limit int) ([]*entity.EnvelopeClaimSum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvelopeClaimSums", ctx, afterEnvelopeID, limit)
+	ret0, _ := ret[0].([]*entity.EnvelopeClaimSum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnvelopeClaimSums indicates an expected call of GetEnvelopeClaimSums.
+func (mr *MockRepositoryMockRecorder) GetEnvelopeClaimSums(ctx, afterEnvelopeID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvelopeClaimSums", reflect.TypeOf((*MockRepository)(nil).GetEnvelopeClaimSums), ctx, afterEnvelopeID, limit)
+}
+
+// GetListDiscrepancy mocks base method.
+func (m *MockRepository) GetListDiscrepancy(ctx context.Context, req *domain.GetReconciliationRequest) ([]*entity.ReconciliationDiscrepancy, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListDiscrepancy", ctx, req)
+	ret0, _ := ret[0].([]*entity.ReconciliationDiscrepancy)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListDiscrepancy indicates an expected call of GetListDiscrepancy.
+func (mr *MockRepositoryMockRecorder) GetListDiscrepancy(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListDiscrepancy", reflect.TypeOf((*MockRepository)(nil).GetListDiscrepancy), ctx, req)
+}
+
+// GetRun mocks base method.
+func (m *MockRepository) GetRun(ctx context.Context, runID int64) (*entity.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRun", ctx, runID)
+	ret0, _ := ret[0].(*entity.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRun indicates an expected call of GetRun.
+func (mr *MockRepositoryMockRecorder) GetRun(ctx, runID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRun", reflect.TypeOf((*MockRepository)(nil).GetRun), ctx, runID)
+}
+
+// GetWalletBalanceSums mocks base method.
+func (m *MockRepository) GetWalletBalanceSums(ctx context.Context, afterWalletID int64, limit int) ([]*entity.WalletBalanceSum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletBalanceSums", ctx, afterWalletID, limit)
+	ret0, _ := ret[0].([]*entity.WalletBalanceSum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletBalanceSums indicates an expected call of GetWalletBalanceSums.
+func (mr *MockRepositoryMockRecorder) GetWalletBalanceSums(ctx, afterWalletID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletBalanceSums", reflect.TypeOf((*MockRepository)(nil).GetWalletBalanceSums), ctx, afterWalletID, limit)
+}
+
+// UpdateRun mocks base method.
+func (m *MockRepository) UpdateRun(ctx context.Context, run *entity.ReconciliationRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRun indicates an expected call of UpdateRun.
+func (mr *MockRepositoryMockRecorder) UpdateRun(ctx, run interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRun", reflect.TypeOf((*MockRepository)(nil).UpdateRun), ctx, run)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_recurring_transfer is a generated GoMock package.
+package mock_recurring_transfer
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRecurringTransfer mocks base method.
+func (m *MockRepository) CreateRecurringTransfer(ctx context.Context, recurring *entity.RecurringTransfer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurringTransfer", ctx, recurring)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurringTransfer indicates an expected call of CreateRecurringTransfer.
+func (mr *MockRepositoryMockRecorder) CreateRecurringTransfer(ctx, recurring interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurringTransfer", reflect.TypeOf((*MockRepository)(nil).CreateRecurringTransfer), ctx, recurring)
+}
+
+// CreateRun mocks base method.
+func (m *MockRepository) CreateRun(ctx context.Context, run *entity.RecurringTransferRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockRepositoryMockRecorder) CreateRun(ctx, run interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockRepository)(nil).CreateRun), ctx, run)
+}
+
+// FetchDueRecurringTransfers mocks base method.
+func (m *MockRepository) FetchDueRecurringTransfers(ctx context.Context, now time.Time) ([]*entity.RecurringTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDueRecurringTransfers", ctx, now)
+	ret0, _ := ret[0].([]*entity.RecurringTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDueRecurringTransfers indicates an expected call of FetchDueRecurringTransfers.
+func (mr *MockRepositoryMockRecorder) FetchDueRecurringTransfers(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDueRecurringTransfers", reflect.TypeOf((*MockRepository)(nil).FetchDueRecurringTransfers), ctx, now)
+}
+
+// GetListRecurringTransfer mocks base method.
+func (m *MockRepository) GetListRecurringTransfer(ctx context.Context, arg *domain.GetListRecurringTransferRequest) ([]*entity.RecurringTransfer, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListRecurringTransfer", ctx, arg)
+	ret0, _ := ret[0].([]*entity.RecurringTransfer)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListRecurringTransfer indicates an expected call of GetListRecurringTransfer.
+func (mr *MockRepositoryMockRecorder) GetListRecurringTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListRecurringTransfer", reflect.TypeOf((*MockRepository)(nil).GetListRecurringTransfer), ctx, arg)
+}
+
+// GetListRun mocks base method.
+func (m *MockRepository) GetListRun(ctx context.Context, arg *domain.GetListRecurringTransferRunRequest) ([]*entity.RecurringTransferRun, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListRun", ctx, arg)
+	ret0, _ := ret[0].([]*entity.RecurringTransferRun)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListRun indicates an expected call of GetListRun.
+func (mr *MockRepositoryMockRecorder) GetListRun(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListRun", reflect.TypeOf((*MockRepository)(nil).GetListRun), ctx, arg)
+}
+
+// GetRecurringTransfer mocks base method.
+func (m *MockRepository) GetRecurringTransfer(ctx context.Context, recurringTransferID int64) (*entity.RecurringTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringTransfer", ctx, recurringTransferID)
+	ret0, _ := ret[0].(*entity.RecurringTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringTransfer indicates an expected call of GetRecurringTransfer.
+func (mr *MockRepositoryMockRecorder) GetRecurringTransfer(ctx, recurringTransferID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringTransfer", reflect.TypeOf((*MockRepository)(nil).GetRecurringTransfer), ctx, recurringTransferID)
+}
+
+// GetRecurringTransferForUpdate mocks base method.
+func (m *MockRepository) GetRecurringTransferForUpdate(ctx context.Context, recurringTransferID int64) (*entity.RecurringTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringTransferForUpdate", ctx, recurringTransferID)
+	ret0, _ := ret[0].(*entity.RecurringTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringTransferForUpdate indicates an expected call of GetRecurringTransferForUpdate.
+func (mr *MockRepositoryMockRecorder) GetRecurringTransferForUpdate(ctx, recurringTransferID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringTransferForUpdate", reflect.TypeOf((*MockRepository)(nil).GetRecurringTransferForUpdate), ctx, recurringTransferID)
+}
+
+// UpdateRecurringTransfer mocks base method.
+func (m *MockRepository) UpdateRecurringTransfer(ctx context.Context, recurring *entity.RecurringTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRecurringTransfer", ctx, recurring)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRecurringTransfer indicates an expected call of UpdateRecurringTransfer.
+func (mr *MockRepositoryMockRecorder) UpdateRecurringTransfer(ctx, recurring interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecurringTransfer", reflect.TypeOf((*MockRepository)(nil).UpdateRecurringTransfer), ctx, recurring)
+}
@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_risk_decision is a generated GoMock package.
+package mock_risk_decision
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountDeviceUsersSince mocks base method.
+func (m *MockRepository) CountDeviceUsersSince(ctx context.Context, operations []entity.RiskOperation, deviceID, userID string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDeviceUsersSince", ctx, operations, deviceID, userID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDeviceUsersSince indicates an expected call of CountDeviceUsersSince.
+func (mr *MockRepositoryMockRecorder) CountDeviceUsersSince(ctx, operations, deviceID, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeviceUsersSince", reflect.TypeOf((*MockRepository)(nil).CountDeviceUsersSince), ctx, operations, deviceID, userID, since)
+}
+
+// CountPairDecisionsSince mocks base method.
+func (m *MockRepository) CountPairDecisionsSince(ctx context.Context, operation entity.RiskOperation, userID, counterpartyID string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPairDecisionsSince", ctx, operation, userID, counterpartyID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPairDecisionsSince indicates an expected call of CountPairDecisionsSince.
+func (mr *MockRepositoryMockRecorder) CountPairDecisionsSince(ctx, operation, userID, counterpartyID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPairDecisionsSince", reflect.TypeOf((*MockRepository)(nil).CountPairDecisionsSince), ctx, operation, userID, counterpartyID, since)
+}
+
+// CountUserDecisionsSince mocks base method.
+func (m *MockRepository) CountUserDecisionsSince(ctx context.Context, userID string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserDecisionsSince", ctx, userID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserDecisionsSince indicates an expected call of CountUserDecisionsSince.
+func (mr *MockRepositoryMockRecorder) CountUserDecisionsSince(ctx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserDecisionsSince", reflect.TypeOf((*MockRepository)(nil).CountUserDecisionsSince), ctx, userID, since)
+}
+
+// CreateRiskDecision mocks base method.
+func (m *MockRepository) CreateRiskDecision(ctx context.Context, decision *entity.RiskDecision) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRiskDecision", ctx, decision)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRiskDecision indicates an expected call of CreateRiskDecision.
+func (mr *MockRepositoryMockRecorder) CreateRiskDecision(ctx, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRiskDecision", reflect.TypeOf((*MockRepository)(nil).CreateRiskDecision), ctx, decision)
+}
+
+// GetListRiskDecision mocks base method.
+func (m *MockRepository) GetListRiskDecision(ctx context.Context, arg *domain.GetListRiskDecisionRequest) ([]*entity.RiskDecision, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListRiskDecision", ctx, arg)
+	ret0, _ := ret[0].([]*entity.RiskDecision)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListRiskDecision indicates an expected call of GetListRiskDecision.
+func (mr *MockRepositoryMockRecorder) GetListRiskDecision(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListRiskDecision", reflect.TypeOf((*MockRepository)(nil).GetListRiskDecision), ctx, arg)
+}
+
+// GetRiskDecisionsByReference mocks base method.
+func (m *MockRepository) GetRiskDecisionsByReference(ctx context.Context, operations []entity.RiskOperation, referenceIDs []int64) ([]*entity.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiskDecisionsByReference", ctx, operations, referenceIDs)
+	ret0, _ := ret[0].([]*entity.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiskDecisionsByReference indicates an expected call of GetRiskDecisionsByReference.
+func (mr *MockRepositoryMockRecorder) GetRiskDecisionsByReference(ctx, operations, referenceIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiskDecisionsByReference", reflect.TypeOf((*MockRepository)(nil).GetRiskDecisionsByReference), ctx, operations, referenceIDs)
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_scheduled_transfer is a generated GoMock package.
+package mock_scheduled_transfer
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateScheduledTransfer mocks base method.
+func (m *MockRepository) CreateScheduledTransfer(ctx context.Context, scheduled *entity.ScheduledTransfer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledTransfer", ctx, scheduled)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledTransfer indicates an expected call of CreateScheduledTransfer.
+func (mr *MockRepositoryMockRecorder) CreateScheduledTransfer(ctx, scheduled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransfer", reflect.TypeOf((*MockRepository)(nil).CreateScheduledTransfer), ctx, scheduled)
+}
+
+// FetchDueScheduledTransfers mocks base method.
+func (m *MockRepository) FetchDueScheduledTransfers(ctx context.Context, now time.Time) ([]*entity.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDueScheduledTransfers", ctx, now)
+	ret0, _ := ret[0].([]*entity.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDueScheduledTransfers indicates an expected call of FetchDueScheduledTransfers.
+func (mr *MockRepositoryMockRecorder) FetchDueScheduledTransfers(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDueScheduledTransfers", reflect.TypeOf((*MockRepository)(nil).FetchDueScheduledTransfers), ctx, now)
+}
+
+// GetListScheduledTransfer mocks base method.
+func (m *MockRepository) GetListScheduledTransfer(ctx context.Context, arg *domain.GetListScheduledTransferRequest) ([]*entity.ScheduledTransfer, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListScheduledTransfer", ctx, arg)
+	ret0, _ := ret[0].([]*entity.ScheduledTransfer)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListScheduledTransfer indicates an expected call of GetListScheduledTransfer.
+func (mr *MockRepositoryMockRecorder) GetListScheduledTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListScheduledTransfer", reflect.TypeOf((*MockRepository)(nil).GetListScheduledTransfer), ctx, arg)
+}
+
+// GetScheduledTransferForUpdate mocks base method.
+func (m *MockRepository) GetScheduledTransferForUpdate(ctx context.Context, scheduledTransferID int64) (*entity.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransferForUpdate", ctx, scheduledTransferID)
+	ret0, _ := ret[0].(*entity.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransferForUpdate indicates an expected call of GetScheduledTransferForUpdate.
+func (mr *MockRepositoryMockRecorder) GetScheduledTransferForUpdate(ctx, scheduledTransferID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransferForUpdate", reflect.TypeOf((*MockRepository)(nil).GetScheduledTransferForUpdate), ctx, scheduledTransferID)
+}
+
+// UpdateScheduledTransfer mocks base method.
+func (m *MockRepository) UpdateScheduledTransfer(ctx context.Context, scheduled *entity.ScheduledTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledTransfer", ctx, scheduled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScheduledTransfer indicates an expected call of UpdateScheduledTransfer.
+func (mr *MockRepositoryMockRecorder) UpdateScheduledTransfer(ctx, scheduled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransfer", reflect.TypeOf((*MockRepository)(nil).UpdateScheduledTransfer), ctx, scheduled)
+}
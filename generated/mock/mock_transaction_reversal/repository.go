@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_transaction_reversal is a generated GoMock package.
+package mock_transaction_reversal
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateReversal mocks base method.
+func (m *MockRepository) CreateReversal(ctx context.Context, reversal *entity.TransactionReversal) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReversal", ctx, reversal)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReversal indicates an expected call of CreateReversal.
+func (mr *MockRepositoryMockRecorder) CreateReversal(ctx, reversal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReversal", reflect.TypeOf((*MockRepository)(nil).CreateReversal), ctx, reversal)
+}
+
+// IsReversed mocks base method.
+func (m *MockRepository) IsReversed(ctx context.Context, walletTransactionID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReversed", ctx, walletTransactionID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsReversed indicates an expected call of IsReversed.
+func (mr *MockRepositoryMockRecorder) IsReversed(ctx, walletTransactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReversed", reflect.TypeOf((*MockRepository)(nil).IsReversed), ctx, walletTransactionID)
+}
@@ -38,47 +38,33 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 }
 
 // CountClaimedTransferInDay mocks base method.
-func (m *MockRepository) CountClaimedTransferInDay(ctx context.Context, userID string, since time.Time) (int64, error) {
+func (m *MockRepository) CountClaimedTransferInDay(ctx context.Context, userID string, now time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountClaimedTransferInDay", ctx, userID, since)
+	ret := m.ctrl.Call(m, "CountClaimedTransferInDay", ctx, userID, now)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountClaimedTransferInDay indicates an expected call of CountClaimedTransferInDay.
-func (mr *MockRepositoryMockRecorder) CountClaimedTransferInDay(ctx, userID, since interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CountClaimedTransferInDay(ctx, userID, now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClaimedTransferInDay", reflect.TypeOf((*MockRepository)(nil).CountClaimedTransferInDay), ctx, userID, since)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountClaimedTransferInDay", reflect.TypeOf((*MockRepository)(nil).CountClaimedTransferInDay), ctx, userID, now)
 }
 
 // CountSentTransferInDay mocks base method.
-func (m *MockRepository) CountSentTransferInDay(ctx context.Context, userID string, since time.Time) (int64, error) {
+func (m *MockRepository) CountSentTransferInDay(ctx context.Context, userID string, now time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountSentTransferInDay", ctx, userID, since)
+	ret := m.ctrl.Call(m, "CountSentTransferInDay", ctx, userID, now)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountSentTransferInDay indicates an expected call of CountSentTransferInDay.
-func (mr *MockRepositoryMockRecorder) CountSentTransferInDay(ctx, userID, since interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CountSentTransferInDay(ctx, userID, now interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSentTransferInDay", reflect.TypeOf((*MockRepository)(nil).CountSentTransferInDay), ctx, userID, since)
-}
-
-// Create mocks base method.
-func (m *MockRepository) Create(transfer *entity.Transfer) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", transfer)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Create indicates an expected call of Create.
-func (mr *MockRepositoryMockRecorder) Create(transfer interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), transfer)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSentTransferInDay", reflect.TypeOf((*MockRepository)(nil).CountSentTransferInDay), ctx, userID, now)
 }
 
 // CreateTransfer mocks base method.
@@ -111,21 +97,6 @@ func (mr *MockRepositoryMockRecorder) FetchExpiredTransfers(ctx, ids interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchExpiredTransfers", reflect.TypeOf((*MockRepository)(nil).FetchExpiredTransfers), ctx, ids)
 }
 
-// FindAllTransferByWalletID mocks base method.
-func (m *MockRepository) FindAllTransferByWalletID(walletID string) ([]entity.Transfer, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllTransferByWalletID", walletID)
-	ret0, _ := ret[0].([]entity.Transfer)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// FindAllTransferByWalletID indicates an expected call of FindAllTransferByWalletID.
-func (mr *MockRepositoryMockRecorder) FindAllTransferByWalletID(walletID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllTransferByWalletID", reflect.TypeOf((*MockRepository)(nil).FindAllTransferByWalletID), walletID)
-}
-
 // FindByTransferID mocks base method.
 func (m *MockRepository) FindByTransferID(ctx context.Context, transferID int64) (*entity.Transfer, error) {
 	m.ctrl.T.Helper()
@@ -186,6 +157,21 @@ func (mr *MockRepositoryMockRecorder) GetEligibleRefundTransfer(ctx, transferID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEligibleRefundTransfer", reflect.TypeOf((*MockRepository)(nil).GetEligibleRefundTransfer), ctx, transferID, userID)
 }
 
+// GetTransferForUpdate mocks base method.
+func (m *MockRepository) GetTransferForUpdate(ctx context.Context, transferID int64) (*entity.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferForUpdate", ctx, transferID)
+	ret0, _ := ret[0].(*entity.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferForUpdate indicates an expected call of GetTransferForUpdate.
+func (mr *MockRepositoryMockRecorder) GetTransferForUpdate(ctx, transferID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferForUpdate", reflect.TypeOf((*MockRepository)(nil).GetTransferForUpdate), ctx, transferID)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, transfer *entity.Transfer, tx *gorm.DB) error {
 	m.ctrl.T.Helper()
@@ -199,3 +185,18 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, transfer, tx interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, transfer, tx)
 }
+
+// UpdateStatusTransfer mocks base method.
+func (m *MockRepository) UpdateStatusTransfer(ctx context.Context, transferID int64, from, to entity.StatusTransfer, operatedBy string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusTransfer", ctx, transferID, from, to, operatedBy)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatusTransfer indicates an expected call of UpdateStatusTransfer.
+func (mr *MockRepositoryMockRecorder) UpdateStatusTransfer(ctx, transferID, from, to, operatedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusTransfer", reflect.TypeOf((*MockRepository)(nil).UpdateStatusTransfer), ctx, transferID, from, to, operatedBy)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_transfer_batch is a generated GoMock package.
+package mock_transfer_batch
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTransferBatch mocks base method.
+func (m *MockRepository) CreateTransferBatch(ctx context.Context, batch *entity.TransferBatch) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferBatch", ctx, batch)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferBatch indicates an expected call of CreateTransferBatch.
+func (mr *MockRepositoryMockRecorder) CreateTransferBatch(ctx, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferBatch", reflect.TypeOf((*MockRepository)(nil).CreateTransferBatch), ctx, batch)
+}
+
+// CreateTransferBatchItem mocks base method.
+func (m *MockRepository) CreateTransferBatchItem(ctx context.Context, item *entity.TransferBatchItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferBatchItem", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTransferBatchItem indicates an expected call of CreateTransferBatchItem.
+func (mr *MockRepositoryMockRecorder) CreateTransferBatchItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferBatchItem", reflect.TypeOf((*MockRepository)(nil).CreateTransferBatchItem), ctx, item)
+}
+
+// GetListTransferBatchItem mocks base method.
+func (m *MockRepository) GetListTransferBatchItem(ctx context.Context, transferBatchID int64) ([]*entity.TransferBatchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListTransferBatchItem", ctx, transferBatchID)
+	ret0, _ := ret[0].([]*entity.TransferBatchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListTransferBatchItem indicates an expected call of GetListTransferBatchItem.
+func (mr *MockRepositoryMockRecorder) GetListTransferBatchItem(ctx, transferBatchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListTransferBatchItem", reflect.TypeOf((*MockRepository)(nil).GetListTransferBatchItem), ctx, transferBatchID)
+}
+
+// GetTransferBatch mocks base method.
+func (m *MockRepository) GetTransferBatch(ctx context.Context, transferBatchID int64) (*entity.TransferBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferBatch", ctx, transferBatchID)
+	ret0, _ := ret[0].(*entity.TransferBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferBatch indicates an expected call of GetTransferBatch.
+func (mr *MockRepositoryMockRecorder) GetTransferBatch(ctx, transferBatchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferBatch", reflect.TypeOf((*MockRepository)(nil).GetTransferBatch), ctx, transferBatchID)
+}
+
+// UpdateTransferBatch mocks base method.
+func (m *MockRepository) UpdateTransferBatch(ctx context.Context, batch *entity.TransferBatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferBatch", ctx, batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTransferBatch indicates an expected call of UpdateTransferBatch.
+func (mr *MockRepositoryMockRecorder) UpdateTransferBatch(ctx, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferBatch", reflect.TypeOf((*MockRepository)(nil).UpdateTransferBatch), ctx, batch)
+}
@@ -36,7 +36,7 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 }
 
 // Do mocks base method.
-func (m *MockRepository) Do(ctx context.Context, fn func(*gorm.DB) error) error {
+func (m *MockRepository) Do(ctx context.Context, fn func(context.Context, *gorm.DB) error) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Do", ctx, fn)
 	ret0, _ := ret[0].(error)
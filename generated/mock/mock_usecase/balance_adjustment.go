@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: balance_adjustment.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBalanceAdjustmentSvc is a mock of BalanceAdjustmentSvc interface.
+type MockBalanceAdjustmentSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceAdjustmentSvcMockRecorder
+}
+
+// MockBalanceAdjustmentSvcMockRecorder is the mock recorder for MockBalanceAdjustmentSvc.
+type MockBalanceAdjustmentSvcMockRecorder struct {
+	mock *MockBalanceAdjustmentSvc
+}
+
+// NewMockBalanceAdjustmentSvc creates a new mock instance.
+func NewMockBalanceAdjustmentSvc(ctrl *gomock.Controller) *MockBalanceAdjustmentSvc {
+	mock := &MockBalanceAdjustmentSvc{ctrl: ctrl}
+	mock.recorder = &MockBalanceAdjustmentSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceAdjustmentSvc) EXPECT() *MockBalanceAdjustmentSvcMockRecorder {
+	return m.recorder
+}
+
+// BalanceAdjustmentByAdmin mocks base method.
+func (m *MockBalanceAdjustmentSvc) BalanceAdjustmentByAdmin(ctx context.Context, arg *domain.BalanceAdjustmentByAdminRequest) (*domain.BalanceAdjustmentByAdminResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceAdjustmentByAdmin", ctx, arg)
+	ret0, _ := ret[0].(*domain.BalanceAdjustmentByAdminResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceAdjustmentByAdmin indicates an expected call of BalanceAdjustmentByAdmin.
+func (mr *MockBalanceAdjustmentSvcMockRecorder) BalanceAdjustmentByAdmin(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAdjustmentByAdmin", reflect.TypeOf((*MockBalanceAdjustmentSvc)(nil).BalanceAdjustmentByAdmin), ctx, arg)
+}
+
+// GetListBalanceAdjustment mocks base method.
+func (m *MockBalanceAdjustmentSvc) GetListBalanceAdjustment(ctx context.Context, arg *domain.GetListbalanceAjustmentRequest) (*domain.GetListBalanceAdjustmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListBalanceAdjustment", ctx, arg)
+	ret0, _ := ret[0].(*domain.GetListBalanceAdjustmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListBalanceAdjustment indicates an expected call of GetListBalanceAdjustment.
+func (mr *MockBalanceAdjustmentSvcMockRecorder) GetListBalanceAdjustment(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListBalanceAdjustment", reflect.TypeOf((*MockBalanceAdjustmentSvc)(nil).GetListBalanceAdjustment), ctx, arg)
+}
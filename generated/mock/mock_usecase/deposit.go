@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deposit.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWalletRechargeRequestSvc is a mock of WalletRechargeRequestSvc interface.
+type MockWalletRechargeRequestSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletRechargeRequestSvcMockRecorder
+}
+
+// MockWalletRechargeRequestSvcMockRecorder is the mock recorder for MockWalletRechargeRequestSvc.
+type MockWalletRechargeRequestSvcMockRecorder struct {
+	mock *MockWalletRechargeRequestSvc
+}
+
+// NewMockWalletRechargeRequestSvc creates a new mock instance.
+func NewMockWalletRechargeRequestSvc(ctrl *gomock.Controller) *MockWalletRechargeRequestSvc {
+	mock := &MockWalletRechargeRequestSvc{ctrl: ctrl}
+	mock.recorder = &MockWalletRechargeRequestSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletRechargeRequestSvc) EXPECT() *MockWalletRechargeRequestSvcMockRecorder {
+	return m.recorder
+}
+
+// ApproveDeposit mocks base method.
+func (m *MockWalletRechargeRequestSvc) ApproveDeposit(ctx context.Context, req *domain.ProcessDepositRequest) (*domain.DepositResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveDeposit", ctx, req)
+	ret0, _ := ret[0].(*domain.DepositResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveDeposit indicates an expected call of ApproveDeposit.
+func (mr *MockWalletRechargeRequestSvcMockRecorder) ApproveDeposit(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveDeposit", reflect.TypeOf((*MockWalletRechargeRequestSvc)(nil).ApproveDeposit), ctx, req)
+}
+
+// GetListDeposit mocks base method.
+func (m *MockWalletRechargeRequestSvc) GetListDeposit(ctx context.Context, arg *domain.GetListDepositRequest) (*domain.GetListDepositResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListDeposit", ctx, arg)
+	ret0, _ := ret[0].(*domain.GetListDepositResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListDeposit indicates an expected call of GetListDeposit.
+func (mr *MockWalletRechargeRequestSvcMockRecorder) GetListDeposit(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListDeposit", reflect.TypeOf((*MockWalletRechargeRequestSvc)(nil).GetListDeposit), ctx, arg)
+}
+
+// ProcessDepositByAdmin mocks base method.
+func (m *MockWalletRechargeRequestSvc) ProcessDepositByAdmin(ctx context.Context, arg *domain.ProcessDepositByAdminRequest) (*domain.ProcessDepositByAdminResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDepositByAdmin", ctx, arg)
+	ret0, _ := ret[0].(*domain.ProcessDepositByAdminResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessDepositByAdmin indicates an expected call of ProcessDepositByAdmin.
+func (mr *MockWalletRechargeRequestSvcMockRecorder) ProcessDepositByAdmin(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDepositByAdmin", reflect.TypeOf((*MockWalletRechargeRequestSvc)(nil).ProcessDepositByAdmin), ctx, arg)
+}
+
+// RejectDeposit mocks base method.
+func (m *MockWalletRechargeRequestSvc) RejectDeposit(ctx context.Context, req *domain.ProcessDepositRequest) (*domain.DepositResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectDeposit", ctx, req)
+	ret0, _ := ret[0].(*domain.DepositResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectDeposit indicates an expected call of RejectDeposit.
+func (mr *MockWalletRechargeRequestSvcMockRecorder) RejectDeposit(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectDeposit", reflect.TypeOf((*MockWalletRechargeRequestSvc)(nil).RejectDeposit), ctx, req)
+}
+
+// RequestDeposit mocks base method.
+func (m *MockWalletRechargeRequestSvc) RequestDeposit(ctx context.Context, req *domain.WalletRechargeRequestCreate) (*domain.DepositResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDeposit", ctx, req)
+	ret0, _ := ret[0].(*domain.DepositResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestDeposit indicates an expected call of RequestDeposit.
+func (mr *MockWalletRechargeRequestSvcMockRecorder) RequestDeposit(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDeposit", reflect.TypeOf((*MockWalletRechargeRequestSvc)(nil).RequestDeposit), ctx, req)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: limit_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLimitSvc is a mock of LimitSvc interface.
+type MockLimitSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitSvcMockRecorder
+}
+
+// MockLimitSvcMockRecorder is the mock recorder for MockLimitSvc.
+type MockLimitSvcMockRecorder struct {
+	mock *MockLimitSvc
+}
+
+// NewMockLimitSvc creates a new mock instance.
+func NewMockLimitSvc(ctrl *gomock.Controller) *MockLimitSvc {
+	mock := &MockLimitSvc{ctrl: ctrl}
+	mock.recorder = &MockLimitSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitSvc) EXPECT() *MockLimitSvcMockRecorder {
+	return m.recorder
+}
+
+// CheckSpendLimits mocks base method.
+func (m *MockLimitSvc) CheckSpendLimits(ctx context.Context, userID string, walletID int64, amount entity.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSpendLimits", ctx, userID, walletID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSpendLimits indicates an expected call of CheckSpendLimits.
+func (mr *MockLimitSvcMockRecorder) CheckSpendLimits(ctx, userID, walletID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSpendLimits", reflect.TypeOf((*MockLimitSvc)(nil).CheckSpendLimits), ctx, userID, walletID, amount)
+}
+
+// GetLimits mocks base method.
+func (m *MockLimitSvc) GetLimits(ctx context.Context, req *domain.GetLimitsRequest) (*domain.GetLimitsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimits", ctx, req)
+	ret0, _ := ret[0].(*domain.GetLimitsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimits indicates an expected call of GetLimits.
+func (mr *MockLimitSvcMockRecorder) GetLimits(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimits", reflect.TypeOf((*MockLimitSvc)(nil).GetLimits), ctx, req)
+}
+
+// GetListLimitOverride mocks base method.
+func (m *MockLimitSvc) GetListLimitOverride(ctx context.Context, req *domain.GetListLimitOverrideRequest) (*domain.GetListLimitOverrideResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListLimitOverride", ctx, req)
+	ret0, _ := ret[0].(*domain.GetListLimitOverrideResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListLimitOverride indicates an expected call of GetListLimitOverride.
+func (mr *MockLimitSvcMockRecorder) GetListLimitOverride(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListLimitOverride", reflect.TypeOf((*MockLimitSvc)(nil).GetListLimitOverride), ctx, req)
+}
+
+// GetUserLimits mocks base method.
+func (m *MockLimitSvc) GetUserLimits(ctx context.Context, userID string) (entity.Limits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLimits", ctx, userID)
+	ret0, _ := ret[0].(entity.Limits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLimits indicates an expected call of GetUserLimits.
+func (mr *MockLimitSvcMockRecorder) GetUserLimits(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLimits", reflect.TypeOf((*MockLimitSvc)(nil).GetUserLimits), ctx, userID)
+}
+
+// SetLimitOverride mocks base method.
+func (m *MockLimitSvc) SetLimitOverride(ctx context.Context, req *domain.SetLimitOverrideRequest) (*domain.LimitOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLimitOverride", ctx, req)
+	ret0, _ := ret[0].(*domain.LimitOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLimitOverride indicates an expected call of SetLimitOverride.
+func (mr *MockLimitSvcMockRecorder) SetLimitOverride(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimitOverride", reflect.TypeOf((*MockLimitSvc)(nil).SetLimitOverride), ctx, req)
+}
@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: payment_pin_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentPinSvc is a mock of PaymentPinSvc interface.
+type MockPaymentPinSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentPinSvcMockRecorder
+}
+
+// MockPaymentPinSvcMockRecorder is the mock recorder for MockPaymentPinSvc.
+type MockPaymentPinSvcMockRecorder struct {
+	mock *MockPaymentPinSvc
+}
+
+// NewMockPaymentPinSvc creates a new mock instance.
+func NewMockPaymentPinSvc(ctrl *gomock.Controller) *MockPaymentPinSvc {
+	mock := &MockPaymentPinSvc{ctrl: ctrl}
+	mock.recorder = &MockPaymentPinSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentPinSvc) EXPECT() *MockPaymentPinSvcMockRecorder {
+	return m.recorder
+}
+
+// ChangePaymentPin mocks base method.
+func (m *MockPaymentPinSvc) ChangePaymentPin(ctx context.Context, req *domain.ChangePaymentPinRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePaymentPin", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePaymentPin indicates an expected call of ChangePaymentPin.
+func (mr *MockPaymentPinSvcMockRecorder) ChangePaymentPin(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePaymentPin", reflect.TypeOf((*MockPaymentPinSvc)(nil).ChangePaymentPin), ctx, req)
+}
+
+// GetListPaymentPinAudit mocks base method.
+func (m *MockPaymentPinSvc) GetListPaymentPinAudit(ctx context.Context, req *domain.GetListPaymentPinAuditRequest) (*domain.GetListPaymentPinAuditResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListPaymentPinAudit", ctx, req)
+	ret0, _ := ret[0].(*domain.GetListPaymentPinAuditResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListPaymentPinAudit indicates an expected call of GetListPaymentPinAudit.
+func (mr *MockPaymentPinSvcMockRecorder) GetListPaymentPinAudit(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListPaymentPinAudit", reflect.TypeOf((*MockPaymentPinSvc)(nil).GetListPaymentPinAudit), ctx, req)
+}
+
+// GetPaymentPinStatus mocks base method.
+func (m *MockPaymentPinSvc) GetPaymentPinStatus(ctx context.Context, userID string) (*domain.PaymentPinStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentPinStatus", ctx, userID)
+	ret0, _ := ret[0].(*domain.PaymentPinStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentPinStatus indicates an expected call of GetPaymentPinStatus.
+func (mr *MockPaymentPinSvcMockRecorder) GetPaymentPinStatus(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentPinStatus", reflect.TypeOf((*MockPaymentPinSvc)(nil).GetPaymentPinStatus), ctx, userID)
+}
+
+// ResetPaymentPin mocks base method.
+func (m *MockPaymentPinSvc) ResetPaymentPin(ctx context.Context, req *domain.ResetPaymentPinRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPaymentPin", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPaymentPin indicates an expected call of ResetPaymentPin.
+func (mr *MockPaymentPinSvcMockRecorder) ResetPaymentPin(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPaymentPin", reflect.TypeOf((*MockPaymentPinSvc)(nil).ResetPaymentPin), ctx, req)
+}
+
+// SetPaymentPin mocks base method.
+func (m *MockPaymentPinSvc) SetPaymentPin(ctx context.Context, req *domain.SetPaymentPinRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaymentPin", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaymentPin indicates an expected call of SetPaymentPin.
+func (mr *MockPaymentPinSvcMockRecorder) SetPaymentPin(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaymentPin", reflect.TypeOf((*MockPaymentPinSvc)(nil).SetPaymentPin), ctx, req)
+}
+
+// VerifyPaymentPin mocks base method.
+func (m *MockPaymentPinSvc) VerifyPaymentPin(ctx context.Context, req *domain.VerifyPaymentPinRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPaymentPin", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyPaymentPin indicates an expected call of VerifyPaymentPin.
+func (mr *MockPaymentPinSvcMockRecorder) VerifyPaymentPin(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPaymentPin", reflect.TypeOf((*MockPaymentPinSvc)(nil).VerifyPaymentPin), ctx, req)
+}
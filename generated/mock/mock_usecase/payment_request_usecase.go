@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: payment_request_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPaymentRequestSvc is a mock of PaymentRequestSvc interface.
+type MockPaymentRequestSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRequestSvcMockRecorder
+}
+
+// MockPaymentRequestSvcMockRecorder is the mock recorder for MockPaymentRequestSvc.
+type MockPaymentRequestSvcMockRecorder struct {
+	mock *MockPaymentRequestSvc
+}
+
+// NewMockPaymentRequestSvc creates a new mock instance.
+func NewMockPaymentRequestSvc(ctrl *gomock.Controller) *MockPaymentRequestSvc {
+	mock := &MockPaymentRequestSvc{ctrl: ctrl}
+	mock.recorder = &MockPaymentRequestSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRequestSvc) EXPECT() *MockPaymentRequestSvcMockRecorder {
+	return m.recorder
+}
+
+// CreatePaymentRequest mocks base method.
+func (m *MockPaymentRequestSvc) CreatePaymentRequest(ctx context.Context, req *domain.CreatePaymentRequestRequest) (*domain.PaymentRequestResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentRequest", ctx, req)
+	ret0, _ := ret[0].(*domain.PaymentRequestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentRequest indicates an expected call of CreatePaymentRequest.
+func (mr *MockPaymentRequestSvcMockRecorder) CreatePaymentRequest(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentRequest", reflect.TypeOf((*MockPaymentRequestSvc)(nil).CreatePaymentRequest), ctx, req)
+}
+
+// DeclinePaymentRequest mocks base method.
+func (m *MockPaymentRequestSvc) DeclinePaymentRequest(ctx context.Context, req *domain.PaymentRequestActionRequest) (*domain.PaymentRequestResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclinePaymentRequest", ctx, req)
+	ret0, _ := ret[0].(*domain.PaymentRequestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclinePaymentRequest indicates an expected call of DeclinePaymentRequest.
+func (mr *MockPaymentRequestSvcMockRecorder) DeclinePaymentRequest(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclinePaymentRequest", reflect.TypeOf((*MockPaymentRequestSvc)(nil).DeclinePaymentRequest), ctx, req)
+}
+
+// ExpirePaymentRequests mocks base method.
+func (m *MockPaymentRequestSvc) ExpirePaymentRequests(ctx context.Context, operatedBy string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePaymentRequests", ctx, operatedBy)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePaymentRequests indicates an expected call of ExpirePaymentRequests.
+func (mr *MockPaymentRequestSvcMockRecorder) ExpirePaymentRequests(ctx, operatedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePaymentRequests", reflect.TypeOf((*MockPaymentRequestSvc)(nil).ExpirePaymentRequests), ctx, operatedBy)
+}
+
+// GetListPaymentRequest mocks base method.
+func (m *MockPaymentRequestSvc) GetListPaymentRequest(ctx context.Context, req *domain.GetListPaymentRequestRequest) (*domain.GetListPaymentRequestResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListPaymentRequest", ctx, req)
+	ret0, _ := ret[0].(*domain.GetListPaymentRequestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListPaymentRequest indicates an expected call of GetListPaymentRequest.
+func (mr *MockPaymentRequestSvcMockRecorder) GetListPaymentRequest(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListPaymentRequest", reflect.TypeOf((*MockPaymentRequestSvc)(nil).GetListPaymentRequest), ctx, req)
+}
+
+// PayPaymentRequest mocks base method.
+func (m *MockPaymentRequestSvc) PayPaymentRequest(ctx context.Context, req *domain.PaymentRequestActionRequest) (*domain.PaymentRequestResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayPaymentRequest", ctx, req)
+	ret0, _ := ret[0].(*domain.PaymentRequestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayPaymentRequest indicates an expected call of PayPaymentRequest.
+func (mr *MockPaymentRequestSvcMockRecorder) PayPaymentRequest(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayPaymentRequest", reflect.TypeOf((*MockPaymentRequestSvc)(nil).PayPaymentRequest), ctx, req)
+}
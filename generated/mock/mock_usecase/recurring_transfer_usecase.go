@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recurring_transfer_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRecurringTransferSvc is a mock of RecurringTransferSvc interface.
+type MockRecurringTransferSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecurringTransferSvcMockRecorder
+}
+
+// MockRecurringTransferSvcMockRecorder is the mock recorder for MockRecurringTransferSvc.
+type MockRecurringTransferSvcMockRecorder struct {
+	mock *MockRecurringTransferSvc
+}
+
+// NewMockRecurringTransferSvc creates a new mock instance.
+func NewMockRecurringTransferSvc(ctrl *gomock.Controller) *MockRecurringTransferSvc {
+	mock := &MockRecurringTransferSvc{ctrl: ctrl}
+	mock.recorder = &MockRecurringTransferSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecurringTransferSvc) EXPECT() *MockRecurringTransferSvcMockRecorder {
+	return m.recorder
+}
+
+// CancelRecurringTransfer mocks base method.
+func (m *MockRecurringTransferSvc) CancelRecurringTransfer(ctx context.Context, req *domain.RecurringTransferActionRequest) (*domain.RecurringTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRecurringTransfer", ctx, req)
+	ret0, _ := ret[0].(*domain.RecurringTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelRecurringTransfer indicates an expected call of CancelRecurringTransfer.
+func (mr *MockRecurringTransferSvcMockRecorder) CancelRecurringTransfer(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRecurringTransfer", reflect.TypeOf((*MockRecurringTransferSvc)(nil).CancelRecurringTransfer), ctx, req)
+}
+
+// CreateRecurringTransfer mocks base method.
+func (m *MockRecurringTransferSvc) CreateRecurringTransfer(ctx context.Context, req *domain.CreateRecurringTransferRequest) (*domain.RecurringTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecurringTransfer", ctx, req)
+	ret0, _ := ret[0].(*domain.RecurringTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecurringTransfer indicates an expected call of CreateRecurringTransfer.
+func (mr *MockRecurringTransferSvcMockRecorder) CreateRecurringTransfer(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecurringTransfer", reflect.TypeOf((*MockRecurringTransferSvc)(nil).CreateRecurringTransfer), ctx, req)
+}
+
+// ExecuteRecurringTransfers mocks base method.
+func (m *MockRecurringTransferSvc) ExecuteRecurringTransfers(ctx context.Context, msgs []*domain.MsgKafkaRecurringTransfer) ([]*domain.MsgKafkaRecurringTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteRecurringTransfers", ctx, msgs)
+	ret0, _ := ret[0].([]*domain.MsgKafkaRecurringTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteRecurringTransfers indicates an expected call of ExecuteRecurringTransfers.
+func (mr *MockRecurringTransferSvcMockRecorder) ExecuteRecurringTransfers(ctx, msgs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteRecurringTransfers", reflect.TypeOf((*MockRecurringTransferSvc)(nil).ExecuteRecurringTransfers), ctx, msgs)
+}
+
+// FetchDueRecurringTransfers mocks base method.
+func (m *MockRecurringTransferSvc) FetchDueRecurringTransfers(ctx context.Context) ([]*domain.MsgKafkaRecurringTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDueRecurringTransfers", ctx)
+	ret0, _ := ret[0].([]*domain.MsgKafkaRecurringTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDueRecurringTransfers indicates an expected call of FetchDueRecurringTransfers.
+func (mr *MockRecurringTransferSvcMockRecorder) FetchDueRecurringTransfers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDueRecurringTransfers", reflect.TypeOf((*MockRecurringTransferSvc)(nil).FetchDueRecurringTransfers), ctx)
+}
+
+// GetListRecurringTransfer mocks base method.
+func (m *MockRecurringTransferSvc) GetListRecurringTransfer(ctx context.Context, req *domain.GetListRecurringTransferRequest) (*domain.GetListRecurringTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListRecurringTransfer", ctx, req)
+	ret0, _ := ret[0].(*domain.GetListRecurringTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListRecurringTransfer indicates an expected call of GetListRecurringTransfer.
+func (mr *MockRecurringTransferSvcMockRecorder) GetListRecurringTransfer(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListRecurringTransfer", reflect.TypeOf((*MockRecurringTransferSvc)(nil).GetListRecurringTransfer), ctx, req)
+}
+
+// GetListRecurringTransferRun mocks base method.
+func (m *MockRecurringTransferSvc) GetListRecurringTransferRun(ctx context.Context, req *domain.GetListRecurringTransferRunRequest) (*domain.GetListRecurringTransferRunResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListRecurringTransferRun", ctx, req)
+	ret0, _ := ret[0].(*domain.GetListRecurringTransferRunResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListRecurringTransferRun indicates an expected call of GetListRecurringTransferRun.
+func (mr *MockRecurringTransferSvcMockRecorder) GetListRecurringTransferRun(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListRecurringTransferRun", reflect.TypeOf((*MockRecurringTransferSvc)(nil).GetListRecurringTransferRun), ctx, req)
+}
+
+// PauseRecurringTransfer mocks base method.
+func (m *MockRecurringTransferSvc) PauseRecurringTransfer(ctx context.Context, req *domain.RecurringTransferActionRequest) (*domain.RecurringTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseRecurringTransfer", ctx, req)
+	ret0, _ := ret[0].(*domain.RecurringTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseRecurringTransfer indicates an expected call of PauseRecurringTransfer.
+func (mr *MockRecurringTransferSvcMockRecorder) PauseRecurringTransfer(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseRecurringTransfer", reflect.TypeOf((*MockRecurringTransferSvc)(nil).PauseRecurringTransfer), ctx, req)
+}
+
+// ResumeRecurringTransfer mocks base method.
+func (m *MockRecurringTransferSvc) ResumeRecurringTransfer(ctx context.Context, req *domain.RecurringTransferActionRequest) (*domain.RecurringTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeRecurringTransfer", ctx, req)
+	ret0, _ := ret[0].(*domain.RecurringTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeRecurringTransfer indicates an expected call of ResumeRecurringTransfer.
+func (mr *MockRecurringTransferSvcMockRecorder) ResumeRecurringTransfer(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeRecurringTransfer", reflect.TypeOf((*MockRecurringTransferSvc)(nil).ResumeRecurringTransfer), ctx, req)
+}
@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: risk_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRiskRule is a mock of RiskRule interface.
+type MockRiskRule struct {
+	ctrl     *gomock.Controller
+	recorder *MockRiskRuleMockRecorder
+}
+
+// MockRiskRuleMockRecorder is the mock recorder for MockRiskRule.
+type MockRiskRuleMockRecorder struct {
+	mock *MockRiskRule
+}
+
+// NewMockRiskRule creates a new mock instance.
+func NewMockRiskRule(ctrl *gomock.Controller) *MockRiskRule {
+	mock := &MockRiskRule{ctrl: ctrl}
+	mock.recorder = &MockRiskRuleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRiskRule) EXPECT() *MockRiskRuleMockRecorder {
+	return m.recorder
+}
+
+// Evaluate mocks base method.
+func (m *MockRiskRule) Evaluate(ctx context.Context, event *domain.RiskEvent) (entity.RiskDecisionType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx, event)
+	ret0, _ := ret[0].(entity.RiskDecisionType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockRiskRuleMockRecorder) Evaluate(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockRiskRule)(nil).Evaluate), ctx, event)
+}
+
+// Name mocks base method.
+func (m *MockRiskRule) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockRiskRuleMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockRiskRule)(nil).Name))
+}
+
+// MockRiskSvc is a mock of RiskSvc interface.
+type MockRiskSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockRiskSvcMockRecorder
+}
+
+// MockRiskSvcMockRecorder is the mock recorder for MockRiskSvc.
+type MockRiskSvcMockRecorder struct {
+	mock *MockRiskSvc
+}
+
+// NewMockRiskSvc creates a new mock instance.
+func NewMockRiskSvc(ctrl *gomock.Controller) *MockRiskSvc {
+	mock := &MockRiskSvc{ctrl: ctrl}
+	mock.recorder = &MockRiskSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRiskSvc) EXPECT() *MockRiskSvcMockRecorder {
+	return m.recorder
+}
+
+// Evaluate mocks base method.
+func (m *MockRiskSvc) Evaluate(ctx context.Context, event *domain.RiskEvent) (entity.RiskDecisionType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx, event)
+	ret0, _ := ret[0].(entity.RiskDecisionType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockRiskSvcMockRecorder) Evaluate(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockRiskSvc)(nil).Evaluate), ctx, event)
+}
+
+// GetListRiskDecision mocks base method.
+func (m *MockRiskSvc) GetListRiskDecision(ctx context.Context, req *domain.GetListRiskDecisionRequest) (*domain.GetListRiskDecisionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListRiskDecision", ctx, req)
+	ret0, _ := ret[0].(*domain.GetListRiskDecisionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListRiskDecision indicates an expected call of GetListRiskDecision.
+func (mr *MockRiskSvcMockRecorder) GetListRiskDecision(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListRiskDecision", reflect.TypeOf((*MockRiskSvc)(nil).GetListRiskDecision), ctx, req)
+}
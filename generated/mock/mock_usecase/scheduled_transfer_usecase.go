@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scheduled_transfer_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockScheduledTransferSvc is a mock of ScheduledTransferSvc interface.
+type MockScheduledTransferSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledTransferSvcMockRecorder
+}
+
+// MockScheduledTransferSvcMockRecorder is the mock recorder for MockScheduledTransferSvc.
+type MockScheduledTransferSvcMockRecorder struct {
+	mock *MockScheduledTransferSvc
+}
+
+// NewMockScheduledTransferSvc creates a new mock instance.
+func NewMockScheduledTransferSvc(ctrl *gomock.Controller) *MockScheduledTransferSvc {
+	mock := &MockScheduledTransferSvc{ctrl: ctrl}
+	mock.recorder = &MockScheduledTransferSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduledTransferSvc) EXPECT() *MockScheduledTransferSvcMockRecorder {
+	return m.recorder
+}
+
+// CancelScheduledTransfer mocks base method.
+func (m *MockScheduledTransferSvc) CancelScheduledTransfer(ctx context.Context, req *domain.CancelScheduledTransferRequest) (*domain.ScheduledTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledTransfer", ctx, req)
+	ret0, _ := ret[0].(*domain.ScheduledTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelScheduledTransfer indicates an expected call of CancelScheduledTransfer.
+func (mr *MockScheduledTransferSvcMockRecorder) CancelScheduledTransfer(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledTransfer", reflect.TypeOf((*MockScheduledTransferSvc)(nil).CancelScheduledTransfer), ctx, req)
+}
+
+// ExecuteScheduledTransfers mocks base method.
+func (m *MockScheduledTransferSvc) ExecuteScheduledTransfers(ctx context.Context, msgs []*domain.MsgKafkaScheduledTransfer) ([]*domain.MsgKafkaScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteScheduledTransfers", ctx, msgs)
+	ret0, _ := ret[0].([]*domain.MsgKafkaScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteScheduledTransfers indicates an expected call of ExecuteScheduledTransfers.
+func (mr *MockScheduledTransferSvcMockRecorder) ExecuteScheduledTransfers(ctx, msgs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteScheduledTransfers", reflect.TypeOf((*MockScheduledTransferSvc)(nil).ExecuteScheduledTransfers), ctx, msgs)
+}
+
+// FetchDueScheduledTransfers mocks base method.
+func (m *MockScheduledTransferSvc) FetchDueScheduledTransfers(ctx context.Context) ([]*domain.MsgKafkaScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDueScheduledTransfers", ctx)
+	ret0, _ := ret[0].([]*domain.MsgKafkaScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDueScheduledTransfers indicates an expected call of FetchDueScheduledTransfers.
+func (mr *MockScheduledTransferSvcMockRecorder) FetchDueScheduledTransfers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDueScheduledTransfers", reflect.TypeOf((*MockScheduledTransferSvc)(nil).FetchDueScheduledTransfers), ctx)
+}
+
+// GetListScheduledTransfer mocks base method.
+func (m *MockScheduledTransferSvc) GetListScheduledTransfer(ctx context.Context, req *domain.GetListScheduledTransferRequest) (*domain.GetListScheduledTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListScheduledTransfer", ctx, req)
+	ret0, _ := ret[0].(*domain.GetListScheduledTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListScheduledTransfer indicates an expected call of GetListScheduledTransfer.
+func (mr *MockScheduledTransferSvcMockRecorder) GetListScheduledTransfer(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListScheduledTransfer", reflect.TypeOf((*MockScheduledTransferSvc)(nil).GetListScheduledTransfer), ctx, req)
+}
+
+// ScheduleTransfer mocks base method.
+func (m *MockScheduledTransferSvc) ScheduleTransfer(ctx context.Context, req *domain.CreateScheduledTransferRequest) (*domain.ScheduledTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleTransfer", ctx, req)
+	ret0, _ := ret[0].(*domain.ScheduledTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleTransfer indicates an expected call of ScheduleTransfer.
+func (mr *MockScheduledTransferSvcMockRecorder) ScheduleTransfer(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleTransfer", reflect.TypeOf((*MockScheduledTransferSvc)(nil).ScheduleTransfer), ctx, req)
+}
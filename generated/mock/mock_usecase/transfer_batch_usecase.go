@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: transfer_batch_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTransferBatchSvc is a mock of TransferBatchSvc interface.
+type MockTransferBatchSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferBatchSvcMockRecorder
+}
+
+// MockTransferBatchSvcMockRecorder is the mock recorder for MockTransferBatchSvc.
+type MockTransferBatchSvcMockRecorder struct {
+	mock *MockTransferBatchSvc
+}
+
+// NewMockTransferBatchSvc creates a new mock instance.
+func NewMockTransferBatchSvc(ctrl *gomock.Controller) *MockTransferBatchSvc {
+	mock := &MockTransferBatchSvc{ctrl: ctrl}
+	mock.recorder = &MockTransferBatchSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferBatchSvc) EXPECT() *MockTransferBatchSvcMockRecorder {
+	return m.recorder
+}
+
+// CreateTransferBatch mocks base method.
+func (m *MockTransferBatchSvc) CreateTransferBatch(ctx context.Context, req *domain.CreateTransferBatchRequest) (*domain.TransferBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferBatch", ctx, req)
+	ret0, _ := ret[0].(*domain.TransferBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferBatch indicates an expected call of CreateTransferBatch.
+func (mr *MockTransferBatchSvcMockRecorder) CreateTransferBatch(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferBatch", reflect.TypeOf((*MockTransferBatchSvc)(nil).CreateTransferBatch), ctx, req)
+}
+
+// GetDetailTransferBatch mocks base method.
+func (m *MockTransferBatchSvc) GetDetailTransferBatch(ctx context.Context, transferBatchID int64, userID string) (*domain.TransferBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetailTransferBatch", ctx, transferBatchID, userID)
+	ret0, _ := ret[0].(*domain.TransferBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetailTransferBatch indicates an expected call of GetDetailTransferBatch.
+func (mr *MockTransferBatchSvcMockRecorder) GetDetailTransferBatch(ctx, transferBatchID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetailTransferBatch", reflect.TypeOf((*MockTransferBatchSvc)(nil).GetDetailTransferBatch), ctx, transferBatchID, userID)
+}
@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: transfer_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTransferSvc is a mock of TransferSvc interface.
+type MockTransferSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferSvcMockRecorder
+}
+
+// MockTransferSvcMockRecorder is the mock recorder for MockTransferSvc.
+type MockTransferSvcMockRecorder struct {
+	mock *MockTransferSvc
+}
+
+// NewMockTransferSvc creates a new mock instance.
+func NewMockTransferSvc(ctrl *gomock.Controller) *MockTransferSvc {
+	mock := &MockTransferSvc{ctrl: ctrl}
+	mock.recorder = &MockTransferSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferSvc) EXPECT() *MockTransferSvcMockRecorder {
+	return m.recorder
+}
+
+// CancelTransfer mocks base method.
+func (m *MockTransferSvc) CancelTransfer(ctx context.Context, arg *domain.CancelTransferRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTransfer", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelTransfer indicates an expected call of CancelTransfer.
+func (mr *MockTransferSvcMockRecorder) CancelTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTransfer", reflect.TypeOf((*MockTransferSvc)(nil).CancelTransfer), ctx, arg)
+}
+
+// ClaimTransfer mocks base method.
+func (m *MockTransferSvc) ClaimTransfer(ctx context.Context, arg *domain.ClaimTransferRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTransfer", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimTransfer indicates an expected call of ClaimTransfer.
+func (mr *MockTransferSvcMockRecorder) ClaimTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTransfer", reflect.TypeOf((*MockTransferSvc)(nil).ClaimTransfer), ctx, arg)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockTransferSvc) CreateTransfer(ctx context.Context, arg *domain.CreateTransferRequest) (*domain.CreateTransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
+	ret0, _ := ret[0].(*domain.CreateTransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockTransferSvcMockRecorder) CreateTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockTransferSvc)(nil).CreateTransfer), ctx, arg)
+}
+
+// DeclineTransfer mocks base method.
+func (m *MockTransferSvc) DeclineTransfer(ctx context.Context, arg *domain.DeclineTransferRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineTransfer", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineTransfer indicates an expected call of DeclineTransfer.
+func (mr *MockTransferSvcMockRecorder) DeclineTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineTransfer", reflect.TypeOf((*MockTransferSvc)(nil).DeclineTransfer), ctx, arg)
+}
+
+// FetchExpiredTransfers mocks base method.
+func (m *MockTransferSvc) FetchExpiredTransfers(ctx context.Context, transferIDs []int64) ([]*domain.MsgKafkaExpiredTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchExpiredTransfers", ctx, transferIDs)
+	ret0, _ := ret[0].([]*domain.MsgKafkaExpiredTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchExpiredTransfers indicates an expected call of FetchExpiredTransfers.
+func (mr *MockTransferSvcMockRecorder) FetchExpiredTransfers(ctx, transferIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchExpiredTransfers", reflect.TypeOf((*MockTransferSvc)(nil).FetchExpiredTransfers), ctx, transferIDs)
+}
+
+// GetDetailTransfer mocks base method.
+func (m *MockTransferSvc) GetDetailTransfer(ctx context.Context, transferID int64, userID string) (*domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetailTransfer", ctx, transferID, userID)
+	ret0, _ := ret[0].(*domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetailTransfer indicates an expected call of GetDetailTransfer.
+func (mr *MockTransferSvcMockRecorder) GetDetailTransfer(ctx, transferID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetailTransfer", reflect.TypeOf((*MockTransferSvc)(nil).GetDetailTransfer), ctx, transferID, userID)
+}
+
+// ProcessExpiredTransfers mocks base method.
+func (m *MockTransferSvc) ProcessExpiredTransfers(ctx context.Context, transfers []*domain.MsgKafkaExpiredTransfer) ([]*domain.MsgKafkaExpiredTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessExpiredTransfers", ctx, transfers)
+	ret0, _ := ret[0].([]*domain.MsgKafkaExpiredTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessExpiredTransfers indicates an expected call of ProcessExpiredTransfers.
+func (mr *MockTransferSvcMockRecorder) ProcessExpiredTransfers(ctx, transfers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessExpiredTransfers", reflect.TypeOf((*MockTransferSvc)(nil).ProcessExpiredTransfers), ctx, transfers)
+}
+
+// ProcessManualRefund mocks base method.
+func (m *MockTransferSvc) ProcessManualRefund(ctx context.Context, transferIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessManualRefund", ctx, transferIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessManualRefund indicates an expected call of ProcessManualRefund.
+func (mr *MockTransferSvcMockRecorder) ProcessManualRefund(ctx, transferIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessManualRefund", reflect.TypeOf((*MockTransferSvc)(nil).ProcessManualRefund), ctx, transferIDs)
+}
+
+// RefundTransfer mocks base method.
+func (m *MockTransferSvc) RefundTransfer(ctx context.Context, arg *domain.RefundTransferReq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundTransfer", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefundTransfer indicates an expected call of RefundTransfer.
+func (mr *MockTransferSvcMockRecorder) RefundTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundTransfer", reflect.TypeOf((*MockTransferSvc)(nil).RefundTransfer), ctx, arg)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: wallet_hold_usecase.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWalletHoldSvc is a mock of WalletHoldSvc interface.
+type MockWalletHoldSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletHoldSvcMockRecorder
+}
+
+// MockWalletHoldSvcMockRecorder is the mock recorder for MockWalletHoldSvc.
+type MockWalletHoldSvcMockRecorder struct {
+	mock *MockWalletHoldSvc
+}
+
+// NewMockWalletHoldSvc creates a new mock instance.
+func NewMockWalletHoldSvc(ctrl *gomock.Controller) *MockWalletHoldSvc {
+	mock := &MockWalletHoldSvc{ctrl: ctrl}
+	mock.recorder = &MockWalletHoldSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletHoldSvc) EXPECT() *MockWalletHoldSvcMockRecorder {
+	return m.recorder
+}
+
+// CaptureHold mocks base method.
+func (m *MockWalletHoldSvc) CaptureHold(ctx context.Context, req *domain.WalletHoldActionRequest) (*domain.WalletHoldResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, req)
+	ret0, _ := ret[0].(*domain.WalletHoldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockWalletHoldSvcMockRecorder) CaptureHold(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockWalletHoldSvc)(nil).CaptureHold), ctx, req)
+}
+
+// CreateHold mocks base method.
+func (m *MockWalletHoldSvc) CreateHold(ctx context.Context, req *domain.CreateWalletHoldRequest) (*domain.WalletHoldResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, req)
+	ret0, _ := ret[0].(*domain.WalletHoldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockWalletHoldSvcMockRecorder) CreateHold(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockWalletHoldSvc)(nil).CreateHold), ctx, req)
+}
+
+// GetListHold mocks base method.
+func (m *MockWalletHoldSvc) GetListHold(ctx context.Context, req *domain.GetListWalletHoldRequest) (*domain.GetListWalletHoldResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListHold", ctx, req)
+	ret0, _ := ret[0].(*domain.GetListWalletHoldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListHold indicates an expected call of GetListHold.
+func (mr *MockWalletHoldSvcMockRecorder) GetListHold(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListHold", reflect.TypeOf((*MockWalletHoldSvc)(nil).GetListHold), ctx, req)
+}
+
+// ReleaseHold mocks base method.
+func (m *MockWalletHoldSvc) ReleaseHold(ctx context.Context, req *domain.WalletHoldActionRequest) (*domain.WalletHoldResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", ctx, req)
+	ret0, _ := ret[0].(*domain.WalletHoldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockWalletHoldSvcMockRecorder) ReleaseHold(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockWalletHoldSvc)(nil).ReleaseHold), ctx, req)
+}
@@ -36,20 +36,6 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockRepository) Create(wallet *entity.Wallet) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", wallet)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Create indicates an expected call of Create.
-func (mr *MockRepositoryMockRecorder) Create(wallet interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), wallet)
-}
-
 // CreateWallet mocks base method.
 func (m *MockRepository) CreateWallet(ctx context.Context, wallet *entity.Wallet) (int64, error) {
 	m.ctrl.T.Helper()
@@ -66,48 +52,63 @@ func (mr *MockRepositoryMockRecorder) CreateWallet(ctx, wallet interface{}) *gom
 }
 
 // FindByUserID mocks base method.
-func (m *MockRepository) FindByUserID(userID string) (*entity.Wallet, error) {
+func (m *MockRepository) FindByUserID(ctx context.Context, userID string, currency entity.Currency) (*entity.Wallet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByUserID", userID)
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID, currency)
 	ret0, _ := ret[0].(*entity.Wallet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByUserID indicates an expected call of FindByUserID.
-func (mr *MockRepositoryMockRecorder) FindByUserID(userID interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindByUserID(ctx, userID, currency interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockRepository)(nil).FindByUserID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockRepository)(nil).FindByUserID), ctx, userID, currency)
 }
 
 // FindByWalletID mocks base method.
-func (m *MockRepository) FindByWalletID(walletID int64) (*entity.Wallet, error) {
+func (m *MockRepository) FindByWalletID(ctx context.Context, walletID int64) (*entity.Wallet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByWalletID", walletID)
+	ret := m.ctrl.Call(m, "FindByWalletID", ctx, walletID)
 	ret0, _ := ret[0].(*entity.Wallet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByWalletID indicates an expected call of FindByWalletID.
-func (mr *MockRepositoryMockRecorder) FindByWalletID(walletID interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindByWalletID(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWalletID", reflect.TypeOf((*MockRepository)(nil).FindByWalletID), ctx, walletID)
+}
+
+// GetTotalBalance mocks base method.
+func (m *MockRepository) GetTotalBalance(ctx context.Context, currency entity.Currency) (entity.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalBalance", ctx, currency)
+	ret0, _ := ret[0].(entity.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotalBalance indicates an expected call of GetTotalBalance.
+func (mr *MockRepositoryMockRecorder) GetTotalBalance(ctx, currency interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWalletID", reflect.TypeOf((*MockRepository)(nil).FindByWalletID), walletID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalBalance", reflect.TypeOf((*MockRepository)(nil).GetTotalBalance), ctx, currency)
 }
 
 // GetWalletByUserID mocks base method.
-func (m *MockRepository) GetWalletByUserID(ctx context.Context, userID string) (*entity.Wallet, error) {
+func (m *MockRepository) GetWalletByUserID(ctx context.Context, userID string, currency entity.Currency) (*entity.Wallet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWalletByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "GetWalletByUserID", ctx, userID, currency)
 	ret0, _ := ret[0].(*entity.Wallet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetWalletByUserID indicates an expected call of GetWalletByUserID.
-func (mr *MockRepositoryMockRecorder) GetWalletByUserID(ctx, userID interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetWalletByUserID(ctx, userID, currency interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletByUserID", reflect.TypeOf((*MockRepository)(nil).GetWalletByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletByUserID", reflect.TypeOf((*MockRepository)(nil).GetWalletByUserID), ctx, userID, currency)
 }
 
 // GetWalletByWalletIDTx mocks base method.
@@ -125,6 +126,49 @@ func (mr *MockRepositoryMockRecorder) GetWalletByWalletIDTx(ctx, tx, walletID in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletByWalletIDTx", reflect.TypeOf((*MockRepository)(nil).GetWalletByWalletIDTx), ctx, tx, walletID)
 }
 
+// GetWalletsByUserID mocks base method.
+func (m *MockRepository) GetWalletsByUserID(ctx context.Context, userID string) ([]*entity.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletsByUserID indicates an expected call of GetWalletsByUserID.
+func (mr *MockRepositoryMockRecorder) GetWalletsByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletsByUserID", reflect.TypeOf((*MockRepository)(nil).GetWalletsByUserID), ctx, userID)
+}
+
+// UpdateBalance mocks base method.
+func (m *MockRepository) UpdateBalance(ctx context.Context, walletID int64, balance entity.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBalance", ctx, walletID, balance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBalance indicates an expected call of UpdateBalance.
+func (mr *MockRepositoryMockRecorder) UpdateBalance(ctx, walletID, balance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalance", reflect.TypeOf((*MockRepository)(nil).UpdateBalance), ctx, walletID, balance)
+}
+
+// UpdateHeldBalance mocks base method.
+func (m *MockRepository) UpdateHeldBalance(ctx context.Context, walletID int64, heldBalance entity.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHeldBalance", ctx, walletID, heldBalance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHeldBalance indicates an expected call of UpdateHeldBalance.
+func (mr *MockRepositoryMockRecorder) UpdateHeldBalance(ctx, walletID, heldBalance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHeldBalance", reflect.TypeOf((*MockRepository)(nil).UpdateHeldBalance), ctx, walletID, heldBalance)
+}
+
 // UpdateWallet mocks base method.
 func (m *MockRepository) UpdateWallet(ctx context.Context, tx *gorm.DB, wallet *entity.Wallet) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_wallet_hold is a generated GoMock package.
+package mock_wallet_hold
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateHold mocks base method.
+func (m *MockRepository) CreateHold(ctx context.Context, hold *entity.WalletHold) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, hold)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockRepositoryMockRecorder) CreateHold(ctx, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockRepository)(nil).CreateHold), ctx, hold)
+}
+
+// GetHold mocks base method.
+func (m *MockRepository) GetHold(ctx context.Context, holdID int64) (*entity.WalletHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, holdID)
+	ret0, _ := ret[0].(*entity.WalletHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockRepositoryMockRecorder) GetHold(ctx, holdID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockRepository)(nil).GetHold), ctx, holdID)
+}
+
+// GetHoldForUpdate mocks base method.
+func (m *MockRepository) GetHoldForUpdate(ctx context.Context, holdID int64) (*entity.WalletHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldForUpdate", ctx, holdID)
+	ret0, _ := ret[0].(*entity.WalletHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldForUpdate indicates an expected call of GetHoldForUpdate.
+func (mr *MockRepositoryMockRecorder) GetHoldForUpdate(ctx, holdID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldForUpdate", reflect.TypeOf((*MockRepository)(nil).GetHoldForUpdate), ctx, holdID)
+}
+
+// GetListHold mocks base method.
+func (m *MockRepository) GetListHold(ctx context.Context, req *domain.GetListWalletHoldRequest) ([]*entity.WalletHold, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListHold", ctx, req)
+	ret0, _ := ret[0].([]*entity.WalletHold)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListHold indicates an expected call of GetListHold.
+func (mr *MockRepositoryMockRecorder) GetListHold(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListHold", reflect.TypeOf((*MockRepository)(nil).GetListHold), ctx, req)
+}
+
+// UpdateHold mocks base method.
+func (m *MockRepository) UpdateHold(ctx context.Context, hold *entity.WalletHold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHold", ctx, hold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHold indicates an expected call of UpdateHold.
+func (mr *MockRepositoryMockRecorder) UpdateHold(ctx, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHold", reflect.TypeOf((*MockRepository)(nil).UpdateHold), ctx, hold)
+}
@@ -52,6 +52,21 @@ func (mr *MockRepositoryMockRecorder) CreateDeposit(ctx, tx, deposit interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeposit", reflect.TypeOf((*MockRepository)(nil).CreateDeposit), ctx, tx, deposit)
 }
 
+// GetDepositForUpdate mocks base method.
+func (m *MockRepository) GetDepositForUpdate(ctx context.Context, depositID int64) (*entity.WalletRechargeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositForUpdate", ctx, depositID)
+	ret0, _ := ret[0].(*entity.WalletRechargeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositForUpdate indicates an expected call of GetDepositForUpdate.
+func (mr *MockRepositoryMockRecorder) GetDepositForUpdate(ctx, depositID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositForUpdate", reflect.TypeOf((*MockRepository)(nil).GetDepositForUpdate), ctx, depositID)
+}
+
 // GetListDeposit mocks base method.
 func (m *MockRepository) GetListDeposit(ctx context.Context, arg *domain.GetListDepositRequest) ([]*entity.WalletRechargeRequest, int64, error) {
 	m.ctrl.T.Helper()
@@ -67,3 +82,32 @@ func (mr *MockRepositoryMockRecorder) GetListDeposit(ctx, arg interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListDeposit", reflect.TypeOf((*MockRepository)(nil).GetListDeposit), ctx, arg)
 }
+
+// UpdateDeposit mocks base method.
+func (m *MockRepository) UpdateDeposit(ctx context.Context, deposit *entity.WalletRechargeRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeposit", ctx, deposit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeposit indicates an expected call of UpdateDeposit.
+func (mr *MockRepositoryMockRecorder) UpdateDeposit(ctx, deposit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeposit", reflect.TypeOf((*MockRepository)(nil).UpdateDeposit), ctx, deposit)
+}
+
+// UpdateStatusRequest mocks base method.
+func (m *MockRepository) UpdateStatusRequest(ctx context.Context, depositID int64, from, to entity.StatusRequest, operatedBy string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusRequest", ctx, depositID, from, to, operatedBy)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatusRequest indicates an expected call of UpdateStatusRequest.
+func (mr *MockRepositoryMockRecorder) UpdateStatusRequest(ctx, depositID, from, to, operatedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusRequest", reflect.TypeOf((*MockRepository)(nil).UpdateStatusRequest), ctx, depositID, from, to, operatedBy)
+}
@@ -7,6 +7,7 @@ package mock_wallet_transaction
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
@@ -52,48 +53,48 @@ func (mr *MockRepositoryMockRecorder) CreateTransaction(ctx, tx, transaction int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransaction", reflect.TypeOf((*MockRepository)(nil).CreateTransaction), ctx, tx, transaction)
 }
 
-// FindAllByWalletID mocks base method.
-func (m *MockRepository) FindAllByWalletID(walletID int64) ([]entity.WalletTransaction, error) {
+// GetListTransaction mocks base method.
+func (m *MockRepository) GetListTransaction(ctx context.Context, req *domain.GetListTransactionRequest) ([]*entity.WalletTransaction, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByWalletID", walletID)
-	ret0, _ := ret[0].([]entity.WalletTransaction)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetListTransaction", ctx, req)
+	ret0, _ := ret[0].([]*entity.WalletTransaction)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// FindAllByWalletID indicates an expected call of FindAllByWalletID.
-func (mr *MockRepositoryMockRecorder) FindAllByWalletID(walletID interface{}) *gomock.Call {
+// GetListTransaction indicates an expected call of GetListTransaction.
+func (mr *MockRepositoryMockRecorder) GetListTransaction(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByWalletID", reflect.TypeOf((*MockRepository)(nil).FindAllByWalletID), walletID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListTransaction", reflect.TypeOf((*MockRepository)(nil).GetListTransaction), ctx, req)
 }
 
-// FindByWalletTransactionID mocks base method.
-func (m *MockRepository) FindByWalletTransactionID(walletTransactionID int64) (entity.WalletTransaction, error) {
+// GetTransactionForUpdate mocks base method.
+func (m *MockRepository) GetTransactionForUpdate(ctx context.Context, walletTransactionID int64) (*entity.WalletTransaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByWalletTransactionID", walletTransactionID)
-	ret0, _ := ret[0].(entity.WalletTransaction)
+	ret := m.ctrl.Call(m, "GetTransactionForUpdate", ctx, walletTransactionID)
+	ret0, _ := ret[0].(*entity.WalletTransaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByWalletTransactionID indicates an expected call of FindByWalletTransactionID.
-func (mr *MockRepositoryMockRecorder) FindByWalletTransactionID(walletTransactionID interface{}) *gomock.Call {
+// GetTransactionForUpdate indicates an expected call of GetTransactionForUpdate.
+func (mr *MockRepositoryMockRecorder) GetTransactionForUpdate(ctx, walletTransactionID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWalletTransactionID", reflect.TypeOf((*MockRepository)(nil).FindByWalletTransactionID), walletTransactionID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionForUpdate", reflect.TypeOf((*MockRepository)(nil).GetTransactionForUpdate), ctx, walletTransactionID)
 }
 
-// GetListTransaction mocks base method.
-func (m *MockRepository) GetListTransaction(ctx context.Context, req *domain.GetListTransactionRequest) ([]*entity.WalletTransaction, int64, error) {
+// SumDebitSince mocks base method.
+func (m *MockRepository) SumDebitSince(ctx context.Context, walletID int64, types []entity.TransactionType, since time.Time) (entity.Money, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetListTransaction", ctx, req)
-	ret0, _ := ret[0].([]*entity.WalletTransaction)
-	ret1, _ := ret[1].(int64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "SumDebitSince", ctx, walletID, types, since)
+	ret0, _ := ret[0].(entity.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetListTransaction indicates an expected call of GetListTransaction.
-func (mr *MockRepositoryMockRecorder) GetListTransaction(ctx, req interface{}) *gomock.Call {
+// SumDebitSince indicates an expected call of SumDebitSince.
+func (mr *MockRepositoryMockRecorder) SumDebitSince(ctx, walletID, types, since interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListTransaction", reflect.TypeOf((*MockRepository)(nil).GetListTransaction), ctx, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumDebitSince", reflect.TypeOf((*MockRepository)(nil).SumDebitSince), ctx, walletID, types, since)
 }
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock_wallet_withdrawal_request is a generated GoMock package.
+package mock_wallet_withdrawal_request
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateWithdrawal mocks base method.
+func (m *MockRepository) CreateWithdrawal(ctx context.Context, withdrawal *entity.WalletWithdrawalRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithdrawal", ctx, withdrawal)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithdrawal indicates an expected call of CreateWithdrawal.
+func (mr *MockRepositoryMockRecorder) CreateWithdrawal(ctx, withdrawal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithdrawal", reflect.TypeOf((*MockRepository)(nil).CreateWithdrawal), ctx, withdrawal)
+}
+
+// GetListWithdrawal mocks base method.
+func (m *MockRepository) GetListWithdrawal(ctx context.Context, arg *domain.GetListWithdrawalRequest) ([]*entity.WalletWithdrawalRequest, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListWithdrawal", ctx, arg)
+	ret0, _ := ret[0].([]*entity.WalletWithdrawalRequest)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListWithdrawal indicates an expected call of GetListWithdrawal.
+func (mr *MockRepositoryMockRecorder) GetListWithdrawal(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListWithdrawal", reflect.TypeOf((*MockRepository)(nil).GetListWithdrawal), ctx, arg)
+}
+
+// GetWithdrawalForUpdate mocks base method.
+func (m *MockRepository) GetWithdrawalForUpdate(ctx context.Context, withdrawalID int64) (*entity.WalletWithdrawalRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithdrawalForUpdate", ctx, withdrawalID)
+	ret0, _ := ret[0].(*entity.WalletWithdrawalRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithdrawalForUpdate indicates an expected call of GetWithdrawalForUpdate.
+func (mr *MockRepositoryMockRecorder) GetWithdrawalForUpdate(ctx, withdrawalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawalForUpdate", reflect.TypeOf((*MockRepository)(nil).GetWithdrawalForUpdate), ctx, withdrawalID)
+}
+
+// UpdateWithdrawal mocks base method.
+func (m *MockRepository) UpdateWithdrawal(ctx context.Context, withdrawal *entity.WalletWithdrawalRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWithdrawal", ctx, withdrawal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWithdrawal indicates an expected call of UpdateWithdrawal.
+func (mr *MockRepositoryMockRecorder) UpdateWithdrawal(ctx, withdrawal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWithdrawal", reflect.TypeOf((*MockRepository)(nil).UpdateWithdrawal), ctx, withdrawal)
+}
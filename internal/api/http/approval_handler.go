@@ -0,0 +1,199 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// ApproveRequest approves an operation waiting for a second admin
+//
+// @Summary Approve pending operation
+// @Description Post a balance adjustment or deposit that exceeded the approval threshold. The admin who created it cannot approve it.
+// @Tags Approval
+// @Accept json
+// @Produce json
+// @Param id path int true "ID of the approval request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Success 200 {object} domain.ApprovalResponse "Successfully approved and posted the operation"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Approval request not found, no longer pending or created by the same admin"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/approvals/{id}/approve [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) ApproveRequest(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while ApproveRequest", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.ProcessApprovalRequest
+	// an id that does not parse is left at zero and rejected by the request validation
+	request.ApprovalRequestID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.ApprovalResponse
+	resp, err = h.approvalUsecase.ApproveRequest(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// RejectRequest rejects an operation waiting for a second admin
+//
+// @Summary Reject pending operation
+// @Description Drop a balance adjustment or deposit that exceeded the approval threshold, no funds move
+// @Tags Approval
+// @Accept json
+// @Produce json
+// @Param id path int true "ID of the approval request"
+// @Param request body domain.ProcessApprovalRequest true "Rejection request, reason is required"
+// @Success 200 {object} domain.ApprovalResponse "Successfully rejected the operation"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Approval request not found or no longer pending"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/approvals/{id}/reject [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) RejectRequest(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while RejectRequest", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.ProcessApprovalRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	// an id that does not parse is left at zero and rejected by the request validation
+	request.ApprovalRequestID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.ApprovalResponse
+	resp, err = h.approvalUsecase.RejectRequest(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// GetListApprovalRequest retrieves the approval inbox
+//
+// @Summary Get approval inbox
+// @Description Retrieve operations that exceeded the approval threshold, oldest first
+// @Tags Approval
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Param statusApproval query string false "Status of the approval request" Enums(pending_approval, approved, rejected) default(pending_approval)
+// @Param operation query string false "Operation waiting for approval" Enums(balance_adjustment, deposit)
+// @Success 200 {object} domain.GetListApprovalResponse "Successfully retrieved approval inbox"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid parameters"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/approvals [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListApprovalRequest(c *gin.Context) {
+	var (
+		request  domain.GetListApprovalRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListApprovalRequest", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var defaultPage int64 = 1
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = defaultPage
+	}
+	request.Page = int32(page)
+
+	var defaultLimit int64 = 10
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	request.Limit = int32(limit)
+
+	request.StatusApproval = c.Query("statusApproval")
+	if request.StatusApproval == "" {
+		request.StatusApproval = "pending_approval"
+	}
+	request.Operation = c.Query("operation")
+
+	var result *domain.GetListApprovalResponse
+	result, err = h.approvalUsecase.GetListApprovalRequest(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
@@ -25,6 +25,7 @@ import (
 // @Accept json
 // @Produce json
 // @Param request body domain.BalanceAdjustmentByAdminRequest true "Balance adjustment request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
 // @Success 200 {object} apiresp.ApiResponse "Successfully adjust balance user"
 // @Failure 400 {object} apiresp.ApiResponse "Bad Request - Request invalid"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
@@ -91,6 +92,7 @@ func (h *WalletHandler) BalanceAdjustmentByAdmin(c *gin.Context) {
 // @Param limit query int false "limit response" default(10)
 // @Param filterDateBy query string false "filter date by"
 // @Param userID query string false "filter by userID"
+// @Param currency query string false "currency of the userID wallet" example(CNY)
 // @Param sortBy query string false "sort list" default(created_at)
 // @Param sortOrder query string false "sort order" Enums(asc, desc) default(desc)
 // @Param startDate query string false "start date if filterdateby used" format(date)
@@ -142,6 +144,7 @@ func (h *WalletHandler) GetListBalanceAdjustment(c *gin.Context) {
 
 	request.FilterDateBy = c.Query("filterDateBy")
 	request.UserID = c.Query("userID")
+	request.Currency = c.Query("currency")
 
 	request.SortBy = c.Query("sortBy")
 	if request.SortBy == "" {
@@ -25,6 +25,7 @@ import (
 // @Accept json
 // @Produce json
 // @Param request body domain.ProcessDepositByAdminRequest true "Deposit processing request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
 // @Success 200 {object} domain.ProcessDepositByAdminResponse "Successfully processed deposit"
 // @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid parameters"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
@@ -84,10 +85,11 @@ func (h *WalletHandler) ProcessDepositByAdmin(c *gin.Context) {
 // @Param limit query int false "Number of items per page" minimum(1) default(10)
 // @Param startDate query string false "Start date for filtering (YYYY-MM-DD format)" format(date)
 // @Param endDate query string false "End date for filtering (YYYY-MM-DD format)" format(date)
-// @Param statusRequest query string false "Status of the deposit request" Enums(requested, approved, rejected, failed)
+// @Param statusRequest query string false "Status of the deposit request" Enums(requested, approved, rejected, failed, reversed)
 // @Param sortBy query string false "Column to sort by" default(created_at)
 // @Param sortOrder query string false "Sort order" Enums(asc, desc) default(desc)
 // @Param filterDateBy query string false "Date column to filter by" Enums(created_at, approved_at, updated_at, deleted_at)
+// @Param currency query string false "Currency of the user wallet, only used together with userID" example(CNY)
 // @Success 200 {object} domain.GetListDepositResponse "Successfully retrieved deposit list"
 // @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid date format or parameters"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
@@ -134,8 +136,9 @@ func (h *WalletHandler) GetListDeposit(c *gin.Context) {
 	request.Limit = int32(limit)
 
 	request.FilterDateBy = c.Query("filterDateBy")
-	request.FilterDateBy = c.Query("statusRequest")
+	request.StatusRequest = c.Query("statusRequest")
 	request.UserID = c.Query("userID")
+	request.Currency = c.Query("currency")
 
 	request.SortBy = c.Query("sortBy")
 	if request.SortBy == "" {
@@ -179,3 +182,170 @@ func (h *WalletHandler) GetListDeposit(c *gin.Context) {
 
 	apiresp.GinSuccess(c, result)
 }
+
+// RequestDeposit requests a deposit to the wallet of the authenticated user
+//
+// @Summary Request deposit
+// @Description Queue a deposit for admin approval, the wallet is credited only once the deposit is approved
+// @Tags Deposit
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param request body domain.WalletRechargeRequestCreate true "Deposit request"
+// @Success 200 {object} domain.DepositResponse "Successfully requested deposit"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid amount or wallet not found"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /deposit/request [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) RequestDeposit(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while RequestDeposit", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.WalletRechargeRequestCreate
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+
+	var resp *domain.DepositResponse
+	resp, err = h.depositUsecase.RequestDeposit(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// ApproveDeposit approves a deposit requested by a user
+//
+// @Summary Approve deposit
+// @Description Credit the wallet of the user with a requested deposit, one above the approval threshold waits for a second admin
+// @Tags Deposit
+// @Accept json
+// @Produce json
+// @Param id path int true "ID of the deposit request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Success 200 {object} domain.DepositResponse "Successfully approved deposit"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Deposit not found or no longer awaiting approval"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/deposit/{id}/approve [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) ApproveDeposit(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while ApproveDeposit", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.ProcessDepositRequest
+	// an id that does not parse is left at zero and rejected by the request validation
+	request.WalletRechargeRequestID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.DepositResponse
+	resp, err = h.depositUsecase.ApproveDeposit(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// RejectDeposit rejects a deposit requested by a user
+//
+// @Summary Reject deposit
+// @Description Reject a requested deposit, nothing is credited to the wallet
+// @Tags Deposit
+// @Accept json
+// @Produce json
+// @Param id path int true "ID of the deposit request"
+// @Param request body domain.ProcessDepositRequest true "Deposit rejection request, reason is required"
+// @Success 200 {object} domain.DepositResponse "Successfully rejected deposit"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Deposit not found or no longer awaiting approval"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/deposit/{id}/reject [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) RejectDeposit(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while RejectDeposit", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.ProcessDepositRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	// an id that does not parse is left at zero and rejected by the request validation
+	request.WalletRechargeRequestID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.DepositResponse
+	resp, err = h.depositUsecase.RejectDeposit(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
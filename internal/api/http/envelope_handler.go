@@ -21,13 +21,16 @@ import (
 // CreateEnvelopeHandler creates a new envelope (red packet)
 //
 // @Summary Create envelope
-// @Description Create a new envelope (red packet) that can be claimed by users
+// @Description Create a new envelope (red packet) that can be claimed by users, an exclusive one only by its toUserIds
 // @Tags Envelope
 // @Accept json
 // @Produce json
 // @Param request body domain.EnvelopeCreateRequest true "Envelope creation request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param X-Payment-Pin header string true "Payment PIN of the user, locked after too many wrong PINs in a row"
+// @Param X-Device-ID header string false "Device of the caller, the risk rules group claims by it"
 // @Success 200 {object} domain.EnvelopeCreateResponse "Successfully created envelope"
-// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid envelope data or insufficient balance"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid envelope data, insufficient balance or group too small"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
 // @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
 // @Router /envelope [post]
@@ -61,10 +64,6 @@ func (h *WalletHandler) CreateEnvelopeHandler(c *gin.Context) {
 		apiresp.GinError(c, err)
 		return
 	}
-	if req.TotalAmount < domain.EnvelopeMinimumAmount || req.TotalAmount > model.MaxAllowedSendEnvelopeAmount {
-		apiresp.GinError(c, eerrs.ErrAmountRange(domain.EnvelopeMinimumAmount, model.MaxAllowedSendEnvelopeAmount))
-		return
-	}
 	if req.TotalClaimer < 1 {
 		apiresp.GinError(c, eerrs.ErrTotalClaimerMustGreaterThanZero)
 		return
@@ -80,6 +79,7 @@ func (h *WalletHandler) CreateEnvelopeHandler(c *gin.Context) {
 	}
 
 	req.UserID = userID
+	req.DeviceID = c.GetHeader(model.DeviceIDHeader)
 	var envelopes *domain.EnvelopeCreateResponse
 	envelopes, err = h.envelopeUsecase.CreateEnvelope(ctx, &req)
 	if err != nil {
@@ -191,8 +191,9 @@ func (h *WalletHandler) RefundEnvelopeByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body domain.EnvelopeClaimRequest true "Envelope claim request"
+// @Param X-Device-ID header string false "Device of the caller, the risk rules group claims by it"
 // @Success 200 {object} domain.EnvelopeClaimResponse "Successfully claimed envelope"
-// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid envelope ID or wallet ID, or envelope already claimed"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid envelope or wallet ID, already claimed or claimer not in its group"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
 // @Failure 404 {object} apiresp.ApiResponse "Not Found - Envelope not found"
 // @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
@@ -236,6 +237,7 @@ func (h *WalletHandler) ClaimEnvelopeHandler(c *gin.Context) {
 		return
 	}
 	req.UserID = userID
+	req.DeviceID = c.GetHeader(model.DeviceIDHeader)
 	var claimData *domain.EnvelopeClaimResponse
 	claimData, err = h.envelopeUsecase.Claim(ctx, &req)
 	if err != nil {
@@ -13,6 +13,20 @@ type WalletHandler struct {
 	transferUsecase          usecase.TransferSvc
 	walletMonitoringUsecase  usecase.WalletMonitoringSvc
 	balanceAdjustmentUsecase usecase.BalanceAdjustmentSvc
+	ledgerUsecase            usecase.LedgerSvc
+	reconciliationUsecase    usecase.ReconciliationSvc
+	walletFreezeUsecase      usecase.WalletFreezeSvc
+	walletHoldUsecase        usecase.WalletHoldSvc
+	reversalUsecase          usecase.TransactionReversalSvc
+	withdrawalUsecase        usecase.WithdrawalSvc
+	approvalUsecase          usecase.ApprovalSvc
+	limitUsecase             usecase.LimitSvc
+	riskUsecase              usecase.RiskSvc
+	scheduledTransferUsecase usecase.ScheduledTransferSvc
+	recurringTransferUsecase usecase.RecurringTransferSvc
+	paymentRequestUsecase    usecase.PaymentRequestSvc
+	transferBatchUsecase     usecase.TransferBatchSvc
+	paymentPinUsecase        usecase.PaymentPinSvc
 }
 
 func NewWalletHandler(u *service.Api) *WalletHandler {
@@ -24,5 +38,19 @@ func NewWalletHandler(u *service.Api) *WalletHandler {
 		transferUsecase:          u.TransferUseCase().Transfer,
 		walletMonitoringUsecase:  u.WalletMonitoringUseCase().WalletMonitoring,
 		balanceAdjustmentUsecase: u.BalanceAdjustmentUsecase().BalanceAdjustment,
+		ledgerUsecase:            u.LedgerUseCase().Ledger,
+		reconciliationUsecase:    u.ReconciliationUseCase().Reconciliation,
+		walletFreezeUsecase:      u.WalletFreezeUseCase().WalletFreeze,
+		walletHoldUsecase:        u.WalletHoldUseCase().WalletHold,
+		reversalUsecase:          u.TransactionReversalUseCase().TransactionReversal,
+		withdrawalUsecase:        u.WithdrawalUseCase().Withdrawal,
+		approvalUsecase:          u.ApprovalUseCase().Approval,
+		limitUsecase:             u.LimitUseCase().Limit,
+		riskUsecase:              u.RiskUseCase().Risk,
+		scheduledTransferUsecase: u.ScheduledTransferUseCase().ScheduledTransfer,
+		recurringTransferUsecase: u.RecurringTransferUseCase().RecurringTransfer,
+		paymentRequestUsecase:    u.PaymentRequestUseCase().PaymentRequest,
+		transferBatchUsecase:     u.TransferBatchUseCase().TransferBatch,
+		paymentPinUsecase:        u.PaymentPinUseCase().PaymentPin,
 	}
 }
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+)
+
+// GetLedgerBalances retrieves the balances of the double-entry ledger
+//
+// @Summary Get ledger balances
+// @Description Retrieve the total of all wallet balances, the balance of every system account and the outstanding escrow liabilities
+// @Tags Ledger
+// @Accept json
+// @Produce json
+// @Param currency query string false "Currency of the ledger, the default currency when empty" example(CNY)
+// @Success 200 {object} domain.GetLedgerBalancesResponse "Successfully retrieved ledger balances"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/ledger/balances [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetLedgerBalances(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetLedgerBalances", err)
+		}
+		span.End()
+	}()
+
+	var result *domain.GetLedgerBalancesResponse
+	result, err = h.ledgerUsecase.GetLedgerBalances(ctx, c.Query("currency"))
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// GetLimits retrieves the wallet limits
+//
+// @Summary Get wallet limits
+// @Description Retrieve the default wallet limits, and for a user the tier and user overrides with the limits that apply
+// @Tags Limits
+// @Accept json
+// @Produce json
+// @Param userID query string false "User ID to resolve the limits for"
+// @Success 200 {object} domain.GetLimitsResponse "Successfully retrieved limits"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/limits [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetLimits(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetLimits", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	request := domain.GetLimitsRequest{UserID: c.Query("userID")}
+
+	var resp *domain.GetLimitsResponse
+	resp, err = h.limitUsecase.GetLimits(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// SetLimitOverride sets the limit override of a tier or a user
+//
+// @Summary Set limit override
+// @Description Replace the limit override of a tier or a user, a null limit falls back to the tier or the default
+// @Tags Limits
+// @Accept json
+// @Produce json
+// @Param request body domain.SetLimitOverrideRequest true "Limit override"
+// @Success 200 {object} domain.LimitOverride "Successfully set limit override"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid scope or negative limit"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/limits/override [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) SetLimitOverride(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while SetLimitOverride", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.SetLimitOverrideRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.LimitOverride
+	resp, err = h.limitUsecase.SetLimitOverride(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// GetListLimitOverride retrieves a paginated list of limit overrides
+//
+// @Summary Get limit override list
+// @Description Retrieve the limit overrides of tiers and users
+// @Tags Limits
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Param scope query string false "Scope of the override" Enums(tier, user)
+// @Success 200 {object} domain.GetListLimitOverrideResponse "Successfully retrieved limit overrides"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid scope"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/limits/overrides [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListLimitOverride(c *gin.Context) {
+	var (
+		request  domain.GetListLimitOverrideRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListLimitOverride", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var defaultPage int64 = 1
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = defaultPage
+	}
+	request.Page = int32(page)
+
+	var defaultLimit int64 = 10
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	request.Limit = int32(limit)
+
+	request.Scope = c.Query("scope")
+
+	var result *domain.GetListLimitOverrideResponse
+	result, err = h.limitUsecase.GetListLimitOverride(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
@@ -0,0 +1,295 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// GetPaymentPinStatus tells whether the authenticated user has a payment PIN
+//
+// @Summary Get payment PIN status
+// @Description Whether the authenticated user has set a payment PIN and whether it is locked after too many wrong PINs
+// @Tags PaymentPin
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.PaymentPinStatusResponse "Successfully retrieved payment PIN status"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /wallet/pin/status [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetPaymentPinStatus(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetPaymentPinStatus", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var resp *domain.PaymentPinStatusResponse
+	resp, err = h.paymentPinUsecase.GetPaymentPinStatus(ctx, userID)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// SetPaymentPin sets the first payment PIN of the authenticated user
+//
+// @Summary Set payment PIN
+// @Description Set the 6-digit PIN the authenticated user confirms transfers, envelopes and withdrawals with
+// @Tags PaymentPin
+// @Accept json
+// @Produce json
+// @Param request body domain.SetPaymentPinRequest true "Set payment PIN request"
+// @Success 200 {object} apiresp.ApiResponse "Successfully set payment PIN"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - PIN already set, not 6 digits or too easy to guess"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /wallet/pin/set [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) SetPaymentPin(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while SetPaymentPin", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.SetPaymentPinRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+	request.DeviceID = c.GetHeader(entity.DeviceIDHeader)
+
+	err = h.paymentPinUsecase.SetPaymentPin(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, nil)
+}
+
+// ChangePaymentPin replaces the payment PIN of the authenticated user
+//
+// @Summary Change payment PIN
+// @Description Replace the payment PIN of the authenticated user, a wrong old PIN counts towards the lockout
+// @Tags PaymentPin
+// @Accept json
+// @Produce json
+// @Param request body domain.ChangePaymentPinRequest true "Change payment PIN request"
+// @Success 200 {object} apiresp.ApiResponse "Successfully changed payment PIN"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Wrong old PIN, PIN locked or new PIN too easy to guess"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /wallet/pin/change [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) ChangePaymentPin(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while ChangePaymentPin", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.ChangePaymentPinRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+	request.DeviceID = c.GetHeader(entity.DeviceIDHeader)
+
+	err = h.paymentPinUsecase.ChangePaymentPin(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, nil)
+}
+
+// ResetPaymentPin removes the payment PIN of a user by admin
+//
+// @Summary Reset payment PIN
+// @Description Remove the payment PIN of a user who forgot it and lift its lockout, the user sets a new PIN afterwards
+// @Tags PaymentPin
+// @Accept json
+// @Produce json
+// @Param request body domain.ResetPaymentPinRequest true "Reset payment PIN request"
+// @Success 200 {object} apiresp.ApiResponse "Successfully reset payment PIN"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - User has no payment PIN"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/wallet/pin/reset [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) ResetPaymentPin(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while ResetPaymentPin", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.ResetPaymentPinRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	err = h.paymentPinUsecase.ResetPaymentPin(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, nil)
+}
+
+// GetListPaymentPinAudit retrieves the payment PIN events of a user
+//
+// @Summary Get payment PIN audit list
+// @Description Retrieve the payment PIN events of a user: set, changed, reset, wrong PINs and lockouts
+// @Tags PaymentPin
+// @Accept json
+// @Produce json
+// @Param userID query string true "User ID"
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Success 200 {object} domain.GetListPaymentPinAuditResponse "Successfully retrieved payment PIN audits"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - User ID is required"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/wallet/pin/audits [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListPaymentPinAudit(c *gin.Context) {
+	var (
+		request  domain.GetListPaymentPinAuditRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListPaymentPinAudit", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var defaultPage int64 = 1
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = defaultPage
+	}
+	request.Page = int32(page)
+
+	var defaultLimit int64 = 10
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	request.Limit = int32(limit)
+
+	request.UserID = c.Query("userID")
+
+	var result *domain.GetListPaymentPinAuditResponse
+	result, err = h.paymentPinUsecase.GetListPaymentPinAudit(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
@@ -0,0 +1,264 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// CreatePaymentRequest asks another user for an amount
+//
+// @Summary Create payment request
+// @Description Ask another user to send an amount to the authenticated user, the payer can pay or decline it until it expires
+// @Tags PaymentRequest
+// @Accept json
+// @Produce json
+// @Param request body domain.CreatePaymentRequestRequest true "Payment request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Success 200 {object} domain.PaymentRequestResponse "Successfully created payment request"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid payer, amount or expiry"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /payment_request/create [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) CreatePaymentRequest(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while CreatePaymentRequest", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.CreatePaymentRequestRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	if utf8.RuneCountInString(request.Remark) > entity.MaxGreetingCharacters {
+		apiresp.GinError(c, eerrs.ErrGreetingLength(entity.MaxGreetingCharacters))
+		return
+	}
+
+	request.RequesterUserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.PaymentRequestResponse
+	resp, err = h.paymentRequestUsecase.CreatePaymentRequest(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// PayPaymentRequest pays a payment request addressed to the authenticated user
+//
+// @Summary Pay payment request
+// @Description Send the requested amount to the requester, the transfer is settled at once without a claim
+// @Tags PaymentRequest
+// @Accept json
+// @Produce json
+// @Param request body domain.PaymentRequestActionRequest true "Pay payment request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param X-Payment-Pin header string true "Payment PIN of the user, locked after too many wrong PINs in a row"
+// @Param X-Device-ID header string false "Device of the caller, the risk rules group transfers by it"
+// @Success 200 {object} domain.PaymentRequestResponse "Successfully paid payment request"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Payment request already paid, declined or expired, or insufficient balance"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 404 {object} apiresp.ApiResponse "Not Found - Payment request not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /payment_request/pay [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) PayPaymentRequest(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while PayPaymentRequest", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.PaymentRequestActionRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+	request.DeviceID = c.GetHeader(entity.DeviceIDHeader)
+
+	var resp *domain.PaymentRequestResponse
+	resp, err = h.paymentRequestUsecase.PayPaymentRequest(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// DeclinePaymentRequest turns down a payment request addressed to the authenticated user
+//
+// @Summary Decline payment request
+// @Description Turn down a pending payment request, nothing is sent to the requester
+// @Tags PaymentRequest
+// @Accept json
+// @Produce json
+// @Param request body domain.PaymentRequestActionRequest true "Decline payment request"
+// @Success 200 {object} domain.PaymentRequestResponse "Successfully declined payment request"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Payment request already paid, declined or expired"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 404 {object} apiresp.ApiResponse "Not Found - Payment request not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /payment_request/decline [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) DeclinePaymentRequest(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while DeclinePaymentRequest", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.PaymentRequestActionRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.PaymentRequestResponse
+	resp, err = h.paymentRequestUsecase.DeclinePaymentRequest(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// GetListPaymentRequest retrieves the payment requests made by or addressed to the authenticated user
+//
+// @Summary Get payment requests
+// @Description Retrieve the payment requests of the authenticated user, as requester, payer or both
+// @Tags PaymentRequest
+// @Accept json
+// @Produce json
+// @Param role query string false "Side of the user, both when empty" Enums(requester, payer)
+// @Param statusPaymentRequest query string false "Filter by status" Enums(pending, paid, declined, expired)
+// @Param page query int false "Page number for pagination" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Success 200 {object} domain.GetListPaymentRequestResponse "Successfully retrieved payment requests"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Request invalid"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /payment_request/list [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListPaymentRequest(c *gin.Context) {
+	var (
+		request  domain.GetListPaymentRequestRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListPaymentRequest", err)
+		}
+		span.End()
+	}()
+
+	request.UserID = c.GetString(constant.RpcOpUserID)
+	if request.UserID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+	request.Role = c.Query("role")
+	request.StatusPaymentRequest = c.Query("statusPaymentRequest")
+
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = domain.DefaultPage
+	}
+	request.Page = int32(page)
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = domain.DefaultLimit
+	}
+	request.Limit = int32(limit)
+
+	var result *domain.GetListPaymentRequestResponse
+	result, err = h.paymentRequestUsecase.GetListPaymentRequest(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+)
+
+// GetReconciliation retrieves a reconciliation run and the discrepancies it found
+//
+// @Summary Get reconciliation report
+// @Description Retrieve a reconciliation run, the latest one by default, with a paginated list of its discrepancies
+// @Tags Reconciliation
+// @Accept json
+// @Produce json
+// @Param runID query int false "Reconciliation run ID, the latest run when omitted"
+// @Param discrepancyType query string false "Type of discrepancy" Enums(wallet_balance, balance_chain, envelope_claimed)
+// @Param page query int false "Page number for pagination" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Success 200 {object} domain.GetReconciliationResponse "Successfully retrieved reconciliation report"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid discrepancy type or run not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/reconciliation [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetReconciliation(c *gin.Context) {
+	var (
+		request  domain.GetReconciliationRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetReconciliation", err)
+		}
+		span.End()
+	}()
+
+	request.ReconciliationRunID, _ = strconv.ParseInt(c.Query("runID"), 10, 64)
+	request.DiscrepancyType = c.Query("discrepancyType")
+
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = domain.DefaultPage
+	}
+	request.Page = int32(page)
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = domain.DefaultLimit
+	}
+	request.Limit = int32(limit)
+
+	var result *domain.GetReconciliationResponse
+	result, err = h.reconciliationUsecase.GetReconciliation(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
@@ -0,0 +1,380 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// CreateRecurringTransfer sets up a standing order of the authenticated user
+//
+// @Summary Create recurring transfer
+// @Description Send the same transfer to another user daily, weekly or monthly from startAt on, until endAt or maxOccurrences runs
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateRecurringTransferRequest true "Recurring transfer request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param X-Payment-Pin header string true "Payment PIN of the user, locked after too many wrong PINs in a row"
+// @Success 200 {object} domain.RecurringTransferResponse "Successfully created recurring transfer"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid transfer data, frequency or start and end time"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/recurring/create [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) CreateRecurringTransfer(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while CreateRecurringTransfer", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.CreateRecurringTransferRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	if utf8.RuneCountInString(request.Remark) > entity.MaxGreetingCharacters {
+		apiresp.GinError(c, eerrs.ErrGreetingLength(entity.MaxGreetingCharacters))
+		return
+	}
+
+	request.FromUserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.RecurringTransferResponse
+	resp, err = h.recurringTransferUsecase.CreateRecurringTransfer(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// PauseRecurringTransfer stops the runs of a standing order until it is resumed
+//
+// @Summary Pause recurring transfer
+// @Description Stop the runs of an active recurring transfer of the authenticated user until it is resumed
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param request body domain.RecurringTransferActionRequest true "Recurring transfer action request"
+// @Success 200 {object} domain.RecurringTransferResponse "Successfully paused recurring transfer"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Recurring transfer is not active"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 404 {object} apiresp.ApiResponse "Not Found - Recurring transfer not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/recurring/pause [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) PauseRecurringTransfer(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while PauseRecurringTransfer", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.RecurringTransferActionRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.RecurringTransferResponse
+	resp, err = h.recurringTransferUsecase.PauseRecurringTransfer(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// ResumeRecurringTransfer starts a paused standing order again
+//
+// @Summary Resume recurring transfer
+// @Description Start a paused recurring transfer again from its next run after now, runs missed while paused are not made
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param request body domain.RecurringTransferActionRequest true "Recurring transfer action request"
+// @Success 200 {object} domain.RecurringTransferResponse "Successfully resumed recurring transfer"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Recurring transfer is not paused"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 404 {object} apiresp.ApiResponse "Not Found - Recurring transfer not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/recurring/resume [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) ResumeRecurringTransfer(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while ResumeRecurringTransfer", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.RecurringTransferActionRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.RecurringTransferResponse
+	resp, err = h.recurringTransferUsecase.ResumeRecurringTransfer(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// CancelRecurringTransfer ends a standing order for good
+//
+// @Summary Cancel recurring transfer
+// @Description End an active or paused recurring transfer of the authenticated user, no further run is made
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param request body domain.RecurringTransferActionRequest true "Recurring transfer action request"
+// @Success 200 {object} domain.RecurringTransferResponse "Successfully canceled recurring transfer"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Recurring transfer already completed or canceled"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 404 {object} apiresp.ApiResponse "Not Found - Recurring transfer not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/recurring/cancel [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) CancelRecurringTransfer(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while CancelRecurringTransfer", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.RecurringTransferActionRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.RecurringTransferResponse
+	resp, err = h.recurringTransferUsecase.CancelRecurringTransfer(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// GetListRecurringTransfer retrieves the standing orders of the authenticated user
+//
+// @Summary Get recurring transfers
+// @Description Retrieve the recurring transfers of the authenticated user with their next run
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param statusRecurringTransfer query string false "Filter by status" Enums(active, paused, canceled, completed)
+// @Param page query int false "Page number for pagination" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Success 200 {object} domain.GetListRecurringTransferResponse "Successfully retrieved recurring transfers"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Request invalid"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/recurring/list [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListRecurringTransfer(c *gin.Context) {
+	var (
+		request  domain.GetListRecurringTransferRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListRecurringTransfer", err)
+		}
+		span.End()
+	}()
+
+	request.UserID = c.GetString(constant.RpcOpUserID)
+	if request.UserID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+	request.StatusRecurringTransfer = c.Query("statusRecurringTransfer")
+
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = domain.DefaultPage
+	}
+	request.Page = int32(page)
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = domain.DefaultLimit
+	}
+	request.Limit = int32(limit)
+
+	var result *domain.GetListRecurringTransferResponse
+	result, err = h.recurringTransferUsecase.GetListRecurringTransfer(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
+
+// GetListRecurringTransferRun retrieves the run history of a standing order
+//
+// @Summary Get recurring transfer runs
+// @Description Retrieve the runs of a recurring transfer of the authenticated user, skipped runs carry the reason they were not sent
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param recurringTransferID query int true "Recurring transfer ID"
+// @Param page query int false "Page number for pagination" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Success 200 {object} domain.GetListRecurringTransferRunResponse "Successfully retrieved recurring transfer runs"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Request invalid"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 404 {object} apiresp.ApiResponse "Not Found - Recurring transfer not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/recurring/runs [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListRecurringTransferRun(c *gin.Context) {
+	var (
+		request  domain.GetListRecurringTransferRunRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListRecurringTransferRun", err)
+		}
+		span.End()
+	}()
+
+	request.UserID = c.GetString(constant.RpcOpUserID)
+	if request.UserID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+	request.RecurringTransferID, _ = strconv.ParseInt(c.Query("recurringTransferID"), 10, 64)
+
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = domain.DefaultPage
+	}
+	request.Page = int32(page)
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = domain.DefaultLimit
+	}
+	request.Limit = int32(limit)
+
+	var result *domain.GetListRecurringTransferRunResponse
+	result, err = h.recurringTransferUsecase.GetListRecurringTransferRun(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// GetListRiskDecision retrieves a paginated list of risk decisions
+//
+// @Summary Get risk decision list
+// @Description Retrieve the decisions of the risk rules on transfers, envelopes and claims, newest first
+// @Tags Risk
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Param decision query string false "Decision of the risk rules" Enums(allow, review, deny)
+// @Param operation query string false "Operation judged" Enums(transfer_create, transfer_claim, envelope_create, envelope_claim)
+// @Param userID query string false "User who made the operation"
+// @Param referenceID query int false "Transfer or envelope ID"
+// @Success 200 {object} domain.GetListRiskDecisionResponse "Successfully retrieved risk decisions"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid decision or operation"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/risk/decisions [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListRiskDecision(c *gin.Context) {
+	var (
+		request  domain.GetListRiskDecisionRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListRiskDecision", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var defaultPage int64 = 1
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = defaultPage
+	}
+	request.Page = int32(page)
+
+	var defaultLimit int64 = 10
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	request.Limit = int32(limit)
+
+	request.Decision = c.Query("decision")
+	request.Operation = c.Query("operation")
+	request.UserID = c.Query("userID")
+	request.ReferenceID, _ = strconv.ParseInt(c.Query("referenceID"), 10, 64)
+
+	var result *domain.GetListRiskDecisionResponse
+	result, err = h.riskUsecase.GetListRiskDecision(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// CreateScheduledTransfer schedules a transfer of the authenticated user
+//
+// @Summary Schedule transfer
+// @Description Send a transfer to another user at a future time. Nothing is debited until then, hold keeps the amount aside meanwhile.
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateScheduledTransferRequest true "Scheduled transfer request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param X-Payment-Pin header string true "Payment PIN of the user, locked after too many wrong PINs in a row"
+// @Success 200 {object} domain.ScheduledTransferResponse "Successfully scheduled transfer"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid transfer data, execute time in the past or insufficient balance to hold"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/schedule/create [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) CreateScheduledTransfer(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while CreateScheduledTransfer", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.CreateScheduledTransferRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	if utf8.RuneCountInString(request.Remark) > entity.MaxGreetingCharacters {
+		apiresp.GinError(c, eerrs.ErrGreetingLength(entity.MaxGreetingCharacters))
+		return
+	}
+
+	request.FromUserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.ScheduledTransferResponse
+	resp, err = h.scheduledTransferUsecase.ScheduleTransfer(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// CancelScheduledTransfer cancels a scheduled transfer that has not run yet
+//
+// @Summary Cancel scheduled transfer
+// @Description Cancel a scheduled transfer of the authenticated user before it runs, the hold kept for it is released
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param request body domain.CancelScheduledTransferRequest true "Cancel scheduled transfer request"
+// @Success 200 {object} domain.ScheduledTransferResponse "Successfully canceled scheduled transfer"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Scheduled transfer already ran, failed or was canceled"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 404 {object} apiresp.ApiResponse "Not Found - Scheduled transfer not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/schedule/cancel [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) CancelScheduledTransfer(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while CancelScheduledTransfer", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.CancelScheduledTransferRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.ScheduledTransferResponse
+	resp, err = h.scheduledTransferUsecase.CancelScheduledTransfer(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// GetListScheduledTransfer retrieves the scheduled transfers of the authenticated user
+//
+// @Summary Get scheduled transfers
+// @Description Retrieve the scheduled transfers of the authenticated user, failed ones carry the reason they were not sent
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param statusScheduledTransfer query string false "Filter by status" Enums(scheduled, executed, failed, canceled)
+// @Param page query int false "Page number for pagination" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Success 200 {object} domain.GetListScheduledTransferResponse "Successfully retrieved scheduled transfers"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Request invalid"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/schedule/list [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListScheduledTransfer(c *gin.Context) {
+	var (
+		request  domain.GetListScheduledTransferRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListScheduledTransfer", err)
+		}
+		span.End()
+	}()
+
+	request.UserID = c.GetString(constant.RpcOpUserID)
+	if request.UserID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+	request.StatusScheduledTransfer = c.Query("statusScheduledTransfer")
+
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = domain.DefaultPage
+	}
+	request.Page = int32(page)
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = domain.DefaultLimit
+	}
+	request.Limit = int32(limit)
+
+	var result *domain.GetListScheduledTransferResponse
+	result, err = h.scheduledTransferUsecase.GetListScheduledTransfer(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
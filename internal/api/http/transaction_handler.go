@@ -20,11 +20,12 @@ import (
 // CreateTransaction creates a new wallet transaction
 //
 // @Summary Create wallet transaction
-// @Description Create a new transaction for the specified wallet with transaction details
+// @Description Create a new transaction for the specified wallet with transaction details, admin only
 // @Tags Wallet Transactions
 // @Accept json
 // @Produce json
 // @Param request body domain.CreateTransactionReq true "Transaction creation request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
 // @Success 200 {object} apiresp.ApiResponse "Successfully created transaction"
 // @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid transaction data"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
@@ -84,6 +85,7 @@ func (h *WalletHandler) CreateTransaction(c *gin.Context) {
 // @Param limit query int false "Number of items per page" minimum(1) default(10)
 // @Param startDate query string false "Start date for filtering (YYYY-MM-DD format)" format(date)
 // @Param endDate query string false "End date for filtering (YYYY-MM-DD format)" format(date)
+// @Param currency query string false "Currency of the wallet, the default currency when empty" example(CNY)
 // @Success 200 {object} domain.GetListTransactionResponse "Successfully retrieved transaction list"
 // @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid date format"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
@@ -114,6 +116,7 @@ func (h *WalletHandler) GetListTransaction(c *gin.Context) {
 		return
 	}
 	request.UserID = userID
+	request.Currency = c.Query("currency")
 
 	var defaultPage int64 = 1
 	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// ReverseTransaction reverses a posted wallet transaction by admin
+//
+// @Summary Reverse wallet transaction
+// @Description Book the opposite entry of a wallet transaction and roll back the deposit, transfer or envelope it was booked for.
+// @Description Claims and refunds cannot be reversed, neither can a transaction be reversed twice.
+// @Tags TransactionReversal
+// @Accept json
+// @Produce json
+// @Param id path int true "ID of the wallet transaction to reverse"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param request body domain.ReverseTransactionRequest true "Reversal request"
+// @Success 200 {object} domain.ReverseTransactionResponse "Successfully reversed wallet transaction"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Transaction not found, already reversed or not reversible"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/transactions/{id}/reverse [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) ReverseTransaction(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while ReverseTransaction", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.ReverseTransactionRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	// an id that does not parse is left at zero and rejected by the request validation
+	request.WalletTransactionID, _ = strconv.ParseInt(c.Param("id"), 10, 64)
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.ReverseTransactionResponse
+	resp, err = h.reversalUsecase.ReverseTransaction(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// CreateTransferBatch pays many recipients in a single request
+//
+// @Summary Create transfer batch
+// @Description Send a pending transfer to every recipient, an atomic batch sends all or none and a partial batch reports rejected items
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateTransferBatchRequest true "Transfer batch"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param X-Payment-Pin header string true "Payment PIN of the user, locked after too many wrong PINs in a row"
+// @Success 200 {object} domain.TransferBatchResponse "Successfully sent transfer batch"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid mode, recipients, amounts or insufficient balance"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/batch [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) CreateTransferBatch(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while CreateTransferBatch", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.CreateTransferBatchRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	for _, item := range request.Items {
+		if item != nil && utf8.RuneCountInString(item.Remark) > entity.MaxGreetingCharacters {
+			apiresp.GinError(c, eerrs.ErrGreetingLength(entity.MaxGreetingCharacters))
+			return
+		}
+	}
+
+	request.FromUserID = userID
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+	request.DeviceID = c.GetHeader(entity.DeviceIDHeader)
+
+	var resp *domain.TransferBatchResponse
+	resp, err = h.transferBatchUsecase.CreateTransferBatch(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// GetDetailTransferBatch returns a batch sent by the authenticated user with the result of every item
+//
+// @Summary Get detail transfer batch
+// @Description Get a transfer batch by id with the transfer or rejection of every recipient
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param transfer_batch_id path string true "ID of transfer batch"
+// @Success 200 {object} domain.TransferBatchResponse "Successfully get transfer batch detail"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid transfer batch ID"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 404 {object} apiresp.ApiResponse "Not Found - Transfer batch not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/batch/:transfer_batch_id/detail [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetDetailTransferBatch(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetDetailTransferBatch", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var transferBatchID int64
+	transferBatchID, err = strconv.ParseInt(c.Param("transfer_batch_id"), 10, 64)
+	if err != nil || transferBatchID <= 0 {
+		err = eerrs.ErrInvalidTransferBatchID
+		apiresp.GinError(c, err)
+		return
+	}
+
+	var resp *domain.TransferBatchResponse
+	resp, err = h.transferBatchUsecase.GetDetailTransferBatch(ctx, transferBatchID, userID)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
@@ -26,6 +26,9 @@ import (
 // @Accept json
 // @Produce json
 // @Param request body domain.CreateTransferRequest true "Transfer creation request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param X-Payment-Pin header string true "Payment PIN of the user, locked after too many wrong PINs in a row"
+// @Param X-Device-ID header string false "Device of the caller, the risk rules group claims by it"
 // @Success 200 {object} domain.CreateTransferResponse "Successfully created transfer"
 // @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid transfer data or insufficient balance"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
@@ -66,13 +69,10 @@ func (h *WalletHandler) CreateTransfer(c *gin.Context) {
 		apiresp.GinError(c, eerrs.ErrGreetingLength(entity.MaxGreetingCharacters))
 		return
 	}
-	if request.Amount > entity.MaxAllowedSendTransferAmount {
-		apiresp.GinError(c, eerrs.ErrTransferLimitExceeded)
-		return
-	}
 
 	request.FromUserID = userID
 	request.CreatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+	request.DeviceID = c.GetHeader(entity.DeviceIDHeader)
 
 	_, err = request.IsValid()
 	if err != nil {
@@ -98,6 +98,8 @@ func (h *WalletHandler) CreateTransfer(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param request body domain.ClaimTransferRequest true "Transfer claim request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param X-Device-ID header string false "Device of the caller, the risk rules group claims by it"
 // @Success 200 {object} apiresp.ApiResponse "Successfully claimed transfer"
 // @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid transfer ID or already claimed"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
@@ -136,6 +138,7 @@ func (h *WalletHandler) ClaimTransfer(c *gin.Context) {
 	}
 	request.ClaimerUserID = userID
 	request.OperateBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+	request.DeviceID = c.GetHeader(entity.DeviceIDHeader)
 
 	_, err = request.IsValid()
 	if err != nil {
@@ -213,6 +216,130 @@ func (h *WalletHandler) RefundTransfer(c *gin.Context) {
 	apiresp.GinSuccess(c, nil)
 }
 
+// CancelTransfer Cancel a pending transfer
+//
+// @Summary Cancel transfer
+// @Description Revoke a transfer of the authenticated user that has not been claimed or expired yet, the amount is credited back at once
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param request body domain.CancelTransferRequest true "Cancel transfer request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Success 200 {object} apiresp.ApiResponse "Successfully canceled transfer"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Transfer already claimed, expired or no longer pending"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 404 {object} apiresp.ApiResponse "Not Found - Transfer not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/cancel [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) CancelTransfer(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while CancelTransfer", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.CancelTransferRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	err = request.IsValid()
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+	request.OperatedBy = userID + "-" + c.GetString(constant.RpcOpUserType)
+
+	err = h.transferUsecase.CancelTransfer(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, nil)
+}
+
+// DeclineTransfer Decline an incoming transfer
+//
+// @Summary Decline transfer
+// @Description Turn down a pending transfer sent to the authenticated user, the amount goes back to the sender at once
+// @Tags Transfer
+// @Accept json
+// @Produce json
+// @Param request body domain.DeclineTransferRequest true "Decline transfer request"
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Success 200 {object} apiresp.ApiResponse "Successfully declined transfer"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Transfer already claimed, expired or no longer pending"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 404 {object} apiresp.ApiResponse "Not Found - Transfer not found"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /transfer/decline [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) DeclineTransfer(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while DeclineTransfer", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.DeclineTransferRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	err = request.IsValid()
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+	request.OperatedBy = userID + "-" + c.GetString(constant.RpcOpUserType)
+
+	err = h.transferUsecase.DeclineTransfer(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, nil)
+}
+
 // GetDetailTransfer Get detail transfer
 //
 // @Summary Get detail transfer
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// FreezeWallet freezes a wallet by admin
+//
+// @Summary Freeze wallet
+// @Description Block outgoing payments, incoming payments or both on a user wallet. Refunds and balance adjustments are still booked.
+// @Tags WalletFreeze
+// @Accept json
+// @Produce json
+// @Param request body domain.WalletFreezeRequest true "Wallet freeze request"
+// @Success 200 {object} domain.WalletFreezeResponse "Successfully froze wallet"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid freeze type or wallet already frozen"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/wallet/freeze [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) FreezeWallet(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while FreezeWallet", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.WalletFreezeRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.WalletFreezeResponse
+	resp, err = h.walletFreezeUsecase.FreezeWallet(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// UnfreezeWallet unfreezes a wallet by admin
+//
+// @Summary Unfreeze wallet
+// @Description Lift the freeze of outgoing payments, incoming payments or both on a user wallet
+// @Tags WalletFreeze
+// @Accept json
+// @Produce json
+// @Param request body domain.WalletFreezeRequest true "Wallet unfreeze request"
+// @Success 200 {object} domain.WalletFreezeResponse "Successfully unfroze wallet"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid freeze type or wallet not frozen"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/wallet/unfreeze [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) UnfreezeWallet(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while UnfreezeWallet", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.WalletFreezeRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.WalletFreezeResponse
+	resp, err = h.walletFreezeUsecase.UnfreezeWallet(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// GetListWalletFreezeHistory retrieves the freeze history of a wallet
+//
+// @Summary Get wallet freeze history
+// @Description Retrieve a paginated list of every freeze and unfreeze of a user wallet, latest first
+// @Tags WalletFreeze
+// @Accept json
+// @Produce json
+// @Param userID query string true "User ID of the wallet"
+// @Param currency query string false "Currency of the wallet, the default currency when empty" example(CNY)
+// @Param page query int false "Page number for pagination" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Success 200 {object} domain.GetListWalletFreezeHistoryResponse "Successfully retrieved wallet freeze history"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Request invalid"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/wallet/freeze/history [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListWalletFreezeHistory(c *gin.Context) {
+	var (
+		request  domain.GetListWalletFreezeHistoryRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListWalletFreezeHistory", err)
+		}
+		span.End()
+	}()
+
+	request.UserID = c.Query("userID")
+	request.Currency = c.Query("currency")
+
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = domain.DefaultPage
+	}
+	request.Page = int32(page)
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = domain.DefaultLimit
+	}
+	request.Limit = int32(limit)
+
+	var result *domain.GetListWalletFreezeHistoryResponse
+	result, err = h.walletFreezeUsecase.GetListFreezeHistory(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
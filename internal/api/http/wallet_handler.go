@@ -18,11 +18,11 @@ import (
 // GetWalletDetail retrieves detailed information about user's wallet
 //
 // @Summary Get wallet details
-// @Description Retrieve detailed information about the authenticated user's wallet including balance and status
+// @Description Retrieve every wallet of the authenticated user, one per currency, with its balance
 // @Tags Wallet
 // @Accept json
 // @Produce json
-// @Success 200 {object} apiresp.ApiResponse "Successfully retrieved wallet details"
+// @Success 200 {object} domain.WalletDetail "Successfully retrieved wallet details"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
 // @Failure 404 {object} apiresp.ApiResponse "Not Found - Wallet not found for user"
 // @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
@@ -51,23 +51,24 @@ func (h *WalletHandler) GetWalletDetail(c *gin.Context) {
 		return
 	}
 
-	var wallet *domain.Wallet
-	wallet, err = h.walletUsecase.GetWalletDetail(ctx, userID)
+	var detail *domain.WalletDetail
+	detail, err = h.walletUsecase.GetWalletDetail(ctx, userID)
 	if err != nil {
 		apiresp.GinError(c, err)
 		return
 	}
 
-	apiresp.GinSuccess(c, wallet)
+	apiresp.GinSuccess(c, detail)
 }
 
 // CreateWallet creates a new wallet for the authenticated user
 //
 // @Summary Create new wallet
-// @Description Create a new wallet for the authenticated user if they don't have one
+// @Description Create a new wallet for the authenticated user if they don't have one in the requested currency
 // @Tags Wallet
 // @Accept json
 // @Produce json
+// @Param request body domain.WalletCreateRequest false "Currency of the wallet, the default currency when omitted"
 // @Success 200 {object} apiresp.ApiResponse "Successfully created wallet"
 // @Failure 400 {object} apiresp.ApiResponse "Bad Request - Wallet already exists"
 // @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
@@ -98,8 +99,16 @@ func (h *WalletHandler) CreateWallet(c *gin.Context) {
 	}
 	createdBy := fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
 
+	var req domain.WalletCreateRequest
+	if c.Request.ContentLength > 0 {
+		if err = c.ShouldBindJSON(&req); err != nil {
+			apiresp.GinError(c, err)
+			return
+		}
+	}
+
 	var wallet *domain.Wallet
-	wallet, err = h.walletUsecase.CreateWallet(ctx, userID, createdBy)
+	wallet, err = h.walletUsecase.CreateWallet(ctx, userID, req.Currency, createdBy)
 	if err != nil {
 		apiresp.GinError(c, err)
 		return
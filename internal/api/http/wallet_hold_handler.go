@@ -0,0 +1,245 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// CreateWalletHold places a hold on a wallet by admin
+//
+// @Summary Create wallet hold
+// @Description Reserve part of a user wallet balance, e.g. for a pending payout or a dispute. The held amount stays in the balance but can no longer be spent.
+// @Tags WalletHold
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param request body domain.CreateWalletHoldRequest true "Wallet hold request"
+// @Success 200 {object} domain.WalletHoldResponse "Successfully created wallet hold"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid amount or insufficient available balance"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/wallet/hold [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) CreateWalletHold(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while CreateWalletHold", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.CreateWalletHoldRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.WalletHoldResponse
+	resp, err = h.walletHoldUsecase.CreateHold(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// ReleaseWalletHold releases a wallet hold by admin
+//
+// @Summary Release wallet hold
+// @Description Return the amount of an active hold to the available balance of the wallet
+// @Tags WalletHold
+// @Accept json
+// @Produce json
+// @Param request body domain.WalletHoldActionRequest true "Wallet hold release request"
+// @Success 200 {object} domain.WalletHoldResponse "Successfully released wallet hold"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Hold not found or not active"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/wallet/hold/release [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) ReleaseWalletHold(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while ReleaseWalletHold", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.WalletHoldActionRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.WalletHoldResponse
+	resp, err = h.walletHoldUsecase.ReleaseHold(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// CaptureWalletHold captures a wallet hold by admin
+//
+// @Summary Capture wallet hold
+// @Description Debit the amount of an active hold from the wallet, the debit is booked even on a frozen wallet
+// @Tags WalletHold
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param request body domain.WalletHoldActionRequest true "Wallet hold capture request"
+// @Success 200 {object} domain.WalletHoldResponse "Successfully captured wallet hold"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Hold not found or not active"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/wallet/hold/capture [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) CaptureWalletHold(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while CaptureWalletHold", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.WalletHoldActionRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.WalletHoldResponse
+	resp, err = h.walletHoldUsecase.CaptureHold(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// GetListWalletHold retrieves the holds of a wallet
+//
+// @Summary Get wallet holds
+// @Description Retrieve a paginated list of the holds of a user wallet, latest first
+// @Tags WalletHold
+// @Accept json
+// @Produce json
+// @Param userID query string true "User ID of the wallet"
+// @Param currency query string false "Currency of the wallet, the default currency when empty" example(CNY)
+// @Param statusHold query string false "Filter by hold status" Enums(active, released, captured)
+// @Param page query int false "Page number for pagination" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Success 200 {object} domain.GetListWalletHoldResponse "Successfully retrieved wallet holds"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Request invalid"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/wallet/hold/list [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListWalletHold(c *gin.Context) {
+	var (
+		request  domain.GetListWalletHoldRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListWalletHold", err)
+		}
+		span.End()
+	}()
+
+	request.UserID = c.Query("userID")
+	request.Currency = c.Query("currency")
+	request.StatusHold = c.Query("statusHold")
+
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = domain.DefaultPage
+	}
+	request.Page = int32(page)
+
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = domain.DefaultLimit
+	}
+	request.Limit = int32(limit)
+
+	var result *domain.GetListWalletHoldResponse
+	result, err = h.walletHoldUsecase.GetListHold(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
@@ -257,7 +257,7 @@ func (h *WalletHandler) GetEnvelopeDetail(c *gin.Context) {
 // @Param limit query int false "Number of items per page" minimum(1) default(10)
 // @Param from_user_id query string false "Source user ID filter" minlength(3) maxlength(50)
 // @Param to_user_id query string false "Destination user ID filter" minlength(3) maxlength(50)
-// @Param status_transfer query string false "Transfer status filter" Enums(pending, claimed, expired, refunded, canceled)
+// @Param status_transfer query string false "Transfer status filter" Enums(pending, claimed, expired, refunded, canceled, declined)
 // @Param from_amount query number false "Minimum transfer amount" minimum(0)
 // @Param to_amount query number false "Maximum transfer amount" minimum(0)
 // @Param from_expired_at query int false "Start timestamp for expiration filter" minimum(0)
@@ -622,8 +622,8 @@ func parseTransferAmountFilters(c *gin.Context, request *domain.GetTransferHisto
 	return nil
 }
 
-func validateAmountFilter(amountRaw string) (amount float64, err error) {
-	amount, err = strconv.ParseFloat(amountRaw, 64)
+func validateAmountFilter(amountRaw string) (amount entity.Money, err error) {
+	amount, err = entity.ParseMoney(amountRaw)
 	if err != nil {
 		return 0, err
 	}
@@ -635,7 +635,7 @@ func validateAmountFilter(amountRaw string) (amount float64, err error) {
 	return amount, nil
 }
 
-func validateAmountRange(fromAmount, toAmount float64) error {
+func validateAmountRange(fromAmount, toAmount entity.Money) error {
 	if fromAmount > 0 && toAmount > 0 && fromAmount > toAmount {
 		return errs.ErrArgs.WithDetail("from_amount cannot be greater than to_amount").Wrap()
 	}
@@ -742,7 +742,7 @@ func parseDateFilters(c *gin.Context, request *domain.GetListTransactionMonitori
 
 func parseNumericFilters(c *gin.Context, request *domain.GetListTransactionMonitoringRequest) error {
 	if fromAmount := strings.TrimSpace(c.Query("from_amount")); fromAmount != "" {
-		amount, err := strconv.ParseFloat(fromAmount, 64)
+		amount, err := entity.ParseMoney(fromAmount)
 		if err != nil {
 			return errs.ErrArgs.WithDetail(fmt.Sprintf("invalid from_amount parameter: %v", err.Error())).Wrap()
 		}
@@ -755,7 +755,7 @@ func parseNumericFilters(c *gin.Context, request *domain.GetListTransactionMonit
 	}
 
 	if toAmount := strings.TrimSpace(c.Query("to_amount")); toAmount != "" {
-		amount, err := strconv.ParseFloat(toAmount, 64)
+		amount, err := entity.ParseMoney(toAmount)
 		if err != nil {
 			return errs.ErrArgs.WithDetail(fmt.Sprintf("invalid to_amount parameter: %v", err.Error())).Wrap()
 		}
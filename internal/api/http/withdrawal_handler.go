@@ -0,0 +1,347 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// RequestWithdrawal requests a cash-out of the authenticated user
+//
+// @Summary Request withdrawal
+// @Description Request a withdrawal from the wallet of the authenticated user. The amount is held on the wallet until an admin rejects the request or marks it paid.
+// @Tags Withdrawal
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param X-Payment-Pin header string true "Payment PIN of the user, locked after too many wrong PINs in a row"
+// @Param request body domain.CreateWithdrawalRequest true "Withdrawal request"
+// @Success 200 {object} domain.WithdrawalResponse "Successfully requested withdrawal"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid amount or insufficient available balance"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /withdrawal/request [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) RequestWithdrawal(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while RequestWithdrawal", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.CreateWithdrawalRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.UserID = userID
+
+	var resp *domain.WithdrawalResponse
+	resp, err = h.withdrawalUsecase.RequestWithdrawal(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// ApproveWithdrawal approves a withdrawal request by admin
+//
+// @Summary Approve withdrawal
+// @Description Approve a requested withdrawal, the amount stays held until the withdrawal is marked paid
+// @Tags Withdrawal
+// @Accept json
+// @Produce json
+// @Param request body domain.ProcessWithdrawalRequest true "Withdrawal approval request"
+// @Success 200 {object} domain.WithdrawalResponse "Successfully approved withdrawal"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Withdrawal not found or not requested"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/withdrawal/approve [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) ApproveWithdrawal(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while ApproveWithdrawal", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.ProcessWithdrawalRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.WithdrawalResponse
+	resp, err = h.withdrawalUsecase.ApproveWithdrawal(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// RejectWithdrawal rejects a withdrawal request by admin
+//
+// @Summary Reject withdrawal
+// @Description Reject a requested or approved withdrawal and release its held amount back to the wallet
+// @Tags Withdrawal
+// @Accept json
+// @Produce json
+// @Param request body domain.ProcessWithdrawalRequest true "Withdrawal rejection request, reason is required"
+// @Success 200 {object} domain.WithdrawalResponse "Successfully rejected withdrawal"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Withdrawal not found, already paid or rejected"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/withdrawal/reject [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) RejectWithdrawal(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while RejectWithdrawal", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.ProcessWithdrawalRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.WithdrawalResponse
+	resp, err = h.withdrawalUsecase.RejectWithdrawal(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// MarkWithdrawalPaid marks an approved withdrawal paid by admin
+//
+// @Summary Mark withdrawal paid
+// @Description Record that an approved withdrawal was paid out, the held amount is debited from the wallet as a withdrawal
+// @Tags Withdrawal
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Replays the original response when the request is retried with the same key"
+// @Param request body domain.ProcessWithdrawalRequest true "Withdrawal payment request"
+// @Success 200 {object} domain.WithdrawalResponse "Successfully marked withdrawal paid"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Withdrawal not found or not approved"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/withdrawal/mark-paid [post]
+// @Security ApiKeyAuth
+func (h *WalletHandler) MarkWithdrawalPaid(c *gin.Context) {
+	var (
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while MarkWithdrawalPaid", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var request domain.ProcessWithdrawalRequest
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	request.OperatedBy = fmt.Sprintf("%s-%s", userID, c.GetString(constant.RpcOpUserType))
+
+	var resp *domain.WithdrawalResponse
+	resp, err = h.withdrawalUsecase.MarkWithdrawalPaid(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, resp)
+}
+
+// GetListWithdrawal retrieves a filtered and paginated list of withdrawal requests
+//
+// @Summary Get withdrawal list
+// @Description Retrieve a filtered and paginated list of withdrawal requests with various filtering and sorting options
+// @Tags Withdrawal
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param limit query int false "Number of items per page" minimum(1) default(10)
+// @Param startDate query string false "Start date for filtering (YYYY-MM-DD format)" format(date)
+// @Param endDate query string false "End date for filtering (YYYY-MM-DD format)" format(date)
+// @Param statusWithdrawal query string false "Status of the withdrawal request" Enums(requested, approved, rejected, paid)
+// @Param sortBy query string false "Column to sort by" default(created_at)
+// @Param sortOrder query string false "Sort order" Enums(asc, desc) default(desc)
+// @Param filterDateBy query string false "Date column to filter by" Enums(created_at, approved_at, rejected_at, paid_at, updated_at)
+// @Param userID query string false "User ID of the wallet"
+// @Param currency query string false "Currency of the user wallet, only used together with userID" example(CNY)
+// @Success 200 {object} domain.GetListWithdrawalResponse "Successfully retrieved withdrawal list"
+// @Failure 400 {object} apiresp.ApiResponse "Bad Request - Invalid date format or parameters"
+// @Failure 401 {object} apiresp.ApiResponse "Unauthorized - User ID not found in context"
+// @Failure 500 {object} apiresp.ApiResponse "Internal Server Error"
+// @Router /bo/withdrawal/list [get]
+// @Security ApiKeyAuth
+func (h *WalletHandler) GetListWithdrawal(c *gin.Context) {
+	var (
+		request  domain.GetListWithdrawalRequest
+		err      error
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelHandler)
+	)
+
+	ctx, span := t.Start(c.Request.Context(), funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			log.ZError(ctx, "an error occurred while GetListWithdrawal", err)
+		}
+		span.End()
+	}()
+
+	userID := c.GetString(constant.RpcOpUserID)
+	if userID == "" {
+		apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+		return
+	}
+
+	var defaultPage int64 = 1
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 32)
+	if page <= 0 {
+		page = defaultPage
+	}
+	request.Page = int32(page)
+
+	var defaultLimit int64 = 10
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 32)
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	request.Limit = int32(limit)
+
+	request.FilterDateBy = c.Query("filterDateBy")
+	request.StatusWithdrawal = c.Query("statusWithdrawal")
+	request.UserID = c.Query("userID")
+	request.Currency = c.Query("currency")
+
+	request.SortBy = c.Query("sortBy")
+	if request.SortBy == "" {
+		request.SortBy = "created_at"
+	}
+
+	request.SortOrder = c.Query("sortOrder")
+	if request.SortOrder == "" {
+		request.SortOrder = "desc"
+	}
+
+	var (
+		rawStartDate     = c.Query("startDate")
+		rawEndDate       = c.Query("endDate")
+		layoutFilterDate = "2006-01-02"
+	)
+
+	if rawStartDate != "" && rawEndDate != "" {
+		request.StartDate, err = time.Parse(layoutFilterDate, rawStartDate)
+		if err != nil {
+			apiresp.GinError(c, eerrs.ErrInvalidFormatStartDate)
+			return
+		}
+
+		request.EndDate, err = time.Parse(layoutFilterDate, rawEndDate)
+		if err != nil {
+			apiresp.GinError(c, eerrs.ErrInvalidFormatEndDate)
+			return
+		}
+	}
+
+	var result *domain.GetListWithdrawalResponse
+	result, err = h.withdrawalUsecase.GetListWithdrawal(ctx, &request)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+
+	apiresp.GinSuccess(c, result)
+}
@@ -0,0 +1,121 @@
+package mw
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/usecase"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+)
+
+// responseRecorder keeps a copy of the response body so it can be stored with the key.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency honours the Idempotency-Key header of money-moving endpoints. The first
+// request with a key runs the handler and stores its response, a retry with the same key
+// and payload gets that response back without running the handler again. Requests
+// without the header are passed through unchanged.
+func Idempotency(svc usecase.IdempotencySvc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(entity.IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Abort()
+			apiresp.GinError(c, err)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.Sum256(body)
+		begin, err := svc.Begin(c, &domain.BeginIdempotentRequest{
+			UserID:         c.GetString(constant.RpcOpUserID),
+			IdempotencyKey: key,
+			Endpoint:       c.Request.Method + " " + c.FullPath(),
+			RequestHash:    hex.EncodeToString(hash[:]),
+		})
+		if err != nil {
+			c.Abort()
+			apiresp.GinError(c, err)
+			return
+		}
+
+		if begin.Replay {
+			c.Header(entity.IdempotencyReplayHeader, "true")
+			c.Data(begin.ResponseCode, gin.MIMEJSON+"; charset=utf-8", begin.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		defer func() {
+			if r := recover(); r != nil {
+				releaseIdempotencyKey(c, svc, begin.IdempotencyKeyID)
+				panic(r)
+			}
+		}()
+
+		c.Next()
+
+		if !isSuccessResponse(recorder.Status(), recorder.body.Bytes()) {
+			releaseIdempotencyKey(c, svc, begin.IdempotencyKeyID)
+			return
+		}
+
+		// the money already moved, so the key stays claimed even if its response can't be stored
+		err = svc.Complete(c, begin.IdempotencyKeyID, recorder.Status(), recorder.body.Bytes())
+		if err != nil {
+			log.ZError(c, "while store idempotent response", err, "idempotencyKeyID", begin.IdempotencyKeyID)
+		}
+	}
+}
+
+// isSuccessResponse reports whether the handler succeeded. Failed requests are rolled back,
+// so their key is released instead of replaying the error to every retry.
+func isSuccessResponse(status int, body []byte) bool {
+	if status >= http.StatusBadRequest {
+		return false
+	}
+
+	var resp apiresp.ApiResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return false
+	}
+
+	return resp.ErrCode == 0
+}
+
+func releaseIdempotencyKey(c *gin.Context, svc usecase.IdempotencySvc, idempotencyKeyID int64) {
+	if err := svc.Release(c, idempotencyKeyID); err != nil {
+		log.ZError(c, "while release idempotency key", err, "idempotencyKeyID", idempotencyKeyID)
+	}
+}
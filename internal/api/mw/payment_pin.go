@@ -0,0 +1,39 @@
+package mw
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/1nterdigital/aka-im-tools/apiresp"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/usecase"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/constant"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// PaymentPin guards the endpoints that send money out of a wallet. The user's payment PIN has
+// to come in the X-Payment-Pin header, the handler only runs once it is verified.
+func PaymentPin(svc usecase.PaymentPinSvc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString(constant.RpcOpUserID)
+		if userID == "" {
+			c.Abort()
+			apiresp.GinError(c, eerrs.ErrUserIDNotFoundCtx)
+			return
+		}
+
+		err := svc.VerifyPaymentPin(c, &domain.VerifyPaymentPinRequest{
+			UserID:   userID,
+			Pin:      c.GetHeader(entity.PaymentPinHeader),
+			Endpoint: c.Request.Method + " " + c.FullPath(),
+			DeviceID: c.GetHeader(entity.DeviceIDHeader),
+		})
+		if err != nil {
+			c.Abort()
+			apiresp.GinError(c, err)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -27,34 +27,77 @@ func SetRouter(svcName string, api *walletapi.Api, mw *walletmw.MW) *gin.Engine
 	r.Use(otelgin.Middleware(svcName))
 
 	handler := http_api.NewWalletHandler(api)
+	idempotent := walletmw.Idempotency(api.IdempotencyUseCase().Idempotency)
+	pinRequired := walletmw.PaymentPin(api.PaymentPinUseCase().PaymentPin)
 
 	wallet := r.Group("wallet")
 	wallet.GET("/detail", handler.GetWalletDetail)
 	wallet.POST("/create", handler.CreateWallet)
+	wallet.GET("/pin/status", handler.GetPaymentPinStatus)
+	wallet.POST("/pin/set", handler.SetPaymentPin)
+	wallet.POST("/pin/change", handler.ChangePaymentPin)
 
 	transaction := r.Group("/wallet_transactions")
 	transaction.GET("/cash_flow", handler.GetListTransaction)
-	transaction.POST("/create", handler.CreateTransaction)
+	// posts a raw ledger entry for any wallet, only an admin may do that
+	transaction.POST("/create", mw.CheckAdmin, idempotent, handler.CreateTransaction)
 
 	transfer := r.Group("/transfer")
-	transfer.POST("/create", handler.CreateTransfer)
-	transfer.POST("/claim", handler.ClaimTransfer)
+	transfer.POST("/create", pinRequired, idempotent, handler.CreateTransfer)
+	transfer.POST("/claim", idempotent, handler.ClaimTransfer)
 	transfer.POST("/refund", handler.RefundTransfer)
+	transfer.POST("/cancel", idempotent, handler.CancelTransfer)
+	transfer.POST("/decline", idempotent, handler.DeclineTransfer)
 	transfer.GET("/:transfer_id/detail", handler.GetDetailTransfer)
+	transfer.POST("/batch", pinRequired, idempotent, handler.CreateTransferBatch)
+	transfer.GET("/batch/:transfer_batch_id/detail", handler.GetDetailTransferBatch)
+
+	schedule := transfer.Group("/schedule")
+	schedule.POST("/create", pinRequired, idempotent, handler.CreateScheduledTransfer)
+	schedule.POST("/cancel", handler.CancelScheduledTransfer)
+	schedule.GET("/list", handler.GetListScheduledTransfer)
+
+	recurring := transfer.Group("/recurring")
+	recurring.POST("/create", pinRequired, idempotent, handler.CreateRecurringTransfer)
+	recurring.POST("/pause", handler.PauseRecurringTransfer)
+	recurring.POST("/resume", handler.ResumeRecurringTransfer)
+	recurring.POST("/cancel", handler.CancelRecurringTransfer)
+	recurring.GET("/list", handler.GetListRecurringTransfer)
+	recurring.GET("/runs", handler.GetListRecurringTransferRun)
+
+	paymentRequest := r.Group("/payment_request")
+	paymentRequest.POST("/create", idempotent, handler.CreatePaymentRequest)
+	paymentRequest.POST("/pay", pinRequired, idempotent, handler.PayPaymentRequest)
+	paymentRequest.POST("/decline", handler.DeclinePaymentRequest)
+	paymentRequest.GET("/list", handler.GetListPaymentRequest)
 
 	envelope := r.Group("/envelope")
-	envelope.POST("/", handler.CreateEnvelopeHandler)
+	envelope.POST("/", pinRequired, idempotent, handler.CreateEnvelopeHandler)
 	envelope.POST("/claim", handler.ClaimEnvelopeHandler)
 	envelope.GET("/:envelope_id/details", handler.GetEnvelopeDetail)
 	envelope.GET("/autoRefund/", handler.AutoRefundEnvelopeHandler)
 	envelope.GET("/refund/:envelope_id", handler.RefundEnvelopeByID)
 
+	deposit := r.Group("/deposit")
+	deposit.POST("/request", idempotent, handler.RequestDeposit)
+
+	withdrawal := r.Group("/withdrawal")
+	withdrawal.POST("/request", pinRequired, idempotent, handler.RequestWithdrawal)
+
 	// admin
 	boRouter := r.Group("/bo", mw.CheckAdmin)
 
 	boWalletRecharge := boRouter.Group("/deposit")
-	boWalletRecharge.POST("/process", handler.ProcessDepositByAdmin)
+	boWalletRecharge.POST("/process", idempotent, handler.ProcessDepositByAdmin)
 	boWalletRecharge.GET("/list", handler.GetListDeposit)
+	boWalletRecharge.POST("/:id/approve", idempotent, handler.ApproveDeposit)
+	boWalletRecharge.POST("/:id/reject", handler.RejectDeposit)
+
+	boWithdrawal := boRouter.Group("/withdrawal")
+	boWithdrawal.POST("/approve", handler.ApproveWithdrawal)
+	boWithdrawal.POST("/reject", handler.RejectWithdrawal)
+	boWithdrawal.POST("/mark-paid", idempotent, handler.MarkWithdrawalPaid)
+	boWithdrawal.GET("/list", handler.GetListWithdrawal)
 
 	walletMonitoring := boRouter.Group("/wallet-monitoring")
 	walletMonitoring.GET("/transaction-volume", handler.GetDashboardTransactionVolume)
@@ -67,8 +110,40 @@ func SetRouter(svcName string, api *walletapi.Api, mw *walletmw.MW) *gin.Engine
 	boRouter.POST("/refund/manual_process", handler.ProcessManualRefund)
 
 	boBalanceAdjustment := boRouter.Group("/balance_adjustment")
-	boBalanceAdjustment.POST("/process", handler.BalanceAdjustmentByAdmin)
+	boBalanceAdjustment.POST("/process", idempotent, handler.BalanceAdjustmentByAdmin)
 	boBalanceAdjustment.GET("/list", handler.GetListBalanceAdjustment)
 
+	boLedger := boRouter.Group("/ledger")
+	boLedger.GET("/balances", handler.GetLedgerBalances)
+
+	boRouter.GET("/reconciliation", handler.GetReconciliation)
+
+	boWallet := boRouter.Group("/wallet")
+	boWallet.POST("/freeze", handler.FreezeWallet)
+	boWallet.POST("/unfreeze", handler.UnfreezeWallet)
+	boWallet.GET("/freeze/history", handler.GetListWalletFreezeHistory)
+	boWallet.POST("/hold", idempotent, handler.CreateWalletHold)
+	boWallet.POST("/hold/release", handler.ReleaseWalletHold)
+	boWallet.POST("/hold/capture", idempotent, handler.CaptureWalletHold)
+	boWallet.GET("/hold/list", handler.GetListWalletHold)
+	boWallet.POST("/pin/reset", handler.ResetPaymentPin)
+	boWallet.GET("/pin/audits", handler.GetListPaymentPinAudit)
+
+	boTransactions := boRouter.Group("/transactions")
+	boTransactions.POST("/:id/reverse", idempotent, handler.ReverseTransaction)
+
+	boApprovals := boRouter.Group("/approvals")
+	boApprovals.GET("", handler.GetListApprovalRequest)
+	boApprovals.POST("/:id/approve", idempotent, handler.ApproveRequest)
+	boApprovals.POST("/:id/reject", handler.RejectRequest)
+
+	boLimits := boRouter.Group("/limits")
+	boLimits.GET("", handler.GetLimits)
+	boLimits.POST("/override", handler.SetLimitOverride)
+	boLimits.GET("/overrides", handler.GetListLimitOverride)
+
+	boRisk := boRouter.Group("/risk")
+	boRisk.GET("/decisions", handler.GetListRiskDecision)
+
 	return r
 }
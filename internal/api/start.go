@@ -50,6 +50,8 @@ type Config struct {
 	MysqlConfig    config.Mysql
 	KafkaConfig    config.Kafka
 	TracerConfig   config.Tracer
+	LimitsConfig   config.Limits
+	RiskConfig     config.Risk
 	RuntimeEnv     string
 }
 
@@ -82,11 +84,19 @@ func initDatabase(
 
 // initService wires up repository, usecase, and wallet service
 func initService(
-	cfg *Config, conn *gorm.DB, mysqlDB *mysqlutil.Client, rdb redis.UniversalClient,
+	cfg *Config, conn *gorm.DB, mysqlDB *mysqlutil.Client, rdb redis.UniversalClient, im imapi.CallerInterface,
 ) (*walletService, *usecase.UseCase, error) {
 	repo := repository.NewRepository(conn)
 
-	uc, err := usecase.New(&usecase.Config{KafkaConfig: cfg.KafkaConfig}, repo, conn)
+	uc, err := usecase.New(&usecase.Config{
+		KafkaConfig: cfg.KafkaConfig,
+		Approval:    cfg.Share.Approval,
+		Limits:      cfg.LimitsConfig,
+		Risk:        cfg.RiskConfig,
+		PaymentPin:  cfg.Share.PaymentPin,
+		Redis:       rdb,
+		IM:          im,
+	}, repo, conn)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -136,7 +146,8 @@ func Start(ctx context.Context, index int, cfg *Config) error {
 		return err
 	}
 
-	srv, uc, err := initService(cfg, conn, pgDB, rdb)
+	im := imapi.New(cfg.Share.AkaIM.ApiURL, cfg.Share.AkaIM.Secret, cfg.Share.AkaIM.AdminUserID)
+	srv, uc, err := initService(cfg, conn, pgDB, rdb, im)
 	if err != nil {
 		return err
 	}
@@ -146,7 +157,6 @@ func Start(ctx context.Context, index int, cfg *Config) error {
 		return err
 	}
 
-	im := imapi.New(cfg.Share.AkaIM.ApiURL, cfg.Share.AkaIM.Secret, cfg.Share.AkaIM.AdminUserID)
 	base := util.Api{
 		ImUserID:          cfg.Share.AkaIM.AdminUserID,
 		ProxyHeader:       cfg.Share.ProxyHeader,
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	// ProcessApprovalRequest approves or rejects an operation waiting in the approval inbox.
+	ProcessApprovalRequest struct {
+		ApprovalRequestID int64 `json:"-"`
+		// Reason: required when rejecting
+		Reason     string `json:"reason"`
+		OperatedBy string `json:"-"`
+	}
+
+	ApprovalResponse struct {
+		ApprovalRequestID int64 `json:"approvalRequestID"`
+		// Operation: ["balance_adjustment", "deposit"]
+		Operation string `json:"operation"`
+		// StatusApproval: ["pending_approval", "approved", "rejected"]
+		StatusApproval string `json:"statusApproval"`
+		// OperationID: the balance adjustment or deposit booked on approval
+		OperationID *int64 `json:"operationID,omitempty"`
+	}
+
+	GetListApprovalRequest struct {
+		Page  int32 `json:"page"`
+		Limit int32 `json:"limit"`
+		// StatusApproval: ["pending_approval", "approved", "rejected"]
+		StatusApproval string `json:"statusApproval"`
+		// Operation: ["balance_adjustment", "deposit"]
+		Operation string `json:"operation"`
+	}
+
+	Approval struct {
+		ApprovalRequestID int64        `json:"approvalRequestID"`
+		Operation         string       `json:"operation"`
+		WalletID          int64        `json:"walletID"`
+		Amount            entity.Money `json:"amount"`
+		Currency          string       `json:"currency"`
+		// Payload: the request of the maker as it will be posted on approval
+		Payload        json.RawMessage `json:"payload"`
+		StatusApproval string          `json:"statusApproval"`
+		OperationID    *int64          `json:"operationID"`
+		RejectReason   string          `json:"rejectReason"`
+		CheckedBy      *string         `json:"checkedBy"`
+		CheckedAt      *time.Time      `json:"checkedAt"`
+		CreatedAt      time.Time       `json:"createdAt"`
+		CreatedBy      string          `json:"createdBy"`
+	}
+
+	GetListApprovalResponse struct {
+		TotalCount int64       `json:"total"`
+		Page       int32       `json:"page"`
+		Limit      int32       `json:"limit"`
+		Approvals  []*Approval `json:"approvals"`
+	}
+)
+
+func (r *ProcessApprovalRequest) Validate() error {
+	if r.ApprovalRequestID <= 0 {
+		return errors.New("approvalRequestID is required")
+	}
+
+	return nil
+}
+
+func (r *GetListApprovalRequest) Validate() error {
+	if r.StatusApproval != "" && !entity.StatusApproval(r.StatusApproval).IsValid() {
+		return eerrs.ErrInvalidStatusApproval
+	}
+	if r.Operation != "" && !entity.ApprovalOperation(r.Operation).IsValid() {
+		return eerrs.ErrInvalidApprovalOperation
+	}
+
+	return nil
+}
@@ -3,19 +3,29 @@ package domain
 import (
 	"errors"
 	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
 )
 
 type (
 	BalanceAdjustmentByAdminRequest struct {
-		Amount      float64 `json:"amount"`
-		UserID      string  `json:"userID"`
-		Reason      string  `json:"reason"`
-		Description string  `json:"description"`
+		Amount entity.Money `json:"amount"`
+		// Currency: currency of the wallet to adjust, the default currency when empty
+		Currency    string `json:"currency"`
+		UserID      string `json:"userID"`
+		Reason      string `json:"reason"`
+		Description string `json:"description"`
 		OperatedBy  string
+		// ApprovedBy: the second admin, set when an approved request is replayed
+		ApprovedBy string `json:"-"`
 	}
 
 	BalanceAdjustmentByAdminResponse struct {
 		BalanceAdjustmentID int64 `json:"balanceAdjustmentID"`
+		// ApprovalRequestID: set instead of BalanceAdjustmentID when the adjustment waits for a second admin
+		ApprovalRequestID int64 `json:"approvalRequestID,omitempty"`
+		// StatusApproval: "pending_approval" when the adjustment waits for a second admin
+		StatusApproval string `json:"statusApproval,omitempty"`
 	}
 
 	GetListbalanceAjustmentRequest struct {
@@ -27,6 +37,8 @@ type (
 		// EndDate: end date of FilterDateBy
 		EndDate time.Time `json:"endDate"`
 		UserID  string    `json:"userID"`
+		// Currency: currency of the user wallet, only used together with UserID
+		Currency string `json:"currency"`
 		// SortBy: sort by column
 		SortBy string `json:"sortBy"`
 		// SortOrder: ["asc", "desc"]
@@ -36,15 +48,16 @@ type (
 	}
 
 	BalanceAdjustment struct {
-		BalanceAdjustmentID int64     `json:"balanceAdjustmentID"`
-		WalletID            int64     `json:"walletID"`
-		Amount              float64   `json:"amount"`
-		Reason              string    `json:"reason"`
-		Description         string    `json:"description"`
-		CreatedAt           time.Time `json:"createdAt"`
-		CreatedBy           string    `json:"createdBy"`
-		UpdatedAt           time.Time `json:"updatedAt"`
-		UpdatedBy           string    `json:"updatedBy"`
+		BalanceAdjustmentID int64        `json:"balanceAdjustmentID"`
+		WalletID            int64        `json:"walletID"`
+		Amount              entity.Money `json:"amount"`
+		Currency            string       `json:"currency"`
+		Reason              string       `json:"reason"`
+		Description         string       `json:"description"`
+		CreatedAt           time.Time    `json:"createdAt"`
+		CreatedBy           string       `json:"createdBy"`
+		UpdatedAt           time.Time    `json:"updatedAt"`
+		UpdatedBy           string       `json:"updatedBy"`
 	}
 
 	GetListBalanceAdjustmentResponse struct {
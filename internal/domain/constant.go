@@ -1,5 +1,7 @@
 package domain
 
+import entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+
 var (
 	TransactionRefundTransferEN = "refund transfer from %d\n"
 	TransactionRefundTransferZN = "refund transfer from %d\n"
@@ -33,6 +35,8 @@ type PublisherKey string
 
 const (
 	PublisherKeyRefundTransferEnvelope PublisherKey = "refundTransferEnvelope"
+	PublisherKeyReconciliation         PublisherKey = "reconciliation"
+	PublisherKeyScheduledTransfer      PublisherKey = "scheduledTransfer"
 )
 
 const (
@@ -41,5 +45,6 @@ const (
 )
 
 const (
-	EnvelopeMinimumAmount = 0.01
+	// EnvelopeMinimumAmount is one minor unit, the smallest share an envelope can hold
+	EnvelopeMinimumAmount entity.Money = 1
 )
@@ -3,19 +3,28 @@ package domain
 import (
 	"errors"
 	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
 )
 
+// WalletRechargeRequestCreate is a deposit asked for by a user, the wallet is credited only
+// once an admin approves it.
 type WalletRechargeRequestCreate struct {
-	Amount float64 `json:"amount"`
-	Notes  string  `json:"notes"`
+	Amount entity.Money `json:"amount"`
+	// Currency: currency of the wallet to deposit to, the default currency when empty
+	Currency string `json:"currency"`
+	Notes    string `json:"notes"`
+	// ProofReference: optional reference of the payment proof, e.g. a bank transfer receipt number
+	ProofReference string `json:"proofReference"`
+	UserID         string `json:"-"`
 }
 
 type WalletRechargeRequest struct {
-	WalletRechargeRequestID string  `json:"walletRechargeRequestID"`
-	Amount                  float64 `json:"amount"`
-	StatusRequest           string  `json:"statusRequest"`
-	Description             string  `json:"description"`
-	Status                  string  `json:"status"`
+	WalletRechargeRequestID string       `json:"walletRechargeRequestID"`
+	Amount                  entity.Money `json:"amount"`
+	StatusRequest           string       `json:"statusRequest"`
+	Description             string       `json:"description"`
+	Status                  string       `json:"status"`
 }
 type WalletRechargeRequestProcess struct {
 	Status    string `json:"status"`
@@ -24,14 +33,43 @@ type WalletRechargeRequestProcess struct {
 
 type (
 	ProcessDepositByAdminRequest struct {
-		Amount      float64 `json:"amount"`
-		UserID      string  `json:"userID"`
-		Description string  `json:"description"`
+		Amount entity.Money `json:"amount"`
+		// Currency: currency of the wallet to deposit to, the default currency when empty
+		Currency    string `json:"currency"`
+		UserID      string `json:"userID"`
+		Description string `json:"description"`
 		OperatedBy  string
+		// ApprovedBy: the second admin, set when an approved request is replayed
+		ApprovedBy string `json:"-"`
 	}
 
 	ProcessDepositByAdminResponse struct {
 		WalletRechargeRequestID int64 `json:"walletRechargeRequestID"`
+		// ApprovalRequestID: set instead of WalletRechargeRequestID when the deposit waits for a second admin
+		ApprovalRequestID int64 `json:"approvalRequestID,omitempty"`
+		// StatusApproval: "pending_approval" when the deposit waits for a second admin
+		StatusApproval string `json:"statusApproval,omitempty"`
+	}
+
+	// ProcessDepositRequest approves or rejects a deposit requested by a user.
+	ProcessDepositRequest struct {
+		WalletRechargeRequestID int64 `json:"walletRechargeRequestID"`
+		// Reason: required when rejecting
+		Reason     string `json:"reason"`
+		OperatedBy string `json:"-"`
+		// ApprovedBy: the second admin, set when an approved request is replayed
+		ApprovedBy string `json:"-"`
+	}
+
+	DepositResponse struct {
+		WalletRechargeRequestID int64 `json:"walletRechargeRequestID"`
+		WalletID                int64 `json:"walletID"`
+		// StatusRequest: ["requested", "approved", "rejected"]
+		StatusRequest string `json:"statusRequest"`
+		// ApprovalRequestID: set when the deposit stays requested until a second admin approves it
+		ApprovalRequestID int64 `json:"approvalRequestID,omitempty"`
+		// StatusApproval: "pending_approval" when the deposit waits for a second admin
+		StatusApproval string `json:"statusApproval,omitempty"`
 	}
 
 	GetListDepositRequest struct {
@@ -43,7 +81,9 @@ type (
 		// EndDate: end date of FilterDateBy
 		EndDate time.Time `json:"endDate"`
 		UserID  string    `json:"userID"`
-		// StatusRequest: ["requested", "approved", "rejected", "failed"]
+		// Currency: currency of the user wallet, only used together with UserID
+		Currency string `json:"currency"`
+		// StatusRequest: ["requested", "approved", "rejected", "failed", "reversed"]
 		StatusRequest string `json:"statusRequest"`
 		// SortBy: sort by column
 		SortBy string `json:"sortBy"`
@@ -54,15 +94,18 @@ type (
 	}
 
 	Deposit struct {
-		DepositID     int64      `json:"depositID"`
-		WalletID      int64      `json:"walletID"`
-		Amount        float64    `json:"amount"`
-		CreatedAt     time.Time  `json:"createdAt"`
-		ApprovedAt    *time.Time `json:"approvedAt"`
-		OperatedBy    *string    `json:"operatedBy"`
-		StatusRequest string     `json:"statusRequest"`
-		Description   string     `json:"description"`
-		CreatedBy     string     `json:"createdBy"`
+		DepositID      int64        `json:"depositID"`
+		WalletID       int64        `json:"walletID"`
+		Amount         entity.Money `json:"amount"`
+		Currency       string       `json:"currency"`
+		CreatedAt      time.Time    `json:"createdAt"`
+		ApprovedAt     *time.Time   `json:"approvedAt"`
+		OperatedBy     *string      `json:"operatedBy"`
+		StatusRequest  string       `json:"statusRequest"`
+		Description    string       `json:"description"`
+		ProofReference string       `json:"proofReference"`
+		RejectReason   string       `json:"rejectReason"`
+		CreatedBy      string       `json:"createdBy"`
 	}
 
 	GetListDepositResponse struct {
@@ -84,3 +127,19 @@ func (p ProcessDepositByAdminRequest) IsValid() (valid bool, err error) {
 
 	return true, nil
 }
+
+func (r *WalletRechargeRequestCreate) Validate() error {
+	if !r.Amount.IsPositive() {
+		return errors.New("amount is required")
+	}
+
+	return nil
+}
+
+func (r *ProcessDepositRequest) Validate() error {
+	if r.WalletRechargeRequestID <= 0 {
+		return errors.New("walletRechargeRequestID is required")
+	}
+
+	return nil
+}
@@ -1,37 +1,56 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
 )
 
+// MaxExclusiveRecipients is the most users an exclusive envelope can be sent to.
+const MaxExclusiveRecipients = 100
+
 type EnvelopeDomain struct {
-	TotalAmount float64 `json:"total_amount"`
+	TotalAmount entity.Money `json:"total_amount"`
 }
 
 type EnvelopeCreateRequest struct {
-	UserID       string  `json:"userId"`
-	WalletID     int64   `json:"walletId" binding:"required"`
-	EnvelopeType string  `json:"envelopeType" binding:"required"`
-	TotalAmount  float64 `json:"totalAmount" binding:"required"`
-	TotalClaimer int     `json:"totalClaimer" binding:"required"`
-	Remarks      string  `json:"remarks" binding:"required"`
-	ToUserID     string  `json:"toUserId"`
+	UserID       string       `json:"userId"`
+	WalletID     int64        `json:"walletId" binding:"required"`
+	EnvelopeType string       `json:"envelopeType" binding:"required"`
+	TotalAmount  entity.Money `json:"totalAmount" binding:"required"`
+	TotalClaimer int          `json:"totalClaimer" binding:"required"`
+	Remarks      string       `json:"remarks" binding:"required"`
+	ToUserID     string       `json:"toUserId"`
+	// ToUserIDs: recipients of an exclusive envelope, one share is reserved for each and TotalClaimer
+	// has to match their number
+	ToUserIDs []string `json:"toUserIds"`
+	// SplitType: ["fixed", "lucky"], how an exclusive envelope is split among ToUserIDs, fixed when empty
+	SplitType string `json:"splitType"`
+	// GroupID: IM group the envelope is sent in, only its members can claim it and TotalClaimer
+	// can't exceed its member count. Empty for an envelope outside a group
+	GroupID string `json:"groupID"`
+	// ExpiresInMinutes: how long the envelope stays claimable, the configured default when 0
+	ExpiresInMinutes int64  `json:"expiresInMinutes"`
+	DeviceID         string `json:"-"`
 }
 
 type EnvelopeCreateResponse struct {
 	EnvelopeID          int64          `json:"envelopeID"`
 	UserID              string         `json:"userID"`
 	WalletID            int64          `json:"walletID"`
-	TotalAmount         float64        `json:"totalAmount"`
-	TotalAmountClaimed  float64        `json:"totalAmountClaimed"`
-	TotalAmountRefunded float64        `json:"totalAmountRefunded"`
+	Currency            string         `json:"currency"`
+	TotalAmount         entity.Money   `json:"totalAmount"`
+	TotalAmountClaimed  entity.Money   `json:"totalAmountClaimed"`
+	TotalAmountRefunded entity.Money   `json:"totalAmountRefunded"`
 	MaxNumReceived      int            `json:"maxNumReceived"`
 	EnvelopeType        string         `json:"envelopeType"`
+	GroupID             string         `json:"groupID"`
+	ToUserIDs           []string       `json:"toUserIds,omitempty"`
 	Remarks             string         `json:"remarks"`
 	ExpiredAt           *time.Time     `json:"expiredAt"`
 	RefundedAt          *time.Time     `json:"refundedAt"`
@@ -46,8 +65,10 @@ type EnvelopeCreateResponse struct {
 
 type EnvelopeDetailOption struct {
 	Envelope   *entity.Envelope
-	Amounts    []float64
+	Amounts    []entity.Money
 	ReceiverID string
+	// ReceiverIDs: the recipients of an exclusive envelope, in the order of Amounts
+	ReceiverIDs []string
 }
 
 type EnvelopeCancelRequest struct {
@@ -58,12 +79,13 @@ type EnvelopeClaimRequest struct {
 	UserID     string `json:"userId"`
 	EnvelopeID int64  `json:"envelopeId" binding:"required"`
 	WalletID   int64  `json:"walletId"`
+	DeviceID   string `json:"-"`
 }
 
 type EnvelopeClaimResponse struct {
 	EnvelopeDetailID     int64            `json:"envelope_detail_id" gorm:"column:envelope_detail_id;primaryKey;autoIncrement"`
 	EnvelopeID           int64            `json:"envelope_id" gorm:"column:envelope_id;not null"`
-	Amount               float64          `json:"amount" gorm:"column:amount;not null"`
+	Amount               entity.Money     `json:"amount" gorm:"column:amount;not null"`
 	UserID               string           `json:"user_id" gorm:"column:user_id;not null"`
 	EnvelopeDetailStatus string           `json:"envelope_detail_status" gorm:"column:envelope_detail_status;type:enum('pending','claimed','refunded');not null"` //nolint:lll // long enum tag required by GORM
 	ClaimedAt            *time.Time       `json:"claimed_at" gorm:"column:claimed_at"`
@@ -98,9 +120,55 @@ func IsEnvelopeExpired(expiredAt *time.Time) bool {
 
 func ParseEnvelopeType(s string) (entity.EnvelopeType, error) {
 	switch entity.EnvelopeType(s) {
-	case entity.EnvelopeTypeLucky, entity.EnvelopeTypeFixed, entity.EnvelopeTypeSingle:
+	case entity.EnvelopeTypeLucky, entity.EnvelopeTypeFixed, entity.EnvelopeTypeSingle, entity.EnvelopeTypeExclusive:
 		return entity.EnvelopeType(s), nil
 	default:
 		return "", fmt.Errorf("invalid envelope type: %s", s)
 	}
 }
+
+// ValidateRecipients checks the recipients of an exclusive envelope, other types take none.
+func (r *EnvelopeCreateRequest) ValidateRecipients() error {
+	if entity.EnvelopeType(r.EnvelopeType) != entity.EnvelopeTypeExclusive {
+		if len(r.ToUserIDs) > 0 || r.SplitType != "" {
+			return errors.New("toUserIds and splitType are only for exclusive envelopes")
+		}
+		return nil
+	}
+
+	if _, err := r.ShareSplit(); err != nil {
+		return err
+	}
+	if len(r.ToUserIDs) == 0 || len(r.ToUserIDs) > MaxExclusiveRecipients {
+		return eerrs.ErrExclusiveRecipients(MaxExclusiveRecipients)
+	}
+	seen := make(map[string]bool, len(r.ToUserIDs))
+	for _, toUserID := range r.ToUserIDs {
+		if toUserID == "" || toUserID == r.UserID || seen[toUserID] {
+			return eerrs.ErrExclusiveRecipients(MaxExclusiveRecipients)
+		}
+		seen[toUserID] = true
+	}
+	if r.TotalClaimer != len(r.ToUserIDs) {
+		return errors.New("totalClaimer must equal the number of toUserIds")
+	}
+
+	return nil
+}
+
+// ShareSplit is how the envelope is split into shares: an exclusive one by its SplitType, every
+// other type by itself.
+func (r *EnvelopeCreateRequest) ShareSplit() (entity.EnvelopeType, error) {
+	if entity.EnvelopeType(r.EnvelopeType) != entity.EnvelopeTypeExclusive {
+		return entity.EnvelopeType(r.EnvelopeType), nil
+	}
+
+	switch entity.EnvelopeType(r.SplitType) {
+	case "", entity.EnvelopeTypeFixed:
+		return entity.EnvelopeTypeFixed, nil
+	case entity.EnvelopeTypeLucky:
+		return entity.EnvelopeTypeLucky, nil
+	default:
+		return "", eerrs.ErrInvalidEnvelopeSplitType
+	}
+}
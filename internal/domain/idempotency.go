@@ -0,0 +1,19 @@
+package domain
+
+type (
+	BeginIdempotentRequest struct {
+		UserID         string
+		IdempotencyKey string
+		// Endpoint: method and route the key was first used on, e.g. "POST /transfer/create"
+		Endpoint    string
+		RequestHash string
+	}
+
+	BeginIdempotentResponse struct {
+		IdempotencyKeyID int64
+		// Replay: the request already completed, ResponseCode and ResponseBody hold its original result
+		Replay       bool
+		ResponseCode int
+		ResponseBody []byte
+	}
+)
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type (
+	SystemAccountBalance struct {
+		SystemAccount string       `json:"systemAccount"`
+		Balance       entity.Money `json:"balance"`
+	}
+
+	GetLedgerBalancesResponse struct {
+		Currency           string                  `json:"currency"`
+		TotalWalletBalance entity.Money            `json:"totalWalletBalance"`
+		SystemAccounts     []*SystemAccountBalance `json:"systemAccounts"`
+		// OutstandingLiabilities: money held in escrow for pending transfers and unclaimed envelopes
+		OutstandingLiabilities entity.Money `json:"outstandingLiabilities"`
+		// Imbalance: sum of every wallet and system account balance, anything but zero means
+		// money was moved without its counter-entry
+		Imbalance entity.Money `json:"imbalance"`
+	}
+)
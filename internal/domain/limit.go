@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	Limits struct {
+		TransferSendPerDay    int64        `json:"transferSendPerDay"`
+		TransferClaimPerDay   int64        `json:"transferClaimPerDay"`
+		MaxSendTransferAmount entity.Money `json:"maxSendTransferAmount"`
+		EnvelopeSendPerDay    int64        `json:"envelopeSendPerDay"`
+		EnvelopeClaimPerDay   int64        `json:"envelopeClaimPerDay"`
+		MaxSendEnvelopeAmount entity.Money `json:"maxSendEnvelopeAmount"`
+		SpendPerHour          entity.Money `json:"spendPerHour"`
+		SpendPerDay           entity.Money `json:"spendPerDay"`
+		SpendPer30Days        entity.Money `json:"spendPer30Days"`
+	}
+
+	// SetLimitOverrideRequest replaces the override of a tier or a user, a null limit keeps the
+	// value of the tier or the default.
+	SetLimitOverrideRequest struct {
+		// Scope: ["tier", "user"]
+		Scope string `json:"scope"`
+		// ScopeID: the tier name or the user ID
+		ScopeID string `json:"scopeID"`
+		// Tier: puts a user in a tier, only for the user scope
+		Tier                  *string       `json:"tier"`
+		TransferSendPerDay    *int64        `json:"transferSendPerDay"`
+		TransferClaimPerDay   *int64        `json:"transferClaimPerDay"`
+		MaxSendTransferAmount *entity.Money `json:"maxSendTransferAmount"`
+		EnvelopeSendPerDay    *int64        `json:"envelopeSendPerDay"`
+		EnvelopeClaimPerDay   *int64        `json:"envelopeClaimPerDay"`
+		MaxSendEnvelopeAmount *entity.Money `json:"maxSendEnvelopeAmount"`
+		SpendPerHour          *entity.Money `json:"spendPerHour"`
+		SpendPerDay           *entity.Money `json:"spendPerDay"`
+		SpendPer30Days        *entity.Money `json:"spendPer30Days"`
+		OperatedBy            string        `json:"-"`
+	}
+
+	LimitOverride struct {
+		LimitOverrideID       int64         `json:"limitOverrideID"`
+		Scope                 string        `json:"scope"`
+		ScopeID               string        `json:"scopeID"`
+		Tier                  *string       `json:"tier"`
+		TransferSendPerDay    *int64        `json:"transferSendPerDay"`
+		TransferClaimPerDay   *int64        `json:"transferClaimPerDay"`
+		MaxSendTransferAmount *entity.Money `json:"maxSendTransferAmount"`
+		EnvelopeSendPerDay    *int64        `json:"envelopeSendPerDay"`
+		EnvelopeClaimPerDay   *int64        `json:"envelopeClaimPerDay"`
+		MaxSendEnvelopeAmount *entity.Money `json:"maxSendEnvelopeAmount"`
+		SpendPerHour          *entity.Money `json:"spendPerHour"`
+		SpendPerDay           *entity.Money `json:"spendPerDay"`
+		SpendPer30Days        *entity.Money `json:"spendPer30Days"`
+		UpdatedAt             time.Time     `json:"updatedAt"`
+		UpdatedBy             string        `json:"updatedBy"`
+	}
+
+	GetLimitsRequest struct {
+		// UserID: resolves the limits of the user, only the defaults when empty
+		UserID string `json:"userID"`
+	}
+
+	GetLimitsResponse struct {
+		Defaults Limits `json:"defaults"`
+		// Tier: the tier the user is in, if any
+		Tier         *string        `json:"tier,omitempty"`
+		TierOverride *LimitOverride `json:"tierOverride,omitempty"`
+		UserOverride *LimitOverride `json:"userOverride,omitempty"`
+		// Effective: the limits the validation applies to the user
+		Effective Limits `json:"effective"`
+	}
+
+	GetListLimitOverrideRequest struct {
+		Page  int32 `json:"page"`
+		Limit int32 `json:"limit"`
+		// Scope: ["tier", "user"]
+		Scope string `json:"scope"`
+	}
+
+	GetListLimitOverrideResponse struct {
+		TotalCount     int64            `json:"total"`
+		Page           int32            `json:"page"`
+		Limit          int32            `json:"limit"`
+		LimitOverrides []*LimitOverride `json:"limitOverrides"`
+	}
+)
+
+func (r *SetLimitOverrideRequest) Validate() error {
+	if !entity.LimitScope(r.Scope).IsValid() {
+		return eerrs.ErrInvalidLimitScope
+	}
+	if r.ScopeID == "" {
+		return errors.New("scopeID is required")
+	}
+	if r.Tier != nil && entity.LimitScope(r.Scope) != entity.LimitScopeUser {
+		return errors.New("tier can only be set on a user")
+	}
+
+	for _, count := range []*int64{
+		r.TransferSendPerDay, r.TransferClaimPerDay, r.EnvelopeSendPerDay, r.EnvelopeClaimPerDay,
+	} {
+		if count != nil && *count < 0 {
+			return errors.New("daily limits cannot be negative")
+		}
+	}
+	for _, amount := range []*entity.Money{
+		r.MaxSendTransferAmount, r.MaxSendEnvelopeAmount, r.SpendPerHour, r.SpendPerDay, r.SpendPer30Days,
+	} {
+		if amount != nil && amount.IsNegative() {
+			return errors.New("amount limits cannot be negative")
+		}
+	}
+
+	return nil
+}
+
+func (r *GetListLimitOverrideRequest) Validate() error {
+	if r.Scope != "" && !entity.LimitScope(r.Scope).IsValid() {
+		return eerrs.ErrInvalidLimitScope
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	SetPaymentPinRequest struct {
+		Pin        string `json:"pin"`
+		UserID     string `json:"-"`
+		OperatedBy string `json:"-"`
+		DeviceID   string `json:"-"`
+	}
+
+	ChangePaymentPinRequest struct {
+		OldPin     string `json:"oldPin"`
+		NewPin     string `json:"newPin"`
+		UserID     string `json:"-"`
+		OperatedBy string `json:"-"`
+		DeviceID   string `json:"-"`
+	}
+
+	// ResetPaymentPinRequest removes the PIN of a user who forgot it, once support has verified
+	// who they are. The user sets a new one afterwards.
+	ResetPaymentPinRequest struct {
+		UserID     string `json:"userID"`
+		OperatedBy string `json:"-"`
+	}
+
+	VerifyPaymentPinRequest struct {
+		UserID string
+		Pin    string
+		// Endpoint: the request the PIN was entered for, kept on the audit of a wrong PIN
+		Endpoint string
+		DeviceID string
+	}
+
+	PaymentPinStatusResponse struct {
+		IsSet    bool `json:"isSet"`
+		IsLocked bool `json:"isLocked"`
+	}
+
+	GetListPaymentPinAuditRequest struct {
+		UserID string `json:"userID"`
+		Page   int32  `json:"page"`
+		Limit  int32  `json:"limit"`
+	}
+
+	PaymentPinAudit struct {
+		PaymentPinAuditID int64  `json:"paymentPinAuditID"`
+		UserID            string `json:"userID"`
+		// Event: ["set", "changed", "reset", "verify_failed", "locked"]
+		Event     string    `json:"event"`
+		Endpoint  string    `json:"endpoint"`
+		DeviceID  string    `json:"deviceID"`
+		CreatedAt time.Time `json:"createdAt"`
+		CreatedBy string    `json:"createdBy"`
+	}
+
+	GetListPaymentPinAuditResponse struct {
+		TotalCount int64              `json:"total"`
+		Page       int32              `json:"page"`
+		Limit      int32              `json:"limit"`
+		Audits     []*PaymentPinAudit `json:"audits"`
+	}
+)
+
+// ValidatePaymentPin accepts a PIN of exactly PaymentPinLength digits that is neither a single
+// repeated digit nor a run like 123456 or 654321.
+func ValidatePaymentPin(pin string) error {
+	if len(pin) != entity.PaymentPinLength {
+		return eerrs.ErrInvalidPaymentPin
+	}
+	for idx := range len(pin) {
+		if pin[idx] < '0' || pin[idx] > '9' {
+			return eerrs.ErrInvalidPaymentPin
+		}
+	}
+
+	repeated, ascending, descending := true, true, true
+	for idx := 1; idx < len(pin); idx++ {
+		step := int(pin[idx]) - int(pin[idx-1])
+		repeated = repeated && step == 0
+		ascending = ascending && step == 1
+		descending = descending && step == -1
+	}
+	if repeated || ascending || descending {
+		return eerrs.ErrWeakPaymentPin
+	}
+
+	return nil
+}
+
+func (r *SetPaymentPinRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+
+	return ValidatePaymentPin(r.Pin)
+}
+
+func (r *ChangePaymentPinRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+	if r.OldPin == r.NewPin {
+		return errors.New("new pin must differ from the old one")
+	}
+
+	return ValidatePaymentPin(r.NewPin)
+}
+
+func (r *ResetPaymentPinRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+
+	return nil
+}
+
+func (r *GetListPaymentPinAuditRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+const (
+	DefaultPaymentRequestExpiry = 24 * time.Hour
+	MaxPaymentRequestExpiry     = 7 * 24 * time.Hour
+)
+
+// PaymentRequestRole selects the requests listed by the side the user is on.
+type PaymentRequestRole string
+
+const (
+	PaymentRequestRoleRequester PaymentRequestRole = "requester"
+	PaymentRequestRolePayer     PaymentRequestRole = "payer"
+)
+
+type (
+	CreatePaymentRequestRequest struct {
+		PayerUserID string       `json:"payerUserID"`
+		Amount      entity.Money `json:"amount"`
+		// Currency: currency of the wallets on both sides, the default currency when empty
+		Currency string `json:"currency"`
+		Remark   string `json:"remark"`
+		// ExpiredAt: the request can no longer be paid after it, 24 hours from now when empty and
+		// at most 7 days from now
+		ExpiredAt       *time.Time `json:"expiredAt"`
+		RequesterUserID string     `json:"-"`
+		OperatedBy      string     `json:"-"`
+	}
+
+	// PaymentRequestActionRequest pays or declines a payment request addressed to the user.
+	PaymentRequestActionRequest struct {
+		PaymentRequestID int64  `json:"paymentRequestID"`
+		UserID           string `json:"-"`
+		OperatedBy       string `json:"-"`
+		DeviceID         string `json:"-"`
+	}
+
+	PaymentRequestResponse struct {
+		PaymentRequestID int64 `json:"paymentRequestID"`
+		// StatusPaymentRequest: ["pending", "paid", "declined", "expired"]
+		StatusPaymentRequest string    `json:"statusPaymentRequest"`
+		TransferID           *int64    `json:"transferID,omitempty"`
+		ExpiredAt            time.Time `json:"expiredAt"`
+	}
+
+	GetListPaymentRequestRequest struct {
+		UserID string `json:"-"`
+		// Role: ["requester", "payer"], the requests made by the user or addressed to the user,
+		// both when empty
+		Role string `json:"role"`
+		// StatusPaymentRequest: ["pending", "paid", "declined", "expired"], every status when empty
+		StatusPaymentRequest string `json:"statusPaymentRequest"`
+		Page                 int32  `json:"page"`
+		Limit                int32  `json:"limit"`
+	}
+
+	PaymentRequest struct {
+		PaymentRequestID     int64        `json:"paymentRequestID"`
+		RequesterUserID      string       `json:"requesterUserID"`
+		PayerUserID          string       `json:"payerUserID"`
+		Amount               entity.Money `json:"amount"`
+		Currency             string       `json:"currency"`
+		Remark               string       `json:"remark"`
+		StatusPaymentRequest string       `json:"statusPaymentRequest"`
+		TransferID           *int64       `json:"transferID"`
+		ExpiredAt            time.Time    `json:"expiredAt"`
+		PaidAt               *time.Time   `json:"paidAt"`
+		DeclinedAt           *time.Time   `json:"declinedAt"`
+		CreatedAt            time.Time    `json:"createdAt"`
+	}
+
+	GetListPaymentRequestResponse struct {
+		TotalCount      int64             `json:"total"`
+		Page            int32             `json:"page"`
+		Limit           int32             `json:"limit"`
+		PaymentRequests []*PaymentRequest `json:"paymentRequests"`
+	}
+)
+
+// Validate checks the request and sets the default expiry when none was given.
+func (r *CreatePaymentRequestRequest) Validate(now time.Time) error {
+	if r.PayerUserID == "" {
+		return errors.New("payerUserID is required")
+	}
+	if r.PayerUserID == r.RequesterUserID {
+		return errors.New("payer and requester must be different")
+	}
+	if r.Amount <= 0 {
+		return errors.New("amount must be greater than 0")
+	}
+
+	if r.ExpiredAt == nil {
+		expiredAt := now.Add(DefaultPaymentRequestExpiry)
+		r.ExpiredAt = &expiredAt
+	}
+	if !r.ExpiredAt.After(now) || r.ExpiredAt.After(now.Add(MaxPaymentRequestExpiry)) {
+		return eerrs.ErrInvalidPaymentRequestExpiry
+	}
+
+	return nil
+}
+
+func (r *PaymentRequestActionRequest) Validate() error {
+	if r.PaymentRequestID <= 0 {
+		return errors.New("paymentRequestID is required")
+	}
+
+	return nil
+}
+
+func (r *GetListPaymentRequestRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+	switch PaymentRequestRole(r.Role) {
+	case "", PaymentRequestRoleRequester, PaymentRequestRolePayer:
+	default:
+		return errors.New("role must be requester or payer")
+	}
+	if r.StatusPaymentRequest != "" && !entity.StatusPaymentRequest(r.StatusPaymentRequest).IsValid() {
+		return eerrs.ErrInvalidStatusPaymentRequest
+	}
+
+	return nil
+}
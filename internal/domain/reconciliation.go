@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	GetReconciliationRequest struct {
+		// ReconciliationRunID: run to report on, the latest run when empty
+		ReconciliationRunID int64  `json:"reconciliationRunID"`
+		DiscrepancyType     string `json:"discrepancyType"`
+		Page                int32  `json:"page"`
+		Limit               int32  `json:"limit"`
+	}
+
+	ReconciliationRun struct {
+		ReconciliationRunID int64      `json:"reconciliationRunID"`
+		Status              string     `json:"status"`
+		WalletsChecked      int64      `json:"walletsChecked"`
+		EnvelopesChecked    int64      `json:"envelopesChecked"`
+		DiscrepancyCount    int64      `json:"discrepancyCount"`
+		StartedAt           time.Time  `json:"startedAt"`
+		FinishedAt          *time.Time `json:"finishedAt"`
+	}
+
+	ReconciliationDiscrepancy struct {
+		ReconciliationDiscrepancyID int64        `json:"reconciliationDiscrepancyID"`
+		DiscrepancyType             string       `json:"discrepancyType"`
+		WalletID                    *int64       `json:"walletID"`
+		WalletTransactionID         *int64       `json:"walletTransactionID"`
+		EnvelopeID                  *int64       `json:"envelopeID"`
+		Expected                    entity.Money `json:"expected"`
+		Actual                      entity.Money `json:"actual"`
+		CreatedAt                   time.Time    `json:"createdAt"`
+	}
+
+	GetReconciliationResponse struct {
+		Run           *ReconciliationRun           `json:"run"`
+		TotalCount    int64                        `json:"total"`
+		Page          int32                        `json:"page"`
+		Limit         int32                        `json:"limit"`
+		Discrepancies []*ReconciliationDiscrepancy `json:"discrepancies"`
+	}
+)
+
+func (r *GetReconciliationRequest) Validate() error {
+	if r.DiscrepancyType != "" && !entity.DiscrepancyType(r.DiscrepancyType).IsValid() {
+		return eerrs.ErrInvalidDiscrepancyType
+	}
+
+	return nil
+}
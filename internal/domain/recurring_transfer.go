@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	CreateRecurringTransferRequest struct {
+		ToUserID string       `json:"toUserID"`
+		Amount   entity.Money `json:"amount"`
+		// Currency: currency of the wallets on both sides, the default currency when empty
+		Currency string `json:"currency"`
+		Remark   string `json:"remark"`
+		// Frequency: ["daily", "weekly", "monthly"]
+		Frequency string `json:"frequency"`
+		// StartAt: the first run, has to be in the future. Monthly runs keep to its day of the month
+		StartAt time.Time `json:"startAt"`
+		// EndAt: no run is made after it, runs until canceled when empty
+		EndAt *time.Time `json:"endAt"`
+		// MaxOccurrences: number of runs, skipped runs included, unlimited when 0
+		MaxOccurrences int    `json:"maxOccurrences"`
+		FromUserID     string `json:"-"`
+		OperatedBy     string `json:"-"`
+	}
+
+	// RecurringTransferActionRequest pauses, resumes or cancels a standing order.
+	RecurringTransferActionRequest struct {
+		RecurringTransferID int64  `json:"recurringTransferID"`
+		UserID              string `json:"-"`
+		OperatedBy          string `json:"-"`
+	}
+
+	RecurringTransferResponse struct {
+		RecurringTransferID int64 `json:"recurringTransferID"`
+		// StatusRecurringTransfer: ["active", "paused", "canceled", "completed"]
+		StatusRecurringTransfer string    `json:"statusRecurringTransfer"`
+		NextRunAt               time.Time `json:"nextRunAt"`
+	}
+
+	MsgKafkaRecurringTransfer struct {
+		Counter             int    `json:"counter"`
+		RecurringTransferID int64  `json:"recurringTransferID"`
+		OperatedBy          string `json:"operatedBy"`
+	}
+
+	GetListRecurringTransferRequest struct {
+		UserID string `json:"-"`
+		// StatusRecurringTransfer: ["active", "paused", "canceled", "completed"], every status when empty
+		StatusRecurringTransfer string `json:"statusRecurringTransfer"`
+		Page                    int32  `json:"page"`
+		Limit                   int32  `json:"limit"`
+	}
+
+	RecurringTransfer struct {
+		RecurringTransferID     int64        `json:"recurringTransferID"`
+		FromUserID              string       `json:"fromUserID"`
+		ToUserID                string       `json:"toUserID"`
+		Amount                  entity.Money `json:"amount"`
+		Currency                string       `json:"currency"`
+		Remark                  string       `json:"remark"`
+		Frequency               string       `json:"frequency"`
+		StartAt                 time.Time    `json:"startAt"`
+		EndAt                   *time.Time   `json:"endAt"`
+		MaxOccurrences          int          `json:"maxOccurrences"`
+		Occurrences             int          `json:"occurrences"`
+		NextRunAt               time.Time    `json:"nextRunAt"`
+		StatusRecurringTransfer string       `json:"statusRecurringTransfer"`
+		LastRunAt               *time.Time   `json:"lastRunAt"`
+		CanceledAt              *time.Time   `json:"canceledAt"`
+		CreatedAt               time.Time    `json:"createdAt"`
+	}
+
+	GetListRecurringTransferResponse struct {
+		TotalCount         int64                `json:"total"`
+		Page               int32                `json:"page"`
+		Limit              int32                `json:"limit"`
+		RecurringTransfers []*RecurringTransfer `json:"recurringTransfers"`
+	}
+
+	GetListRecurringTransferRunRequest struct {
+		RecurringTransferID int64  `json:"recurringTransferID"`
+		UserID              string `json:"-"`
+		Page                int32  `json:"page"`
+		Limit               int32  `json:"limit"`
+	}
+
+	RecurringTransferRun struct {
+		RecurringTransferRunID int64     `json:"recurringTransferRunID"`
+		Occurrence             int       `json:"occurrence"`
+		ScheduledAt            time.Time `json:"scheduledAt"`
+		// StatusRun: ["executed", "skipped"]
+		StatusRun  string `json:"statusRun"`
+		TransferID *int64 `json:"transferID"`
+		// FailureCode and FailureReason: why a skipped run was not sent, e.g. insufficient balance
+		FailureCode   int       `json:"failureCode,omitempty"`
+		FailureReason string    `json:"failureReason,omitempty"`
+		CreatedAt     time.Time `json:"createdAt"`
+	}
+
+	GetListRecurringTransferRunResponse struct {
+		TotalCount int64                   `json:"total"`
+		Page       int32                   `json:"page"`
+		Limit      int32                   `json:"limit"`
+		Runs       []*RecurringTransferRun `json:"runs"`
+	}
+)
+
+func (r *CreateRecurringTransferRequest) Validate(now time.Time) error {
+	transfer := CreateTransferRequest{FromUserID: r.FromUserID, ToUserID: r.ToUserID, Amount: r.Amount}
+	if _, err := transfer.IsValid(); err != nil {
+		return err
+	}
+	if !entity.RecurringFrequency(r.Frequency).IsValid() {
+		return eerrs.ErrInvalidRecurringFrequency
+	}
+	if !r.StartAt.After(now) || (r.EndAt != nil && r.EndAt.Before(r.StartAt)) {
+		return eerrs.ErrInvalidRecurringSchedule
+	}
+	if r.MaxOccurrences < 0 {
+		return errors.New("maxOccurrences must not be negative")
+	}
+
+	return nil
+}
+
+func (r *RecurringTransferActionRequest) Validate() error {
+	if r.RecurringTransferID <= 0 {
+		return errors.New("recurringTransferID is required")
+	}
+
+	return nil
+}
+
+func (r *GetListRecurringTransferRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+	if r.StatusRecurringTransfer != "" && !entity.StatusRecurringTransfer(r.StatusRecurringTransfer).IsValid() {
+		return eerrs.ErrInvalidStatusRecurringTransfer
+	}
+
+	return nil
+}
+
+func (r *GetListRecurringTransferRunRequest) Validate() error {
+	if r.RecurringTransferID <= 0 {
+		return errors.New("recurringTransferID is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	// RiskEvent describes a money movement about to commit, for the risk rules to judge.
+	RiskEvent struct {
+		Operation entity.RiskOperation
+		// ReferenceID: the transfer or envelope moved
+		ReferenceID int64
+		UserID      string
+		// CounterpartyID: the receiver of a transfer or direct envelope, the sender on a claim
+		CounterpartyID string
+		WalletID       int64
+		Amount         entity.Money
+		Currency       entity.Currency
+		DeviceID       string
+	}
+
+	GetListRiskDecisionRequest struct {
+		Page  int32 `json:"page"`
+		Limit int32 `json:"limit"`
+		// Decision: ["allow", "review", "deny"]
+		Decision string `json:"decision"`
+		// Operation: ["transfer_create", "transfer_claim", "envelope_create", "envelope_claim"]
+		Operation   string `json:"operation"`
+		UserID      string `json:"userID"`
+		ReferenceID int64  `json:"referenceID"`
+	}
+
+	RiskDecision struct {
+		RiskDecisionID int64        `json:"riskDecisionID"`
+		Operation      string       `json:"operation"`
+		ReferenceID    int64        `json:"referenceID"`
+		UserID         string       `json:"userID"`
+		CounterpartyID string       `json:"counterpartyID"`
+		WalletID       int64        `json:"walletID"`
+		Amount         entity.Money `json:"amount"`
+		Currency       string       `json:"currency"`
+		DeviceID       string       `json:"deviceID"`
+		Decision       string       `json:"decision"`
+		// Rules: the rules that fired
+		Rules     []string  `json:"rules"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+
+	GetListRiskDecisionResponse struct {
+		TotalCount    int64           `json:"total"`
+		Page          int32           `json:"page"`
+		Limit         int32           `json:"limit"`
+		RiskDecisions []*RiskDecision `json:"riskDecisions"`
+	}
+)
+
+func (r *GetListRiskDecisionRequest) Validate() error {
+	if r.Decision != "" && !entity.RiskDecisionType(r.Decision).IsValid() {
+		return eerrs.ErrInvalidRiskDecision
+	}
+	if r.Operation != "" && !entity.RiskOperation(r.Operation).IsValid() {
+		return eerrs.ErrInvalidRiskOperation
+	}
+
+	return nil
+}
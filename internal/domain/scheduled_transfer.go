@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	CreateScheduledTransferRequest struct {
+		ToUserID string       `json:"toUserID"`
+		Amount   entity.Money `json:"amount"`
+		// Currency: currency of the wallets on both sides, the default currency when empty
+		Currency string `json:"currency"`
+		Remark   string `json:"remark"`
+		// ExecuteAt: when the transfer is sent, has to be in the future
+		ExecuteAt time.Time `json:"executeAt"`
+		// Hold: keep the amount aside on the wallet until the transfer is sent
+		Hold       bool   `json:"hold"`
+		FromUserID string `json:"-"`
+		OperatedBy string `json:"-"`
+	}
+
+	CancelScheduledTransferRequest struct {
+		ScheduledTransferID int64  `json:"scheduledTransferID"`
+		UserID              string `json:"-"`
+		OperatedBy          string `json:"-"`
+	}
+
+	ScheduledTransferResponse struct {
+		ScheduledTransferID int64 `json:"scheduledTransferID"`
+		// StatusScheduledTransfer: ["scheduled", "executed", "failed", "canceled"]
+		StatusScheduledTransfer string `json:"statusScheduledTransfer"`
+		WalletHoldID            *int64 `json:"walletHoldID,omitempty"`
+	}
+
+	MsgKafkaScheduledTransfer struct {
+		Counter             int    `json:"counter"`
+		ScheduledTransferID int64  `json:"scheduledTransferID"`
+		OperatedBy          string `json:"operatedBy"`
+	}
+
+	GetListScheduledTransferRequest struct {
+		UserID string `json:"-"`
+		// StatusScheduledTransfer: ["scheduled", "executed", "failed", "canceled"], every status when empty
+		StatusScheduledTransfer string `json:"statusScheduledTransfer"`
+		Page                    int32  `json:"page"`
+		Limit                   int32  `json:"limit"`
+	}
+
+	ScheduledTransfer struct {
+		ScheduledTransferID     int64        `json:"scheduledTransferID"`
+		FromUserID              string       `json:"fromUserID"`
+		ToUserID                string       `json:"toUserID"`
+		Amount                  entity.Money `json:"amount"`
+		Currency                string       `json:"currency"`
+		Remark                  string       `json:"remark"`
+		ExecuteAt               time.Time    `json:"executeAt"`
+		StatusScheduledTransfer string       `json:"statusScheduledTransfer"`
+		WalletHoldID            *int64       `json:"walletHoldID"`
+		// TransferID: the transfer sent when the schedule ran
+		TransferID *int64 `json:"transferID"`
+		// FailureCode and FailureReason: why the transfer could not be sent, e.g. insufficient balance
+		FailureCode   int        `json:"failureCode,omitempty"`
+		FailureReason string     `json:"failureReason,omitempty"`
+		ExecutedAt    *time.Time `json:"executedAt"`
+		CanceledAt    *time.Time `json:"canceledAt"`
+		CreatedAt     time.Time  `json:"createdAt"`
+	}
+
+	GetListScheduledTransferResponse struct {
+		TotalCount         int64                `json:"total"`
+		Page               int32                `json:"page"`
+		Limit              int32                `json:"limit"`
+		ScheduledTransfers []*ScheduledTransfer `json:"scheduledTransfers"`
+	}
+)
+
+// Transfer is the request the schedule sends once it is due.
+func (r *CreateScheduledTransferRequest) Transfer() CreateTransferRequest {
+	return CreateTransferRequest{
+		FromUserID: r.FromUserID,
+		ToUserID:   r.ToUserID,
+		Amount:     r.Amount,
+		Currency:   r.Currency,
+		Remark:     r.Remark,
+		CreatedBy:  r.OperatedBy,
+	}
+}
+
+func (r *CreateScheduledTransferRequest) Validate(now time.Time) error {
+	if _, err := r.Transfer().IsValid(); err != nil {
+		return err
+	}
+	if !r.ExecuteAt.After(now) {
+		return eerrs.ErrInvalidScheduleTime
+	}
+
+	return nil
+}
+
+func (r *CancelScheduledTransferRequest) Validate() error {
+	if r.ScheduledTransferID <= 0 {
+		return errors.New("scheduledTransferID is required")
+	}
+
+	return nil
+}
+
+func (r *GetListScheduledTransferRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+	if r.StatusScheduledTransfer != "" && !entity.StatusScheduledTransfer(r.StatusScheduledTransfer).IsValid() {
+		return eerrs.ErrInvalidStatusScheduledTransfer
+	}
+
+	return nil
+}
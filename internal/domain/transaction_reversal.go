@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+)
+
+type (
+	ReverseTransactionRequest struct {
+		WalletTransactionID int64  `json:"-"`
+		Reason              string `json:"reason"`
+		OperatedBy          string `json:"-"`
+	}
+
+	ReverseTransactionResponse struct {
+		TransactionReversalID int64 `json:"transactionReversalID"`
+		// WalletTransactionID: the reversed transaction
+		WalletTransactionID int64 `json:"walletTransactionID"`
+		// ReversalTransactionID: the compensating transaction booked by the reversal
+		ReversalTransactionID int64 `json:"reversalTransactionID"`
+	}
+)
+
+func (r *ReverseTransactionRequest) Validate() error {
+	if r.WalletTransactionID <= 0 {
+		return errors.New("walletTransactionID is required")
+	}
+	if r.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// MaxTransferBatchItems is the most recipients a single batch may pay.
+const MaxTransferBatchItems = 100
+
+type (
+	CreateTransferBatchRequest struct {
+		// Currency: currency of every transfer of the batch, the default currency when empty
+		Currency string `json:"currency"`
+		// Mode: ["atomic", "partial"], atomic when empty. An atomic batch sends every transfer or
+		// none, a partial batch sends what it can and reports the rejected items
+		Mode       string                      `json:"mode"`
+		Items      []*TransferBatchItemRequest `json:"items"`
+		FromUserID string                      `json:"-"`
+		OperatedBy string                      `json:"-"`
+		DeviceID   string                      `json:"-"`
+	}
+
+	TransferBatchItemRequest struct {
+		ToUserID string       `json:"toUserID"`
+		Amount   entity.Money `json:"amount"`
+		Remark   string       `json:"remark"`
+	}
+
+	TransferBatchResponse struct {
+		TransferBatchID int64        `json:"transferBatchID"`
+		Mode            string       `json:"mode"`
+		Currency        string       `json:"currency"`
+		TotalAmount     entity.Money `json:"totalAmount"`
+		ItemCount       int          `json:"itemCount"`
+		SucceededCount  int          `json:"succeededCount"`
+		FailedCount     int          `json:"failedCount"`
+		// StatusTransferBatch: ["processing", "completed", "partially_completed", "failed"]
+		StatusTransferBatch string               `json:"statusTransferBatch"`
+		CreatedAt           time.Time            `json:"createdAt"`
+		Items               []*TransferBatchItem `json:"items"`
+	}
+
+	TransferBatchItem struct {
+		ToUserID string       `json:"toUserID"`
+		Amount   entity.Money `json:"amount"`
+		Remark   string       `json:"remark"`
+		// StatusTransferBatchItem: ["succeeded", "failed"]
+		StatusTransferBatchItem string `json:"statusTransferBatchItem"`
+		TransferID              *int64 `json:"transferID"`
+		// FailureCode and FailureReason: why a failed item was not sent, e.g. a recipient without a wallet
+		FailureCode   int    `json:"failureCode,omitempty"`
+		FailureReason string `json:"failureReason,omitempty"`
+	}
+)
+
+func (r *CreateTransferBatchRequest) Validate() error {
+	if r.Mode == "" {
+		r.Mode = entity.TransferBatchModeAtomic.String()
+	}
+	if !entity.TransferBatchMode(r.Mode).IsValid() {
+		return eerrs.ErrInvalidTransferBatchMode
+	}
+	if len(r.Items) == 0 || len(r.Items) > MaxTransferBatchItems {
+		return eerrs.ErrTransferBatchSize(MaxTransferBatchItems)
+	}
+
+	for idx, item := range r.Items {
+		if item == nil {
+			return fmt.Errorf("items[%d]: item is required", idx)
+		}
+		transfer := CreateTransferRequest{FromUserID: r.FromUserID, ToUserID: item.ToUserID, Amount: item.Amount}
+		if _, err := transfer.IsValid(); err != nil {
+			return fmt.Errorf("items[%d]: %w", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// TotalAmount is the amount the batch debits from the sender when every item is sent.
+func (r *CreateTransferBatchRequest) TotalAmount() (total entity.Money) {
+	for _, item := range r.Items {
+		total += item.Amount
+	}
+
+	return total
+}
@@ -3,23 +3,30 @@ package domain
 import (
 	"errors"
 	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
 )
 
 type TransferDomain struct {
 	FromUserID     string
 	ToUserID       string
-	Amount         float64
+	Amount         entity.Money
 	StatusTransfer string
 	Remark         string
 }
 
 type (
 	CreateTransferRequest struct {
-		FromUserID string  `json:"fromUserID"`
-		ToUserID   string  `json:"toUserID"`
-		Amount     float64 `json:"amount"`
-		Remark     string  `json:"remark"`
-		CreatedBy  string
+		FromUserID string       `json:"fromUserID"`
+		ToUserID   string       `json:"toUserID"`
+		Amount     entity.Money `json:"amount"`
+		// Currency: currency of the wallets on both sides, the default currency when empty
+		Currency string `json:"currency"`
+		Remark   string `json:"remark"`
+		// ExpiresInMinutes: how long the transfer stays claimable, the configured default when 0
+		ExpiresInMinutes int64 `json:"expiresInMinutes"`
+		CreatedBy        string
+		DeviceID         string `json:"-"`
 	}
 
 	CreateTransferResponse struct {
@@ -36,6 +43,7 @@ type (
 		TransferID    int64 `json:"transferID"`
 		ClaimerUserID string
 		OperateBy     string
+		DeviceID      string `json:"-"`
 	}
 
 	RefundTransferReq struct {
@@ -44,20 +52,35 @@ type (
 		UserID     string
 	}
 
+	CancelTransferRequest struct {
+		TransferID int64 `json:"transferID"`
+		OperatedBy string
+		UserID     string
+	}
+
+	DeclineTransferRequest struct {
+		TransferID int64 `json:"transferID"`
+		OperatedBy string
+		UserID     string
+	}
+
 	Transfer struct {
-		TransferID     int64      `json:"transferID"`
-		FromUserID     string     `json:"fromUserID"`
-		ToUserID       string     `json:"toUserID"`
-		Amount         float64    `json:"amount"`
-		StatusTransfer string     `json:"statusTransfer"`
-		Remark         string     `json:"remark"`
-		ExpiredAt      *time.Time `json:"expiredAt"`
-		RefundedAt     *time.Time `json:"refundedAt"`
-		ClaimedAt      *time.Time `json:"claimedAt"`
-		CreatedAt      time.Time  `json:"createdAt"`
-		CreatedBy      string     `json:"createdBy"`
-		UpdatedAt      time.Time  `json:"updatedAt"`
-		UpdatedBy      string     `json:"updatedBy"`
+		TransferID     int64        `json:"transferID"`
+		FromUserID     string       `json:"fromUserID"`
+		ToUserID       string       `json:"toUserID"`
+		Amount         entity.Money `json:"amount"`
+		Currency       string       `json:"currency"`
+		StatusTransfer string       `json:"statusTransfer"`
+		Remark         string       `json:"remark"`
+		ExpiredAt      *time.Time   `json:"expiredAt"`
+		RefundedAt     *time.Time   `json:"refundedAt"`
+		ClaimedAt      *time.Time   `json:"claimedAt"`
+		CanceledAt     *time.Time   `json:"canceledAt"`
+		DeclinedAt     *time.Time   `json:"declinedAt"`
+		CreatedAt      time.Time    `json:"createdAt"`
+		CreatedBy      string       `json:"createdBy"`
+		UpdatedAt      time.Time    `json:"updatedAt"`
+		UpdatedBy      string       `json:"updatedBy"`
 	}
 )
 
@@ -92,3 +115,19 @@ func (c RefundTransferReq) IsValid() error {
 
 	return nil
 }
+
+func (c CancelTransferRequest) IsValid() error {
+	if c.TransferID <= 0 {
+		return errors.New("transfer id must be greater than 0")
+	}
+
+	return nil
+}
+
+func (c DeclineTransferRequest) IsValid() error {
+	if c.TransferID <= 0 {
+		return errors.New("transfer id must be greater than 0")
+	}
+
+	return nil
+}
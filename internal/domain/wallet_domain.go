@@ -1,16 +1,23 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
 
 type WalletDomain struct {
-	WalletID  int64     `json:"walletID"`
-	Balance   float64   `json:"balance"`
-	IsActive  bool      `json:"isActive"`
-	CreatedAt time.Time `json:"createdAt"`
+	WalletID  int64        `json:"walletID"`
+	Currency  string       `json:"currency"`
+	Balance   entity.Money `json:"balance"`
+	IsActive  bool         `json:"isActive"`
+	CreatedAt time.Time    `json:"createdAt"`
 }
 
 type WalletCreateRequest struct {
 	UserId string `json:"userID"`
+	// Currency: currency of the new wallet, the default currency when empty
+	Currency string `json:"currency"`
 }
 
 type WalletActiveRequest struct {
@@ -19,20 +26,33 @@ type WalletActiveRequest struct {
 }
 
 type WalletUpdateBalanceRequest struct {
-	Amount          float64 `json:"amount"`
-	EntryType       string  `json:"entryType"`
-	TransactionType string  `json:"transactionType"`
-	ReferenceCode   string  `json:"referenceCode"`
-	Description     string  `json:"description"`
-	CreatedBy       string  `json:"createdBy"`
+	Amount          entity.Money `json:"amount"`
+	EntryType       string       `json:"entryType"`
+	TransactionType string       `json:"transactionType"`
+	ReferenceCode   string       `json:"referenceCode"`
+	Description     string       `json:"description"`
+	CreatedBy       string       `json:"createdBy"`
 }
 
 type (
 	Wallet struct {
-		ID        int64     `json:"walletID"`
-		UserID    string    `json:"userID"`
-		Balance   float64   `json:"balance"`
-		CreatedAt time.Time `json:"createdAt"`
-		CreatedBy string    `json:"createdBy"`
+		ID       int64        `json:"walletID"`
+		UserID   string       `json:"userID"`
+		Currency string       `json:"currency"`
+		Balance  entity.Money `json:"balance"`
+		// HeldBalance: part of the balance reserved by active holds
+		HeldBalance entity.Money `json:"heldBalance"`
+		// AvailableBalance: balance minus held balance, the most that can be spent
+		AvailableBalance entity.Money `json:"availableBalance"`
+		// FreezeStatus: ["none", "debit_frozen", "credit_frozen", "frozen"]
+		FreezeStatus string    `json:"freezeStatus"`
+		CreatedAt    time.Time `json:"createdAt"`
+		CreatedBy    string    `json:"createdBy"`
+	}
+
+	// WalletDetail holds every wallet of a user, one per currency.
+	WalletDetail struct {
+		UserID  string    `json:"userID"`
+		Wallets []*Wallet `json:"wallets"`
 	}
 )
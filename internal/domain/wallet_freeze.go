@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	WalletFreezeRequest struct {
+		UserID string `json:"userID"`
+		// Currency: currency of the wallet, the default currency when empty
+		Currency string `json:"currency"`
+		// FreezeType: ["debit", "credit", "full"]
+		FreezeType string `json:"freezeType"`
+		Reason     string `json:"reason"`
+		OperatedBy string `json:"-"`
+	}
+
+	WalletFreezeResponse struct {
+		WalletID              int64  `json:"walletID"`
+		WalletFreezeHistoryID int64  `json:"walletFreezeHistoryID"`
+		FreezeStatus          string `json:"freezeStatus"`
+	}
+
+	GetListWalletFreezeHistoryRequest struct {
+		UserID string `json:"userID"`
+		// Currency: currency of the wallet, the default currency when empty
+		Currency string `json:"currency"`
+		WalletID int64  `json:"-"`
+		Page     int32  `json:"page"`
+		Limit    int32  `json:"limit"`
+	}
+
+	WalletFreezeHistory struct {
+		WalletFreezeHistoryID int64     `json:"walletFreezeHistoryID"`
+		WalletID              int64     `json:"walletID"`
+		FreezeAction          string    `json:"freezeAction"`
+		FreezeType            string    `json:"freezeType"`
+		StatusBefore          string    `json:"statusBefore"`
+		StatusAfter           string    `json:"statusAfter"`
+		Reason                string    `json:"reason"`
+		CreatedAt             time.Time `json:"createdAt"`
+		CreatedBy             string    `json:"createdBy"`
+	}
+
+	GetListWalletFreezeHistoryResponse struct {
+		TotalCount int64                  `json:"total"`
+		Page       int32                  `json:"page"`
+		Limit      int32                  `json:"limit"`
+		Histories  []*WalletFreezeHistory `json:"histories"`
+	}
+)
+
+func (r *WalletFreezeRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+	if !entity.FreezeType(r.FreezeType).IsValid() {
+		return eerrs.ErrInvalidFreezeType
+	}
+	if r.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	return nil
+}
+
+func (r *GetListWalletFreezeHistoryRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+
+	return nil
+}
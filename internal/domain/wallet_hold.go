@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	CreateWalletHoldRequest struct {
+		UserID string `json:"userID"`
+		// Currency: currency of the wallet, the default currency when empty
+		Currency   string       `json:"currency"`
+		Amount     entity.Money `json:"amount"`
+		Reason     string       `json:"reason"`
+		OperatedBy string       `json:"-"`
+	}
+
+	// WalletHoldActionRequest releases or captures a single hold.
+	WalletHoldActionRequest struct {
+		WalletHoldID int64  `json:"walletHoldID"`
+		OperatedBy   string `json:"-"`
+		// Capture: how a capture is booked when the hold backs another flow, e.g. a withdrawal.
+		// The wallet, amount, currency and entry type are always taken from the hold. A
+		// hold_capture of the hold is booked when nil.
+		Capture *CreateTransactionReq `json:"-"`
+	}
+
+	WalletHoldResponse struct {
+		WalletHoldID int64 `json:"walletHoldID"`
+		WalletID     int64 `json:"walletID"`
+		// StatusHold: ["active", "released", "captured"]
+		StatusHold string `json:"statusHold"`
+		// WalletTransactionID: the debit booked by a capture
+		WalletTransactionID *int64 `json:"walletTransactionID,omitempty"`
+	}
+
+	GetListWalletHoldRequest struct {
+		UserID string `json:"userID"`
+		// Currency: currency of the wallet, the default currency when empty
+		Currency string `json:"currency"`
+		// StatusHold: ["active", "released", "captured"], every status when empty
+		StatusHold string `json:"statusHold"`
+		WalletID   int64  `json:"-"`
+		Page       int32  `json:"page"`
+		Limit      int32  `json:"limit"`
+	}
+
+	WalletHold struct {
+		WalletHoldID        int64        `json:"walletHoldID"`
+		WalletID            int64        `json:"walletID"`
+		Amount              entity.Money `json:"amount"`
+		Currency            string       `json:"currency"`
+		StatusHold          string       `json:"statusHold"`
+		Reason              string       `json:"reason"`
+		WalletTransactionID *int64       `json:"walletTransactionID"`
+		ReleasedAt          *time.Time   `json:"releasedAt"`
+		CapturedAt          *time.Time   `json:"capturedAt"`
+		CreatedAt           time.Time    `json:"createdAt"`
+		CreatedBy           string       `json:"createdBy"`
+	}
+
+	GetListWalletHoldResponse struct {
+		TotalCount int64         `json:"total"`
+		Page       int32         `json:"page"`
+		Limit      int32         `json:"limit"`
+		Holds      []*WalletHold `json:"holds"`
+	}
+)
+
+func (r *CreateWalletHoldRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+	if !r.Amount.IsPositive() {
+		return eerrs.ErrInvalidHoldAmount
+	}
+	if r.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	return nil
+}
+
+func (r *WalletHoldActionRequest) Validate() error {
+	if r.WalletHoldID <= 0 {
+		return errors.New("walletHoldID is required")
+	}
+
+	return nil
+}
+
+func (r *GetListWalletHoldRequest) Validate() error {
+	if r.UserID == "" {
+		return errors.New("userID is required")
+	}
+	if r.StatusHold != "" && !entity.StatusHold(r.StatusHold).IsValid() {
+		return errors.New("invalid statusHold")
+	}
+
+	return nil
+}
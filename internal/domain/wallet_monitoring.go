@@ -21,28 +21,31 @@ type DashboardTransactionVolumeResponse struct {
 }
 
 type TransactionTypeCount struct {
-	Transfer         int64 `json:"transfer" validate:"min=0"`
-	EnvelopeFixed    int64 `json:"envelope_fixed" validate:"min=0"`
-	EnvelopeLucky    int64 `json:"envelope_lucky" validate:"min=0"`
-	EnvelopeSingle   int64 `json:"envelope_single" validate:"min=0"`
-	Deposit          int64 `json:"deposit" validate:"min=0"`
-	RefundEnvelope   int64 `json:"refund_envelope" validate:"min=0"`
-	RefundTransfer   int64 `json:"refund_transfer" validate:"min=0"`
-	SystemAdjustment int64 `json:"system_adjustment" validate:"min=0"`
+	Transfer          int64 `json:"transfer" validate:"min=0"`
+	EnvelopeFixed     int64 `json:"envelope_fixed" validate:"min=0"`
+	EnvelopeLucky     int64 `json:"envelope_lucky" validate:"min=0"`
+	EnvelopeSingle    int64 `json:"envelope_single" validate:"min=0"`
+	EnvelopeExclusive int64 `json:"envelope_exclusive" validate:"min=0"`
+	Deposit           int64 `json:"deposit" validate:"min=0"`
+	RefundEnvelope    int64 `json:"refund_envelope" validate:"min=0"`
+	RefundTransfer    int64 `json:"refund_transfer" validate:"min=0"`
+	SystemAdjustment  int64 `json:"system_adjustment" validate:"min=0"`
+	DeclineTransfer   int64 `json:"decline_transfer" validate:"min=0"`
+	CancelTransfer    int64 `json:"cancel_transfer" validate:"min=0"`
 }
 
 // GetListTransactionMonitoringRequest 2. Get List Transaction
 type GetListTransactionMonitoringRequest struct {
 	PaginationRequest
-	FromTransactionDate time.Time `json:"from_transaction_date"`
-	ToTransactionDate   time.Time `json:"to_transaction_date"`
-	TransactionType     string    `json:"transaction_type" validate:"omitempty,oneof=transfer deposit withdrawal"`
-	EntryType           string    `json:"entry_type" validate:"omitempty,oneof=credit debit"`
-	FromAmount          float64   `json:"from_amount" validate:"min=0"`
-	ToAmount            float64   `json:"to_amount" validate:"min=0"`
-	WalletID            int64     `json:"wallet_id" validate:"min=0"`
-	ReferenceCode       string    `json:"reference_code"`
-	WalletTransactionID string    `json:"wallet_transaction_id"`
+	FromTransactionDate time.Time    `json:"from_transaction_date"`
+	ToTransactionDate   time.Time    `json:"to_transaction_date"`
+	TransactionType     string       `json:"transaction_type" validate:"omitempty,oneof=transfer deposit withdrawal"`
+	EntryType           string       `json:"entry_type" validate:"omitempty,oneof=credit debit"`
+	FromAmount          entity.Money `json:"from_amount" validate:"min=0"`
+	ToAmount            entity.Money `json:"to_amount" validate:"min=0"`
+	WalletID            int64        `json:"wallet_id" validate:"min=0"`
+	ReferenceCode       string       `json:"reference_code"`
+	WalletTransactionID string       `json:"wallet_transaction_id"`
 }
 
 type GetListTransactionMonitoringResponse struct {
@@ -56,33 +59,33 @@ type GetListTransactionMonitoringResponse struct {
 }
 
 type WalletTransactionDTO struct {
-	WalletTransactionID int64     `json:"wallet_transaction_id"`
-	WalletID            int64     `json:"wallet_id"`
-	Amount              float64   `json:"amount"`
-	TransactionType     string    `json:"transaction_type"`
-	EntryType           string    `json:"entry_type"`
-	BeforeBalance       float64   `json:"before_balance"`
-	AfterBalance        float64   `json:"after_balance"`
-	ReferenceCode       string    `json:"reference_code"`
-	TransactionDate     time.Time `json:"transaction_date"`
-	DescriptionEN       string    `json:"description_en"`
-	DescriptionZH       string    `json:"description_zh"`
-	ImpactedItem        int64     `json:"impacted_item"`
-	CreatedAt           time.Time `json:"created_at"`
+	WalletTransactionID int64        `json:"wallet_transaction_id"`
+	WalletID            int64        `json:"wallet_id"`
+	Amount              entity.Money `json:"amount"`
+	TransactionType     string       `json:"transaction_type"`
+	EntryType           string       `json:"entry_type"`
+	BeforeBalance       entity.Money `json:"before_balance"`
+	AfterBalance        entity.Money `json:"after_balance"`
+	ReferenceCode       string       `json:"reference_code"`
+	TransactionDate     time.Time    `json:"transaction_date"`
+	DescriptionEN       string       `json:"description_en"`
+	DescriptionZH       string       `json:"description_zh"`
+	ImpactedItem        int64        `json:"impacted_item"`
+	CreatedAt           time.Time    `json:"created_at"`
 }
 
 // GetListEnvelopeRequest 3. Get List Envelope
 type GetListEnvelopeRequest struct {
 	PaginationRequest
-	FromTotalAmount float64 `json:"from_total_amount" validate:"min=0"`
-	ToTotalAmount   float64 `json:"to_total_amount" validate:"min=0"`
-	UserID          string  `json:"user_id" validate:"omitempty,min=3,max=50"`
-	MaxNumReceived  int32   `json:"max_num_received" validate:"min=0,max=10000"`
-	EnvelopeType    string  `json:"envelope_type" validate:"omitempty,oneof=fixed lucky single"`
-	FromExpiredAt   int64   `json:"from_expired_at" validate:"min=0"`
-	ToExpiredAt     int64   `json:"to_expired_at" validate:"min=0"`
-	FromRefundedAt  int64   `json:"from_refunded_at" validate:"min=0"`
-	ToRefundedAt    int64   `json:"to_refunded_at" validate:"min=0"`
+	FromTotalAmount entity.Money `json:"from_total_amount" validate:"min=0"`
+	ToTotalAmount   entity.Money `json:"to_total_amount" validate:"min=0"`
+	UserID          string       `json:"user_id" validate:"omitempty,min=3,max=50"`
+	MaxNumReceived  int32        `json:"max_num_received" validate:"min=0,max=10000"`
+	EnvelopeType    string       `json:"envelope_type" validate:"omitempty,oneof=fixed lucky single"`
+	FromExpiredAt   int64        `json:"from_expired_at" validate:"min=0"`
+	ToExpiredAt     int64        `json:"to_expired_at" validate:"min=0"`
+	FromRefundedAt  int64        `json:"from_refunded_at" validate:"min=0"`
+	ToRefundedAt    int64        `json:"to_refunded_at" validate:"min=0"`
 }
 
 type GetListEnvelopeResponse struct {
@@ -96,21 +99,23 @@ type GetListEnvelopeResponse struct {
 }
 
 type EnvelopeDTO struct {
-	EnvelopeID          int64      `json:"envelope_id"`
-	UserID              string     `json:"user_id"`
-	TotalAmount         float64    `json:"total_amount"`
-	TotalAmountClaimed  float64    `json:"total_amount_claimed"`
-	TotalAmountRefunded float64    `json:"total_amount_refunded"`
-	RemainingAmount     float64    `json:"remaining_amount"`
-	MaxNumReceived      int        `json:"max_num_received"`
-	EnvelopeType        string     `json:"envelope_type"`
-	Remarks             string     `json:"remarks"`
-	ExpiredAt           *time.Time `json:"expired_at"`
-	RefundedAt          *time.Time `json:"refunded_at,omitempty"`
-	CreatedAt           time.Time  `json:"created_at"`
-	Status              string     `json:"status"`
-	IsExpired           bool       `json:"is_expired"`
-	IsRefunded          bool       `json:"is_refunded"`
+	EnvelopeID          int64        `json:"envelope_id"`
+	UserID              string       `json:"user_id"`
+	TotalAmount         entity.Money `json:"total_amount"`
+	TotalAmountClaimed  entity.Money `json:"total_amount_claimed"`
+	TotalAmountRefunded entity.Money `json:"total_amount_refunded"`
+	RemainingAmount     entity.Money `json:"remaining_amount"`
+	MaxNumReceived      int          `json:"max_num_received"`
+	EnvelopeType        string       `json:"envelope_type"`
+	Remarks             string       `json:"remarks"`
+	ExpiredAt           *time.Time   `json:"expired_at"`
+	RefundedAt          *time.Time   `json:"refunded_at,omitempty"`
+	CreatedAt           time.Time    `json:"created_at"`
+	Status              string       `json:"status"`
+	IsExpired           bool         `json:"is_expired"`
+	IsRefunded          bool         `json:"is_refunded"`
+	// RiskDecision: the most severe decision of the risk rules on the envelope and its claims
+	RiskDecision string `json:"risk_decision,omitempty"`
 }
 
 // GetEnvelopeDetailRequest 4. Get Envelope Detail
@@ -124,10 +129,10 @@ type GetEnvelopeDetailRequest struct {
 type GetEnvelopeDetailResponse struct {
 	EnvelopeID           int64                `json:"envelope_id"`
 	UserID               string               `json:"user_id"`
-	TotalAmount          float64              `json:"total_amount"`
-	TotalAmountClaimed   float64              `json:"total_amount_claimed"`
-	TotalAmountRefunded  float64              `json:"total_amount_refunded"`
-	RemainingAmount      float64              `json:"remaining_amount"`
+	TotalAmount          entity.Money         `json:"total_amount"`
+	TotalAmountClaimed   entity.Money         `json:"total_amount_claimed"`
+	TotalAmountRefunded  entity.Money         `json:"total_amount_refunded"`
+	RemainingAmount      entity.Money         `json:"remaining_amount"`
 	MaxNumReceived       int                  `json:"max_num_received"`
 	EnvelopeType         string               `json:"envelope_type"`
 	Remarks              string               `json:"remarks"`
@@ -140,13 +145,14 @@ type GetEnvelopeDetailResponse struct {
 	CompletionPercentage float64              `json:"completion_percentage"`
 	TimeUntilExpiry      *time.Duration       `json:"time_until_expiry,omitempty"`
 	Statistics           *EnvelopeStatistics  `json:"statistics"`
+	RiskDecision         string               `json:"risk_decision,omitempty"`
 	Details              []*EnvelopeDetailDTO `json:"details"`
 }
 
 type EnvelopeDetailDTO struct {
 	EnvelopeDetailID     int64          `json:"envelope_detail_id"`
 	EnvelopeID           int64          `json:"envelope_id"`
-	Amount               float64        `json:"amount"`
+	Amount               entity.Money   `json:"amount"`
 	UserID               string         `json:"user_id"`
 	EnvelopeDetailStatus string         `json:"envelope_detail_status"`
 	ClaimedAt            *time.Time     `json:"claimed_at,omitempty"`
@@ -156,27 +162,27 @@ type EnvelopeDetailDTO struct {
 }
 
 type EnvelopeStatistics struct {
-	TotalDetails         int     `json:"total_details"`
-	ClaimedCount         int     `json:"claimed_count"`
-	PendingCount         int     `json:"pending_count"`
-	ExpiredCount         int     `json:"expired_count"`
-	RefundedCount        int     `json:"refunded_count"`
-	UniqueClaimantsCount int     `json:"unique_claimants_count"`
-	TotalClaimedAmount   float64 `json:"total_claimed_amount"`
+	TotalDetails         int          `json:"total_details"`
+	ClaimedCount         int          `json:"claimed_count"`
+	PendingCount         int          `json:"pending_count"`
+	ExpiredCount         int          `json:"expired_count"`
+	RefundedCount        int          `json:"refunded_count"`
+	UniqueClaimantsCount int          `json:"unique_claimants_count"`
+	TotalClaimedAmount   entity.Money `json:"total_claimed_amount"`
 }
 
 // GetTransferHistoryRequest 5. GetTransfer History Request
 type GetTransferHistoryRequest struct {
 	PaginationRequest
-	FromUserID     string  `json:"from_user_id" validate:"omitempty,min=3,max=50"`
-	ToUserID       string  `json:"to_user_id" validate:"omitempty,min=3,max=50"`
-	StatusTransfer string  `json:"status_transfer" validate:"omitempty,oneof=pending claimed expired refunded canceled"`
-	FromAmount     float64 `json:"from_amount" validate:"min=0"`
-	ToAmount       float64 `json:"to_amount" validate:"min=0"`
-	FromExpiredAt  int64   `json:"from_expired_at" validate:"min=0"`
-	ToExpiredAt    int64   `json:"to_expired_at" validate:"min=0"`
-	FromClaimedAt  int64   `json:"from_claimed_at" validate:"min=0"`
-	ToClaimedAt    int64   `json:"to_claimed_at" validate:"min=0"`
+	FromUserID     string       `json:"from_user_id" validate:"omitempty,min=3,max=50"`
+	ToUserID       string       `json:"to_user_id" validate:"omitempty,min=3,max=50"`
+	StatusTransfer string       `json:"status_transfer" validate:"omitempty,oneof=pending claimed expired refunded canceled declined"`
+	FromAmount     entity.Money `json:"from_amount" validate:"min=0"`
+	ToAmount       entity.Money `json:"to_amount" validate:"min=0"`
+	FromExpiredAt  int64        `json:"from_expired_at" validate:"min=0"`
+	ToExpiredAt    int64        `json:"to_expired_at" validate:"min=0"`
+	FromClaimedAt  int64        `json:"from_claimed_at" validate:"min=0"`
+	ToClaimedAt    int64        `json:"to_claimed_at" validate:"min=0"`
 }
 
 type GetTransferHistoryResponse struct {
@@ -194,12 +200,14 @@ type TransferDTO struct {
 	TransferID       int64          `json:"transfer_id"`
 	FromUserID       string         `json:"from_user_id"`
 	ToUserID         string         `json:"to_user_id"`
-	Amount           float64        `json:"amount"`
+	Amount           entity.Money   `json:"amount"`
 	StatusTransfer   string         `json:"status_transfer"`
 	Remark           string         `json:"remark"`
 	ExpiredAt        *time.Time     `json:"expired_at,omitempty"`
 	RefundedAt       *time.Time     `json:"refunded_at,omitempty"`
 	ClaimedAt        *time.Time     `json:"claimed_at,omitempty"`
+	CanceledAt       *time.Time     `json:"canceled_at,omitempty"`
+	DeclinedAt       *time.Time     `json:"declined_at,omitempty"`
 	CreatedAt        time.Time      `json:"created_at"`
 	IsClaimed        bool           `json:"is_claimed"`
 	IsExpired        bool           `json:"is_expired"`
@@ -208,22 +216,28 @@ type TransferDTO struct {
 	TimeSinceClaimed *time.Duration `json:"time_since_claimed,omitempty"`
 	TimeUntilExpiry  *time.Duration `json:"time_until_expiry,omitempty"`
 	ProcessingTime   *time.Duration `json:"processing_time,omitempty"`
+	// RiskDecision: the most severe decision of the risk rules on the transfer and its claim
+	RiskDecision string `json:"risk_decision,omitempty"`
 }
 
 type TransferStatistics struct {
-	TotalTransfers       int     `json:"total_transfers"`
-	TotalAmount          float64 `json:"total_amount"`
-	AverageAmount        float64 `json:"average_amount"`
-	PendingCount         int     `json:"pending_count"`
-	PendingAmount        float64 `json:"pending_amount"`
-	ClaimedCount         int     `json:"claimed_count"`
-	ClaimedAmount        float64 `json:"claimed_amount"`
-	ExpiredCount         int     `json:"expired_count"`
-	ExpiredAmount        float64 `json:"expired_amount"`
-	RefundedCount        int     `json:"refunded_count"`
-	RefundedAmount       float64 `json:"refunded_amount"`
-	UniqueSendersCount   int     `json:"unique_senders_count"`
-	UniqueReceiversCount int     `json:"unique_receivers_count"`
+	TotalTransfers       int          `json:"total_transfers"`
+	TotalAmount          entity.Money `json:"total_amount"`
+	AverageAmount        entity.Money `json:"average_amount"`
+	PendingCount         int          `json:"pending_count"`
+	PendingAmount        entity.Money `json:"pending_amount"`
+	ClaimedCount         int          `json:"claimed_count"`
+	ClaimedAmount        entity.Money `json:"claimed_amount"`
+	ExpiredCount         int          `json:"expired_count"`
+	ExpiredAmount        entity.Money `json:"expired_amount"`
+	RefundedCount        int          `json:"refunded_count"`
+	RefundedAmount       entity.Money `json:"refunded_amount"`
+	CanceledCount        int          `json:"canceled_count"`
+	CanceledAmount       entity.Money `json:"canceled_amount"`
+	DeclinedCount        int          `json:"declined_count"`
+	DeclinedAmount       entity.Money `json:"declined_amount"`
+	UniqueSendersCount   int          `json:"unique_senders_count"`
+	UniqueReceiversCount int          `json:"unique_receivers_count"`
 }
 
 // GetTop10UsersRequest represents the request for getting top 10 users
@@ -241,9 +255,9 @@ type GetTop10UsersResponse struct {
 // HighFrequencyUserDTO represents a high frequency user data transfer object
 type HighFrequencyUserDTO struct {
 	WalletID    int64                `json:"wallet_id"`
-	TotalCredit float64              `json:"total_credit"`
+	TotalCredit entity.Money         `json:"total_credit"`
 	Credit      TransactionTypeCount `json:"credit"`
-	TotalDebit  float64              `json:"total_debit"`
+	TotalDebit  entity.Money         `json:"total_debit"`
 	Debit       TransactionTypeCount `json:"debit"`
 	Position    int                  `json:"position"`
 }
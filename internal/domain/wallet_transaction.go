@@ -9,35 +9,42 @@ import (
 
 type (
 	CreateTransactionReq struct {
-		WalletID        int64   `json:"walletID"`
-		TransactionType string  `json:"transactionType"`
-		Entrytype       string  `json:"entryType"`
-		Amount          float64 `json:"amount"`
-		DescriptionEn   string  `json:"descriptionEn"`
-		DescriptionZh   string  `json:"descriptionZh"`
-		ReferenceCode   string  `json:"referenceCode"`
-		ImpactedItem    int64   `json:"impactedItem"`
-		CreatedBy       string  `json:"createdBy"`
+		WalletID int64 `json:"walletID"`
+		// Currency: currency the amount is denominated in, it must match the wallet currency.
+		// The wallet currency is assumed when empty.
+		Currency        string       `json:"currency"`
+		TransactionType string       `json:"transactionType"`
+		Entrytype       string       `json:"entryType"`
+		Amount          entity.Money `json:"amount"`
+		DescriptionEn   string       `json:"descriptionEn"`
+		DescriptionZh   string       `json:"descriptionZh"`
+		ReferenceCode   string       `json:"referenceCode"`
+		ImpactedItem    int64        `json:"impactedItem"`
+		CreatedBy       string       `json:"createdBy"`
+		// ReversalOfID: the transaction this one compensates, set by admin reversals only
+		ReversalOfID int64 `json:"-"`
 	}
 
 	WalletTransaction struct {
-		WalletTransactionID int64     `json:"walletTransactionID"`
-		WalletID            int64     `json:"walletID"`
-		TransactionType     string    `json:"transactionType"`
-		EntryType           string    `json:"entryType"`
-		Amount              float64   `json:"amount"`
-		BeforeBalance       float64   `json:"beforeBalance"`
-		AfterBalance        float64   `json:"afterBalance"`
-		DescriptionEn       string    `json:"descriptionEn"`
-		DescriptionZh       string    `json:"descriptionZh"`
-		ReferenceCode       string    `json:"referenceCode"`
-		ImpactedItem        int64     `json:"impactedItem"`
-		IsShown             bool      `json:"isShown"`
-		TransactionDate     time.Time `json:"transactionDate"`
+		WalletTransactionID int64        `json:"walletTransactionID"`
+		WalletID            int64        `json:"walletID"`
+		TransactionType     string       `json:"transactionType"`
+		EntryType           string       `json:"entryType"`
+		Amount              entity.Money `json:"amount"`
+		Currency            string       `json:"currency"`
+		BeforeBalance       entity.Money `json:"beforeBalance"`
+		AfterBalance        entity.Money `json:"afterBalance"`
+		DescriptionEn       string       `json:"descriptionEn"`
+		DescriptionZh       string       `json:"descriptionZh"`
+		ReferenceCode       string       `json:"referenceCode"`
+		ImpactedItem        int64        `json:"impactedItem"`
+		IsShown             bool         `json:"isShown"`
+		TransactionDate     time.Time    `json:"transactionDate"`
 	}
 
 	GetListTransactionRequest struct {
 		UserID    string    `json:"userID"`
+		Currency  string    `json:"currency"`
 		WalletID  int64     `json:"walletID"`
 		Page      int32     `json:"page"`
 		Limit     int32     `json:"limit"`
@@ -53,12 +60,12 @@ type (
 	}
 
 	TransactionAction struct {
-		Action          string  `json:"action"`
-		EntryType       string  `json:"entryType"`
-		TransactionType string  `json:"transactionType"`
-		Amount          float64 `json:"amount"`
-		WalletID        int64   `json:"walletID"`
-		UserID          string  `json:"userId"`
+		Action          string       `json:"action"`
+		EntryType       string       `json:"entryType"`
+		TransactionType string       `json:"transactionType"`
+		Amount          entity.Money `json:"amount"`
+		WalletID        int64        `json:"walletID"`
+		UserID          string       `json:"userId"`
 	}
 )
 
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// withdrawalDateColumns are the columns a withdrawal list can be filtered and sorted by.
+var withdrawalDateColumns = map[string]bool{
+	"created_at":  true,
+	"approved_at": true,
+	"rejected_at": true,
+	"paid_at":     true,
+	"updated_at":  true,
+}
+
+type (
+	CreateWithdrawalRequest struct {
+		Amount entity.Money `json:"amount"`
+		// Currency: currency of the wallet to withdraw from, the default currency when empty
+		Currency string `json:"currency"`
+		// Destination: where the money is paid out to, e.g. the bank account of the user
+		Destination string `json:"destination"`
+		Description string `json:"description"`
+		UserID      string `json:"-"`
+	}
+
+	// ProcessWithdrawalRequest approves, rejects or marks a withdrawal request paid.
+	ProcessWithdrawalRequest struct {
+		WalletWithdrawalRequestID int64 `json:"walletWithdrawalRequestID"`
+		// Reason: required when rejecting
+		Reason     string `json:"reason"`
+		OperatedBy string `json:"-"`
+	}
+
+	WithdrawalResponse struct {
+		WalletWithdrawalRequestID int64 `json:"walletWithdrawalRequestID"`
+		WalletID                  int64 `json:"walletID"`
+		// StatusWithdrawal: ["requested", "approved", "rejected", "paid"]
+		StatusWithdrawal string `json:"statusWithdrawal"`
+		// WalletTransactionID: the debit booked when the withdrawal was paid
+		WalletTransactionID *int64 `json:"walletTransactionID,omitempty"`
+	}
+
+	GetListWithdrawalRequest struct {
+		WalletID int64
+		Page     int32 `json:"page"`
+		Limit    int32 `json:"limit"`
+		// StartDate: start date of FilterDateBy
+		StartDate time.Time `json:"startDate"`
+		// EndDate: end date of FilterDateBy
+		EndDate time.Time `json:"endDate"`
+		UserID  string    `json:"userID"`
+		// Currency: currency of the user wallet, only used together with UserID
+		Currency string `json:"currency"`
+		// StatusWithdrawal: ["requested", "approved", "rejected", "paid"]
+		StatusWithdrawal string `json:"statusWithdrawal"`
+		// SortBy: sort by column
+		SortBy string `json:"sortBy"`
+		// SortOrder: ["asc", "desc"]
+		SortOrder string `json:"sortOrder"`
+		// FilterDateBy: ["created_at", "approved_at", "rejected_at", "paid_at", "updated_at"]
+		FilterDateBy string `json:"filterDateBy"`
+	}
+
+	Withdrawal struct {
+		WithdrawalID        int64        `json:"withdrawalID"`
+		WalletID            int64        `json:"walletID"`
+		Amount              entity.Money `json:"amount"`
+		Currency            string       `json:"currency"`
+		StatusWithdrawal    string       `json:"statusWithdrawal"`
+		Destination         string       `json:"destination"`
+		Description         string       `json:"description"`
+		RejectReason        string       `json:"rejectReason"`
+		WalletTransactionID *int64       `json:"walletTransactionID"`
+		CreatedAt           time.Time    `json:"createdAt"`
+		ApprovedAt          *time.Time   `json:"approvedAt"`
+		RejectedAt          *time.Time   `json:"rejectedAt"`
+		PaidAt              *time.Time   `json:"paidAt"`
+		OperatedBy          *string      `json:"operatedBy"`
+		CreatedBy           string       `json:"createdBy"`
+	}
+
+	GetListWithdrawalResponse struct {
+		TotalCount  int64         `json:"total"`
+		Page        int32         `json:"page"`
+		Limit       int32         `json:"limit"`
+		Withdrawals []*Withdrawal `json:"withdrawals"`
+	}
+)
+
+func (r *CreateWithdrawalRequest) Validate() error {
+	if !r.Amount.IsPositive() {
+		return errors.New("amount is required")
+	}
+	if r.Destination == "" {
+		return errors.New("destination is required")
+	}
+
+	return nil
+}
+
+func (r *ProcessWithdrawalRequest) Validate() error {
+	if r.WalletWithdrawalRequestID <= 0 {
+		return errors.New("walletWithdrawalRequestID is required")
+	}
+
+	return nil
+}
+
+func (r *GetListWithdrawalRequest) Validate() error {
+	if r.StatusWithdrawal != "" && !entity.StatusWithdrawal(r.StatusWithdrawal).IsValid() {
+		return eerrs.ErrInvalidStatusWithdrawal
+	}
+	if r.FilterDateBy != "" && !withdrawalDateColumns[r.FilterDateBy] {
+		return errors.New("invalid filterDateBy")
+	}
+	if r.SortBy != "" && r.SortBy != "amount" && !withdrawalDateColumns[r.SortBy] {
+		return errors.New("invalid sortBy")
+	}
+	if r.SortOrder != "" && r.SortOrder != "asc" && r.SortOrder != "desc" {
+		return errors.New("invalid sortOrder")
+	}
+
+	return nil
+}
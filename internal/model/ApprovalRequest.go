@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"time"
+)
+
+type ApprovalOperation string
+
+const (
+	ApprovalOperationBalanceAdjustment ApprovalOperation = "balance_adjustment"
+	ApprovalOperationDeposit           ApprovalOperation = "deposit"
+	// ApprovalOperationDepositRequest is the approval of a deposit requested by a user
+	ApprovalOperationDepositRequest ApprovalOperation = "deposit_request"
+)
+
+var validApprovalOperation = map[ApprovalOperation]bool{
+	ApprovalOperationBalanceAdjustment: true,
+	ApprovalOperationDeposit:           true,
+	ApprovalOperationDepositRequest:    true,
+}
+
+func (e ApprovalOperation) IsValid() bool {
+	_, exist := validApprovalOperation[e]
+	return exist
+}
+
+func (e ApprovalOperation) String() string {
+	return string(e)
+}
+
+type StatusApproval string
+
+const (
+	StatusApprovalPending  StatusApproval = "pending_approval"
+	StatusApprovalApproved StatusApproval = "approved"
+	StatusApprovalRejected StatusApproval = "rejected"
+)
+
+var validStatusApproval = map[StatusApproval]bool{
+	StatusApprovalPending:  true,
+	StatusApprovalApproved: true,
+	StatusApprovalRejected: true,
+}
+
+func (e StatusApproval) IsValid() bool {
+	_, exist := validStatusApproval[e]
+	return exist
+}
+
+func (e StatusApproval) String() string {
+	return string(e)
+}
+
+// ApprovalRequest is an admin operation above the maker-checker threshold. Payload keeps the
+// original request of the maker, it is replayed once a different admin approves it.
+type ApprovalRequest struct {
+	ApprovalRequestID int64             `json:"approval_request_id" gorm:"column:approval_request_id;primaryKey;autoIncrement"`
+	Operation         ApprovalOperation `json:"operation" gorm:"column:operation;type:enum('balance_adjustment', 'deposit', 'deposit_request');not null"` //nolint:lll // long enum tag required by GORM
+	WalletID          int64             `json:"wallet_id" gorm:"column:wallet_id;not null;index"`
+	Amount            Money             `json:"amount" gorm:"column:amount;not null"`
+	Currency          Currency          `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
+	Payload           string            `json:"payload" gorm:"column:payload;type:text;not null"`
+	StatusApproval    StatusApproval    `json:"status_approval" gorm:"column:status_approval;type:enum('pending_approval', 'approved', 'rejected');default:'pending_approval';index"` //nolint:lll // long enum tag required by GORM
+	// OperationID: the balance adjustment or deposit booked or approved on approval
+	OperationID  *int64     `json:"operation_id" gorm:"column:operation_id"`
+	RejectReason string     `json:"reject_reason" gorm:"column:reject_reason;type:text"`
+	CheckedBy    *string    `json:"checked_by" gorm:"column:checked_by"`
+	CheckedAt    *time.Time `json:"checked_at" gorm:"column:checked_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy    string     `json:"created_by" gorm:"column:created_by;not null"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy    string     `json:"updated_by" gorm:"column:updated_by"`
+}
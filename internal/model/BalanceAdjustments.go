@@ -9,7 +9,8 @@ import (
 type BalanceAdjustments struct {
 	BalanceAdjustmentID int64          `json:"balance_adjustment_id" gorm:"column:balance_adjustment_id;primaryKey;autoIncrement"`
 	WalletID            int64          `json:"wallet_id" gorm:"column:wallet_id;not null"`
-	Amount              float64        `json:"amount" gorm:"column:amount;not null"`
+	Amount              Money          `json:"amount" gorm:"column:amount;not null"`
+	Currency            Currency       `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
 	Reason              string         `json:"reason" gorm:"column:reason;not null"`
 	Description         string         `json:"description" gorm:"column:description;not null"`
 	IsActive            bool           `json:"is_active" gorm:"column:is_active;index"`
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// Currency is the ISO 4217 code of the currency a wallet, and every amount booked on it,
+// is denominated in. Only currencies with MoneyScale minor digits are supported.
+type Currency string
+
+const (
+	CurrencyCNY Currency = "CNY"
+	CurrencyUSD Currency = "USD"
+
+	// DefaultCurrency is used when a request does not name a currency, every wallet created
+	// before wallets were per currency is denominated in it.
+	DefaultCurrency = CurrencyCNY
+)
+
+var validCurrency = map[Currency]bool{
+	CurrencyCNY: true,
+	CurrencyUSD: true,
+}
+
+// ParseCurrency normalizes a currency code taken from a request, an empty code resolves to
+// DefaultCurrency.
+func ParseCurrency(s string) (Currency, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return DefaultCurrency, nil
+	}
+
+	currency := Currency(s)
+	if !currency.IsValid() {
+		return "", eerrs.ErrInvalidCurrency
+	}
+
+	return currency, nil
+}
+
+func (c Currency) IsValid() bool {
+	_, exist := validCurrency[c]
+	return exist
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
@@ -11,21 +11,20 @@ const (
 	ActionClaim  = "claim"
 	ActionRefund = "refund"
 
-	MaxEnvelopeSendPerDay        = 500
-	MaxEnvelopeClaimPerDay       = 200
-	MaxGreetingCharacters        = 50
-	MaxAllowedSendEnvelopeAmount = 1000
+	MaxGreetingCharacters = 50
 )
 
 type Envelope struct {
 	EnvelopeID          int64          `json:"envelope_id" gorm:"column:envelope_id;primaryKey;autoIncrement"`
 	UserID              string         `json:"user_id" gorm:"column:user_id;not null"`
 	WalletID            int64          `json:"wallet_id" gorm:"column:wallet_id;not null"`
-	TotalAmount         float64        `json:"total_amount" gorm:"column:total_amount;not null"`
-	TotalAmountClaimed  float64        `json:"total_amount_claimed" gorm:"column:total_amount_claimed;not null"`
-	TotalAmountRefunded float64        `json:"total_amount_refunded" gorm:"column:total_amount_refunded;not null"`
+	Currency            Currency       `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
+	TotalAmount         Money          `json:"total_amount" gorm:"column:total_amount;not null"`
+	TotalAmountClaimed  Money          `json:"total_amount_claimed" gorm:"column:total_amount_claimed;not null"`
+	TotalAmountRefunded Money          `json:"total_amount_refunded" gorm:"column:total_amount_refunded;not null"`
 	MaxNumReceived      int            `json:"max_num_received" gorm:"column:max_num_received;not null"`
-	EnvelopeType        string         `gorm:"column:envelope_type;type:enum('lucky','fixed','single');not null"`
+	EnvelopeType        string         `gorm:"column:envelope_type;type:enum('lucky','fixed','single','exclusive');not null"`
+	GroupID             string         `json:"group_id" gorm:"column:group_id;type:varchar(64)"`
 	Remarks             string         `json:"remarks" gorm:"column:remarks"`
 	ExpiredAt           *time.Time     `json:"expired_at" gorm:"column:expired_at"`
 	RefundedAt          *time.Time     `json:"refunded_at" gorm:"column:refunded_at"`
@@ -49,18 +48,18 @@ var EnvelopeDescFormat = map[string]struct {
 	RefPrefix string
 }{
 	ActionCreate: {
-		En:        "userID:%s created #%d envelope with amount %.0f",
-		Zh:        "用户ID:%s 创建了 #%d 红包，金额为 %.0f",
+		En:        "userID:%s created #%d envelope with amount %s",
+		Zh:        "用户ID:%s 创建了 #%d 红包，金额为 %s",
 		RefPrefix: "EN",
 	},
 	ActionClaim: {
-		En:        "userID:%s claimed envelope #%d with amount %.0f",
-		Zh:        "用户ID:%s 领取了红包 #%d，金额为 %.0f",
+		En:        "userID:%s claimed envelope #%d with amount %s",
+		Zh:        "用户ID:%s 领取了红包 #%d，金额为 %s",
 		RefPrefix: "CE",
 	},
 	ActionRefund: {
-		En:        "userID:%s received refund from envelope #%d with amount %.0f",
-		Zh:        "用户ID:%s 从红包 #%d 收到退款，金额为 %.0f",
+		En:        "userID:%s received refund from envelope #%d with amount %s",
+		Zh:        "用户ID:%s 从红包 #%d 收到退款，金额为 %s",
 		RefPrefix: "RE",
 	},
 }
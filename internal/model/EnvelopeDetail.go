@@ -13,7 +13,7 @@ const (
 type EnvelopeDetail struct {
 	EnvelopeDetailID     int64                `json:"envelope_detail_id" gorm:"column:envelope_detail_id;primaryKey;autoIncrement"`
 	EnvelopeID           int64                `json:"envelope_id" gorm:"column:envelope_id;not null"`
-	Amount               float64              `json:"amount" gorm:"column:amount;not null"`
+	Amount               Money                `json:"amount" gorm:"column:amount;not null"`
 	UserID               string               `json:"user_id" gorm:"column:user_id;not null"`
 	EnvelopeDetailStatus EnvelopeDetailStatus `json:"envelope_detail_status" gorm:"column:envelope_detail_status;type:enum('pending','claimed','refunded');not null"` //nolint:lll // long enum tag required by GORM
 	ClaimedAt            *time.Time           `json:"claimed_at" gorm:"column:claimed_at"`
@@ -36,6 +36,8 @@ func GetTransactionTypeByEnvelopeType(envType string) (TransactionType, error) {
 		return TransactionTypeEnvelopeLucky, nil
 	case string(EnvelopeTypeSingle):
 		return TransactionTypeEnvelopeSingle, nil
+	case string(EnvelopeTypeExclusive):
+		return TransactionTypeEnvelopeExclusive, nil
 	default:
 		return "", eerrs.ErrUnsupportedEnvelopeType
 	}
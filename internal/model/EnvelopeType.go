@@ -6,12 +6,15 @@ const (
 	EnvelopeTypeLucky  EnvelopeType = "lucky"
 	EnvelopeTypeFixed  EnvelopeType = "fixed"
 	EnvelopeTypeSingle EnvelopeType = "single"
+	// EnvelopeTypeExclusive is split among a list of recipients, a share is reserved for each of them
+	EnvelopeTypeExclusive EnvelopeType = "exclusive"
 )
 
 var validEnvelopeType = map[EnvelopeType]bool{
-	EnvelopeTypeLucky:  true,
-	EnvelopeTypeFixed:  true,
-	EnvelopeTypeSingle: true,
+	EnvelopeTypeLucky:     true,
+	EnvelopeTypeFixed:     true,
+	EnvelopeTypeSingle:    true,
+	EnvelopeTypeExclusive: true,
 }
 
 func (e EnvelopeType) IsValid() bool {
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+)
+
+// ExpiryPolicy bounds how long a transfer or envelope stays claimable before it is refunded.
+// The sender picks a duration within [Min, Max], Default applies when they pick none.
+type ExpiryPolicy struct {
+	Default time.Duration
+	Min     time.Duration
+	Max     time.Duration
+}
+
+// DefaultTransferExpiry and DefaultEnvelopeExpiry apply when limits.yml leaves the expiry unset.
+var (
+	DefaultTransferExpiry = ExpiryPolicy{Default: 24 * time.Hour, Min: time.Hour, Max: 72 * time.Hour}
+	DefaultEnvelopeExpiry = ExpiryPolicy{Default: 24 * time.Hour, Min: time.Hour, Max: 72 * time.Hour}
+)
+
+func (p ExpiryPolicy) IsValid() bool {
+	return p.Min > 0 && p.Min <= p.Default && p.Default <= p.Max
+}
+
+// Resolve returns the claim window for a request asking for minutes, zero asks for the default.
+// It reports false when the requested window is out of bounds.
+func (p ExpiryPolicy) Resolve(minutes int64) (time.Duration, bool) {
+	if minutes == 0 {
+		return p.Default, true
+	}
+
+	expiry := time.Duration(minutes) * time.Minute
+	if minutes < 0 || expiry < p.Min || expiry > p.Max {
+		return 0, false
+	}
+
+	return expiry, true
+}
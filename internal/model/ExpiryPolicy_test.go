@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiryPolicy_Resolve(t *testing.T) {
+	policy := ExpiryPolicy{Default: 24 * time.Hour, Min: time.Hour, Max: 72 * time.Hour}
+	testCases := []struct {
+		desc     string
+		minutes  int64
+		expected time.Duration
+		ok       bool
+	}{
+		{desc: "default", minutes: 0, expected: 24 * time.Hour, ok: true},
+		{desc: "custom", minutes: 90, expected: 90 * time.Minute, ok: true},
+		{desc: "min", minutes: 60, expected: time.Hour, ok: true},
+		{desc: "max", minutes: 72 * 60, expected: 72 * time.Hour, ok: true},
+		{desc: "below_min", minutes: 59},
+		{desc: "above_max", minutes: 72*60 + 1},
+		{desc: "negative", minutes: -30},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			expiry, ok := policy.Resolve(tC.minutes)
+			assert.Equal(t, tC.ok, ok)
+			assert.Equal(t, tC.expected, expiry)
+		})
+	}
+}
+
+func TestExpiryPolicy_IsValid(t *testing.T) {
+	assert.True(t, DefaultTransferExpiry.IsValid())
+	assert.True(t, DefaultEnvelopeExpiry.IsValid())
+	assert.False(t, ExpiryPolicy{Default: time.Hour, Min: 2 * time.Hour, Max: 3 * time.Hour}.IsValid())
+	assert.False(t, ExpiryPolicy{Default: time.Hour, Max: time.Hour}.IsValid())
+}
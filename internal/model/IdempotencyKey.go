@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	IdempotencyReplayHeader = "Idempotent-Replayed"
+	MaxIdempotencyKeyLength = 128
+
+	// IdempotencyKeyTTL is how long a key is remembered, a retry after that is treated as a new request.
+	IdempotencyKeyTTL = 24 * time.Hour
+)
+
+type StatusIdempotency string
+
+const (
+	StatusIdempotencyProcessing StatusIdempotency = "processing"
+	StatusIdempotencyCompleted  StatusIdempotency = "completed"
+)
+
+type IdempotencyKey struct {
+	IdempotencyKeyID  int64             `json:"idempotency_key_id" gorm:"column:idempotency_key_id;primaryKey;autoIncrement"`
+	IdempotencyKey    string            `json:"idempotency_key" gorm:"column:idempotency_key;type:varchar(128);not null;uniqueIndex:uq_idempotency_user_key"` //nolint:lll // long index tag required by GORM
+	UserID            string            `json:"user_id" gorm:"column:user_id;type:varchar(20);not null;uniqueIndex:uq_idempotency_user_key"`
+	Endpoint          string            `json:"endpoint" gorm:"column:endpoint;type:varchar(255);not null"`
+	RequestHash       string            `json:"request_hash" gorm:"column:request_hash;type:char(64);not null"`
+	StatusIdempotency StatusIdempotency `json:"status_idempotency" gorm:"column:status_idempotency;type:enum('processing', 'completed');default:'processing'"` //nolint:lll // long enum tag required by GORM
+	ResponseCode      int               `json:"response_code" gorm:"column:response_code"`
+	ResponseBody      []byte            `json:"response_body" gorm:"column:response_body;type:mediumblob"`
+	ExpiredAt         time.Time         `json:"expired_at" gorm:"column:expired_at;not null;index"`
+	CreatedAt         time.Time         `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt         time.Time         `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (e *IdempotencyKey) IsExpired(now time.Time) bool {
+	return !e.ExpiredAt.After(now)
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+// JournalEntry is the counter-entry of a WalletTransaction against a system account,
+// its Amount is the wallet transaction amount with the sign flipped.
+type JournalEntry struct {
+	JournalEntryID      int64         `json:"journal_entry_id" gorm:"column:journal_entry_id;primaryKey;autoIncrement"`
+	WalletTransactionID int64         `json:"wallet_transaction_id" gorm:"column:wallet_transaction_id;not null;uniqueIndex"`
+	SystemAccount       SystemAccount `json:"system_account" gorm:"column:system_account;type:enum('transfer_escrow', 'envelope_escrow', 'deposit_funding', 'adjustment_expense', 'hold_settlement', 'withdrawal_payout');not null;index"` //nolint:lll // long enum tag required by GORM
+	Currency            Currency      `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY';index"`
+	Amount              Money         `json:"amount" gorm:"column:amount;not null"`
+	CreatedAt           time.Time     `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy           string        `json:"created_by" gorm:"column:created_by"`
+}
+
+type SystemAccountBalance struct {
+	SystemAccount SystemAccount `gorm:"column:system_account"`
+	Balance       Money         `gorm:"column:balance"`
+}
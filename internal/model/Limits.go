@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"time"
+)
+
+// Limits caps what a single user may send and claim. The spend limits cap the amount sent
+// by transfers and envelopes together over a rolling window, zero disables a window.
+type Limits struct {
+	TransferSendPerDay    int64
+	TransferClaimPerDay   int64
+	MaxSendTransferAmount Money
+	EnvelopeSendPerDay    int64
+	EnvelopeClaimPerDay   int64
+	MaxSendEnvelopeAmount Money
+	SpendPerHour          Money
+	SpendPerDay           Money
+	SpendPer30Days        Money
+}
+
+// DefaultLimits apply when limits.yml leaves a value unset.
+var DefaultLimits = Limits{
+	TransferSendPerDay:    200,
+	TransferClaimPerDay:   200,
+	MaxSendTransferAmount: 5000 * minorUnitsPerMajor,
+	EnvelopeSendPerDay:    500,
+	EnvelopeClaimPerDay:   200,
+	MaxSendEnvelopeAmount: 1000 * minorUnitsPerMajor,
+	SpendPerHour:          20000 * minorUnitsPerMajor,
+	SpendPerDay:           50000 * minorUnitsPerMajor,
+	SpendPer30Days:        300000 * minorUnitsPerMajor,
+}
+
+// Apply returns l with every value set on the override replaced, a nil override changes nothing.
+func (l Limits) Apply(o *LimitOverride) Limits {
+	if o == nil {
+		return l
+	}
+
+	if o.TransferSendPerDay != nil {
+		l.TransferSendPerDay = *o.TransferSendPerDay
+	}
+	if o.TransferClaimPerDay != nil {
+		l.TransferClaimPerDay = *o.TransferClaimPerDay
+	}
+	if o.MaxSendTransferAmount != nil {
+		l.MaxSendTransferAmount = *o.MaxSendTransferAmount
+	}
+	if o.EnvelopeSendPerDay != nil {
+		l.EnvelopeSendPerDay = *o.EnvelopeSendPerDay
+	}
+	if o.EnvelopeClaimPerDay != nil {
+		l.EnvelopeClaimPerDay = *o.EnvelopeClaimPerDay
+	}
+	if o.MaxSendEnvelopeAmount != nil {
+		l.MaxSendEnvelopeAmount = *o.MaxSendEnvelopeAmount
+	}
+	if o.SpendPerHour != nil {
+		l.SpendPerHour = *o.SpendPerHour
+	}
+	if o.SpendPerDay != nil {
+		l.SpendPerDay = *o.SpendPerDay
+	}
+	if o.SpendPer30Days != nil {
+		l.SpendPer30Days = *o.SpendPer30Days
+	}
+
+	return l
+}
+
+// SpendLimit returns the amount the user may send over the window.
+func (l Limits) SpendLimit(w SpendWindow) Money {
+	switch w {
+	case SpendWindowHour:
+		return l.SpendPerHour
+	case SpendWindowDay:
+		return l.SpendPerDay
+	case SpendWindow30Days:
+		return l.SpendPer30Days
+	default:
+		return 0
+	}
+}
+
+// SpendWindow is a rolling period ending now over which the amounts a user sent are added up.
+type SpendWindow string
+
+const (
+	SpendWindowHour   SpendWindow = "hour"
+	SpendWindowDay    SpendWindow = "day"
+	SpendWindow30Days SpendWindow = "30_days"
+)
+
+// SpendWindows lists every window, the shortest first.
+var SpendWindows = []SpendWindow{SpendWindowHour, SpendWindowDay, SpendWindow30Days}
+
+func (e SpendWindow) Duration() time.Duration {
+	switch e {
+	case SpendWindowHour:
+		return time.Hour
+	case SpendWindowDay:
+		return 24 * time.Hour
+	case SpendWindow30Days:
+		return 30 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+func (e SpendWindow) String() string {
+	return string(e)
+}
+
+type LimitScope string
+
+const (
+	LimitScopeTier LimitScope = "tier"
+	LimitScopeUser LimitScope = "user"
+)
+
+var validLimitScope = map[LimitScope]bool{
+	LimitScopeTier: true,
+	LimitScopeUser: true,
+}
+
+func (e LimitScope) IsValid() bool {
+	_, exist := validLimitScope[e]
+	return exist
+}
+
+func (e LimitScope) String() string {
+	return string(e)
+}
+
+// LimitOverride replaces some of the default limits for a tier or a single user, a nil field
+// keeps the value below it. A user row may also put the user in a tier, the tier applies first.
+type LimitOverride struct {
+	LimitOverrideID       int64      `json:"limit_override_id" gorm:"column:limit_override_id;primaryKey;autoIncrement"`
+	Scope                 LimitScope `json:"scope" gorm:"column:scope;type:enum('tier', 'user');not null;uniqueIndex:uk_limit_override_scope"`
+	ScopeID               string     `json:"scope_id" gorm:"column:scope_id;type:varchar(64);not null;uniqueIndex:uk_limit_override_scope"`
+	Tier                  *string    `json:"tier" gorm:"column:tier;type:varchar(64)"`
+	TransferSendPerDay    *int64     `json:"transfer_send_per_day" gorm:"column:transfer_send_per_day"`
+	TransferClaimPerDay   *int64     `json:"transfer_claim_per_day" gorm:"column:transfer_claim_per_day"`
+	MaxSendTransferAmount *Money     `json:"max_send_transfer_amount" gorm:"column:max_send_transfer_amount"`
+	EnvelopeSendPerDay    *int64     `json:"envelope_send_per_day" gorm:"column:envelope_send_per_day"`
+	EnvelopeClaimPerDay   *int64     `json:"envelope_claim_per_day" gorm:"column:envelope_claim_per_day"`
+	MaxSendEnvelopeAmount *Money     `json:"max_send_envelope_amount" gorm:"column:max_send_envelope_amount"`
+	SpendPerHour          *Money     `json:"spend_per_hour" gorm:"column:spend_per_hour"`
+	SpendPerDay           *Money     `json:"spend_per_day" gorm:"column:spend_per_day"`
+	SpendPer30Days        *Money     `json:"spend_per_30_days" gorm:"column:spend_per_30_days"`
+	CreatedAt             time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy             string     `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt             time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy             string     `json:"updated_by" gorm:"column:updated_by"`
+}
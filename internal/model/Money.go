@@ -0,0 +1,260 @@
+package entity
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// MoneyScale is the number of fractional digits kept for every amount.
+	MoneyScale = 2
+	// MoneyColumnType is the column type used to persist Money values.
+	MoneyColumnType = "decimal(20,2)"
+
+	minorUnitsPerMajor = 100
+)
+
+// Money is an exact monetary amount stored as an integer number of minor units (cents).
+// It is serialized to JSON as a decimal number and persisted as a DECIMAL column, so
+// amounts never go through binary floating point on their way in or out of the ledger.
+type Money int64
+
+// NewMoney returns the Money for a whole number of major units.
+func NewMoney(major int64) Money {
+	return Money(major * minorUnitsPerMajor)
+}
+
+// NewMoneyFromMinor returns the Money for the given number of minor units.
+func NewMoneyFromMinor(minor int64) Money {
+	return Money(minor)
+}
+
+// NewMoneyFromFloat converts a float amount to Money, rounding half away from zero to the
+// nearest minor unit. It only exists for boundaries that still hand us floats.
+func NewMoneyFromFloat(f float64) Money {
+	return Money(math.Round(f * minorUnitsPerMajor))
+}
+
+// ParseMoney parses a decimal string such as "12", "-0.5" or "1234.56". More than
+// MoneyScale fractional digits is rejected instead of silently rounded.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("invalid money amount %q", s)
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	if intPart == "" && (!hasFrac || fracPart == "") {
+		return 0, fmt.Errorf("invalid money amount %q", s)
+	}
+
+	// trailing zeros do not carry precision, "1.500" is still exactly 1.50
+	fracPart = strings.TrimRight(fracPart, "0")
+	if len(fracPart) > MoneyScale {
+		return 0, fmt.Errorf("money amount %q has more than %d decimal places", s, MoneyScale)
+	}
+
+	fracPart += strings.Repeat("0", MoneyScale-len(fracPart))
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	for _, r := range intPart + fracPart {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid money amount %q", s)
+		}
+	}
+
+	minor, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid money amount %q: %w", s, err)
+	}
+
+	if negative {
+		minor = -minor
+	}
+
+	return Money(minor), nil
+}
+
+// Minor returns the amount in minor units.
+func (m Money) Minor() int64 {
+	return int64(m)
+}
+
+// Float64 returns the amount in major units. Use it only for display or statistics,
+// never to compute a value that is written back to the ledger.
+func (m Money) Float64() float64 {
+	return float64(m) / minorUnitsPerMajor
+}
+
+// Abs returns the absolute value of m.
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+
+	return m
+}
+
+// IsZero reports whether m is zero.
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+// IsPositive reports whether m is greater than zero.
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+// IsNegative reports whether m is less than zero.
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+// MulInt multiplies m by n.
+func (m Money) MulInt(n int64) Money {
+	return m * Money(n)
+}
+
+// Split divides m into n shares of equal size. The remainder is spread one minor unit at a
+// time over the first shares, so the shares always sum exactly to m.
+func (m Money) Split(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+
+	var (
+		shares    = make([]Money, n)
+		share     = m / Money(n)
+		remainder = m % Money(n)
+		unit      = Money(1)
+	)
+
+	if remainder < 0 {
+		unit = -1
+	}
+
+	for i := range shares {
+		shares[i] = share
+		if remainder != 0 {
+			shares[i] += unit
+			remainder -= unit
+		}
+	}
+
+	return shares
+}
+
+// String formats m as a decimal number with exactly MoneyScale fractional digits.
+func (m Money) String() string {
+	sign := ""
+	minor := int64(m)
+	if minor < 0 {
+		sign = "-"
+	}
+
+	// avoid overflowing on math.MinInt64 by working with the unsigned magnitude
+	abs := uint64(minor)
+	if minor < 0 {
+		abs = uint64(-(minor + 1)) + 1
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, abs/minorUnitsPerMajor, abs%minorUnitsPerMajor)
+}
+
+// MarshalJSON encodes m as a JSON number, e.g. 12.30.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts both JSON numbers and quoted decimal strings.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// Value implements driver.Valuer, values are written as decimal strings so the DECIMAL
+// column receives them without any float conversion.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan implements sql.Scanner.
+func (m *Money) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	case int64:
+		*m = NewMoney(v)
+		return nil
+	case float64:
+		// legacy DOUBLE columns, see db.MigrateMoneyColumns
+		*m = NewMoneyFromFloat(v)
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+}
+
+func (m *Money) scanString(s string) error {
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		// aggregates such as SUM/AVG on a DECIMAL column may come back with a wider scale
+		f, fErr := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if fErr != nil {
+			return err
+		}
+
+		parsed = NewMoneyFromFloat(f)
+	}
+
+	*m = parsed
+	return nil
+}
+
+// GormDataType implements schema.GormDataTypeInterface.
+func (Money) GormDataType() string {
+	return MoneyColumnType
+}
+
+// GormDBDataType implements migrator.GormDBDataTypeInterface.
+func (Money) GormDBDataType(_ *gorm.DB, _ *schema.Field) string {
+	return MoneyColumnType
+}
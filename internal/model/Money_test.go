@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoney_ParseMoney(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arg       string
+		expected  Money
+		wantError bool
+	}{
+		{desc: "integer", arg: "12", expected: 1200},
+		{desc: "one_decimal", arg: "0.5", expected: 50},
+		{desc: "two_decimals", arg: "1234.56", expected: 123456},
+		{desc: "negative", arg: "-0.01", expected: -1},
+		{desc: "trailing_zeros", arg: "1.500", expected: 150},
+		{desc: "leading_dot", arg: ".25", expected: 25},
+		{desc: "too_many_decimals", arg: "0.001", wantError: true},
+		{desc: "not_a_number", arg: "abc", wantError: true},
+		{desc: "exponent", arg: "1e2", wantError: true},
+		{desc: "empty", arg: "", wantError: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := ParseMoney(tC.arg)
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected, got)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	var payload struct {
+		Amount Money `json:"amount"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": 0.1}`), &payload))
+	assert.Equal(t, NewMoneyFromMinor(10), payload.Amount)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": "19.99"}`), &payload))
+	assert.Equal(t, NewMoneyFromMinor(1999), payload.Amount)
+
+	payload.Amount += NewMoneyFromMinor(1) + NewMoneyFromMinor(20)
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 20.20}`, string(raw))
+}
+
+func TestMoney_Scan(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		arg      any
+		expected Money
+	}{
+		{desc: "decimal_column", arg: []byte("10.30"), expected: 1030},
+		{desc: "aggregate_with_wider_scale", arg: []byte("3.3333"), expected: 333},
+		{desc: "legacy_double_column", arg: 0.1 + 0.2, expected: 30},
+		{desc: "null", arg: nil, expected: 0},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var got Money
+			require.NoError(t, got.Scan(tC.arg))
+			assert.Equal(t, tC.expected, got)
+		})
+	}
+}
+
+func TestMoney_Split(t *testing.T) {
+	shares := NewMoneyFromMinor(-100).Split(3)
+	assert.Equal(t, []Money{-34, -33, -33}, shares)
+	assert.Equal(t, "-0.01", NewMoneyFromMinor(-1).String())
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	// PaymentPinHeader carries the payment PIN on every request that sends money out of the wallet.
+	PaymentPinHeader = "X-Payment-Pin"
+	PaymentPinLength = 6
+)
+
+// PaymentPinPolicy locks the PIN of a user after MaxAttempts wrong PINs in a row, the lock
+// lifts LockDuration after the last wrong one.
+type PaymentPinPolicy struct {
+	MaxAttempts  int64
+	LockDuration time.Duration
+}
+
+// DefaultPaymentPinPolicy applies when share.yml leaves the payment PIN unset.
+var DefaultPaymentPinPolicy = PaymentPinPolicy{MaxAttempts: 5, LockDuration: 30 * time.Minute}
+
+// PaymentPin is the PIN a user confirms outgoing money with. Only a salted bcrypt hash is kept.
+type PaymentPin struct {
+	PaymentPinID int64     `json:"payment_pin_id" gorm:"column:payment_pin_id;primaryKey;autoIncrement"`
+	UserID       string    `json:"user_id" gorm:"column:user_id;type:varchar(20);not null;uniqueIndex"`
+	PinHash      string    `json:"-" gorm:"column:pin_hash;type:varchar(255);not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy    string    `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy    string    `json:"updated_by" gorm:"column:updated_by"`
+}
+
+type PaymentPinEvent string
+
+const (
+	PaymentPinEventSet     PaymentPinEvent = "set"
+	PaymentPinEventChanged PaymentPinEvent = "changed"
+	// PaymentPinEventReset: an admin removed the PIN, the user has to set a new one
+	PaymentPinEventReset        PaymentPinEvent = "reset"
+	PaymentPinEventVerifyFailed PaymentPinEvent = "verify_failed"
+	PaymentPinEventLocked       PaymentPinEvent = "locked"
+)
+
+func (e PaymentPinEvent) String() string {
+	return string(e)
+}
+
+// PaymentPinAudit records a change of the PIN or a wrong PIN, the PIN itself is never kept.
+type PaymentPinAudit struct {
+	PaymentPinAuditID int64           `json:"payment_pin_audit_id" gorm:"column:payment_pin_audit_id;primaryKey;autoIncrement"`
+	UserID            string          `json:"user_id" gorm:"column:user_id;type:varchar(20);not null;index"`
+	Event             PaymentPinEvent `json:"event" gorm:"column:event;type:enum('set', 'changed', 'reset', 'verify_failed', 'locked');not null"` //nolint:lll // long enum tag required by GORM
+	// Endpoint: the request the PIN was entered for
+	Endpoint  string    `json:"endpoint" gorm:"column:endpoint;type:varchar(255)"`
+	DeviceID  string    `json:"device_id" gorm:"column:device_id;type:varchar(128)"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PaymentRequest is an amount RequesterUserID asks PayerUserID to send. Paying it sends a
+// transfer that is settled to the requester at once, TransferID points to it.
+type PaymentRequest struct {
+	PaymentRequestID     int64                `json:"payment_request_id" gorm:"column:payment_request_id;primaryKey;autoIncrement"`
+	RequesterUserID      string               `json:"requester_user_id" gorm:"column:requester_user_id;not null;index"`
+	PayerUserID          string               `json:"payer_user_id" gorm:"column:payer_user_id;not null;index"`
+	Amount               Money                `json:"amount" gorm:"column:amount;not null"`
+	Currency             Currency             `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
+	Remark               string               `json:"remark" gorm:"column:remark;type:text"`
+	StatusPaymentRequest StatusPaymentRequest `json:"status_payment_request" gorm:"column:status_payment_request;type:enum('pending', 'paid', 'declined', 'expired');default:'pending';index"` //nolint:lll // long enum tag required by GORM
+	TransferID           *int64               `json:"transfer_id" gorm:"column:transfer_id"`
+	ExpiredAt            time.Time            `json:"expired_at" gorm:"column:expired_at;not null;index"`
+	PaidAt               *time.Time           `json:"paid_at" gorm:"column:paid_at"`
+	DeclinedAt           *time.Time           `json:"declined_at" gorm:"column:declined_at"`
+	IsActive             bool                 `json:"is_active" gorm:"column:is_active;not null"`
+	CreatedAt            time.Time            `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy            string               `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt            time.Time            `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy            string               `json:"updated_by" gorm:"column:updated_by"`
+	DeletedAt            gorm.DeletedAt       `gorm:"column:deleted_at;index"`
+	DeletedBy            *string              `json:"deleted_by" gorm:"column:deleted_by"`
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	// ReconciliationBatchSize is how many wallets or envelopes are checked per query.
+	ReconciliationBatchSize = 500
+)
+
+type StatusReconciliation string
+
+const (
+	StatusReconciliationRunning  StatusReconciliation = "running"
+	StatusReconciliationFinished StatusReconciliation = "finished"
+	StatusReconciliationFailed   StatusReconciliation = "failed"
+)
+
+type DiscrepancyType string
+
+const (
+	// DiscrepancyTypeWalletBalance: Wallet.Balance differs from the sum of its wallet transactions
+	DiscrepancyTypeWalletBalance DiscrepancyType = "wallet_balance"
+	// DiscrepancyTypeBalanceChain: a wallet transaction does not start from the balance the previous one ended with
+	DiscrepancyTypeBalanceChain DiscrepancyType = "balance_chain"
+	// DiscrepancyTypeEnvelopeClaimed: Envelope.TotalAmountClaimed differs from the sum of its claimed details
+	DiscrepancyTypeEnvelopeClaimed DiscrepancyType = "envelope_claimed"
+)
+
+var validDiscrepancyType = map[DiscrepancyType]bool{
+	DiscrepancyTypeWalletBalance:   true,
+	DiscrepancyTypeBalanceChain:    true,
+	DiscrepancyTypeEnvelopeClaimed: true,
+}
+
+func (e DiscrepancyType) IsValid() bool {
+	_, exist := validDiscrepancyType[e]
+	return exist
+}
+
+func (e DiscrepancyType) String() string {
+	return string(e)
+}
+
+type ReconciliationRun struct {
+	ReconciliationRunID  int64                `json:"reconciliation_run_id" gorm:"column:reconciliation_run_id;primaryKey;autoIncrement"`
+	StatusReconciliation StatusReconciliation `json:"status_reconciliation" gorm:"column:status_reconciliation;type:enum('running', 'finished', 'failed');default:'running'"` //nolint:lll // long enum tag required by GORM
+	WalletsChecked       int64                `json:"wallets_checked" gorm:"column:wallets_checked;not null"`
+	EnvelopesChecked     int64                `json:"envelopes_checked" gorm:"column:envelopes_checked;not null"`
+	DiscrepancyCount     int64                `json:"discrepancy_count" gorm:"column:discrepancy_count;not null"`
+	StartedAt            time.Time            `json:"started_at" gorm:"column:started_at;not null"`
+	FinishedAt           *time.Time           `json:"finished_at" gorm:"column:finished_at"`
+	CreatedBy            string               `json:"created_by" gorm:"column:created_by"`
+}
+
+type ReconciliationDiscrepancy struct {
+	ReconciliationDiscrepancyID int64           `json:"reconciliation_discrepancy_id" gorm:"column:reconciliation_discrepancy_id;primaryKey;autoIncrement"` //nolint:lll // long tag required by GORM
+	ReconciliationRunID         int64           `json:"reconciliation_run_id" gorm:"column:reconciliation_run_id;not null;index"`
+	DiscrepancyType             DiscrepancyType `json:"discrepancy_type" gorm:"column:discrepancy_type;type:enum('wallet_balance', 'balance_chain', 'envelope_claimed');not null"` //nolint:lll // long enum tag required by GORM
+	WalletID                    *int64          `json:"wallet_id" gorm:"column:wallet_id;index"`
+	WalletTransactionID         *int64          `json:"wallet_transaction_id" gorm:"column:wallet_transaction_id"`
+	EnvelopeID                  *int64          `json:"envelope_id" gorm:"column:envelope_id"`
+	Expected                    Money           `json:"expected" gorm:"column:expected;not null"`
+	Actual                      Money           `json:"actual" gorm:"column:actual;not null"`
+	CreatedAt                   time.Time       `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+// WalletBalanceSum compares a wallet balance with the sum of its wallet transactions.
+type WalletBalanceSum struct {
+	WalletID         int64 `gorm:"column:wallet_id"`
+	Balance          Money `gorm:"column:balance"`
+	TransactionTotal Money `gorm:"column:transaction_total"`
+}
+
+// BalanceChainBreak is a wallet transaction whose BeforeBalance is not the AfterBalance of the
+// previous transaction of the same wallet.
+type BalanceChainBreak struct {
+	WalletTransactionID  int64 `gorm:"column:wallet_transaction_id"`
+	WalletID             int64 `gorm:"column:wallet_id"`
+	BeforeBalance        Money `gorm:"column:before_balance"`
+	PreviousAfterBalance Money `gorm:"column:previous_after_balance"`
+}
+
+// EnvelopeClaimSum compares the claimed total of an envelope with the sum of its claimed details.
+type EnvelopeClaimSum struct {
+	EnvelopeID         int64 `gorm:"column:envelope_id"`
+	TotalAmountClaimed Money `gorm:"column:total_amount_claimed"`
+	ClaimedTotal       Money `gorm:"column:claimed_total"`
+}
@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RecurringFrequency is how often a standing order sends its transfer.
+type RecurringFrequency string
+
+const (
+	RecurringFrequencyDaily   RecurringFrequency = "daily"
+	RecurringFrequencyWeekly  RecurringFrequency = "weekly"
+	RecurringFrequencyMonthly RecurringFrequency = "monthly"
+)
+
+func (e RecurringFrequency) IsValid() bool {
+	switch e {
+	case RecurringFrequencyDaily, RecurringFrequencyWeekly, RecurringFrequencyMonthly:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e RecurringFrequency) String() string {
+	return string(e)
+}
+
+// Next returns the run after prev. A monthly order keeps to anchorDay, the day of the month it
+// started on, and falls back to the last day of shorter months.
+func (e RecurringFrequency) Next(prev time.Time, anchorDay int) time.Time {
+	switch e {
+	case RecurringFrequencyDaily:
+		return prev.AddDate(0, 0, 1)
+	case RecurringFrequencyWeekly:
+		return prev.AddDate(0, 0, 7)
+	default:
+		// the first of the month never overflows into the one after
+		first := time.Date(prev.Year(), prev.Month(), 1, prev.Hour(), prev.Minute(), prev.Second(), 0, prev.Location()).
+			AddDate(0, 1, 0)
+		lastDay := first.AddDate(0, 1, -1).Day()
+
+		return first.AddDate(0, 0, min(anchorDay, lastDay)-1)
+	}
+}
+
+type StatusRecurringTransfer string
+
+const (
+	StatusRecurringTransferActive    StatusRecurringTransfer = "active"
+	StatusRecurringTransferPaused    StatusRecurringTransfer = "paused"
+	StatusRecurringTransferCanceled  StatusRecurringTransfer = "canceled"
+	StatusRecurringTransferCompleted StatusRecurringTransfer = "completed"
+)
+
+func (e StatusRecurringTransfer) IsValid() bool {
+	switch e {
+	case StatusRecurringTransferActive, StatusRecurringTransferPaused,
+		StatusRecurringTransferCanceled, StatusRecurringTransferCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e StatusRecurringTransfer) String() string {
+	return string(e)
+}
+
+// RecurringTransfer is a standing order: the same transfer sent every period from StartAt on,
+// until EndAt or until MaxOccurrences runs were made. Each run is kept as a RecurringTransferRun.
+type RecurringTransfer struct {
+	RecurringTransferID     int64                   `json:"recurring_transfer_id" gorm:"column:recurring_transfer_id;primaryKey;autoIncrement"` //nolint:lll // long tag required by GORM
+	FromUserID              string                  `json:"from_user_id" gorm:"column:from_user_id;not null;index"`
+	ToUserID                string                  `json:"to_user_id" gorm:"column:to_user_id;not null"`
+	Amount                  Money                   `json:"amount" gorm:"column:amount;not null"`
+	Currency                Currency                `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
+	Remark                  string                  `json:"remark" gorm:"column:remark;type:text"`
+	Frequency               RecurringFrequency      `json:"frequency" gorm:"column:frequency;type:enum('daily', 'weekly', 'monthly');not null"`
+	StartAt                 time.Time               `json:"start_at" gorm:"column:start_at;not null"`
+	EndAt                   *time.Time              `json:"end_at" gorm:"column:end_at"`
+	MaxOccurrences          int                     `json:"max_occurrences" gorm:"column:max_occurrences;not null;default:0"`
+	Occurrences             int                     `json:"occurrences" gorm:"column:occurrences;not null;default:0"`
+	NextRunAt               time.Time               `json:"next_run_at" gorm:"column:next_run_at;not null;index"`
+	StatusRecurringTransfer StatusRecurringTransfer `json:"status_recurring_transfer" gorm:"column:status_recurring_transfer;type:enum('active', 'paused', 'canceled', 'completed');default:'active';index"` //nolint:lll // long enum tag required by GORM
+	LastRunAt               *time.Time              `json:"last_run_at" gorm:"column:last_run_at"`
+	CanceledAt              *time.Time              `json:"canceled_at" gorm:"column:canceled_at"`
+	IsActive                bool                    `json:"is_active" gorm:"column:is_active;not null"`
+	CreatedAt               time.Time               `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy               string                  `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt               time.Time               `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy               string                  `json:"updated_by" gorm:"column:updated_by"`
+	DeletedAt               gorm.DeletedAt          `gorm:"column:deleted_at;index"`
+	DeletedBy               *string                 `json:"deleted_by" gorm:"column:deleted_by"`
+}
+
+// Advance counts the run due at NextRunAt and moves to the following one, the order completes
+// once the next run would go past EndAt or MaxOccurrences.
+func (r *RecurringTransfer) Advance() {
+	r.Occurrences++
+	r.NextRunAt = r.Frequency.Next(r.NextRunAt, r.StartAt.Day())
+
+	if (r.MaxOccurrences > 0 && r.Occurrences >= r.MaxOccurrences) || (r.EndAt != nil && r.NextRunAt.After(*r.EndAt)) {
+		r.StatusRecurringTransfer = StatusRecurringTransferCompleted
+	}
+}
+
+type StatusRecurringRun string
+
+const (
+	StatusRecurringRunExecuted StatusRecurringRun = "executed"
+	// StatusRecurringRunSkipped: the transfer was rejected, e.g. for insufficient balance, the
+	// order carries on with the next run
+	StatusRecurringRunSkipped StatusRecurringRun = "skipped"
+)
+
+func (e StatusRecurringRun) String() string {
+	return string(e)
+}
+
+// RecurringTransferRun is one run of a standing order.
+type RecurringTransferRun struct {
+	RecurringTransferRunID int64              `json:"recurring_transfer_run_id" gorm:"column:recurring_transfer_run_id;primaryKey;autoIncrement"` //nolint:lll // long tag required by GORM
+	RecurringTransferID    int64              `json:"recurring_transfer_id" gorm:"column:recurring_transfer_id;not null;index"`
+	Occurrence             int                `json:"occurrence" gorm:"column:occurrence;not null"`
+	ScheduledAt            time.Time          `json:"scheduled_at" gorm:"column:scheduled_at;not null"`
+	StatusRun              StatusRecurringRun `json:"status_run" gorm:"column:status_run;type:enum('executed', 'skipped');not null"`
+	TransferID             *int64             `json:"transfer_id" gorm:"column:transfer_id"`
+	FailureCode            int                `json:"failure_code" gorm:"column:failure_code"`
+	FailureReason          string             `json:"failure_reason" gorm:"column:failure_reason;type:text"`
+	CreatedAt              time.Time          `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy              string             `json:"created_by" gorm:"column:created_by"`
+}
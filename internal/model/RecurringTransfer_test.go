@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurringFrequency_Next(t *testing.T) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		desc      string
+		frequency RecurringFrequency
+		prev      time.Time
+		anchorDay int
+		expected  time.Time
+	}{
+		{desc: "daily", frequency: RecurringFrequencyDaily, prev: at(2026, 2, 28), anchorDay: 28, expected: at(2026, 3, 1)},
+		{desc: "weekly", frequency: RecurringFrequencyWeekly, prev: at(2026, 12, 29), anchorDay: 29, expected: at(2027, 1, 5)},
+		{desc: "monthly", frequency: RecurringFrequencyMonthly, prev: at(2026, 1, 15), anchorDay: 15, expected: at(2026, 2, 15)},
+		{desc: "monthly_short_month", frequency: RecurringFrequencyMonthly, prev: at(2026, 1, 31), anchorDay: 31, expected: at(2026, 2, 28)},
+		{desc: "monthly_back_to_anchor", frequency: RecurringFrequencyMonthly, prev: at(2026, 2, 28), anchorDay: 31, expected: at(2026, 3, 31)},
+		{desc: "monthly_leap_year", frequency: RecurringFrequencyMonthly, prev: at(2028, 1, 30), anchorDay: 30, expected: at(2028, 2, 29)},
+		{desc: "monthly_year_end", frequency: RecurringFrequencyMonthly, prev: at(2026, 12, 31), anchorDay: 31, expected: at(2027, 1, 31)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expected, tC.frequency.Next(tC.prev, tC.anchorDay))
+		})
+	}
+}
+
+func TestRecurringTransfer_Advance(t *testing.T) {
+	start := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	endAt := start.AddDate(0, 0, 2)
+	testCases := []struct {
+		desc           string
+		order          RecurringTransfer
+		runs           int
+		expectedStatus StatusRecurringTransfer
+		expectedNext   time.Time
+	}{
+		{
+			desc:           "open_ended",
+			order:          RecurringTransfer{Frequency: RecurringFrequencyDaily, StartAt: start, NextRunAt: start},
+			runs:           5,
+			expectedStatus: StatusRecurringTransferActive,
+			expectedNext:   start.AddDate(0, 0, 5),
+		},
+		{
+			desc: "max_occurrences",
+			order: RecurringTransfer{
+				Frequency: RecurringFrequencyWeekly, StartAt: start, NextRunAt: start, MaxOccurrences: 2,
+			},
+			runs:           2,
+			expectedStatus: StatusRecurringTransferCompleted,
+			expectedNext:   start.AddDate(0, 0, 14),
+		},
+		{
+			desc:           "end_date_includes_last_day",
+			order:          RecurringTransfer{Frequency: RecurringFrequencyDaily, StartAt: start, NextRunAt: start, EndAt: &endAt},
+			runs:           2,
+			expectedStatus: StatusRecurringTransferActive,
+			expectedNext:   endAt,
+		},
+		{
+			desc:           "end_date_passed",
+			order:          RecurringTransfer{Frequency: RecurringFrequencyDaily, StartAt: start, NextRunAt: start, EndAt: &endAt},
+			runs:           3,
+			expectedStatus: StatusRecurringTransferCompleted,
+			expectedNext:   start.AddDate(0, 0, 3),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			order := tC.order
+			order.StatusRecurringTransfer = StatusRecurringTransferActive
+			for range tC.runs {
+				order.Advance()
+			}
+
+			assert.Equal(t, tC.runs, order.Occurrences)
+			assert.Equal(t, tC.expectedStatus, order.StatusRecurringTransfer)
+			assert.Equal(t, tC.expectedNext, order.NextRunAt)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"time"
+)
+
+// DeviceIDHeader identifies the device of the caller, the risk rules group claims by it.
+const DeviceIDHeader = "X-Device-ID"
+
+type RiskOperation string
+
+const (
+	RiskOperationTransferCreate RiskOperation = "transfer_create"
+	RiskOperationTransferClaim  RiskOperation = "transfer_claim"
+	RiskOperationEnvelopeCreate RiskOperation = "envelope_create"
+	RiskOperationEnvelopeClaim  RiskOperation = "envelope_claim"
+)
+
+var validRiskOperation = map[RiskOperation]bool{
+	RiskOperationTransferCreate: true,
+	RiskOperationTransferClaim:  true,
+	RiskOperationEnvelopeCreate: true,
+	RiskOperationEnvelopeClaim:  true,
+}
+
+func (e RiskOperation) IsValid() bool {
+	_, exist := validRiskOperation[e]
+	return exist
+}
+
+func (e RiskOperation) String() string {
+	return string(e)
+}
+
+type RiskDecisionType string
+
+const (
+	RiskDecisionAllow  RiskDecisionType = "allow"
+	RiskDecisionReview RiskDecisionType = "review"
+	RiskDecisionDeny   RiskDecisionType = "deny"
+)
+
+// riskDecisionSeverity orders the decisions, the most severe decision of the rules wins.
+var riskDecisionSeverity = map[RiskDecisionType]int{
+	RiskDecisionAllow:  0,
+	RiskDecisionReview: 1,
+	RiskDecisionDeny:   2,
+}
+
+func (e RiskDecisionType) IsValid() bool {
+	_, exist := riskDecisionSeverity[e]
+	return exist
+}
+
+// MoreSevere reports whether e outranks other: deny over review over allow.
+func (e RiskDecisionType) MoreSevere(other RiskDecisionType) bool {
+	return riskDecisionSeverity[e] > riskDecisionSeverity[other]
+}
+
+func (e RiskDecisionType) String() string {
+	return string(e)
+}
+
+// RiskDecision records how the risk rules judged a money movement. ReferenceID is the transfer
+// or envelope it belongs to, for a deny the movement itself was rolled back.
+type RiskDecision struct {
+	RiskDecisionID int64            `json:"risk_decision_id" gorm:"column:risk_decision_id;primaryKey;autoIncrement"`
+	Operation      RiskOperation    `json:"operation" gorm:"column:operation;type:enum('transfer_create', 'transfer_claim', 'envelope_create', 'envelope_claim');not null;index:idx_risk_decision_reference,priority:1"` //nolint:lll // long enum tag required by GORM
+	ReferenceID    int64            `json:"reference_id" gorm:"column:reference_id;not null;index:idx_risk_decision_reference,priority:2"`
+	UserID         string           `json:"user_id" gorm:"column:user_id;type:varchar(64);not null;index:idx_risk_decision_user,priority:1;index:idx_risk_decision_pair,priority:1"` //nolint:lll // long index tag required by GORM
+	CounterpartyID string           `json:"counterparty_id" gorm:"column:counterparty_id;type:varchar(64);index:idx_risk_decision_pair,priority:2"`                                  //nolint:lll // long index tag required by GORM
+	WalletID       int64            `json:"wallet_id" gorm:"column:wallet_id;not null"`
+	Amount         Money            `json:"amount" gorm:"column:amount;not null"`
+	Currency       Currency         `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
+	DeviceID       string           `json:"device_id" gorm:"column:device_id;type:varchar(128);index:idx_risk_decision_device,priority:1"`
+	Decision       RiskDecisionType `json:"decision" gorm:"column:decision;type:enum('allow', 'review', 'deny');not null;index"`
+	// Rules: comma separated names of the rules that fired
+	Rules     string    `json:"rules" gorm:"column:rules"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime;index:idx_risk_decision_user,priority:2;index:idx_risk_decision_pair,priority:3;index:idx_risk_decision_device,priority:2"` //nolint:lll // long index tag required by GORM
+}
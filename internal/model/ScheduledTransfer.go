@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ScheduledTransfer is a transfer the sender asked to send at ExecuteAt. Nothing is debited
+// until it runs, a hold may keep the amount aside in the meantime. When the transfer cannot be
+// sent at that time the row is marked failed and keeps the error the user is shown.
+type ScheduledTransfer struct {
+	ScheduledTransferID     int64                   `json:"scheduled_transfer_id" gorm:"column:scheduled_transfer_id;primaryKey;autoIncrement"` //nolint:lll // long tag required by GORM
+	FromUserID              string                  `json:"from_user_id" gorm:"column:from_user_id;not null;index"`
+	ToUserID                string                  `json:"to_user_id" gorm:"column:to_user_id;not null"`
+	Amount                  Money                   `json:"amount" gorm:"column:amount;not null"`
+	Currency                Currency                `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
+	Remark                  string                  `json:"remark" gorm:"column:remark;type:text"`
+	ExecuteAt               time.Time               `json:"execute_at" gorm:"column:execute_at;not null;index"`
+	StatusScheduledTransfer StatusScheduledTransfer `json:"status_scheduled_transfer" gorm:"column:status_scheduled_transfer;type:enum('scheduled', 'executed', 'failed', 'canceled');default:'scheduled';index"` //nolint:lll // long enum tag required by GORM
+	WalletHoldID            *int64                  `json:"wallet_hold_id" gorm:"column:wallet_hold_id"`
+	TransferID              *int64                  `json:"transfer_id" gorm:"column:transfer_id"`
+	FailureCode             int                     `json:"failure_code" gorm:"column:failure_code"`
+	FailureReason           string                  `json:"failure_reason" gorm:"column:failure_reason;type:text"`
+	ExecutedAt              *time.Time              `json:"executed_at" gorm:"column:executed_at"`
+	CanceledAt              *time.Time              `json:"canceled_at" gorm:"column:canceled_at"`
+	IsActive                bool                    `json:"is_active" gorm:"column:is_active;not null"`
+	CreatedAt               time.Time               `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy               string                  `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt               time.Time               `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy               string                  `json:"updated_by" gorm:"column:updated_by"`
+	DeletedAt               gorm.DeletedAt          `gorm:"column:deleted_at;index"`
+	DeletedBy               *string                 `json:"deleted_by" gorm:"column:deleted_by"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+type StatusPaymentRequest string
+
+const (
+	StatusPaymentRequestPending  StatusPaymentRequest = "pending"
+	StatusPaymentRequestPaid     StatusPaymentRequest = "paid"
+	StatusPaymentRequestDeclined StatusPaymentRequest = "declined"
+	StatusPaymentRequestExpired  StatusPaymentRequest = "expired"
+)
+
+var validStatusPaymentRequest = map[StatusPaymentRequest]bool{
+	StatusPaymentRequestPending:  true,
+	StatusPaymentRequestPaid:     true,
+	StatusPaymentRequestDeclined: true,
+	StatusPaymentRequestExpired:  true,
+}
+
+func (e StatusPaymentRequest) IsValid() bool {
+	_, exist := validStatusPaymentRequest[e]
+	return exist
+}
+
+func (e StatusPaymentRequest) String() string {
+	return string(e)
+}
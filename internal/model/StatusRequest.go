@@ -7,6 +7,8 @@ const (
 	StatusRequestApproved  StatusRequest = "approved"
 	StatusRequestRejected  StatusRequest = "rejected"
 	StatusRequestFailed    StatusRequest = "failed"
+	// StatusRequestReversed: the deposit was approved and later reversed by an admin
+	StatusRequestReversed StatusRequest = "reversed"
 )
 
 var validStatusRequest = map[StatusRequest]bool{
@@ -14,6 +16,7 @@ var validStatusRequest = map[StatusRequest]bool{
 	StatusRequestApproved:  true,
 	StatusRequestRejected:  true,
 	StatusRequestFailed:    true,
+	StatusRequestReversed:  true,
 }
 
 func (e StatusRequest) IsValid() bool {
@@ -0,0 +1,26 @@
+package entity
+
+type StatusScheduledTransfer string
+
+const (
+	StatusScheduledTransferScheduled StatusScheduledTransfer = "scheduled"
+	StatusScheduledTransferExecuted  StatusScheduledTransfer = "executed"
+	StatusScheduledTransferFailed    StatusScheduledTransfer = "failed"
+	StatusScheduledTransferCanceled  StatusScheduledTransfer = "canceled"
+)
+
+var validStatusScheduledTransfer = map[StatusScheduledTransfer]bool{
+	StatusScheduledTransferScheduled: true,
+	StatusScheduledTransferExecuted:  true,
+	StatusScheduledTransferFailed:    true,
+	StatusScheduledTransferCanceled:  true,
+}
+
+func (e StatusScheduledTransfer) IsValid() bool {
+	_, exist := validStatusScheduledTransfer[e]
+	return exist
+}
+
+func (e StatusScheduledTransfer) String() string {
+	return string(e)
+}
@@ -6,12 +6,21 @@ const (
 	StatusTransferPending  StatusTransfer = "pending"
 	StatusTransferClaimed  StatusTransfer = "claimed"
 	StatusTransferRefunded StatusTransfer = "refunded"
+	// StatusTransferReversed: the send was reversed by an admin before the transfer was claimed
+	StatusTransferReversed StatusTransfer = "reversed"
+	// StatusTransferCanceled: the sender revoked the transfer before it was claimed
+	StatusTransferCanceled StatusTransfer = "canceled"
+	// StatusTransferDeclined: the receiver turned the transfer down, it went back to the sender
+	StatusTransferDeclined StatusTransfer = "declined"
 )
 
 var validStatusTransfer = map[StatusTransfer]bool{
 	StatusTransferPending:  true,
 	StatusTransferClaimed:  true,
 	StatusTransferRefunded: true,
+	StatusTransferReversed: true,
+	StatusTransferCanceled: true,
+	StatusTransferDeclined: true,
 }
 
 func (e StatusTransfer) IsValid() bool {
@@ -0,0 +1,49 @@
+package entity
+
+// TransferBatchMode decides what happens to a batch when one of its transfers is rejected.
+type TransferBatchMode string
+
+const (
+	// TransferBatchModeAtomic sends every transfer of the batch or none of them.
+	TransferBatchModeAtomic TransferBatchMode = "atomic"
+	// TransferBatchModePartial sends every transfer it can and reports the rejected ones per item.
+	TransferBatchModePartial TransferBatchMode = "partial"
+)
+
+var validTransferBatchMode = map[TransferBatchMode]bool{
+	TransferBatchModeAtomic:  true,
+	TransferBatchModePartial: true,
+}
+
+func (e TransferBatchMode) IsValid() bool {
+	_, exist := validTransferBatchMode[e]
+	return exist
+}
+
+func (e TransferBatchMode) String() string {
+	return string(e)
+}
+
+type StatusTransferBatch string
+
+const (
+	StatusTransferBatchProcessing         StatusTransferBatch = "processing"
+	StatusTransferBatchCompleted          StatusTransferBatch = "completed"
+	StatusTransferBatchPartiallyCompleted StatusTransferBatch = "partially_completed"
+	StatusTransferBatchFailed             StatusTransferBatch = "failed"
+)
+
+func (e StatusTransferBatch) String() string {
+	return string(e)
+}
+
+type StatusTransferBatchItem string
+
+const (
+	StatusTransferBatchItemSucceeded StatusTransferBatchItem = "succeeded"
+	StatusTransferBatchItemFailed    StatusTransferBatchItem = "failed"
+)
+
+func (e StatusTransferBatchItem) String() string {
+	return string(e)
+}
@@ -0,0 +1,26 @@
+package entity
+
+type StatusWithdrawal string
+
+const (
+	StatusWithdrawalRequested StatusWithdrawal = "requested"
+	StatusWithdrawalApproved  StatusWithdrawal = "approved"
+	StatusWithdrawalRejected  StatusWithdrawal = "rejected"
+	StatusWithdrawalPaid      StatusWithdrawal = "paid"
+)
+
+var validStatusWithdrawal = map[StatusWithdrawal]bool{
+	StatusWithdrawalRequested: true,
+	StatusWithdrawalApproved:  true,
+	StatusWithdrawalRejected:  true,
+	StatusWithdrawalPaid:      true,
+}
+
+func (e StatusWithdrawal) IsValid() bool {
+	_, exist := validStatusWithdrawal[e]
+	return exist
+}
+
+func (e StatusWithdrawal) String() string {
+	return string(e)
+}
@@ -0,0 +1,91 @@
+package entity
+
+// SystemAccount is the counterparty of every wallet transaction in the double-entry
+// journal. Money leaving a wallet is held by a system account until it lands in another
+// wallet, so wallet balances and system account balances always sum to zero.
+type SystemAccount string
+
+const (
+	// SystemAccountTransferEscrow holds transfers that are sent but not yet claimed or refunded.
+	SystemAccountTransferEscrow SystemAccount = "transfer_escrow"
+	// SystemAccountEnvelopeEscrow holds the unclaimed remainder of every envelope.
+	SystemAccountEnvelopeEscrow SystemAccount = "envelope_escrow"
+	// SystemAccountDepositFunding is the source of deposited money, its balance goes negative
+	// by the total amount ever deposited.
+	SystemAccountDepositFunding SystemAccount = "deposit_funding"
+	// SystemAccountAdjustmentExpense absorbs admin balance adjustments.
+	SystemAccountAdjustmentExpense SystemAccount = "adjustment_expense"
+	// SystemAccountHoldSettlement receives captured holds, e.g. a payout or a lost dispute, until
+	// they are settled outside the wallet.
+	SystemAccountHoldSettlement SystemAccount = "hold_settlement"
+	// SystemAccountWithdrawalPayout is the destination of paid withdrawals, its balance grows by
+	// the total amount ever paid out of the wallets.
+	SystemAccountWithdrawalPayout SystemAccount = "withdrawal_payout"
+)
+
+var validSystemAccount = map[SystemAccount]bool{
+	SystemAccountTransferEscrow:    true,
+	SystemAccountEnvelopeEscrow:    true,
+	SystemAccountDepositFunding:    true,
+	SystemAccountAdjustmentExpense: true,
+	SystemAccountHoldSettlement:    true,
+	SystemAccountWithdrawalPayout:  true,
+}
+
+var counterAccountByTransactionType = map[TransactionType]SystemAccount{
+	TransactionTypeTransfer:          SystemAccountTransferEscrow,
+	TransactionTypeRefundTransfer:    SystemAccountTransferEscrow,
+	TransactionTypeCancelTransfer:    SystemAccountTransferEscrow,
+	TransactionTypeDeclineTransfer:   SystemAccountTransferEscrow,
+	TransactionTypeEnvelopeFixed:     SystemAccountEnvelopeEscrow,
+	TransactionTypeEnvelopeLucky:     SystemAccountEnvelopeEscrow,
+	TransactionTypeEnvelopeSingle:    SystemAccountEnvelopeEscrow,
+	TransactionTypeEnvelopeExclusive: SystemAccountEnvelopeEscrow,
+	TransactionTypeRefundEnvelope:    SystemAccountEnvelopeEscrow,
+	TransactionTypeDeposit:           SystemAccountDepositFunding,
+	TransactionTypeSystemAdjustment:  SystemAccountAdjustmentExpense,
+	TransactionTypeHoldCapture:       SystemAccountHoldSettlement,
+	TransactionTypeWithdrawal:        SystemAccountWithdrawalPayout,
+}
+
+func (e SystemAccount) IsValid() bool {
+	_, exist := validSystemAccount[e]
+	return exist
+}
+
+func (e SystemAccount) String() string {
+	return string(e)
+}
+
+// IsEscrow reports whether the account holds money owed to users, i.e. an outstanding liability.
+func (e SystemAccount) IsEscrow() bool {
+	return e == SystemAccountTransferEscrow || e == SystemAccountEnvelopeEscrow
+}
+
+// SystemAccounts returns every system account in a stable order.
+func SystemAccounts() []SystemAccount {
+	return []SystemAccount{
+		SystemAccountTransferEscrow,
+		SystemAccountEnvelopeEscrow,
+		SystemAccountDepositFunding,
+		SystemAccountAdjustmentExpense,
+		SystemAccountHoldSettlement,
+		SystemAccountWithdrawalPayout,
+	}
+}
+
+// CounterAccount returns the system account that takes the opposite side of a wallet
+// transaction of this type.
+func (e TransactionType) CounterAccount() SystemAccount {
+	return counterAccountByTransactionType[e]
+}
+
+// CounterAccounts returns the counter account of every transaction type.
+func CounterAccounts() map[TransactionType]SystemAccount {
+	accounts := make(map[TransactionType]SystemAccount, len(counterAccountByTransactionType))
+	for transactionType, account := range counterAccountByTransactionType {
+		accounts[transactionType] = account
+	}
+
+	return accounts
+}
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionType_CounterAccount(t *testing.T) {
+	// a transaction type without a counter account would leave money unbalanced
+	for transactionType := range validTransactionType {
+		assert.True(t, transactionType.CounterAccount().IsValid(), transactionType)
+	}
+
+	assert.Empty(t, TransactionType("unknown").CounterAccount())
+}
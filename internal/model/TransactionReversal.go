@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+)
+
+// TransactionReversal records an admin reversal of a posted wallet transaction. The
+// compensating transaction carries the opposite entry and points back to the original
+// through WalletTransaction.ReversalOfID, a transaction is reversed at most once.
+type TransactionReversal struct {
+	TransactionReversalID int64     `json:"transaction_reversal_id" gorm:"column:transaction_reversal_id;primaryKey;autoIncrement"`
+	WalletTransactionID   int64     `json:"wallet_transaction_id" gorm:"column:wallet_transaction_id;not null;uniqueIndex"`
+	ReversalTransactionID int64     `json:"reversal_transaction_id" gorm:"column:reversal_transaction_id;not null"`
+	Reason                string    `json:"reason" gorm:"column:reason;not null"`
+	CreatedAt             time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy             string    `json:"created_by" gorm:"column:created_by"`
+}
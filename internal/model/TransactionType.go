@@ -3,25 +3,44 @@ package entity
 type TransactionType string
 
 const (
-	TransactionTypeTransfer         TransactionType = "transfer"
-	TransactionTypeEnvelopeFixed    TransactionType = "envelope_fixed"
-	TransactionTypeEnvelopeLucky    TransactionType = "envelope_lucky"
-	TransactionTypeEnvelopeSingle   TransactionType = "envelope_single"
-	TransactionTypeRefundEnvelope   TransactionType = "refund_envelope"
-	TransactionTypeRefundTransfer   TransactionType = "refund_transfer"
-	TransactionTypeSystemAdjustment TransactionType = "system_adjustment"
-	TransactionTypeDeposit          TransactionType = "deposit"
+	TransactionTypeTransfer          TransactionType = "transfer"
+	TransactionTypeEnvelopeFixed     TransactionType = "envelope_fixed"
+	TransactionTypeEnvelopeLucky     TransactionType = "envelope_lucky"
+	TransactionTypeEnvelopeSingle    TransactionType = "envelope_single"
+	TransactionTypeEnvelopeExclusive TransactionType = "envelope_exclusive"
+	TransactionTypeRefundEnvelope    TransactionType = "refund_envelope"
+	TransactionTypeRefundTransfer    TransactionType = "refund_transfer"
+	TransactionTypeCancelTransfer    TransactionType = "cancel_transfer"
+	TransactionTypeDeclineTransfer   TransactionType = "decline_transfer"
+	TransactionTypeSystemAdjustment  TransactionType = "system_adjustment"
+	TransactionTypeDeposit           TransactionType = "deposit"
+	TransactionTypeHoldCapture       TransactionType = "hold_capture"
+	TransactionTypeWithdrawal        TransactionType = "withdrawal"
 )
 
 var validTransactionType = map[TransactionType]bool{
-	TransactionTypeTransfer:         true,
-	TransactionTypeEnvelopeFixed:    true,
-	TransactionTypeEnvelopeLucky:    true,
-	TransactionTypeEnvelopeSingle:   true,
-	TransactionTypeRefundEnvelope:   true,
-	TransactionTypeRefundTransfer:   true,
-	TransactionTypeSystemAdjustment: true,
-	TransactionTypeDeposit:          true,
+	TransactionTypeTransfer:          true,
+	TransactionTypeEnvelopeFixed:     true,
+	TransactionTypeEnvelopeLucky:     true,
+	TransactionTypeEnvelopeSingle:    true,
+	TransactionTypeEnvelopeExclusive: true,
+	TransactionTypeRefundEnvelope:    true,
+	TransactionTypeRefundTransfer:    true,
+	TransactionTypeCancelTransfer:    true,
+	TransactionTypeDeclineTransfer:   true,
+	TransactionTypeSystemAdjustment:  true,
+	TransactionTypeDeposit:           true,
+	TransactionTypeHoldCapture:       true,
+	TransactionTypeWithdrawal:        true,
+}
+
+// SpendTransactionTypes are the debits that count towards the spend limits of a user.
+var SpendTransactionTypes = []TransactionType{
+	TransactionTypeTransfer,
+	TransactionTypeEnvelopeFixed,
+	TransactionTypeEnvelopeLucky,
+	TransactionTypeEnvelopeSingle,
+	TransactionTypeEnvelopeExclusive,
 }
 
 func (e TransactionType) IsValid() bool {
@@ -32,3 +51,17 @@ func (e TransactionType) IsValid() bool {
 func (e TransactionType) String() string {
 	return string(e)
 }
+
+// BypassesFreeze reports whether the transaction is booked even on a frozen wallet. Refunds
+// and canceled or declined transfers only return money the wallet already owned, adjustments
+// are made by an admin, and a capture or a paid withdrawal takes money that was set aside
+// before the freeze.
+func (e TransactionType) BypassesFreeze() bool {
+	switch e {
+	case TransactionTypeRefundEnvelope, TransactionTypeRefundTransfer, TransactionTypeCancelTransfer,
+		TransactionTypeDeclineTransfer, TransactionTypeSystemAdjustment, TransactionTypeHoldCapture, TransactionTypeWithdrawal:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TransferBatch is a payout of one sender to many recipients made in a single request. Each
+// recipient gets an ordinary pending transfer, kept per recipient as a TransferBatchItem.
+type TransferBatch struct {
+	TransferBatchID     int64               `json:"transfer_batch_id" gorm:"column:transfer_batch_id;primaryKey;autoIncrement"`
+	FromUserID          string              `json:"from_user_id" gorm:"column:from_user_id;not null;index"`
+	Currency            Currency            `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
+	Mode                TransferBatchMode   `json:"mode" gorm:"column:mode;type:enum('atomic', 'partial');not null"`
+	TotalAmount         Money               `json:"total_amount" gorm:"column:total_amount;not null"`
+	ItemCount           int                 `json:"item_count" gorm:"column:item_count;not null"`
+	SucceededCount      int                 `json:"succeeded_count" gorm:"column:succeeded_count;not null;default:0"`
+	FailedCount         int                 `json:"failed_count" gorm:"column:failed_count;not null;default:0"`
+	StatusTransferBatch StatusTransferBatch `json:"status_transfer_batch" gorm:"column:status_transfer_batch;type:enum('processing', 'completed', 'partially_completed', 'failed');default:'processing'"` //nolint:lll // long enum tag required by GORM
+	IsActive            bool                `json:"is_active" gorm:"column:is_active;not null"`
+	CreatedAt           time.Time           `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy           string              `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt           time.Time           `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy           string              `json:"updated_by" gorm:"column:updated_by"`
+	DeletedAt           gorm.DeletedAt      `gorm:"column:deleted_at;index"`
+	DeletedBy           *string             `json:"deleted_by" gorm:"column:deleted_by"`
+}
+
+// Finish sets the final status from the item counts.
+func (b *TransferBatch) Finish() {
+	switch {
+	case b.FailedCount == 0:
+		b.StatusTransferBatch = StatusTransferBatchCompleted
+	case b.SucceededCount == 0:
+		b.StatusTransferBatch = StatusTransferBatchFailed
+	default:
+		b.StatusTransferBatch = StatusTransferBatchPartiallyCompleted
+	}
+}
+
+// TransferBatchItem is the transfer to one recipient of a batch. A rejected item keeps the
+// error code and message it was rejected with.
+type TransferBatchItem struct {
+	TransferBatchItemID     int64                   `json:"transfer_batch_item_id" gorm:"column:transfer_batch_item_id;primaryKey;autoIncrement"` //nolint:lll // long tag required by GORM
+	TransferBatchID         int64                   `json:"transfer_batch_id" gorm:"column:transfer_batch_id;not null;index"`
+	ToUserID                string                  `json:"to_user_id" gorm:"column:to_user_id;not null"`
+	Amount                  Money                   `json:"amount" gorm:"column:amount;not null"`
+	Remark                  string                  `json:"remark" gorm:"column:remark;type:text"`
+	StatusTransferBatchItem StatusTransferBatchItem `json:"status_transfer_batch_item" gorm:"column:status_transfer_batch_item;type:enum('succeeded', 'failed');not null"` //nolint:lll // long enum tag required by GORM
+	TransferID              *int64                  `json:"transfer_id" gorm:"column:transfer_id"`
+	FailureCode             int                     `json:"failure_code" gorm:"column:failure_code"`
+	FailureReason           string                  `json:"failure_reason" gorm:"column:failure_reason;type:text"`
+	CreatedAt               time.Time               `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy               string                  `json:"created_by" gorm:"column:created_by"`
+}
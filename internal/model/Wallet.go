@@ -7,14 +7,23 @@ import (
 )
 
 type Wallet struct {
-	WalletID  int64          `json:"wallet_id" gorm:"column:wallet_id;primaryKey;autoIncrement"`
-	UserID    string         `json:"user_id" gorm:"column:user_id;unique;not null"`
-	Balance   float64        `json:"balance" gorm:"column:balance;not null"`
-	IsActive  bool           `json:"is_active" gorm:"column:is_active;not null"`
-	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at;autoCreateTime"`
-	CreatedBy string         `json:"created_by" gorm:"column:created_by"`
-	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
-	UpdatedBy string         `json:"updated_by" gorm:"column:updated_by"`
-	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
-	DeletedBy *string        `json:"deleted_by" gorm:"column:deleted_by"`
+	WalletID     int64          `json:"wallet_id" gorm:"column:wallet_id;primaryKey;autoIncrement"`
+	UserID       string         `json:"user_id" gorm:"column:user_id;not null;uniqueIndex:uq_wallet_user_currency,priority:1"`
+	Currency     Currency       `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY';uniqueIndex:uq_wallet_user_currency,priority:2"` //nolint:lll // long index tag required by GORM
+	Balance      Money          `json:"balance" gorm:"column:balance;not null"`
+	HeldBalance  Money          `json:"held_balance" gorm:"column:held_balance;not null;default:0"`
+	IsActive     bool           `json:"is_active" gorm:"column:is_active;not null"`
+	FreezeStatus FreezeStatus   `json:"freeze_status" gorm:"column:freeze_status;type:enum('none', 'debit_frozen', 'credit_frozen', 'frozen');not null;default:'none'"` //nolint:lll // long enum tag required by GORM
+	CreatedAt    time.Time      `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy    string         `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt    time.Time      `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy    string         `json:"updated_by" gorm:"column:updated_by"`
+	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;index"`
+	DeletedBy    *string        `json:"deleted_by" gorm:"column:deleted_by"`
+}
+
+// AvailableBalance is the part of the balance not reserved by an active hold, the most a
+// debit may take from the wallet.
+func (w *Wallet) AvailableBalance() Money {
+	return w.Balance - w.HeldBalance
 }
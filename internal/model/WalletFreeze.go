@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"time"
+)
+
+// FreezeStatus is the state an admin freeze leaves a wallet in, independent of IsActive.
+type FreezeStatus string
+
+const (
+	FreezeStatusNone FreezeStatus = "none"
+	// FreezeStatusDebitFrozen: money can still come in, nothing can leave
+	FreezeStatusDebitFrozen FreezeStatus = "debit_frozen"
+	// FreezeStatusCreditFrozen: money can still leave, nothing can come in
+	FreezeStatusCreditFrozen FreezeStatus = "credit_frozen"
+	FreezeStatusFrozen       FreezeStatus = "frozen"
+)
+
+func (e FreezeStatus) String() string {
+	return string(e)
+}
+
+// CanDebit reports whether money may be taken from the wallet. An empty status is
+// treated as FreezeStatusNone.
+func (e FreezeStatus) CanDebit() bool {
+	return e != FreezeStatusDebitFrozen && e != FreezeStatusFrozen
+}
+
+// CanCredit reports whether money may be added to the wallet.
+func (e FreezeStatus) CanCredit() bool {
+	return e != FreezeStatusCreditFrozen && e != FreezeStatusFrozen
+}
+
+// Freeze returns the status after additionally blocking the sides of freezeType.
+func (e FreezeStatus) Freeze(freezeType FreezeType) FreezeStatus {
+	return freezeStatusOf(!e.CanDebit() || freezeType.blocksDebit(), !e.CanCredit() || freezeType.blocksCredit())
+}
+
+// Unfreeze returns the status after lifting the sides of freezeType.
+func (e FreezeStatus) Unfreeze(freezeType FreezeType) FreezeStatus {
+	return freezeStatusOf(!e.CanDebit() && !freezeType.blocksDebit(), !e.CanCredit() && !freezeType.blocksCredit())
+}
+
+func freezeStatusOf(debitFrozen, creditFrozen bool) FreezeStatus {
+	switch {
+	case debitFrozen && creditFrozen:
+		return FreezeStatusFrozen
+	case debitFrozen:
+		return FreezeStatusDebitFrozen
+	case creditFrozen:
+		return FreezeStatusCreditFrozen
+	default:
+		return FreezeStatusNone
+	}
+}
+
+// FreezeType is the side of a wallet a freeze or unfreeze applies to.
+type FreezeType string
+
+const (
+	FreezeTypeDebit  FreezeType = "debit"
+	FreezeTypeCredit FreezeType = "credit"
+	FreezeTypeFull   FreezeType = "full"
+)
+
+var validFreezeType = map[FreezeType]bool{
+	FreezeTypeDebit:  true,
+	FreezeTypeCredit: true,
+	FreezeTypeFull:   true,
+}
+
+func (e FreezeType) IsValid() bool {
+	_, exist := validFreezeType[e]
+	return exist
+}
+
+func (e FreezeType) String() string {
+	return string(e)
+}
+
+func (e FreezeType) blocksDebit() bool {
+	return e == FreezeTypeDebit || e == FreezeTypeFull
+}
+
+func (e FreezeType) blocksCredit() bool {
+	return e == FreezeTypeCredit || e == FreezeTypeFull
+}
+
+type FreezeAction string
+
+const (
+	FreezeActionFreeze   FreezeAction = "freeze"
+	FreezeActionUnfreeze FreezeAction = "unfreeze"
+)
+
+func (e FreezeAction) String() string {
+	return string(e)
+}
+
+// WalletFreezeHistory records every freeze and unfreeze of a wallet, with the status
+// it moved the wallet between.
+type WalletFreezeHistory struct {
+	WalletFreezeHistoryID int64        `json:"wallet_freeze_history_id" gorm:"column:wallet_freeze_history_id;primaryKey;autoIncrement"`
+	WalletID              int64        `json:"wallet_id" gorm:"column:wallet_id;not null;index"`
+	FreezeAction          FreezeAction `json:"freeze_action" gorm:"column:freeze_action;type:enum('freeze', 'unfreeze');not null"`
+	FreezeType            FreezeType   `json:"freeze_type" gorm:"column:freeze_type;type:enum('debit', 'credit', 'full');not null"`
+	StatusBefore          FreezeStatus `json:"status_before" gorm:"column:status_before;type:enum('none', 'debit_frozen', 'credit_frozen', 'frozen');not null"` //nolint:lll // long enum tag required by GORM
+	StatusAfter           FreezeStatus `json:"status_after" gorm:"column:status_after;type:enum('none', 'debit_frozen', 'credit_frozen', 'frozen');not null"`   //nolint:lll // long enum tag required by GORM
+	Reason                string       `json:"reason" gorm:"column:reason;not null"`
+	CreatedAt             time.Time    `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy             string       `json:"created_by" gorm:"column:created_by"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreezeStatus_Freeze(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		status     FreezeStatus
+		freezeType FreezeType
+		expected   FreezeStatus
+	}{
+		{desc: "debit_on_none", status: FreezeStatusNone, freezeType: FreezeTypeDebit, expected: FreezeStatusDebitFrozen},
+		{desc: "credit_on_none", status: FreezeStatusNone, freezeType: FreezeTypeCredit, expected: FreezeStatusCreditFrozen},
+		{desc: "full_on_none", status: FreezeStatusNone, freezeType: FreezeTypeFull, expected: FreezeStatusFrozen},
+		{desc: "credit_on_debit_frozen", status: FreezeStatusDebitFrozen, freezeType: FreezeTypeCredit, expected: FreezeStatusFrozen},
+		{desc: "debit_on_debit_frozen", status: FreezeStatusDebitFrozen, freezeType: FreezeTypeDebit, expected: FreezeStatusDebitFrozen},
+		{desc: "debit_on_empty", status: "", freezeType: FreezeTypeDebit, expected: FreezeStatusDebitFrozen},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expected, tC.status.Freeze(tC.freezeType))
+		})
+	}
+}
+
+func TestFreezeStatus_Unfreeze(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		status     FreezeStatus
+		freezeType FreezeType
+		expected   FreezeStatus
+	}{
+		{desc: "debit_on_frozen", status: FreezeStatusFrozen, freezeType: FreezeTypeDebit, expected: FreezeStatusCreditFrozen},
+		{desc: "credit_on_frozen", status: FreezeStatusFrozen, freezeType: FreezeTypeCredit, expected: FreezeStatusDebitFrozen},
+		{desc: "full_on_frozen", status: FreezeStatusFrozen, freezeType: FreezeTypeFull, expected: FreezeStatusNone},
+		{desc: "full_on_debit_frozen", status: FreezeStatusDebitFrozen, freezeType: FreezeTypeFull, expected: FreezeStatusNone},
+		{desc: "credit_on_debit_frozen", status: FreezeStatusDebitFrozen, freezeType: FreezeTypeCredit, expected: FreezeStatusDebitFrozen},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expected, tC.status.Unfreeze(tC.freezeType))
+		})
+	}
+}
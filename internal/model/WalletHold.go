@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+)
+
+type StatusHold string
+
+const (
+	StatusHoldActive   StatusHold = "active"
+	StatusHoldReleased StatusHold = "released"
+	StatusHoldCaptured StatusHold = "captured"
+)
+
+var validStatusHold = map[StatusHold]bool{
+	StatusHoldActive:   true,
+	StatusHoldReleased: true,
+	StatusHoldCaptured: true,
+}
+
+func (e StatusHold) IsValid() bool {
+	_, exist := validStatusHold[e]
+	return exist
+}
+
+func (e StatusHold) String() string {
+	return string(e)
+}
+
+// WalletHold reserves part of a wallet balance, e.g. for a pending payout or a dispute,
+// without moving money. The amount of every active hold is counted in Wallet.HeldBalance
+// until the hold is released back to the wallet or captured from it.
+type WalletHold struct {
+	WalletHoldID        int64      `json:"wallet_hold_id" gorm:"column:wallet_hold_id;primaryKey;autoIncrement"`
+	WalletID            int64      `json:"wallet_id" gorm:"column:wallet_id;not null;index"`
+	Amount              Money      `json:"amount" gorm:"column:amount;not null"`
+	Currency            Currency   `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
+	StatusHold          StatusHold `json:"status_hold" gorm:"column:status_hold;type:enum('active', 'released', 'captured');not null;default:'active'"` //nolint:lll // long enum tag required by GORM
+	Reason              string     `json:"reason" gorm:"column:reason;not null"`
+	WalletTransactionID *int64     `json:"wallet_transaction_id" gorm:"column:wallet_transaction_id"`
+	ReleasedAt          *time.Time `json:"released_at" gorm:"column:released_at"`
+	CapturedAt          *time.Time `json:"captured_at" gorm:"column:captured_at"`
+	CreatedAt           time.Time  `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy           string     `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt           time.Time  `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy           string     `json:"updated_by" gorm:"column:updated_by"`
+}
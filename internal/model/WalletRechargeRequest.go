@@ -9,9 +9,12 @@ import (
 type WalletRechargeRequest struct {
 	WalletRechargeRequestID int64          `json:"wallet_recharge_request_id" gorm:"column:wallet_recharge_request_id;primaryKey;autoIncrement"` //nolint:lll // long enum tag required by GORM
 	WalletID                int64          `json:"wallet_id" gorm:"column:wallet_id;not null"`
-	Amount                  float64        `json:"amount" gorm:"column:amount;not null"`
-	StatusRequest           StatusRequest  `json:"status_request" gorm:"column:status_request;type:enum('requested', 'approved', 'rejected', 'failed');default:'requested'"` //nolint:lll // long enum tag required by GORM
+	Amount                  Money          `json:"amount" gorm:"column:amount;not null"`
+	Currency                Currency       `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
+	StatusRequest           StatusRequest  `json:"status_request" gorm:"column:status_request;type:enum('requested', 'approved', 'rejected', 'failed', 'reversed');default:'requested'"` //nolint:lll // long enum tag required by GORM
 	Description             string         `json:"description" gorm:"column:description;type:text"`
+	ProofReference          string         `json:"proof_reference" gorm:"column:proof_reference"`
+	RejectReason            string         `json:"reject_reason" gorm:"column:reject_reason;type:text"`
 	ApprovedAt              *time.Time     `json:"approved_at" gorm:"column:approved_at"`
 	OperatedBy              *string        `json:"operated_by" gorm:"column:operated_by"`
 	IsActive                bool           `json:"is_active" gorm:"column:is_active;not null"`
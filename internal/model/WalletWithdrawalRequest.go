@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WalletWithdrawalRequest is a cash-out asked for by a user. The amount is held on the wallet
+// from the request on, a rejection releases the hold and marking the request paid captures it.
+type WalletWithdrawalRequest struct {
+	WalletWithdrawalRequestID int64            `json:"wallet_withdrawal_request_id" gorm:"column:wallet_withdrawal_request_id;primaryKey;autoIncrement"` //nolint:lll // long tag required by GORM
+	WalletID                  int64            `json:"wallet_id" gorm:"column:wallet_id;not null;index"`
+	WalletHoldID              int64            `json:"wallet_hold_id" gorm:"column:wallet_hold_id;not null"`
+	Amount                    Money            `json:"amount" gorm:"column:amount;not null"`
+	Currency                  Currency         `json:"currency" gorm:"column:currency;type:char(3);not null;default:'CNY'"`
+	StatusWithdrawal          StatusWithdrawal `json:"status_withdrawal" gorm:"column:status_withdrawal;type:enum('requested', 'approved', 'rejected', 'paid');default:'requested'"` //nolint:lll // long enum tag required by GORM
+	Destination               string           `json:"destination" gorm:"column:destination;type:text;not null"`
+	Description               string           `json:"description" gorm:"column:description;type:text"`
+	RejectReason              string           `json:"reject_reason" gorm:"column:reject_reason;type:text"`
+	WalletTransactionID       *int64           `json:"wallet_transaction_id" gorm:"column:wallet_transaction_id"`
+	ApprovedAt                *time.Time       `json:"approved_at" gorm:"column:approved_at"`
+	RejectedAt                *time.Time       `json:"rejected_at" gorm:"column:rejected_at"`
+	PaidAt                    *time.Time       `json:"paid_at" gorm:"column:paid_at"`
+	OperatedBy                *string          `json:"operated_by" gorm:"column:operated_by"`
+	IsActive                  bool             `json:"is_active" gorm:"column:is_active;not null"`
+	CreatedAt                 time.Time        `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+	CreatedBy                 string           `json:"created_by" gorm:"column:created_by"`
+	UpdatedAt                 time.Time        `json:"updated_at" gorm:"column:updated_at;autoUpdateTime"`
+	UpdatedBy                 string           `json:"updated_by" gorm:"column:updated_by"`
+	DeletedAt                 gorm.DeletedAt   `gorm:"column:deleted_at;index"`
+	DeletedBy                 *string          `json:"deleted_by" gorm:"column:deleted_by"`
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/1nterdigital/aka-im-wallet/internal/usecase"
 	conf "github.com/1nterdigital/aka-im-wallet/pkg/common/config"
 	"github.com/1nterdigital/aka-im-wallet/pkg/common/db/kafka"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/imapi"
 	"github.com/1nterdigital/aka-im-wallet/pkg/common/kdisc"
 )
 
@@ -29,6 +30,8 @@ type MsgTransfer struct {
 
 	expiredTransferCH *ExpiredTransferConsumerHandler
 	expiredEnvelopeCH *ExpiredEnvelopeConsumerHandler
+	scheduledCH       *ScheduledTransferConsumerHandler
+	recurringCH       *RecurringTransferConsumerHandler
 }
 
 type Config struct {
@@ -38,11 +41,17 @@ type Config struct {
 	MysqlConfig conf.Mysql
 	Share       conf.Share
 	Discovery   conf.Discovery
+	// LimitsConfig and RiskConfig: scheduled and recurring transfers run the same checks as a transfer sent
+	// from the api
+	LimitsConfig conf.Limits
+	RiskConfig   conf.Risk
 }
 
 type mapKafkaProducer struct {
-	expiredTransfer *kafka.Producer
-	expiredEnvelope *kafka.Producer
+	expiredTransfer   *kafka.Producer
+	expiredEnvelope   *kafka.Producer
+	scheduledTransfer *kafka.Producer
+	recurringTransfer *kafka.Producer
 }
 
 func Start(ctx context.Context, index int, config *Config) error {
@@ -73,6 +82,10 @@ func Start(ctx context.Context, index int, config *Config) error {
 	// usecase
 	usecases, err := usecase.New(&usecase.Config{
 		KafkaConfig: config.KafkaConfig,
+		Limits:      config.LimitsConfig,
+		Risk:        config.RiskConfig,
+		// the recurring transfer consumer tells senders about skipped runs over IM
+		IM: imapi.New(config.Share.AkaIM.ApiURL, config.Share.AkaIM.Secret, config.Share.AkaIM.AdminUserID),
 	}, repos, dbGorm)
 	if err != nil {
 		return err
@@ -97,9 +110,25 @@ func Start(ctx context.Context, index int, config *Config) error {
 		return err
 	}
 
+	scheduledCH, err := NewScheduledTransferConsumerHandler(
+		ctx, config, producers.scheduledTransfer,
+		usecases.ScheduledTransfer)
+	if err != nil {
+		return err
+	}
+
+	recurringCH, err := NewRecurringTransferConsumerHandler(
+		ctx, config, producers.recurringTransfer,
+		usecases.RecurringTransfer)
+	if err != nil {
+		return err
+	}
+
 	msgTransfer := &MsgTransfer{
 		expiredTransferCH: expiredTransferCH,
 		expiredEnvelopeCH: expiredEnvelopeCH,
+		scheduledCH:       scheduledCH,
+		recurringCH:       recurringCH,
 	}
 	return msgTransfer.Start(index, config)
 }
@@ -114,6 +143,8 @@ func (m *MsgTransfer) Start(index int, cfg *Config) error {
 	// consumer
 	go m.expiredTransferCH.consumerGroup.RegisterHandleAndConsumer(m.ctx, m.expiredTransferCH)
 	go m.expiredEnvelopeCH.consumerGroup.RegisterHandleAndConsumer(m.ctx, m.expiredEnvelopeCH)
+	go m.scheduledCH.consumerGroup.RegisterHandleAndConsumer(m.ctx, m.scheduledCH)
+	go m.recurringCH.consumerGroup.RegisterHandleAndConsumer(m.ctx, m.recurringCH)
 
 	err := m.expiredTransferCH.redisMessageBatches.Start()
 	if err != nil {
@@ -125,6 +156,16 @@ func (m *MsgTransfer) Start(index int, cfg *Config) error {
 		return err
 	}
 
+	err = m.scheduledCH.redisMessageBatches.Start()
+	if err != nil {
+		return err
+	}
+
+	err = m.recurringCH.redisMessageBatches.Start()
+	if err != nil {
+		return err
+	}
+
 	_, err = kdisc.NewDiscoveryRegister(&cfg.Discovery, "", nil)
 	if err != nil {
 		return errs.WrapMsg(err, "failed to register discovery service")
@@ -168,6 +209,10 @@ func (m *MsgTransfer) Start(index int, cfg *Config) error {
 		m.expiredTransferCH.consumerGroup.Close()
 		m.expiredEnvelopeCH.redisMessageBatches.Close()
 		m.expiredEnvelopeCH.consumerGroup.Close()
+		m.scheduledCH.redisMessageBatches.Close()
+		m.scheduledCH.consumerGroup.Close()
+		m.recurringCH.redisMessageBatches.Close()
+		m.recurringCH.consumerGroup.Close()
 		return nil
 	case <-netDone:
 		m.cancel()
@@ -175,6 +220,10 @@ func (m *MsgTransfer) Start(index int, cfg *Config) error {
 		m.expiredTransferCH.consumerGroup.Close()
 		m.expiredEnvelopeCH.redisMessageBatches.Close()
 		m.expiredEnvelopeCH.consumerGroup.Close()
+		m.scheduledCH.redisMessageBatches.Close()
+		m.scheduledCH.consumerGroup.Close()
+		m.recurringCH.redisMessageBatches.Close()
+		m.recurringCH.consumerGroup.Close()
 		close(netDone)
 		return netErr
 	}
@@ -198,5 +247,15 @@ func initKafkaProducers(cfg *Config) (resp *mapKafkaProducer, err error) {
 		return nil, err
 	}
 
+	producers.scheduledTransfer, err = kafka.NewKafkaProducer(configuration, kafkaConf.Address, kafkaConf.ToScheduledTransferTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	producers.recurringTransfer, err = kafka.NewKafkaProducer(configuration, kafkaConf.Address, kafkaConf.ToRecurringTransferTopic)
+	if err != nil {
+		return nil, err
+	}
+
 	return &producers, nil
 }
@@ -0,0 +1,198 @@
+package msgtransfer
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/sarama"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/mcontext"
+	"github.com/1nterdigital/aka-im-tools/utils/stringutil"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/internal/usecase"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/db/kafka"
+	"github.com/1nterdigital/aka-im-wallet/pkg/tools/batcher"
+)
+
+type CtxMsgRecurringTransfer struct {
+	msg *domain.MsgKafkaRecurringTransfer
+	ctx context.Context
+}
+
+type RecurringTransferConsumerHandler struct {
+	consumerGroup            *kafka.MConsumerGroup
+	producer                 *kafka.Producer
+	redisMessageBatches      *batcher.Batcher[sarama.ConsumerMessage]
+	recurringTransferUsecase usecase.RecurringTransferSvc
+}
+
+func NewRecurringTransferConsumerHandler(
+	_ context.Context,
+	config *Config,
+	producer *kafka.Producer,
+	recurringTransferUsecase usecase.RecurringTransferSvc,
+) (*RecurringTransferConsumerHandler, error) {
+	kafkaConf := config.KafkaConfig
+	consumerGroup, err := kafka.NewMConsumerGroup(
+		kafkaConf.Build(),
+		kafkaConf.ToRecurringTransferGroupID,
+		[]string{kafkaConf.ToRecurringTransferTopic},
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	och := RecurringTransferConsumerHandler{
+		producer:                 producer,
+		recurringTransferUsecase: recurringTransferUsecase,
+	}
+
+	b := batcher.New[sarama.ConsumerMessage](
+		batcher.WithSize(size),
+		batcher.WithWorker(worker),
+		batcher.WithInterval(interval),
+		batcher.WithDataBuffer(mainDataBuffer),
+		batcher.WithSyncWait(true),
+		batcher.WithBuffer(subChanBuffer),
+	)
+
+	b.Sharding = func(key string) int {
+		hashCode := stringutil.GetHashCode(key)
+		return int(hashCode) % och.redisMessageBatches.Worker()
+	}
+	b.Key = func(consumerMessage *sarama.ConsumerMessage) string {
+		return string(consumerMessage.Key)
+	}
+	b.Do = och.do
+	och.redisMessageBatches = b
+	och.consumerGroup = consumerGroup
+
+	return &och, nil
+}
+
+func (och *RecurringTransferConsumerHandler) do(
+	ctx context.Context, channelID int, val *batcher.Msg[sarama.ConsumerMessage],
+) {
+	ctx = mcontext.WithTriggerIDContext(ctx, val.TriggerID())
+	ctxMessages := parseConsumerRecurringTransferMessages(ctx, val.Val())
+	ctx = withAggregationCtxRecurringTransfer(ctx, ctxMessages)
+	log.ZInfo(ctx, "msg arrived channel", "channel id", channelID, "msgList length", len(ctxMessages), "key", val.Key())
+
+	och.handleMsg(ctx, val.Key(), ctxMessages)
+}
+
+func parseConsumerRecurringTransferMessages(
+	ctx context.Context, consumerMessages []*sarama.ConsumerMessage,
+) []*CtxMsgRecurringTransfer {
+	var ctxMessages []*CtxMsgRecurringTransfer
+	for i := range consumerMessages {
+		ctxMsg := &CtxMsgRecurringTransfer{}
+		msgFromMQ := &domain.MsgKafkaRecurringTransfer{}
+		err := json.Unmarshal(consumerMessages[i].Value, msgFromMQ)
+		if err != nil {
+			log.ZWarn(ctx, "msg_transfer Unmarshal msg err", err, string(consumerMessages[i].Value))
+			continue
+		}
+
+		var arr []string
+		for i, header := range consumerMessages[i].Headers {
+			arr = append(arr, strconv.Itoa(i), string(header.Key), string(header.Value))
+		}
+		log.ZDebug(ctx, "consumer.kafka.GetContextWithMQHeader", "len", len(consumerMessages[i].Headers),
+			"header", strings.Join(arr, ", "))
+		ctxMsg.ctx = kafka.GetContextWithMQHeader(consumerMessages[i].Headers)
+		ctxMsg.msg = msgFromMQ
+		log.ZDebug(ctx, "message parse finish", "message", msgFromMQ, "key",
+			string(consumerMessages[i].Key))
+		ctxMessages = append(ctxMessages, ctxMsg)
+	}
+
+	return ctxMessages
+}
+
+func (och *RecurringTransferConsumerHandler) handleMsg(
+	ctx context.Context, key string, kafkaMsg []*CtxMsgRecurringTransfer,
+) {
+	var err error
+	defer func() {
+		if err != nil {
+			log.ZWarn(ctx, "an error occurs while handle kafka msg", err, "key", key)
+		}
+	}()
+	log.ZInfo(ctx, "handle recurring transfer")
+
+	var recurringTransfers []*domain.MsgKafkaRecurringTransfer
+	for idx := range kafkaMsg {
+		recurringTransfers = append(recurringTransfers, kafkaMsg[idx].msg)
+	}
+
+	failedList, err := och.recurringTransferUsecase.ExecuteRecurringTransfers(ctx, recurringTransfers)
+	if err != nil {
+		log.ZError(ctx, "while ExecuteRecurringTransfers", err, "key", key)
+	}
+
+	newCtx := context.WithoutCancel(ctx)
+	for idx := range failedList {
+		if _, _, errx := och.producer.SendMessage(newCtx, key, failedList[idx]); errx != nil {
+			log.ZError(ctx, "while republish failed recurring transfer",
+				errx, "key", key, "recurringTransferID", failedList[idx].RecurringTransferID)
+		}
+	}
+}
+
+//nolint:revive // keep receiver for interface compliance, may be used in the future
+func (och *RecurringTransferConsumerHandler) Setup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+//nolint:revive // keep receiver for interface compliance, may be used in the future
+func (och *RecurringTransferConsumerHandler) Cleanup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+//nolint:dupl // similar code for different entities
+func (och *RecurringTransferConsumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
+	claim sarama.ConsumerGroupClaim) error { // a instance in the consumer group
+	log.ZDebug(context.Background(), "new session recurring transfer msg come", "highWaterMarkOffset",
+		claim.HighWaterMarkOffset(), "topic", claim.Topic(), "partition", claim.Partition())
+	och.redisMessageBatches.OnComplete = func(lastMessage *sarama.ConsumerMessage, _ int) {
+		session.MarkMessage(lastMessage, "")
+		session.Commit()
+	}
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			if len(msg.Value) == 0 {
+				continue
+			}
+			err := och.redisMessageBatches.Put(context.Background(), msg)
+			if err != nil {
+				log.ZWarn(context.Background(), "put msg to  error", err, "msg", msg)
+			}
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+func withAggregationCtxRecurringTransfer(ctx context.Context, values []*CtxMsgRecurringTransfer) context.Context {
+	var allMessageOperationID string
+	for i, v := range values {
+		if opid := mcontext.GetOperationID(v.ctx); opid != "" {
+			if i == 0 {
+				allMessageOperationID += opid
+			} else {
+				allMessageOperationID += "$" + opid
+			}
+		}
+	}
+	return mcontext.SetOperationID(ctx, allMessageOperationID)
+}
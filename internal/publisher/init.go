@@ -78,6 +78,24 @@ func Start(ctx context.Context, index int, config *Config) error {
 	if err != nil {
 		return err
 	}
+	expiredPaymentRequest, err := NewPaymentRequestPublisherHandler(ctx, config, usecases.PaymentRequest)
+	if err != nil {
+		return err
+	}
+
+	reconciliation, err := NewReconciliationPublisherHandler(ctx, config, usecases.Reconciliation)
+	if err != nil {
+		return err
+	}
+
+	scheduledTransfer, err := NewScheduledTransferPublisherHandler(ctx, config, usecases.ScheduledTransfer)
+	if err != nil {
+		return err
+	}
+	recurringTransfer, err := NewRecurringTransferPublisherHandler(ctx, config, usecases.RecurringTransfer)
+	if err != nil {
+		return err
+	}
 
 	publisher := &Publisher{
 		ctx: ctx,
@@ -85,6 +103,14 @@ func Start(ctx context.Context, index int, config *Config) error {
 			domain.PublisherKeyRefundTransferEnvelope: {
 				refundEnvelope,
 				refundTransfer,
+				expiredPaymentRequest,
+			},
+			domain.PublisherKeyReconciliation: {
+				reconciliation,
+			},
+			domain.PublisherKeyScheduledTransfer: {
+				scheduledTransfer,
+				recurringTransfer,
 			},
 		},
 	}
@@ -0,0 +1,36 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/internal/usecase"
+)
+
+// PaymentRequestPublisherHandler expires the payment requests in-process, nothing is debited
+// before a request is paid so there is no refund to hand to the consumers.
+type PaymentRequestPublisherHandler struct {
+	paymentRequestUsecase usecase.PaymentRequestSvc
+}
+
+func NewPaymentRequestPublisherHandler(
+	_ context.Context,
+	_ *Config,
+	paymentRequestUC usecase.PaymentRequestSvc,
+) (PublisherInterface, error) {
+	return &PaymentRequestPublisherHandler{
+		paymentRequestUsecase: paymentRequestUC,
+	}, nil
+}
+
+func (p *PaymentRequestPublisherHandler) Publish(ctx context.Context, _ string) error {
+	expired, err := p.paymentRequestUsecase.ExpirePaymentRequests(ctx, domain.KafkaProducerOperator)
+	if err != nil {
+		return err
+	}
+
+	log.ZInfo(ctx, "payment requests expired", "total", expired)
+
+	return nil
+}
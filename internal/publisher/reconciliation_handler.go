@@ -0,0 +1,41 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/internal/usecase"
+)
+
+// ReconciliationPublisherHandler runs the reconciliation in-process: unlike the refund
+// publishers there is no per-item work to fan out to the consumers.
+type ReconciliationPublisherHandler struct {
+	reconciliationUsecase usecase.ReconciliationSvc
+}
+
+func NewReconciliationPublisherHandler(
+	_ context.Context,
+	_ *Config,
+	reconciliationUC usecase.ReconciliationSvc,
+) (PublisherInterface, error) {
+	return &ReconciliationPublisherHandler{
+		reconciliationUsecase: reconciliationUC,
+	}, nil
+}
+
+func (p *ReconciliationPublisherHandler) Publish(ctx context.Context, _ string) error {
+	run, err := p.reconciliationUsecase.RunReconciliation(ctx, domain.KafkaProducerOperator)
+	if err != nil {
+		return err
+	}
+
+	log.ZInfo(ctx, "reconciliation finished",
+		"reconciliationRunID", run.ReconciliationRunID,
+		"walletsChecked", run.WalletsChecked,
+		"envelopesChecked", run.EnvelopesChecked,
+		"discrepancyCount", run.DiscrepancyCount,
+	)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+//nolint:dupl // similar code for different entities
+package publisher
+
+import (
+	"context"
+	"time"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/mcontext"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/internal/usecase"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/db/kafka"
+)
+
+type RecurringTransferPublisherHandler struct {
+	recurringTransferPublisher *kafka.Producer
+	recurringTransferUsecase   usecase.RecurringTransferSvc
+}
+
+func NewRecurringTransferPublisherHandler(
+	_ context.Context,
+	config *Config,
+	recurringTransferUC usecase.RecurringTransferSvc,
+) (PublisherInterface, error) {
+	kafkaConf := config.KafkaConfig
+	conf, err := kafka.BuildProducerConfig(kafkaConf.Build())
+	if err != nil {
+		return nil, err
+	}
+
+	recurringPublisher, err := kafka.NewKafkaProducer(conf, kafkaConf.Address, kafkaConf.ToRecurringTransferTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecurringTransferPublisherHandler{
+		recurringTransferPublisher: recurringPublisher,
+		recurringTransferUsecase:   recurringTransferUC,
+	}, nil
+}
+
+func (p *RecurringTransferPublisherHandler) Publish(ctx context.Context, key string) error {
+	dueTransfers, err := p.recurringTransferUsecase.FetchDueRecurringTransfers(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(dueTransfers) == 0 {
+		log.ZInfo(ctx, "there are no due recurring transfers")
+		return nil
+	}
+
+	maxRetry := 3
+	var lastErr error
+
+	for _, recurring := range dueTransfers {
+		recurring.OperatedBy = domain.KafkaProducerOperator
+		for retry := 1; retry <= maxRetry; retry++ {
+			ctx = mcontext.SetOperationID(ctx, time.Now().String())
+			if _, _, tempErr := p.recurringTransferPublisher.SendMessage(ctx, key, recurring); tempErr != nil {
+				lastErr = tempErr
+				if retry == maxRetry {
+					log.ZError(ctx, "SendMessage recurring transfer failed after max retries",
+						tempErr,
+						"recurringTransferID", recurring.RecurringTransferID,
+					)
+				}
+
+				continue
+			}
+
+			break
+		}
+	}
+
+	return lastErr
+}
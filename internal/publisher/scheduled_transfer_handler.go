@@ -0,0 +1,77 @@
+//nolint:dupl // similar code for different entities
+package publisher
+
+import (
+	"context"
+	"time"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/mcontext"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	"github.com/1nterdigital/aka-im-wallet/internal/usecase"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/db/kafka"
+)
+
+type ScheduledTransferPublisherHandler struct {
+	scheduledTransferPublisher *kafka.Producer
+	scheduledTransferUsecase   usecase.ScheduledTransferSvc
+}
+
+func NewScheduledTransferPublisherHandler(
+	_ context.Context,
+	config *Config,
+	scheduledTransferUC usecase.ScheduledTransferSvc,
+) (PublisherInterface, error) {
+	kafkaConf := config.KafkaConfig
+	conf, err := kafka.BuildProducerConfig(kafkaConf.Build())
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledPublisher, err := kafka.NewKafkaProducer(conf, kafkaConf.Address, kafkaConf.ToScheduledTransferTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScheduledTransferPublisherHandler{
+		scheduledTransferPublisher: scheduledPublisher,
+		scheduledTransferUsecase:   scheduledTransferUC,
+	}, nil
+}
+
+func (p *ScheduledTransferPublisherHandler) Publish(ctx context.Context, key string) error {
+	dueTransfers, err := p.scheduledTransferUsecase.FetchDueScheduledTransfers(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(dueTransfers) == 0 {
+		log.ZInfo(ctx, "there are no due scheduled transfers")
+		return nil
+	}
+
+	maxRetry := 3
+	var lastErr error
+
+	for _, scheduled := range dueTransfers {
+		scheduled.OperatedBy = domain.KafkaProducerOperator
+		for retry := 1; retry <= maxRetry; retry++ {
+			ctx = mcontext.SetOperationID(ctx, time.Now().String())
+			if _, _, tempErr := p.scheduledTransferPublisher.SendMessage(ctx, key, scheduled); tempErr != nil {
+				lastErr = tempErr
+				if retry == maxRetry {
+					log.ZError(ctx, "SendMessage scheduled transfer failed after max retries",
+						tempErr,
+						"scheduledTransferID", scheduled.ScheduledTransferID,
+					)
+				}
+
+				continue
+			}
+
+			break
+		}
+	}
+
+	return lastErr
+}
@@ -0,0 +1,24 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package approval_request
+
+import (
+	"context"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreateApprovalRequest(
+		ctx context.Context, approval *entity.ApprovalRequest,
+	) (approvalRequestID int64, err error)
+	// GetApprovalRequestForUpdate locks the approval row until the transaction carried by ctx ends.
+	GetApprovalRequestForUpdate(
+		ctx context.Context, approvalRequestID int64,
+	) (approval *entity.ApprovalRequest, err error)
+	UpdateApprovalRequest(ctx context.Context, approval *entity.ApprovalRequest) (err error)
+	GetListApprovalRequest(
+		ctx context.Context, arg *domain.GetListApprovalRequest,
+	) (approvals []*entity.ApprovalRequest, total int64, err error)
+}
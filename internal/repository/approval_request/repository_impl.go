@@ -0,0 +1,188 @@
+package approval_request
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateApprovalRequest(
+	ctx context.Context, approval *entity.ApprovalRequest,
+) (approvalRequestID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(approval).Error
+	if err != nil {
+		log.ZError(ctx, "while create approval request", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.String("operation", approval.Operation.String()),
+		attribute.Int64("walletID", approval.WalletID),
+		attribute.String("amount", approval.Amount.String()),
+		attribute.Int64("approvalRequestID", approval.ApprovalRequestID),
+	)
+
+	return approval.ApprovalRequestID, nil
+}
+
+func (r *repositoryImpl) GetApprovalRequestForUpdate(
+	ctx context.Context, approvalRequestID int64,
+) (approval *entity.ApprovalRequest, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("approvalRequestID", approvalRequestID))
+
+	approval = &entity.ApprovalRequest{}
+	err = r.conn(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("approval_request_id = ?", approvalRequestID).
+		First(approval).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrApprovalRequestNotFound
+		}
+
+		log.ZError(ctx, "while lock approval request", err)
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+func (r *repositoryImpl) UpdateApprovalRequest(
+	ctx context.Context, approval *entity.ApprovalRequest,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("approvalRequestID", approval.ApprovalRequestID),
+		attribute.String("statusApproval", approval.StatusApproval.String()),
+	)
+
+	err = r.conn(ctx).Model(&entity.ApprovalRequest{}).
+		Where("approval_request_id = ?", approval.ApprovalRequestID).
+		Updates(approval).Error
+	if err != nil {
+		log.ZError(ctx, "while update approval request", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) GetListApprovalRequest(
+	ctx context.Context, arg *domain.GetListApprovalRequest,
+) (approvals []*entity.ApprovalRequest, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).Model(&entity.ApprovalRequest{})
+
+	if arg.StatusApproval != "" {
+		query = query.Where("status_approval = ?", arg.StatusApproval)
+		span.SetAttributes(attribute.String("statusApproval", arg.StatusApproval))
+	}
+
+	if arg.Operation != "" {
+		query = query.Where("operation = ?", arg.Operation)
+		span.SetAttributes(attribute.String("operation", arg.Operation))
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	span.SetAttributes(
+		attribute.Int("page", int(arg.Page)),
+		attribute.Int("limit", int(arg.Limit)),
+	)
+
+	// oldest first, the inbox is worked through in the order requests came in
+	offset := (arg.Page - 1) * arg.Limit
+	err = query.
+		Order("created_at ASC, approval_request_id ASC").
+		Limit(int(arg.Limit)).
+		Offset(int(offset)).
+		Find(&approvals).Error
+	if err != nil {
+		log.ZError(ctx, "while repositoryImpl GetListApprovalRequest", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("total", total))
+	return approvals, total, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	"github.com/1nterdigital/aka-im-wallet/internal/domain"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
 )
 
 type repositoryImpl struct {
@@ -23,6 +24,11 @@ func New(db *gorm.DB) Repository {
 	return &repositoryImpl{db: db}
 }
 
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
 func (r *repositoryImpl) CreateBalanceAdjustment(
 	ctx context.Context, tx *gorm.DB, adjustment *entity.BalanceAdjustments,
 ) (balanceAdjustmentID int64, err error) {
@@ -42,7 +48,7 @@ func (r *repositoryImpl) CreateBalanceAdjustment(
 
 	switch tx {
 	case nil:
-		err = r.db.WithContext(ctx).Create(adjustment).Error
+		err = r.conn(ctx).Create(adjustment).Error
 	default:
 		err = tx.WithContext(ctx).Create(adjustment).Error
 	}
@@ -72,7 +78,7 @@ func (r *repositoryImpl) GetListBalanceAdjustment(
 		span.End()
 	}()
 
-	query := r.db.WithContext(ctx).
+	query := r.conn(ctx).
 		Model(&entity.BalanceAdjustments{}).
 		Where("is_active IS TRUE AND deleted_at IS NULL")
 
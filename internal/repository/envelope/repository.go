@@ -12,22 +12,26 @@ import (
 )
 
 type Repository interface {
-	GetAllEnvelopesByUserID(userID int64) (resp []*e.Envelope, err error)
+	GetAllEnvelopesByUserID(ctx context.Context, userID int64) (resp []*e.Envelope, err error)
 	GetEnvelope(ctx context.Context, envelopeID int64) (resp *e.Envelope, err error)
 	CreateEnvelope(ctx context.Context, envelope *e.Envelope) (err error)
 	CountSentEnvelope(ctx context.Context, userID string, now time.Time) (count int64, err error)
 	CountClaimedEnvelope(ctx context.Context, userID string, now time.Time) (count int64, err error)
 	LockEnvelopeByID(ctx context.Context, envelopeID int64) (resp *e.Envelope, err error)
 	ClaimNextLuckyShare(ctx context.Context, envelopeID int64) (resp *e.EnvelopeDetail, err error)
+	// ClaimReservedShare locks the pending share reserved for userID, nil when there is none.
+	ClaimReservedShare(ctx context.Context, envelopeID int64, userID string) (resp *e.EnvelopeDetail, err error)
 	UpdateEnvelopeDetail(ctx context.Context, detail *e.EnvelopeDetail, tx *gorm.DB) (err error)
-	UpdateClaimedAmount(ctx context.Context, envelopeID int64, amount float64) (err error)
+	UpdateClaimedAmount(ctx context.Context, envelopeID int64, amount e.Money) (err error)
 	GetExpiredUnRefundedEnvelopes(ctx context.Context) (resp []*e.Envelope, err error)
 	GetExpiredUnRefundedEnvelopesByID(ctx context.Context, envelopeID int64, userID string) (resp *e.Envelope, err error)
-	RefundEnvelope(ctx context.Context, envelopeID int64, refundAmount float64) (err error)
+	RefundEnvelope(ctx context.Context, envelopeID int64, refundAmount e.Money) (err error)
 	DeactivateUnclaimedDetails(ctx context.Context, envelopeID int64) (err error)
 	CreateEnvelopeDetails(ctx context.Context, envelopeDetail []*e.EnvelopeDetail) (err error)
 	CheckClaimStatus(ctx context.Context, envelopeID int64, userID string) (resp *e.ClaimStatus, err error)
-	WithTransaction(ctx context.Context, fn func(txRepo Repository) error) (err error)
+	// WithTransaction runs fn with a repository bound to a transaction, joining the
+	// transaction carried by ctx when there is one (see tx.Repository).
+	WithTransaction(ctx context.Context, fn func(ctx context.Context, txRepo Repository) error) (err error)
 	WithTx(tx *gorm.DB) Repository
 	GetTx() *gorm.DB
 	Update(ctx context.Context, envelope *e.Envelope, tx *gorm.DB) (err error)
@@ -14,6 +14,7 @@ import (
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	"github.com/1nterdigital/aka-im-wallet/internal/domain"
 	e "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
 	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
 )
 
@@ -27,9 +28,14 @@ func New(db *gorm.DB) Repository {
 	return &repositoryImpl{db: db}
 }
 
-func (r *repositoryImpl) GetAllEnvelopesByUserID(userID int64) (resp []*e.Envelope, err error) {
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) GetAllEnvelopesByUserID(ctx context.Context, userID int64) (resp []*e.Envelope, err error) {
 	var envelopes []*e.Envelope
-	if err := r.db.Where("user_id = ? AND is_active = ?", userID, true).Find(&envelopes).Error; err != nil {
+	if err := r.conn(ctx).Where("user_id = ? AND is_active = ?", userID, true).Find(&envelopes).Error; err != nil {
 		return nil, err
 	}
 
@@ -57,7 +63,7 @@ func (r *repositoryImpl) GetEnvelope(ctx context.Context, envelopeID int64) (res
 
 	span.SetAttributes(attribute.Int64("envelopeID", envelopeID))
 	var envelope e.Envelope
-	err = activeEnvelopeQuery(r.db.WithContext(ctx), envelopeID).First(&envelope).Error
+	err = activeEnvelopeQuery(r.conn(ctx), envelopeID).First(&envelope).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, eerrs.ErrEnvelopeNotFound
@@ -83,7 +89,7 @@ func (r *repositoryImpl) CreateEnvelope(ctx context.Context, env *e.Envelope) (e
 		span.End()
 	}()
 
-	err = r.db.WithContext(ctx).Create(env).Error
+	err = r.conn(ctx).Create(env).Error
 	return err
 }
 
@@ -102,7 +108,7 @@ func (r *repositoryImpl) CreateEnvelopeDetails(ctx context.Context, envDetail []
 		span.End()
 	}()
 
-	err = r.db.WithContext(ctx).CreateInBatches(envDetail, domain.BatchSizeCreateEnvelope).Error
+	err = r.conn(ctx).CreateInBatches(envDetail, domain.BatchSizeCreateEnvelope).Error
 	return err
 }
 
@@ -122,7 +128,7 @@ func (r *repositoryImpl) CheckClaimStatus(ctx context.Context, envelopeID int64,
 	}()
 
 	var result e.ClaimStatus
-	err = activeEnvelopeQuery(r.db.WithContext(ctx), envelopeID).
+	err = activeEnvelopeQuery(r.conn(ctx), envelopeID).
 		Model(&e.EnvelopeDetail{}).
 		Select("COUNT(*) AS total_claimed, SUM(CASE WHEN user_id = ? THEN 1 ELSE 0 END) AS user_has_claimed", userID).
 		Where("envelope_detail_status = ?", e.EnvelopeClaimed).
@@ -159,7 +165,7 @@ func (r *repositoryImpl) CountSentEnvelope(ctx context.Context, userID string, n
 	var count int64
 	startOfDay := now.Truncate(hoursInDay * time.Hour)
 	endOfDay := startOfDay.Add(hoursInDay * time.Hour)
-	err = r.db.WithContext(ctx).
+	err = r.conn(ctx).
 		Model(&e.Envelope{}).
 		Where("user_id = ?", userID).
 		Where("created_at > ? AND created_at <= ?", startOfDay, endOfDay).
@@ -192,7 +198,7 @@ func (r *repositoryImpl) CountClaimedEnvelope(ctx context.Context, userID string
 	var count int64
 	startOfDay := now.Truncate(hoursInDay * time.Hour)
 	endOfDay := startOfDay.Add(hoursInDay * time.Hour)
-	err = r.db.WithContext(ctx).
+	err = r.conn(ctx).
 		Model(&e.EnvelopeDetail{}).
 		Where("user_id = ?", userID).
 		Where("claimed_at > ? AND claimed_at <= ?", startOfDay, endOfDay).
@@ -225,7 +231,7 @@ func (r *repositoryImpl) LockEnvelopeByID(ctx context.Context, envelopeID int64)
 	span.SetAttributes(attribute.Int64("envelopeID", envelopeID))
 
 	var env e.Envelope
-	err = activeEnvelopeQuery(r.db.WithContext(ctx), envelopeID).
+	err = activeEnvelopeQuery(r.conn(ctx), envelopeID).
 		Clauses(clause.Locking{Strength: "UPDATE"}).
 		First(&env).Error
 	if err != nil {
@@ -252,7 +258,7 @@ func (r *repositoryImpl) ClaimNextLuckyShare(ctx context.Context, envelopeID int
 	span.SetAttributes(attribute.Int64("envelopeID", envelopeID))
 
 	var detail e.EnvelopeDetail
-	err = activeEnvelopeQuery(r.db.WithContext(ctx), envelopeID).
+	err = activeEnvelopeQuery(r.conn(ctx), envelopeID).
 		Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("envelope_detail_status != ?", e.EnvelopeClaimed).
 		Order("created_at ASC").
@@ -267,6 +273,43 @@ func (r *repositoryImpl) ClaimNextLuckyShare(ctx context.Context, envelopeID int
 	return &detail, nil
 }
 
+func (r *repositoryImpl) ClaimReservedShare(
+	ctx context.Context, envelopeID int64, userID string,
+) (resp *e.EnvelopeDetail, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("envelopeID", envelopeID),
+		attribute.String("userID", userID),
+	)
+
+	var detail e.EnvelopeDetail
+	err = activeEnvelopeQuery(r.conn(ctx), envelopeID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND envelope_detail_status = ?", userID, e.EnvelopePending).
+		First(&detail).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &detail, nil
+}
+
 func (r *repositoryImpl) UpdateEnvelopeDetail(ctx context.Context, detail *e.EnvelopeDetail, tx *gorm.DB) (err error) {
 	var (
 		funcName = tracer.GetFullFunctionPath()
@@ -285,12 +328,12 @@ func (r *repositoryImpl) UpdateEnvelopeDetail(ctx context.Context, detail *e.Env
 	span.SetAttributes(attribute.Int64("envelopeID", detail.EnvelopeDetailID))
 
 	if tx != nil {
-		return tx.Save(detail).WithContext(ctx).Error
+		return tx.WithContext(ctx).Save(detail).Error
 	}
-	return r.db.Save(detail).WithContext(ctx).Error
+	return r.conn(ctx).Save(detail).Error
 }
 
-func (r *repositoryImpl) UpdateClaimedAmount(ctx context.Context, envelopeID int64, amount float64) (err error) {
+func (r *repositoryImpl) UpdateClaimedAmount(ctx context.Context, envelopeID int64, amount e.Money) (err error) {
 	var (
 		funcName = tracer.GetFullFunctionPath()
 		t        = otel.Tracer(tracer.LevelRepository)
@@ -307,10 +350,10 @@ func (r *repositoryImpl) UpdateClaimedAmount(ctx context.Context, envelopeID int
 
 	span.SetAttributes(
 		attribute.Int64("envelopeID", envelopeID),
-		attribute.Float64("claimedAmount", amount),
+		attribute.String("claimedAmount", amount.String()),
 	)
 
-	err = activeEnvelopeQuery(r.db.WithContext(ctx), envelopeID).
+	err = activeEnvelopeQuery(r.conn(ctx), envelopeID).
 		Model(&e.Envelope{}).
 		UpdateColumn("total_amount_claimed", gorm.Expr("total_amount_claimed + ?", amount)).
 		Error
@@ -333,7 +376,7 @@ func (r *repositoryImpl) GetExpiredUnRefundedEnvelopes(ctx context.Context) (res
 		span.End()
 	}()
 	var envelopes []*e.Envelope
-	err = r.db.WithContext(ctx).
+	err = r.conn(ctx).
 		Where("is_active = ? AND total_amount_refunded = ? AND expired_at IS NOT NULL AND expired_at < ?", true, 0, time.Now()).
 		Find(&envelopes).Error
 	return envelopes, err
@@ -357,7 +400,7 @@ func (r *repositoryImpl) GetExpiredUnRefundedEnvelopesByID(
 	}()
 
 	var env e.Envelope
-	err = r.db.WithContext(ctx).
+	err = r.conn(ctx).
 		Where("is_active = ?", true).
 		Where("total_amount_refunded = ?", 0).
 		Where("expired_at IS NOT NULL").
@@ -374,7 +417,7 @@ func (r *repositoryImpl) GetExpiredUnRefundedEnvelopesByID(
 	return &env, err
 }
 
-func (r *repositoryImpl) RefundEnvelope(ctx context.Context, envelopeID int64, refundAmount float64) error {
+func (r *repositoryImpl) RefundEnvelope(ctx context.Context, envelopeID int64, refundAmount e.Money) error {
 	var (
 		funcName = tracer.GetFullFunctionPath()
 		t        = otel.Tracer(tracer.LevelRepository)
@@ -392,10 +435,10 @@ func (r *repositoryImpl) RefundEnvelope(ctx context.Context, envelopeID int64, r
 
 	span.SetAttributes(
 		attribute.Int64("envelopeID", envelopeID),
-		attribute.Float64("refundAmount", refundAmount),
+		attribute.String("refundAmount", refundAmount.String()),
 	)
 
-	err = activeEnvelopeQuery(r.db.WithContext(ctx), envelopeID).
+	err = activeEnvelopeQuery(r.conn(ctx), envelopeID).
 		Model(&e.Envelope{}).
 		Updates(map[string]interface{}{
 			"total_amount_refunded": refundAmount,
@@ -425,7 +468,7 @@ func (r *repositoryImpl) DeactivateUnclaimedDetails(ctx context.Context, envelop
 		attribute.Int64("envelopeID", envelopeID),
 	)
 
-	err = activeEnvelopeQuery(r.db.WithContext(ctx), envelopeID).
+	err = activeEnvelopeQuery(r.conn(ctx), envelopeID).
 		Model(&e.EnvelopeDetail{}).
 		Where("(user_id IS NULL OR user_id = '') AND envelope_detail_status != ?", e.EnvelopeClaimed).
 		Updates(map[string]interface{}{
@@ -436,7 +479,9 @@ func (r *repositoryImpl) DeactivateUnclaimedDetails(ctx context.Context, envelop
 	return err
 }
 
-func (r *repositoryImpl) WithTransaction(ctx context.Context, fn func(txRepo Repository) error) error {
+func (r *repositoryImpl) WithTransaction(
+	ctx context.Context, fn func(ctx context.Context, txRepo Repository) error,
+) error {
 	var (
 		funcName = tracer.GetFullFunctionPath()
 		t        = otel.Tracer(tracer.LevelRepository)
@@ -452,9 +497,8 @@ func (r *repositoryImpl) WithTransaction(ctx context.Context, fn func(txRepo Rep
 		span.End()
 	}()
 
-	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txRepo := r.WithTx(tx)
-		return fn(txRepo)
+	err = trx.Run(ctx, r.db, func(ctx context.Context, tx *gorm.DB) error {
+		return fn(ctx, r.WithTx(tx))
 	})
 
 	return err
@@ -486,11 +530,11 @@ func (r *repositoryImpl) Update(ctx context.Context, envelope *e.Envelope, tx *g
 	}()
 
 	if tx != nil {
-		err = tx.Save(envelope).WithContext(ctx).Error
+		err = tx.WithContext(ctx).Save(envelope).Error
 		return err
 	}
 
-	err = r.db.Save(envelope).WithContext(ctx).Error
+	err = r.conn(ctx).Save(envelope).Error
 	return err
 }
 
@@ -514,8 +558,7 @@ func (r *repositoryImpl) GetEnvelopeDetail(ctx context.Context, envelopeDetailID
 	)
 
 	var envelopeDetail e.EnvelopeDetail
-	err = r.db.Where("envelope_detail_id = ? AND is_active = ?", envelopeDetailID, true).
-		WithContext(ctx).
+	err = r.conn(ctx).Where("envelope_detail_id = ? AND is_active = ?", envelopeDetailID, true).
 		First(&envelopeDetail).
 		Error
 	if err != nil {
@@ -541,8 +584,7 @@ func (r *repositoryImpl) GetEnvelopeDetailsByEnvelopID(ctx context.Context, enve
 	}()
 
 	var envelopeDetails []*e.EnvelopeDetail
-	err = r.db.Where("envelope_id = ? AND is_active = ?", envelopID, true).
-		WithContext(ctx).
+	err = r.conn(ctx).Where("envelope_id = ? AND is_active = ?", envelopID, true).
 		Find(&envelopeDetails).
 		Error
 	if err != nil {
@@ -573,7 +615,7 @@ func (r *repositoryImpl) FetchExpiredEnvelopes(ctx context.Context, ids []int64)
 	}()
 
 	var envelopes []*e.Envelope
-	q := r.db.WithContext(ctx).
+	q := r.conn(ctx).
 		Where("expired_at < ?", time.Now()).
 		Where("refunded_at IS NULL").
 		Where("total_amount > total_amount_claimed").
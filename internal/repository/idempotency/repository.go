@@ -0,0 +1,18 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package idempotency
+
+import (
+	"context"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	// CreateIdempotencyKey inserts key unless the user already holds the same key,
+	// created reports whether the row was inserted.
+	CreateIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) (created bool, err error)
+	GetIdempotencyKey(ctx context.Context, userID, key string) (resp *entity.IdempotencyKey, err error)
+	CompleteIdempotencyKey(ctx context.Context, idempotencyKeyID int64, responseCode int, responseBody []byte) (err error)
+	DeleteIdempotencyKey(ctx context.Context, idempotencyKeyID int64) (err error)
+}
@@ -0,0 +1,161 @@
+package idempotency
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateIdempotencyKey(
+	ctx context.Context, key *entity.IdempotencyKey,
+) (created bool, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("userID", key.UserID),
+		attribute.String("idempotencyKey", key.IdempotencyKey),
+	)
+
+	// the unique index on (user_id, idempotency_key) decides which of two concurrent
+	// requests carrying the same key gets to run
+	result := r.conn(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	if result.Error != nil {
+		log.ZError(ctx, "while create idempotency key", result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repositoryImpl) GetIdempotencyKey(
+	ctx context.Context, userID, key string,
+) (resp *entity.IdempotencyKey, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("userID", userID),
+		attribute.String("idempotencyKey", key),
+	)
+
+	resp = &entity.IdempotencyKey{}
+	err = r.conn(ctx).
+		Where("user_id = ?", userID).
+		Where("idempotency_key = ?", key).
+		First(resp).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *repositoryImpl) CompleteIdempotencyKey(
+	ctx context.Context, idempotencyKeyID int64, responseCode int, responseBody []byte,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("idempotencyKeyID", idempotencyKeyID),
+		attribute.Int("responseCode", responseCode),
+	)
+
+	err = r.conn(ctx).Model(&entity.IdempotencyKey{}).
+		Where("idempotency_key_id = ?", idempotencyKeyID).
+		Updates(map[string]interface{}{
+			"status_idempotency": entity.StatusIdempotencyCompleted,
+			"response_code":      responseCode,
+			"response_body":      responseBody,
+		}).Error
+	if err != nil {
+		log.ZError(ctx, "while complete idempotency key", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) DeleteIdempotencyKey(ctx context.Context, idempotencyKeyID int64) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("idempotencyKeyID", idempotencyKeyID))
+
+	err = r.conn(ctx).
+		Where("idempotency_key_id = ?", idempotencyKeyID).
+		Delete(&entity.IdempotencyKey{}).Error
+	if err != nil {
+		log.ZError(ctx, "while delete idempotency key", err)
+		return err
+	}
+
+	return nil
+}
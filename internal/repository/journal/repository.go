@@ -0,0 +1,18 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package journal
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreateJournalEntry(ctx context.Context, tx *gorm.DB, entry *entity.JournalEntry) (journalEntryID int64, err error)
+	GetSystemAccountBalances(
+		ctx context.Context, currency entity.Currency,
+	) (resp []*entity.SystemAccountBalance, err error)
+}
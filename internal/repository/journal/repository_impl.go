@@ -0,0 +1,102 @@
+package journal
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateJournalEntry(
+	ctx context.Context, tx *gorm.DB, entry *entity.JournalEntry,
+) (journalEntryID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	switch tx {
+	case nil:
+		err = r.conn(ctx).Create(entry).Error
+	default:
+		err = tx.WithContext(ctx).Create(entry).Error
+	}
+
+	if err != nil {
+		log.ZError(ctx, "while create journal entry", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("journalEntryID", entry.JournalEntryID),
+		attribute.Int64("walletTransactionID", entry.WalletTransactionID),
+		attribute.String("systemAccount", entry.SystemAccount.String()),
+	)
+
+	return entry.JournalEntryID, nil
+}
+
+func (r *repositoryImpl) GetSystemAccountBalances(
+	ctx context.Context, currency entity.Currency,
+) (resp []*entity.SystemAccountBalance, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).
+		Model(&entity.JournalEntry{}).
+		Select("system_account, COALESCE(SUM(amount), 0) AS balance").
+		Where("currency = ?", currency).
+		Group("system_account").
+		Scan(&resp).Error
+	if err != nil {
+		log.ZError(ctx, "while get system account balances", err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("currency", currency.String()),
+		attribute.Int("total", len(resp)),
+	)
+
+	return resp, nil
+}
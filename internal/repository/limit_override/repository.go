@@ -0,0 +1,21 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package limit_override
+
+import (
+	"context"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	GetLimitOverride(
+		ctx context.Context, scope entity.LimitScope, scopeID string,
+	) (override *entity.LimitOverride, err error)
+	// UpsertLimitOverride creates the override of the scope or replaces every limit of the existing one.
+	UpsertLimitOverride(ctx context.Context, override *entity.LimitOverride) (err error)
+	GetListLimitOverride(
+		ctx context.Context, arg *domain.GetListLimitOverrideRequest,
+	) (overrides []*entity.LimitOverride, total int64, err error)
+}
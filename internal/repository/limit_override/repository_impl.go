@@ -0,0 +1,168 @@
+package limit_override
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) GetLimitOverride(
+	ctx context.Context, scope entity.LimitScope, scopeID string,
+) (override *entity.LimitOverride, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("scope", scope.String()),
+		attribute.String("scopeID", scopeID),
+	)
+
+	override = &entity.LimitOverride{}
+	err = r.conn(ctx).
+		Where("scope = ? AND scope_id = ?", scope, scopeID).
+		First(override).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrLimitOverrideNotFound
+		}
+
+		log.ZError(ctx, "while get limit override", err)
+		return nil, err
+	}
+
+	return override, nil
+}
+
+func (r *repositoryImpl) UpsertLimitOverride(
+	ctx context.Context, override *entity.LimitOverride,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("scope", override.Scope.String()),
+		attribute.String("scopeID", override.ScopeID),
+	)
+
+	// nil limits are written as NULL too, the request replaces the whole override
+	err = r.conn(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "scope"}, {Name: "scope_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"tier",
+				"transfer_send_per_day",
+				"transfer_claim_per_day",
+				"max_send_transfer_amount",
+				"envelope_send_per_day",
+				"envelope_claim_per_day",
+				"max_send_envelope_amount",
+				"spend_per_hour",
+				"spend_per_day",
+				"spend_per_30_days",
+				"updated_at",
+				"updated_by",
+			}),
+		}).
+		Create(override).Error
+	if err != nil {
+		log.ZError(ctx, "while upsert limit override", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) GetListLimitOverride(
+	ctx context.Context, arg *domain.GetListLimitOverrideRequest,
+) (overrides []*entity.LimitOverride, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).Model(&entity.LimitOverride{})
+
+	if arg.Scope != "" {
+		query = query.Where("scope = ?", arg.Scope)
+		span.SetAttributes(attribute.String("scope", arg.Scope))
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	span.SetAttributes(
+		attribute.Int("page", int(arg.Page)),
+		attribute.Int("limit", int(arg.Limit)),
+	)
+
+	offset := (arg.Page - 1) * arg.Limit
+	err = query.
+		Order("scope ASC, scope_id ASC").
+		Limit(int(arg.Limit)).
+		Offset(int(offset)).
+		Find(&overrides).Error
+	if err != nil {
+		log.ZError(ctx, "while repositoryImpl GetListLimitOverride", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("total", total))
+	return overrides, total, nil
+}
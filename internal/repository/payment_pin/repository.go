@@ -0,0 +1,24 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package payment_pin
+
+import (
+	"context"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	// GetPaymentPin returns ErrPaymentPinNotSet for a user without a PIN.
+	GetPaymentPin(ctx context.Context, userID string) (pin *entity.PaymentPin, err error)
+	CreatePaymentPin(ctx context.Context, pin *entity.PaymentPin) (err error)
+	UpdatePaymentPinHash(ctx context.Context, userID, pinHash, operatedBy string) (err error)
+	// DeletePaymentPin removes the PIN for good so the user can set a new one, the audit keeps
+	// the history.
+	DeletePaymentPin(ctx context.Context, userID string) (deleted bool, err error)
+	CreateAudit(ctx context.Context, audit *entity.PaymentPinAudit) (err error)
+	GetListAudit(
+		ctx context.Context, arg *domain.GetListPaymentPinAuditRequest,
+	) (audits []*entity.PaymentPinAudit, total int64, err error)
+}
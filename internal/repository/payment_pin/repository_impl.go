@@ -0,0 +1,219 @@
+package payment_pin
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) GetPaymentPin(ctx context.Context, userID string) (pin *entity.PaymentPin, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.String("userID", userID))
+
+	pin = &entity.PaymentPin{}
+	err = r.conn(ctx).Where("user_id = ?", userID).First(pin).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrPaymentPinNotSet
+		}
+
+		log.ZError(ctx, "while get payment pin", err)
+		return nil, err
+	}
+
+	return pin, nil
+}
+
+func (r *repositoryImpl) CreatePaymentPin(ctx context.Context, pin *entity.PaymentPin) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.String("userID", pin.UserID))
+
+	err = r.conn(ctx).Create(pin).Error
+	if err != nil {
+		log.ZError(ctx, "while create payment pin", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) UpdatePaymentPinHash(ctx context.Context, userID, pinHash, operatedBy string) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.String("userID", userID))
+
+	err = r.conn(ctx).Model(&entity.PaymentPin{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"pin_hash":   pinHash,
+			"updated_by": operatedBy,
+		}).Error
+	if err != nil {
+		log.ZError(ctx, "while update payment pin", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) DeletePaymentPin(ctx context.Context, userID string) (deleted bool, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.String("userID", userID))
+
+	result := r.conn(ctx).Where("user_id = ?", userID).Delete(&entity.PaymentPin{})
+	if result.Error != nil {
+		log.ZError(ctx, "while delete payment pin", result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repositoryImpl) CreateAudit(ctx context.Context, audit *entity.PaymentPinAudit) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("userID", audit.UserID),
+		attribute.String("event", audit.Event.String()),
+	)
+
+	err = r.conn(ctx).Create(audit).Error
+	if err != nil {
+		log.ZError(ctx, "while create payment pin audit", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) GetListAudit(
+	ctx context.Context, arg *domain.GetListPaymentPinAuditRequest,
+) (audits []*entity.PaymentPinAudit, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).
+		Model(&entity.PaymentPinAudit{}).
+		Where("user_id = ?", arg.UserID)
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (arg.Page - 1) * arg.Limit
+	err = query.
+		Order("payment_pin_audit_id DESC").
+		Limit(int(arg.Limit)).
+		Offset(int(offset)).
+		Find(&audits).Error
+	if err != nil {
+		log.ZError(ctx, "while get list payment pin audit", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(
+		attribute.String("userID", arg.UserID),
+		attribute.Int64("total", total),
+	)
+
+	return audits, total, nil
+}
@@ -0,0 +1,23 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package payment_request
+
+import (
+	"context"
+	"time"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreatePaymentRequest(ctx context.Context, request *entity.PaymentRequest) (paymentRequestID int64, err error)
+	// GetPaymentRequestForUpdate locks the payment request row until the transaction carried by ctx ends.
+	GetPaymentRequestForUpdate(ctx context.Context, paymentRequestID int64) (request *entity.PaymentRequest, err error)
+	UpdatePaymentRequest(ctx context.Context, request *entity.PaymentRequest) (err error)
+	GetListPaymentRequest(
+		ctx context.Context, arg *domain.GetListPaymentRequestRequest,
+	) (requests []*entity.PaymentRequest, total int64, err error)
+	// ExpirePaymentRequests marks the pending requests expired at or before now as expired.
+	ExpirePaymentRequests(ctx context.Context, now time.Time, operatedBy string) (expired int64, err error)
+}
@@ -0,0 +1,224 @@
+package payment_request
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreatePaymentRequest(
+	ctx context.Context, request *entity.PaymentRequest,
+) (paymentRequestID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(request).Error
+	if err != nil {
+		log.ZError(ctx, "while create payment request", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.String("requesterUserID", request.RequesterUserID),
+		attribute.String("payerUserID", request.PayerUserID),
+		attribute.Int64("paymentRequestID", request.PaymentRequestID),
+	)
+
+	return request.PaymentRequestID, nil
+}
+
+func (r *repositoryImpl) GetPaymentRequestForUpdate(
+	ctx context.Context, paymentRequestID int64,
+) (request *entity.PaymentRequest, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("paymentRequestID", paymentRequestID))
+
+	request = &entity.PaymentRequest{}
+	err = r.conn(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("payment_request_id = ? AND is_active IS TRUE", paymentRequestID).
+		First(request).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrPaymentRequestNotFound
+		}
+
+		log.ZError(ctx, "while get payment request for update", err)
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func (r *repositoryImpl) UpdatePaymentRequest(ctx context.Context, request *entity.PaymentRequest) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("paymentRequestID", request.PaymentRequestID),
+		attribute.String("statusPaymentRequest", request.StatusPaymentRequest.String()),
+	)
+
+	err = r.conn(ctx).Model(&entity.PaymentRequest{}).
+		Where("payment_request_id = ?", request.PaymentRequestID).
+		Updates(request).Error
+	if err != nil {
+		log.ZError(ctx, "while update payment request", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) GetListPaymentRequest(
+	ctx context.Context, arg *domain.GetListPaymentRequestRequest,
+) (requests []*entity.PaymentRequest, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).
+		Model(&entity.PaymentRequest{}).
+		Where("is_active IS TRUE")
+	switch domain.PaymentRequestRole(arg.Role) {
+	case domain.PaymentRequestRoleRequester:
+		query = query.Where("requester_user_id = ?", arg.UserID)
+	case domain.PaymentRequestRolePayer:
+		query = query.Where("payer_user_id = ?", arg.UserID)
+	default:
+		query = query.Where("(requester_user_id = ? OR payer_user_id = ?)", arg.UserID, arg.UserID)
+	}
+	if arg.StatusPaymentRequest != "" {
+		query = query.Where("status_payment_request = ?", arg.StatusPaymentRequest)
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (arg.Page - 1) * arg.Limit
+	err = query.
+		Order("payment_request_id DESC").
+		Limit(int(arg.Limit)).
+		Offset(int(offset)).
+		Find(&requests).Error
+	if err != nil {
+		log.ZError(ctx, "while get list payment request", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(
+		attribute.String("userID", arg.UserID),
+		attribute.Int64("total", total),
+	)
+
+	return requests, total, nil
+}
+
+func (r *repositoryImpl) ExpirePaymentRequests(
+	ctx context.Context, now time.Time, operatedBy string,
+) (expired int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	result := r.conn(ctx).Model(&entity.PaymentRequest{}).
+		Where("expired_at <= ?", now).
+		Where("status_payment_request = ?", entity.StatusPaymentRequestPending).
+		Where("is_active IS TRUE").
+		Updates(map[string]interface{}{
+			"status_payment_request": entity.StatusPaymentRequestExpired,
+			"updated_by":             operatedBy,
+		})
+	if result.Error != nil {
+		err = result.Error
+		log.ZError(ctx, "while expire payment requests", err)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("expired", result.RowsAffected))
+
+	return result.RowsAffected, nil
+}
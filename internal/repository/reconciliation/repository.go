@@ -0,0 +1,31 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package reconciliation
+
+import (
+	"context"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreateRun(ctx context.Context, run *entity.ReconciliationRun) (runID int64, err error)
+	UpdateRun(ctx context.Context, run *entity.ReconciliationRun) (err error)
+	// GetRun returns the run with runID, or the latest run when runID is 0.
+	GetRun(ctx context.Context, runID int64) (resp *entity.ReconciliationRun, err error)
+	CreateDiscrepancies(ctx context.Context, discrepancies []*entity.ReconciliationDiscrepancy) (err error)
+	GetListDiscrepancy(
+		ctx context.Context, req *domain.GetReconciliationRequest,
+	) (discrepancies []*entity.ReconciliationDiscrepancy, total int64, err error)
+
+	// GetWalletBalanceSums returns up to limit wallets with an ID above afterWalletID, in ID order.
+	GetWalletBalanceSums(
+		ctx context.Context, afterWalletID int64, limit int,
+	) (resp []*entity.WalletBalanceSum, err error)
+	GetBalanceChainBreaks(ctx context.Context, walletIDs []int64) (resp []*entity.BalanceChainBreak, err error)
+	// GetEnvelopeClaimSums returns up to limit envelopes with an ID above afterEnvelopeID, in ID order.
+	GetEnvelopeClaimSums(
+		ctx context.Context, afterEnvelopeID int64, limit int,
+	) (resp []*entity.EnvelopeClaimSum, err error)
+}
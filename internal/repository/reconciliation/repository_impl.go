@@ -0,0 +1,338 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+const (
+	queryWalletBalanceSums = `
+		SELECT w.wallet_id, w.balance, COALESCE(SUM(wt.amount), 0) AS transaction_total
+		FROM (
+			SELECT wallet_id, balance FROM wallets
+			WHERE wallet_id > ? AND deleted_at IS NULL
+			ORDER BY wallet_id
+			LIMIT ?
+		) w
+		LEFT JOIN wallet_transactions wt ON wt.wallet_id = w.wallet_id AND wt.deleted_at IS NULL
+		GROUP BY w.wallet_id, w.balance
+		ORDER BY w.wallet_id`
+
+	queryBalanceChainBreaks = `
+		SELECT wallet_transaction_id, wallet_id, before_balance, previous_after_balance
+		FROM (
+			SELECT wallet_transaction_id, wallet_id, before_balance,
+				LAG(after_balance) OVER (PARTITION BY wallet_id ORDER BY wallet_transaction_id) AS previous_after_balance
+			FROM wallet_transactions
+			WHERE wallet_id IN ? AND deleted_at IS NULL
+		) chain
+		WHERE previous_after_balance IS NOT NULL AND previous_after_balance <> before_balance
+		ORDER BY wallet_id, wallet_transaction_id`
+
+	queryEnvelopeClaimSums = `
+		SELECT e.envelope_id, e.total_amount_claimed,
+			COALESCE(SUM(CASE WHEN ed.envelope_detail_status = ? THEN ed.amount END), 0) AS claimed_total
+		FROM (
+			SELECT envelope_id, total_amount_claimed FROM envelopes
+			WHERE envelope_id > ? AND deleted_at IS NULL
+			ORDER BY envelope_id
+			LIMIT ?
+		) e
+		LEFT JOIN envelope_details ed ON ed.envelope_id = e.envelope_id AND ed.deleted_at IS NULL
+		GROUP BY e.envelope_id, e.total_amount_claimed
+		ORDER BY e.envelope_id`
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateRun(ctx context.Context, run *entity.ReconciliationRun) (runID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(run).Error
+	if err != nil {
+		log.ZError(ctx, "while create reconciliation run", err)
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("reconciliationRunID", run.ReconciliationRunID))
+
+	return run.ReconciliationRunID, nil
+}
+
+func (r *repositoryImpl) UpdateRun(ctx context.Context, run *entity.ReconciliationRun) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("reconciliationRunID", run.ReconciliationRunID))
+
+	err = r.conn(ctx).Model(&entity.ReconciliationRun{}).
+		Where("reconciliation_run_id = ?", run.ReconciliationRunID).
+		Updates(run).Error
+	if err != nil {
+		log.ZError(ctx, "while update reconciliation run", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) GetRun(ctx context.Context, runID int64) (resp *entity.ReconciliationRun, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("reconciliationRunID", runID))
+
+	query := r.conn(ctx).Model(&entity.ReconciliationRun{})
+	if runID > 0 {
+		query = query.Where("reconciliation_run_id = ?", runID)
+	}
+
+	resp = &entity.ReconciliationRun{}
+	err = query.Order("reconciliation_run_id DESC").First(resp).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrReconciliationRunNotFound
+		}
+
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *repositoryImpl) CreateDiscrepancies(
+	ctx context.Context, discrepancies []*entity.ReconciliationDiscrepancy,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int("total", len(discrepancies)))
+
+	if len(discrepancies) == 0 {
+		return nil
+	}
+
+	err = r.conn(ctx).CreateInBatches(discrepancies, entity.ReconciliationBatchSize).Error
+	if err != nil {
+		log.ZError(ctx, "while create reconciliation discrepancies", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) GetListDiscrepancy(
+	ctx context.Context, req *domain.GetReconciliationRequest,
+) (discrepancies []*entity.ReconciliationDiscrepancy, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).
+		Model(&entity.ReconciliationDiscrepancy{}).
+		Where("reconciliation_run_id = ?", req.ReconciliationRunID)
+
+	if req.DiscrepancyType != "" {
+		query = query.Where("discrepancy_type = ?", req.DiscrepancyType)
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	err = query.
+		Order("reconciliation_discrepancy_id ASC").
+		Limit(int(req.Limit)).
+		Offset(int(offset)).
+		Find(&discrepancies).Error
+	if err != nil {
+		log.ZError(ctx, "while get list reconciliation discrepancy", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("reconciliationRunID", req.ReconciliationRunID),
+		attribute.Int64("total", total),
+	)
+
+	return discrepancies, total, nil
+}
+
+func (r *repositoryImpl) GetWalletBalanceSums(
+	ctx context.Context, afterWalletID int64, limit int,
+) (resp []*entity.WalletBalanceSum, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Raw(queryWalletBalanceSums, afterWalletID, limit).Scan(&resp).Error
+	if err != nil {
+		log.ZError(ctx, "while get wallet balance sums", err, "afterWalletID", afterWalletID)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("afterWalletID", afterWalletID),
+		attribute.Int("total", len(resp)),
+	)
+
+	return resp, nil
+}
+
+func (r *repositoryImpl) GetBalanceChainBreaks(
+	ctx context.Context, walletIDs []int64,
+) (resp []*entity.BalanceChainBreak, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if len(walletIDs) == 0 {
+		return nil, nil
+	}
+
+	err = r.conn(ctx).Raw(queryBalanceChainBreaks, walletIDs).Scan(&resp).Error
+	if err != nil {
+		log.ZError(ctx, "while get balance chain breaks", err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("wallets", len(walletIDs)),
+		attribute.Int("total", len(resp)),
+	)
+
+	return resp, nil
+}
+
+func (r *repositoryImpl) GetEnvelopeClaimSums(
+	ctx context.Context, afterEnvelopeID int64, limit int,
+) (resp []*entity.EnvelopeClaimSum, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).
+		Raw(queryEnvelopeClaimSums, entity.EnvelopeClaimed, afterEnvelopeID, limit).
+		Scan(&resp).Error
+	if err != nil {
+		log.ZError(ctx, "while get envelope claim sums", err, "afterEnvelopeID", afterEnvelopeID)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("afterEnvelopeID", afterEnvelopeID),
+		attribute.Int("total", len(resp)),
+	)
+
+	return resp, nil
+}
@@ -0,0 +1,32 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package recurring_transfer
+
+import (
+	"context"
+	"time"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreateRecurringTransfer(
+		ctx context.Context, recurring *entity.RecurringTransfer,
+	) (recurringTransferID int64, err error)
+	GetRecurringTransfer(ctx context.Context, recurringTransferID int64) (recurring *entity.RecurringTransfer, err error)
+	// GetRecurringTransferForUpdate locks the standing order row until the transaction carried by ctx ends.
+	GetRecurringTransferForUpdate(
+		ctx context.Context, recurringTransferID int64,
+	) (recurring *entity.RecurringTransfer, err error)
+	UpdateRecurringTransfer(ctx context.Context, recurring *entity.RecurringTransfer) (err error)
+	// FetchDueRecurringTransfers returns the active standing orders with a run due at or before now.
+	FetchDueRecurringTransfers(ctx context.Context, now time.Time) (recurring []*entity.RecurringTransfer, err error)
+	GetListRecurringTransfer(
+		ctx context.Context, arg *domain.GetListRecurringTransferRequest,
+	) (recurring []*entity.RecurringTransfer, total int64, err error)
+	CreateRun(ctx context.Context, run *entity.RecurringTransferRun) (err error)
+	GetListRun(
+		ctx context.Context, arg *domain.GetListRecurringTransferRunRequest,
+	) (runs []*entity.RecurringTransferRun, total int64, err error)
+}
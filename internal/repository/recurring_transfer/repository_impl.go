@@ -0,0 +1,301 @@
+package recurring_transfer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateRecurringTransfer(
+	ctx context.Context, recurring *entity.RecurringTransfer,
+) (recurringTransferID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(recurring).Error
+	if err != nil {
+		log.ZError(ctx, "while create recurring transfer", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.String("fromUserID", recurring.FromUserID),
+		attribute.String("frequency", recurring.Frequency.String()),
+		attribute.Int64("recurringTransferID", recurring.RecurringTransferID),
+	)
+
+	return recurring.RecurringTransferID, nil
+}
+
+func (r *repositoryImpl) GetRecurringTransfer(
+	ctx context.Context, recurringTransferID int64,
+) (recurring *entity.RecurringTransfer, err error) {
+	return r.getRecurringTransfer(ctx, r.conn(ctx), recurringTransferID)
+}
+
+func (r *repositoryImpl) GetRecurringTransferForUpdate(
+	ctx context.Context, recurringTransferID int64,
+) (recurring *entity.RecurringTransfer, err error) {
+	return r.getRecurringTransfer(ctx, r.conn(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), recurringTransferID)
+}
+
+func (r *repositoryImpl) getRecurringTransfer(
+	ctx context.Context, db *gorm.DB, recurringTransferID int64,
+) (recurring *entity.RecurringTransfer, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("recurringTransferID", recurringTransferID))
+
+	recurring = &entity.RecurringTransfer{}
+	err = db.WithContext(ctx).
+		Where("recurring_transfer_id = ? AND is_active IS TRUE", recurringTransferID).
+		First(recurring).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrRecurringTransferNotFound
+		}
+
+		log.ZError(ctx, "while get recurring transfer", err)
+		return nil, err
+	}
+
+	return recurring, nil
+}
+
+func (r *repositoryImpl) UpdateRecurringTransfer(
+	ctx context.Context, recurring *entity.RecurringTransfer,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("recurringTransferID", recurring.RecurringTransferID),
+		attribute.String("statusRecurringTransfer", recurring.StatusRecurringTransfer.String()),
+	)
+
+	err = r.conn(ctx).Model(&entity.RecurringTransfer{}).
+		Where("recurring_transfer_id = ?", recurring.RecurringTransferID).
+		Updates(recurring).Error
+	if err != nil {
+		log.ZError(ctx, "while update recurring transfer", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) FetchDueRecurringTransfers(
+	ctx context.Context, now time.Time,
+) (recurring []*entity.RecurringTransfer, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).
+		Where("next_run_at <= ?", now).
+		Where("status_recurring_transfer = ?", entity.StatusRecurringTransferActive).
+		Where("is_active IS TRUE").
+		Order("next_run_at ASC").
+		Find(&recurring).Error
+	if err != nil {
+		log.ZError(ctx, "while fetch due recurring transfers", err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("total", len(recurring)))
+
+	return recurring, nil
+}
+
+func (r *repositoryImpl) GetListRecurringTransfer(
+	ctx context.Context, arg *domain.GetListRecurringTransferRequest,
+) (recurring []*entity.RecurringTransfer, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).
+		Model(&entity.RecurringTransfer{}).
+		Where("from_user_id = ? AND is_active IS TRUE", arg.UserID)
+	if arg.StatusRecurringTransfer != "" {
+		query = query.Where("status_recurring_transfer = ?", arg.StatusRecurringTransfer)
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (arg.Page - 1) * arg.Limit
+	err = query.
+		Order("recurring_transfer_id DESC").
+		Limit(int(arg.Limit)).
+		Offset(int(offset)).
+		Find(&recurring).Error
+	if err != nil {
+		log.ZError(ctx, "while get list recurring transfer", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(
+		attribute.String("userID", arg.UserID),
+		attribute.Int64("total", total),
+	)
+
+	return recurring, total, nil
+}
+
+func (r *repositoryImpl) CreateRun(ctx context.Context, run *entity.RecurringTransferRun) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("recurringTransferID", run.RecurringTransferID),
+		attribute.Int("occurrence", run.Occurrence),
+		attribute.String("statusRun", run.StatusRun.String()),
+	)
+
+	err = r.conn(ctx).Create(run).Error
+	if err != nil {
+		log.ZError(ctx, "while create recurring transfer run", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) GetListRun(
+	ctx context.Context, arg *domain.GetListRecurringTransferRunRequest,
+) (runs []*entity.RecurringTransferRun, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).
+		Model(&entity.RecurringTransferRun{}).
+		Where("recurring_transfer_id = ?", arg.RecurringTransferID)
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (arg.Page - 1) * arg.Limit
+	err = query.
+		Order("occurrence DESC").
+		Limit(int(arg.Limit)).
+		Offset(int(offset)).
+		Find(&runs).Error
+	if err != nil {
+		log.ZError(ctx, "while get list recurring transfer run", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("recurringTransferID", arg.RecurringTransferID),
+		attribute.Int64("total", total),
+	)
+
+	return runs, total, nil
+}
@@ -3,14 +3,29 @@ package repository
 import (
 	"gorm.io/gorm"
 
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/approval_request"
 	ba "github.com/1nterdigital/aka-im-wallet/internal/repository/balance_adjustment"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/envelope"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/idempotency"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/journal"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/limit_override"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/payment_pin"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/payment_request"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/reconciliation"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/recurring_transfer"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/risk_decision"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/scheduled_transfer"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/transaction_reversal"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/transfer"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/transfer_batch"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_freeze"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_hold"
 	monitoring "github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_monitoring"
 	deposit "github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_recharge_request"
 	transaction "github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_transaction"
+	withdrawal "github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_withdrawal_request"
 )
 
 type Repository interface {
@@ -22,6 +37,21 @@ type Repository interface {
 	WalletMonitoring() monitoring.WalletMonitoringRepository
 	TxRepo() tx.Repository
 	BalanceAdjustment() ba.Repository
+	Idempotency() idempotency.Repository
+	Journal() journal.Repository
+	Reconciliation() reconciliation.Repository
+	WalletFreeze() wallet_freeze.Repository
+	WalletHold() wallet_hold.Repository
+	TransactionReversal() transaction_reversal.Repository
+	WalletWithdrawalRequest() withdrawal.Repository
+	ApprovalRequest() approval_request.Repository
+	LimitOverride() limit_override.Repository
+	RiskDecision() risk_decision.Repository
+	ScheduledTransfer() scheduled_transfer.Repository
+	RecurringTransfer() recurring_transfer.Repository
+	PaymentRequest() payment_request.Repository
+	TransferBatch() transfer_batch.Repository
+	PaymentPin() payment_pin.Repository
 }
 
 type repository struct {
@@ -65,3 +95,63 @@ func (r *repository) TxRepo() tx.Repository {
 func (r *repository) BalanceAdjustment() ba.Repository {
 	return ba.New(r.db)
 }
+
+func (r *repository) Idempotency() idempotency.Repository {
+	return idempotency.New(r.db)
+}
+
+func (r *repository) Journal() journal.Repository {
+	return journal.New(r.db)
+}
+
+func (r *repository) Reconciliation() reconciliation.Repository {
+	return reconciliation.New(r.db)
+}
+
+func (r *repository) WalletFreeze() wallet_freeze.Repository {
+	return wallet_freeze.New(r.db)
+}
+
+func (r *repository) WalletHold() wallet_hold.Repository {
+	return wallet_hold.New(r.db)
+}
+
+func (r *repository) TransactionReversal() transaction_reversal.Repository {
+	return transaction_reversal.New(r.db)
+}
+
+func (r *repository) WalletWithdrawalRequest() withdrawal.Repository {
+	return withdrawal.New(r.db)
+}
+
+func (r *repository) ApprovalRequest() approval_request.Repository {
+	return approval_request.New(r.db)
+}
+
+func (r *repository) LimitOverride() limit_override.Repository {
+	return limit_override.New(r.db)
+}
+
+func (r *repository) RiskDecision() risk_decision.Repository {
+	return risk_decision.New(r.db)
+}
+
+func (r *repository) ScheduledTransfer() scheduled_transfer.Repository {
+	return scheduled_transfer.New(r.db)
+}
+
+func (r *repository) RecurringTransfer() recurring_transfer.Repository {
+	return recurring_transfer.New(r.db)
+}
+
+func (r *repository) PaymentRequest() payment_request.Repository {
+	return payment_request.New(r.db)
+}
+
+func (r *repository) TransferBatch() transfer_batch.Repository {
+	return transfer_batch.New(r.db)
+}
+
+func (r *repository) PaymentPin() payment_pin.Repository {
+	return payment_pin.New(r.db)
+}
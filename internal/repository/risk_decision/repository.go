@@ -0,0 +1,34 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package risk_decision
+
+import (
+	"context"
+	"time"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+// Repository keeps the decisions of the risk rules. The counts feeding the rules leave out
+// denied movements, those never went through.
+type Repository interface {
+	CreateRiskDecision(ctx context.Context, decision *entity.RiskDecision) (riskDecisionID int64, err error)
+	// CountUserDecisionsSince counts the movements of the user of any operation.
+	CountUserDecisionsSince(ctx context.Context, userID string, since time.Time) (count int64, err error)
+	// CountPairDecisionsSince counts the movements of the operation from userID to counterpartyID.
+	CountPairDecisionsSince(
+		ctx context.Context, operation entity.RiskOperation, userID, counterpartyID string, since time.Time,
+	) (count int64, err error)
+	// CountDeviceUsersSince counts the users, other than userID, who made one of the operations
+	// from the device.
+	CountDeviceUsersSince(
+		ctx context.Context, operations []entity.RiskOperation, deviceID, userID string, since time.Time,
+	) (count int64, err error)
+	GetListRiskDecision(
+		ctx context.Context, arg *domain.GetListRiskDecisionRequest,
+	) (decisions []*entity.RiskDecision, total int64, err error)
+	GetRiskDecisionsByReference(
+		ctx context.Context, operations []entity.RiskOperation, referenceIDs []int64,
+	) (decisions []*entity.RiskDecision, err error)
+}
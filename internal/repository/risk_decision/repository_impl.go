@@ -0,0 +1,260 @@
+package risk_decision
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+// counted scopes a query to the movements that went through since the given time.
+func (r *repositoryImpl) counted(ctx context.Context, since time.Time) *gorm.DB {
+	return r.conn(ctx).
+		Model(&entity.RiskDecision{}).
+		Where("decision <> ? AND created_at >= ?", entity.RiskDecisionDeny, since)
+}
+
+func (r *repositoryImpl) CreateRiskDecision(
+	ctx context.Context, decision *entity.RiskDecision,
+) (riskDecisionID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(decision).Error
+	if err != nil {
+		log.ZError(ctx, "while create risk decision", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("riskDecisionID", decision.RiskDecisionID),
+		attribute.String("decision", decision.Decision.String()),
+	)
+
+	return decision.RiskDecisionID, nil
+}
+
+func (r *repositoryImpl) CountUserDecisionsSince(
+	ctx context.Context, userID string, since time.Time,
+) (count int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.String("userID", userID))
+
+	err = r.counted(ctx, since).
+		Where("user_id = ?", userID).
+		Count(&count).Error
+	if err != nil {
+		log.ZError(ctx, "while count user risk decisions", err, "userID", userID)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *repositoryImpl) CountPairDecisionsSince(
+	ctx context.Context, operation entity.RiskOperation, userID, counterpartyID string, since time.Time,
+) (count int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("operation", operation.String()),
+		attribute.String("userID", userID),
+		attribute.String("counterpartyID", counterpartyID),
+	)
+
+	err = r.counted(ctx, since).
+		Where("operation = ? AND user_id = ? AND counterparty_id = ?", operation, userID, counterpartyID).
+		Count(&count).Error
+	if err != nil {
+		log.ZError(ctx, "while count pair risk decisions", err, "userID", userID, "counterpartyID", counterpartyID)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *repositoryImpl) CountDeviceUsersSince(
+	ctx context.Context, operations []entity.RiskOperation, deviceID, userID string, since time.Time,
+) (count int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("deviceID", deviceID),
+		attribute.String("userID", userID),
+	)
+
+	err = r.counted(ctx, since).
+		Where("operation IN ? AND device_id = ? AND user_id <> ?", operations, deviceID, userID).
+		Distinct("user_id").
+		Count(&count).Error
+	if err != nil {
+		log.ZError(ctx, "while count device users", err, "deviceID", deviceID)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *repositoryImpl) GetListRiskDecision(
+	ctx context.Context, arg *domain.GetListRiskDecisionRequest,
+) (decisions []*entity.RiskDecision, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).Model(&entity.RiskDecision{})
+
+	if arg.Decision != "" {
+		query = query.Where("decision = ?", arg.Decision)
+		span.SetAttributes(attribute.String("decision", arg.Decision))
+	}
+	if arg.Operation != "" {
+		query = query.Where("operation = ?", arg.Operation)
+		span.SetAttributes(attribute.String("operation", arg.Operation))
+	}
+	if arg.UserID != "" {
+		query = query.Where("user_id = ?", arg.UserID)
+		span.SetAttributes(attribute.String("userID", arg.UserID))
+	}
+	if arg.ReferenceID > 0 {
+		query = query.Where("reference_id = ?", arg.ReferenceID)
+		span.SetAttributes(attribute.Int64("referenceID", arg.ReferenceID))
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	span.SetAttributes(
+		attribute.Int("page", int(arg.Page)),
+		attribute.Int("limit", int(arg.Limit)),
+	)
+
+	offset := (arg.Page - 1) * arg.Limit
+	err = query.
+		Order("created_at DESC, risk_decision_id DESC").
+		Limit(int(arg.Limit)).
+		Offset(int(offset)).
+		Find(&decisions).Error
+	if err != nil {
+		log.ZError(ctx, "while repositoryImpl GetListRiskDecision", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("total", total))
+	return decisions, total, nil
+}
+
+func (r *repositoryImpl) GetRiskDecisionsByReference(
+	ctx context.Context, operations []entity.RiskOperation, referenceIDs []int64,
+) (decisions []*entity.RiskDecision, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int("references", len(referenceIDs)))
+
+	if len(referenceIDs) == 0 {
+		return nil, nil
+	}
+
+	err = r.conn(ctx).
+		Where("operation IN ? AND reference_id IN ?", operations, referenceIDs).
+		Find(&decisions).Error
+	if err != nil {
+		log.ZError(ctx, "while get risk decisions by reference", err)
+		return nil, err
+	}
+
+	return decisions, nil
+}
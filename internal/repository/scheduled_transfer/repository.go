@@ -0,0 +1,27 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package scheduled_transfer
+
+import (
+	"context"
+	"time"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreateScheduledTransfer(
+		ctx context.Context, scheduled *entity.ScheduledTransfer,
+	) (scheduledTransferID int64, err error)
+	// GetScheduledTransferForUpdate locks the scheduled transfer row until the transaction carried by ctx ends.
+	GetScheduledTransferForUpdate(
+		ctx context.Context, scheduledTransferID int64,
+	) (scheduled *entity.ScheduledTransfer, err error)
+	UpdateScheduledTransfer(ctx context.Context, scheduled *entity.ScheduledTransfer) (err error)
+	// FetchDueScheduledTransfers returns the transfers still scheduled to run at or before now.
+	FetchDueScheduledTransfers(ctx context.Context, now time.Time) (scheduled []*entity.ScheduledTransfer, err error)
+	GetListScheduledTransfer(
+		ctx context.Context, arg *domain.GetListScheduledTransferRequest,
+	) (scheduled []*entity.ScheduledTransfer, total int64, err error)
+}
@@ -0,0 +1,215 @@
+package scheduled_transfer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateScheduledTransfer(
+	ctx context.Context, scheduled *entity.ScheduledTransfer,
+) (scheduledTransferID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(scheduled).Error
+	if err != nil {
+		log.ZError(ctx, "while create scheduled transfer", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.String("fromUserID", scheduled.FromUserID),
+		attribute.String("amount", scheduled.Amount.String()),
+		attribute.Int64("scheduledTransferID", scheduled.ScheduledTransferID),
+	)
+
+	return scheduled.ScheduledTransferID, nil
+}
+
+func (r *repositoryImpl) GetScheduledTransferForUpdate(
+	ctx context.Context, scheduledTransferID int64,
+) (scheduled *entity.ScheduledTransfer, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("scheduledTransferID", scheduledTransferID))
+
+	scheduled = &entity.ScheduledTransfer{}
+	err = r.conn(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("scheduled_transfer_id = ? AND is_active IS TRUE", scheduledTransferID).
+		First(scheduled).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrScheduledTransferNotFound
+		}
+
+		log.ZError(ctx, "while lock scheduled transfer", err)
+		return nil, err
+	}
+
+	return scheduled, nil
+}
+
+func (r *repositoryImpl) UpdateScheduledTransfer(
+	ctx context.Context, scheduled *entity.ScheduledTransfer,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("scheduledTransferID", scheduled.ScheduledTransferID),
+		attribute.String("statusScheduledTransfer", scheduled.StatusScheduledTransfer.String()),
+	)
+
+	err = r.conn(ctx).Model(&entity.ScheduledTransfer{}).
+		Where("scheduled_transfer_id = ?", scheduled.ScheduledTransferID).
+		Updates(scheduled).Error
+	if err != nil {
+		log.ZError(ctx, "while update scheduled transfer", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) FetchDueScheduledTransfers(
+	ctx context.Context, now time.Time,
+) (scheduled []*entity.ScheduledTransfer, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).
+		Where("execute_at <= ?", now).
+		Where("status_scheduled_transfer = ?", entity.StatusScheduledTransferScheduled).
+		Where("is_active IS TRUE").
+		Order("execute_at ASC").
+		Find(&scheduled).Error
+	if err != nil {
+		log.ZError(ctx, "while fetch due scheduled transfers", err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("total", len(scheduled)))
+
+	return scheduled, nil
+}
+
+func (r *repositoryImpl) GetListScheduledTransfer(
+	ctx context.Context, arg *domain.GetListScheduledTransferRequest,
+) (scheduled []*entity.ScheduledTransfer, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).
+		Model(&entity.ScheduledTransfer{}).
+		Where("from_user_id = ? AND is_active IS TRUE", arg.UserID)
+	if arg.StatusScheduledTransfer != "" {
+		query = query.Where("status_scheduled_transfer = ?", arg.StatusScheduledTransfer)
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (arg.Page - 1) * arg.Limit
+	err = query.
+		Order("execute_at DESC").
+		Limit(int(arg.Limit)).
+		Offset(int(offset)).
+		Find(&scheduled).Error
+	if err != nil {
+		log.ZError(ctx, "while get list scheduled transfer", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(
+		attribute.String("userID", arg.UserID),
+		attribute.Int64("total", total),
+	)
+
+	return scheduled, total, nil
+}
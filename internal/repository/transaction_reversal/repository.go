@@ -0,0 +1,14 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package transaction_reversal
+
+import (
+	"context"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreateReversal(ctx context.Context, reversal *entity.TransactionReversal) (reversalID int64, err error)
+	IsReversed(ctx context.Context, walletTransactionID int64) (reversed bool, err error)
+}
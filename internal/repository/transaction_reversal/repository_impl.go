@@ -0,0 +1,92 @@
+package transaction_reversal
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateReversal(
+	ctx context.Context, reversal *entity.TransactionReversal,
+) (reversalID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(reversal).Error
+	if err != nil {
+		log.ZError(ctx, "while create transaction reversal", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletTransactionID", reversal.WalletTransactionID),
+		attribute.Int64("transactionReversalID", reversal.TransactionReversalID),
+	)
+
+	return reversal.TransactionReversalID, nil
+}
+
+func (r *repositoryImpl) IsReversed(ctx context.Context, walletTransactionID int64) (reversed bool, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var count int64
+	err = r.conn(ctx).
+		Model(&entity.TransactionReversal{}).
+		Where("wallet_transaction_id = ?", walletTransactionID).
+		Count(&count).Error
+	if err != nil {
+		log.ZError(ctx, "while count transaction reversal", err)
+		return false, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletTransactionID", walletTransactionID),
+		attribute.Bool("reversed", count > 0),
+	)
+
+	return count > 0, nil
+}
@@ -12,10 +12,16 @@ import (
 )
 
 type Repository interface {
-	Create(transfer *entity.Transfer) error
 	Update(ctx context.Context, transfer *entity.Transfer, tx *gorm.DB) error
-	FindAllTransferByWalletID(walletID string) ([]entity.Transfer, error)
+	// UpdateStatusTransfer moves the transfer from one status to another, updated is false
+	// when the transfer was no longer in the from status.
+	UpdateStatusTransfer(
+		ctx context.Context, transferID int64, from, to entity.StatusTransfer, operatedBy string,
+	) (updated bool, err error)
 	FindByTransferID(ctx context.Context, transferID int64) (*entity.Transfer, error)
+	// GetTransferForUpdate locks the transfer until the transaction carried by ctx ends, so a
+	// claim and a cancel of the same transfer run one after the other.
+	GetTransferForUpdate(ctx context.Context, transferID int64) (transfer *entity.Transfer, err error)
 	CreateTransfer(
 		ctx context.Context, tx *gorm.DB, transfer *entity.Transfer,
 	) (transferID int64, err error)
@@ -9,10 +9,12 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/1nterdigital/aka-im-tools/log"
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
 	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
 )
 
@@ -26,11 +28,9 @@ func New(db *gorm.DB) Repository {
 	return &repositoryImpl{db: db}
 }
 
-func (r *repositoryImpl) Create(transfer *entity.Transfer) error {
-	if err := r.db.Create(&transfer).Error; err != nil {
-		return err
-	}
-	return nil
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
 }
 
 func (r *repositoryImpl) Update(ctx context.Context, transfer *entity.Transfer, tx *gorm.DB) error {
@@ -55,8 +55,7 @@ func (r *repositoryImpl) Update(ctx context.Context, transfer *entity.Transfer,
 
 	switch tx {
 	case nil:
-		err = r.db.Model(&entity.Transfer{}).
-			WithContext(ctx).
+		err = r.conn(ctx).Model(&entity.Transfer{}).
 			Where("transfer_id = ?", transfer.TransferID).
 			Updates(transfer).Error
 	default:
@@ -72,14 +71,6 @@ func (r *repositoryImpl) Update(ctx context.Context, transfer *entity.Transfer,
 
 	return nil
 }
-func (r *repositoryImpl) FindAllTransferByWalletID(walletID string) ([]entity.Transfer, error) {
-	var transfers []entity.Transfer
-	if err := r.db.Where("wallet_id = ?", walletID).Find(&transfers).Error; err != nil {
-		return nil, err
-	}
-	return transfers, nil
-}
-
 func (r *repositoryImpl) FindByTransferID(ctx context.Context, transferID int64) (*entity.Transfer, error) {
 	var (
 		funcName = tracer.GetFullFunctionPath()
@@ -101,8 +92,7 @@ func (r *repositoryImpl) FindByTransferID(ctx context.Context, transferID int64)
 	)
 
 	var transfer entity.Transfer
-	err = r.db.Where("transfer_id = ?", transferID).
-		WithContext(ctx).
+	err = r.conn(ctx).Where("transfer_id = ?", transferID).
 		First(&transfer).Error
 	if err != nil {
 		return &entity.Transfer{}, err
@@ -111,6 +101,42 @@ func (r *repositoryImpl) FindByTransferID(ctx context.Context, transferID int64)
 	return &transfer, nil
 }
 
+func (r *repositoryImpl) GetTransferForUpdate(
+	ctx context.Context, transferID int64,
+) (transfer *entity.Transfer, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("transferID", transferID))
+
+	transfer = &entity.Transfer{}
+	err = r.conn(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("transfer_id = ?", transferID).
+		First(transfer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrTransferNotFound
+		}
+
+		log.ZError(ctx, "while lock transfer", err)
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
 func (r *repositoryImpl) CreateTransfer(
 	ctx context.Context, tx *gorm.DB, transfer *entity.Transfer,
 ) (transferID int64, err error) {
@@ -130,7 +156,7 @@ func (r *repositoryImpl) CreateTransfer(
 
 	switch tx {
 	case nil:
-		err = r.db.WithContext(ctx).Create(transfer).Error
+		err = r.conn(ctx).Create(transfer).Error
 	default:
 		err = tx.WithContext(ctx).Create(transfer).Error
 	}
@@ -165,7 +191,7 @@ func (r *repositoryImpl) GetEligibleClaimTransfer(
 	}()
 
 	detail = &entity.Transfer{}
-	err = r.db.WithContext(ctx).
+	err = r.conn(ctx).
 		Where("transfer_id = ?", transferID).
 		Where("to_user_id = ?", claimerUserID).
 		Where("status_transfer = ?", entity.StatusTransferPending).
@@ -206,7 +232,7 @@ func (r *repositoryImpl) CountSentTransferInDay(
 	var count int64
 	startOfDay := now.Truncate(hoursInDay * time.Hour)
 	endOfDay := startOfDay.Add(hoursInDay * time.Hour)
-	err = r.db.WithContext(ctx).
+	err = r.conn(ctx).
 		Model(&entity.Transfer{}).
 		Where("from_user_id = ?", userID).
 		Where("created_at > ? AND created_at <= ?", startOfDay, endOfDay).
@@ -241,7 +267,7 @@ func (r *repositoryImpl) CountClaimedTransferInDay(
 	var count int64
 	startOfDay := now.Truncate(hoursInDay * time.Hour)
 	endOfDay := startOfDay.Add(hoursInDay * time.Hour)
-	err = r.db.WithContext(ctx).
+	err = r.conn(ctx).
 		Model(&entity.Transfer{}).
 		Where("to_user_id = ?", userID).
 		Where("claimed_at > ? AND claimed_at <= ?", startOfDay, endOfDay).
@@ -276,7 +302,7 @@ func (r *repositoryImpl) FetchExpiredTransfers(ctx context.Context, ids []int64)
 	span.SetAttributes(attribute.Int64Slice("transferIDsReq", ids))
 
 	var transfers []*entity.Transfer
-	q := r.db.WithContext(ctx).
+	q := r.conn(ctx).
 		Where("expired_at < ?", time.Now()).
 		Where("status_transfer = ?", "pending").
 		Where("claimed_at IS NULL").
@@ -322,7 +348,7 @@ func (r *repositoryImpl) GetEligibleRefundTransfer(
 		attribute.String("statusTransfer", string(entity.StatusTransferPending)),
 	)
 
-	q := r.db.WithContext(ctx).
+	q := r.conn(ctx).
 		Where("transfer_id = ?", transferID).
 		Where("from_user_id = ?", userID).
 		Where("status_transfer = ?", entity.StatusTransferPending).
@@ -366,7 +392,7 @@ func (r *repositoryImpl) GetDetailTransfer(
 		attribute.String("userID", userID),
 	)
 
-	q := r.db.WithContext(ctx).
+	q := r.conn(ctx).
 		Where("transfer_id = ?", transferID).
 		Where("(from_user_id = ? OR to_user_id = ?)", userID, userID).
 		Where("is_active = ?", true)
@@ -382,3 +408,40 @@ func (r *repositoryImpl) GetDetailTransfer(
 
 	return detail, nil
 }
+
+func (r *repositoryImpl) UpdateStatusTransfer(
+	ctx context.Context, transferID int64, from, to entity.StatusTransfer, operatedBy string,
+) (updated bool, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	result := r.conn(ctx).Model(&entity.Transfer{}).
+		Where("transfer_id = ? AND status_transfer = ?", transferID, from).
+		Updates(map[string]interface{}{
+			"status_transfer": to,
+			"updated_by":      operatedBy,
+		})
+	if result.Error != nil {
+		log.ZError(ctx, "while update status transfer", result.Error)
+		return false, result.Error
+	}
+
+	span.SetAttributes(
+		attribute.Int64("transferID", transferID),
+		attribute.String("statusTransfer", to.String()),
+		attribute.Int64("rowsAffected", result.RowsAffected),
+	)
+
+	return result.RowsAffected > 0, nil
+}
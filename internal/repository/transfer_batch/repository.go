@@ -0,0 +1,18 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package transfer_batch
+
+import (
+	"context"
+
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreateTransferBatch(ctx context.Context, batch *entity.TransferBatch) (transferBatchID int64, err error)
+	GetTransferBatch(ctx context.Context, transferBatchID int64) (batch *entity.TransferBatch, err error)
+	UpdateTransferBatch(ctx context.Context, batch *entity.TransferBatch) (err error)
+	CreateTransferBatchItem(ctx context.Context, item *entity.TransferBatchItem) (err error)
+	// GetListTransferBatchItem returns every item of the batch in request order.
+	GetListTransferBatchItem(ctx context.Context, transferBatchID int64) (items []*entity.TransferBatchItem, err error)
+}
@@ -0,0 +1,192 @@
+package transfer_batch
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateTransferBatch(
+	ctx context.Context, batch *entity.TransferBatch,
+) (transferBatchID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(batch).Error
+	if err != nil {
+		log.ZError(ctx, "while create transfer batch", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.String("fromUserID", batch.FromUserID),
+		attribute.String("mode", batch.Mode.String()),
+		attribute.Int64("transferBatchID", batch.TransferBatchID),
+	)
+
+	return batch.TransferBatchID, nil
+}
+
+func (r *repositoryImpl) GetTransferBatch(
+	ctx context.Context, transferBatchID int64,
+) (batch *entity.TransferBatch, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("transferBatchID", transferBatchID))
+
+	batch = &entity.TransferBatch{}
+	err = r.conn(ctx).
+		Where("transfer_batch_id = ? AND is_active IS TRUE", transferBatchID).
+		First(batch).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrTransferBatchNotFound
+		}
+
+		log.ZError(ctx, "while get transfer batch", err)
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+func (r *repositoryImpl) UpdateTransferBatch(ctx context.Context, batch *entity.TransferBatch) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("transferBatchID", batch.TransferBatchID),
+		attribute.String("statusTransferBatch", batch.StatusTransferBatch.String()),
+	)
+
+	err = r.conn(ctx).Model(&entity.TransferBatch{}).
+		Where("transfer_batch_id = ?", batch.TransferBatchID).
+		Updates(batch).Error
+	if err != nil {
+		log.ZError(ctx, "while update transfer batch", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) CreateTransferBatchItem(ctx context.Context, item *entity.TransferBatchItem) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("transferBatchID", item.TransferBatchID),
+		attribute.String("toUserID", item.ToUserID),
+		attribute.String("statusTransferBatchItem", item.StatusTransferBatchItem.String()),
+	)
+
+	err = r.conn(ctx).Create(item).Error
+	if err != nil {
+		log.ZError(ctx, "while create transfer batch item", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) GetListTransferBatchItem(
+	ctx context.Context, transferBatchID int64,
+) (items []*entity.TransferBatchItem, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).
+		Where("transfer_batch_id = ?", transferBatchID).
+		Order("transfer_batch_item_id ASC").
+		Find(&items).Error
+	if err != nil {
+		log.ZError(ctx, "while get list transfer batch item", err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("transferBatchID", transferBatchID),
+		attribute.Int("total", len(items)),
+	)
+
+	return items, nil
+}
@@ -0,0 +1,49 @@
+package tx
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying the given transaction.
+func NewContext(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, ctxKey{}, tx)
+}
+
+// FromContext returns the transaction carried by ctx, if any.
+func FromContext(ctx context.Context) (tx *gorm.DB, ok bool) {
+	tx, ok = ctx.Value(ctxKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
+
+// Detach returns a copy of ctx carrying no transaction. Statements run with it commit on their
+// own, whether the transaction of the caller commits or rolls back.
+func Detach(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey{}, (*gorm.DB)(nil))
+}
+
+// Conn returns the transaction carried by ctx, or db when ctx carries none.
+// Repositories use it for every statement so they join the caller's transaction.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := FromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
+
+// Run executes fn inside a transaction opened on db and propagates it through the ctx
+// handed to fn. When ctx already carries a transaction fn joins it instead of opening a
+// new one, the error returned by fn then rolls back the outer transaction as well.
+func Run(ctx context.Context, db *gorm.DB, fn func(ctx context.Context, tx *gorm.DB) error) error {
+	if tx, ok := FromContext(ctx); ok {
+		return fn(ctx, tx)
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(NewContext(ctx, tx), tx)
+	})
+}
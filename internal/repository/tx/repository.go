@@ -9,5 +9,9 @@ import (
 )
 
 type Repository interface {
-	Do(ctx context.Context, fn func(tx *gorm.DB) error) error
+	// Do runs fn inside a database transaction. The ctx handed to fn carries the
+	// transaction, repositories called with it join the same transaction. When ctx
+	// already carries a transaction fn joins it, so the outermost Do commits or rolls
+	// back the whole business operation.
+	Do(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error
 }
@@ -15,9 +15,7 @@ func NewTx(db *gorm.DB) Repository {
 }
 
 func (r *repositoryImpl) Do(
-	ctx context.Context, fn func(tx *gorm.DB) error,
+	ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error,
 ) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return fn(tx)
-	})
+	return Run(ctx, r.db, fn)
 }
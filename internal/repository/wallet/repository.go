@@ -11,15 +11,20 @@ import (
 )
 
 type Repository interface {
-	FindByUserID(userID string) (resp *entity.Wallet, err error)
-	Create(wallet *entity.Wallet) (err error)
-	FindByWalletID(walletID int64) (resp *entity.Wallet, err error)
+	FindByUserID(ctx context.Context, userID string, currency entity.Currency) (resp *entity.Wallet, err error)
+	FindByWalletID(ctx context.Context, walletID int64) (resp *entity.Wallet, err error)
 	GetWalletByWalletIDTx(
 		ctx context.Context, tx *gorm.DB, walletID int64,
 	) (wallet *entity.Wallet, err error)
 	UpdateWallet(ctx context.Context, tx *gorm.DB, wallet *entity.Wallet) (err error)
-	GetWalletByUserID(ctx context.Context, userID string) (wallet *entity.Wallet, err error)
+	UpdateBalance(ctx context.Context, walletID int64, balance entity.Money) (err error)
+	UpdateHeldBalance(ctx context.Context, walletID int64, heldBalance entity.Money) (err error)
+	GetWalletByUserID(
+		ctx context.Context, userID string, currency entity.Currency,
+	) (wallet *entity.Wallet, err error)
+	GetWalletsByUserID(ctx context.Context, userID string) (wallets []*entity.Wallet, err error)
 	CreateWallet(
 		ctx context.Context, wallet *entity.Wallet,
 	) (walletID int64, err error)
+	GetTotalBalance(ctx context.Context, currency entity.Currency) (total entity.Money, err error)
 }
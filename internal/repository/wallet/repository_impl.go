@@ -12,6 +12,7 @@ import (
 	"github.com/1nterdigital/aka-im-tools/log"
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
 )
 
 type repositoryImpl struct {
@@ -22,31 +23,66 @@ func New(db *gorm.DB) Repository {
 	return &repositoryImpl{db: db}
 }
 
-func (r *repositoryImpl) FindByUserID(userID string) (resp *entity.Wallet, err error) {
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) FindByUserID(
+	ctx context.Context, userID string, currency entity.Currency,
+) (resp *entity.Wallet, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	var wallet entity.Wallet
-	if err := r.db.Where("user_id = ?", userID).First(&wallet).Error; err != nil {
+	if err = r.conn(ctx).Where("user_id = ? AND currency = ?", userID, currency).First(&wallet).Error; err != nil {
 		return nil, err
 	}
 
+	span.SetAttributes(
+		attribute.String("userID", userID),
+		attribute.String("currency", currency.String()),
+	)
+
 	return &wallet, nil
 }
 
-func (r *repositoryImpl) Create(wallet *entity.Wallet) (err error) {
-	if err := r.db.Create(wallet).Error; err != nil {
-		return err
-	}
-	return nil
-}
+func (r *repositoryImpl) FindByWalletID(ctx context.Context, walletID int64) (resp *entity.Wallet, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
 
-func (r *repositoryImpl) FindByWalletID(walletID int64) (resp *entity.Wallet, err error) {
 	var wallet entity.Wallet
-	if err := r.db.Where("wallet_id = ?", walletID).First(&wallet).Error; err != nil {
+	if err = r.conn(ctx).Where("wallet_id = ?", walletID).First(&wallet).Error; err != nil {
 		return nil, err
 	}
+
+	span.SetAttributes(attribute.Int64("walletID", walletID))
+
 	return &wallet, nil
 }
 
-//nolint:revive // keep receiver for interface compliance
 func (r *repositoryImpl) GetWalletByWalletIDTx(
 	ctx context.Context, tx *gorm.DB, walletID int64,
 ) (wallet *entity.Wallet, err error) {
@@ -64,7 +100,12 @@ func (r *repositoryImpl) GetWalletByWalletIDTx(
 		span.End()
 	}()
 
-	err = tx.WithContext(ctx).Clauses(
+	db := r.conn(ctx)
+	if tx != nil {
+		db = tx.WithContext(ctx)
+	}
+
+	err = db.Clauses(
 		clause.Locking{
 			Strength: "UPDATE",
 		},
@@ -81,6 +122,7 @@ func (r *repositoryImpl) GetWalletByWalletIDTx(
 	return wallet, nil
 }
 
+// UpdateWallet writes the non-zero fields of wallet only, a balance goes through UpdateBalance.
 func (r *repositoryImpl) UpdateWallet(ctx context.Context, tx *gorm.DB, wallet *entity.Wallet) error {
 	var (
 		funcName = tracer.GetFullFunctionPath()
@@ -101,7 +143,7 @@ func (r *repositoryImpl) UpdateWallet(ctx context.Context, tx *gorm.DB, wallet *
 
 	switch tx {
 	case nil:
-		err = r.db.WithContext(ctx).Model(&entity.Wallet{}).
+		err = r.conn(ctx).Model(&entity.Wallet{}).
 			Where("wallet_id = ? AND is_active IS TRUE", wallet.WalletID).
 			Updates(wallet).Error
 	default:
@@ -118,8 +160,78 @@ func (r *repositoryImpl) UpdateWallet(ctx context.Context, tx *gorm.DB, wallet *
 	return nil
 }
 
+// UpdateBalance sets the balance column explicitly, Updates would skip it when a debit takes
+// the wallet down to exactly zero and leave the old balance in place.
+func (r *repositoryImpl) UpdateBalance(
+	ctx context.Context, walletID int64, balance entity.Money,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Model(&entity.Wallet{}).
+		Where("wallet_id = ? AND is_active IS TRUE", walletID).
+		Update("balance", balance).Error
+	if err != nil {
+		log.ZError(ctx, "while repositoryImpl UpdateBalance", err)
+		return err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", walletID),
+		attribute.String("balance", balance.String()),
+	)
+
+	return nil
+}
+
+// UpdateHeldBalance sets the held balance column alone, Updates would skip it once every hold
+// on the wallet is gone and the held balance drops back to zero.
+func (r *repositoryImpl) UpdateHeldBalance(
+	ctx context.Context, walletID int64, heldBalance entity.Money,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Model(&entity.Wallet{}).
+		Where("wallet_id = ? AND is_active IS TRUE", walletID).
+		Update("held_balance", heldBalance).Error
+	if err != nil {
+		log.ZError(ctx, "while repositoryImpl UpdateHeldBalance", err)
+		return err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", walletID),
+		attribute.String("heldBalance", heldBalance.String()),
+	)
+
+	return nil
+}
+
 func (r *repositoryImpl) GetWalletByUserID(
-	ctx context.Context, userID string,
+	ctx context.Context, userID string, currency entity.Currency,
 ) (wallet *entity.Wallet, err error) {
 	var (
 		funcName = tracer.GetFullFunctionPath()
@@ -135,8 +247,8 @@ func (r *repositoryImpl) GetWalletByUserID(
 		span.End()
 	}()
 
-	err = r.db.WithContext(ctx).
-		Where("user_id = ? AND is_active IS TRUE", userID).
+	err = r.conn(ctx).
+		Where("user_id = ? AND currency = ? AND is_active IS TRUE", userID, currency).
 		First(&wallet).Error
 	if err != nil {
 		log.ZError(ctx, "while repositoryImpl GetWalletByUserID", err)
@@ -145,12 +257,47 @@ func (r *repositoryImpl) GetWalletByUserID(
 
 	span.SetAttributes(
 		attribute.String("userID", userID),
+		attribute.String("currency", currency.String()),
 		attribute.Int64("walletID", wallet.WalletID),
 	)
 
 	return wallet, nil
 }
 
+func (r *repositoryImpl) GetWalletsByUserID(
+	ctx context.Context, userID string,
+) (wallets []*entity.Wallet, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).
+		Where("user_id = ? AND is_active IS TRUE", userID).
+		Order("currency ASC").
+		Find(&wallets).Error
+	if err != nil {
+		log.ZError(ctx, "while repositoryImpl GetWalletsByUserID", err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("userID", userID),
+		attribute.Int("total", len(wallets)),
+	)
+
+	return wallets, nil
+}
+
 func (r *repositoryImpl) CreateWallet(
 	ctx context.Context, wallet *entity.Wallet,
 ) (walletID int64, err error) {
@@ -168,7 +315,7 @@ func (r *repositoryImpl) CreateWallet(
 		span.End()
 	}()
 
-	err = r.db.WithContext(ctx).Create(wallet).Error
+	err = r.conn(ctx).Create(wallet).Error
 	if err != nil {
 		log.ZError(ctx, "while create wallet", err)
 		return walletID, err
@@ -179,3 +326,39 @@ func (r *repositoryImpl) CreateWallet(
 
 	return wallet.WalletID, nil
 }
+
+func (r *repositoryImpl) GetTotalBalance(ctx context.Context, currency entity.Currency) (total entity.Money, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var result struct {
+		Total entity.Money `gorm:"column:total"`
+	}
+	err = r.conn(ctx).
+		Model(&entity.Wallet{}).
+		Select("COALESCE(SUM(balance), 0) AS total").
+		Where("currency = ?", currency).
+		Scan(&result).Error
+	if err != nil {
+		log.ZError(ctx, "while get total wallet balance", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.String("currency", currency.String()),
+		attribute.String("total", result.Total.String()),
+	)
+
+	return result.Total, nil
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package wallet_freeze
+
+import (
+	"context"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreateHistory(ctx context.Context, history *entity.WalletFreezeHistory) (historyID int64, err error)
+	GetListHistory(
+		ctx context.Context, req *domain.GetListWalletFreezeHistoryRequest,
+	) (histories []*entity.WalletFreezeHistory, total int64, err error)
+}
@@ -0,0 +1,105 @@
+package wallet_freeze
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateHistory(
+	ctx context.Context, history *entity.WalletFreezeHistory,
+) (historyID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(history).Error
+	if err != nil {
+		log.ZError(ctx, "while create wallet freeze history", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", history.WalletID),
+		attribute.Int64("walletFreezeHistoryID", history.WalletFreezeHistoryID),
+	)
+
+	return history.WalletFreezeHistoryID, nil
+}
+
+func (r *repositoryImpl) GetListHistory(
+	ctx context.Context, req *domain.GetListWalletFreezeHistoryRequest,
+) (histories []*entity.WalletFreezeHistory, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).
+		Model(&entity.WalletFreezeHistory{}).
+		Where("wallet_id = ?", req.WalletID)
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	err = query.
+		Order("wallet_freeze_history_id DESC").
+		Limit(int(req.Limit)).
+		Offset(int(offset)).
+		Find(&histories).Error
+	if err != nil {
+		log.ZError(ctx, "while get list wallet freeze history", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", req.WalletID),
+		attribute.Int64("total", total),
+	)
+
+	return histories, total, nil
+}
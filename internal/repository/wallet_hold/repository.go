@@ -0,0 +1,21 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package wallet_hold
+
+import (
+	"context"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreateHold(ctx context.Context, hold *entity.WalletHold) (holdID int64, err error)
+	GetHold(ctx context.Context, holdID int64) (hold *entity.WalletHold, err error)
+	// GetHoldForUpdate locks the hold row until the transaction carried by ctx ends.
+	GetHoldForUpdate(ctx context.Context, holdID int64) (hold *entity.WalletHold, err error)
+	UpdateHold(ctx context.Context, hold *entity.WalletHold) (err error)
+	GetListHold(
+		ctx context.Context, req *domain.GetListWalletHoldRequest,
+	) (holds []*entity.WalletHold, total int64, err error)
+}
@@ -0,0 +1,194 @@
+package wallet_hold
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateHold(ctx context.Context, hold *entity.WalletHold) (holdID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(hold).Error
+	if err != nil {
+		log.ZError(ctx, "while create wallet hold", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", hold.WalletID),
+		attribute.Int64("walletHoldID", hold.WalletHoldID),
+	)
+
+	return hold.WalletHoldID, nil
+}
+
+func (r *repositoryImpl) GetHold(ctx context.Context, holdID int64) (hold *entity.WalletHold, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("walletHoldID", holdID))
+
+	return r.findHold(r.conn(ctx), holdID)
+}
+
+func (r *repositoryImpl) GetHoldForUpdate(ctx context.Context, holdID int64) (hold *entity.WalletHold, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("walletHoldID", holdID))
+
+	return r.findHold(r.conn(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), holdID)
+}
+
+func (r *repositoryImpl) findHold(db *gorm.DB, holdID int64) (hold *entity.WalletHold, err error) {
+	hold = &entity.WalletHold{}
+	err = db.Where("wallet_hold_id = ?", holdID).First(hold).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrWalletHoldNotFound
+		}
+
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (r *repositoryImpl) UpdateHold(ctx context.Context, hold *entity.WalletHold) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("walletHoldID", hold.WalletHoldID),
+		attribute.String("statusHold", hold.StatusHold.String()),
+	)
+
+	err = r.conn(ctx).Model(&entity.WalletHold{}).
+		Where("wallet_hold_id = ?", hold.WalletHoldID).
+		Updates(hold).Error
+	if err != nil {
+		log.ZError(ctx, "while update wallet hold", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) GetListHold(
+	ctx context.Context, req *domain.GetListWalletHoldRequest,
+) (holds []*entity.WalletHold, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).
+		Model(&entity.WalletHold{}).
+		Where("wallet_id = ?", req.WalletID)
+	if req.StatusHold != "" {
+		query = query.Where("status_hold = ?", req.StatusHold)
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	err = query.
+		Order("wallet_hold_id DESC").
+		Limit(int(req.Limit)).
+		Offset(int(offset)).
+		Find(&holds).Error
+	if err != nil {
+		log.ZError(ctx, "while get list wallet hold", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", req.WalletID),
+		attribute.Int64("total", total),
+	)
+
+	return holds, total, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	"github.com/1nterdigital/aka-im-wallet/internal/domain"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
 )
 
 type walletMonitoringRepositoryImpl struct {
@@ -27,6 +28,11 @@ func NewWalletMonitoringRepository(db *gorm.DB) WalletMonitoringRepository {
 	}
 }
 
+// conn joins the transaction carried by ctx, if any.
+func (r *walletMonitoringRepositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
 // GetDashboardTransactionVolume TODO: implement caching
 func (r *walletMonitoringRepositoryImpl) GetDashboardTransactionVolume(
 	ctx context.Context,
@@ -47,7 +53,7 @@ func (r *walletMonitoringRepositoryImpl) GetDashboardTransactionVolume(
 
 	var results []*entity.TransactionCount
 
-	query := r.db.WithContext(ctx).
+	query := r.conn(ctx).
 		Model(&entity.WalletTransaction{}).
 		Select("transaction_type, entry_type, COUNT(*) as count").
 		Where("is_active = ? AND deleted_at IS NULL", true).
@@ -81,7 +87,7 @@ func (r *walletMonitoringRepositoryImpl) GetListTransactionMonitoring(
 		span.End()
 	}()
 
-	baseQuery := r.db.WithContext(ctx).
+	baseQuery := r.conn(ctx).
 		Model(&entity.WalletTransaction{}).
 		Where("is_active = ? AND deleted_at IS NULL", true)
 
@@ -91,8 +97,8 @@ func (r *walletMonitoringRepositoryImpl) GetListTransactionMonitoring(
 		attribute.String("transactionTypeReq", req.TransactionType),
 		attribute.String("entryTypeReq", req.EntryType),
 		attribute.String("referenceCodeReq", req.ReferenceCode),
-		attribute.Float64("fromAmountReq", req.FromAmount),
-		attribute.Float64("toAmountReq", req.ToAmount),
+		attribute.String("fromAmountReq", req.FromAmount.String()),
+		attribute.String("toAmountReq", req.ToAmount.String()),
 		attribute.Int64("walletIDReq", req.WalletID),
 	)
 
@@ -194,15 +200,15 @@ func (r *walletMonitoringRepositoryImpl) GetListEnvelope(
 		span.End()
 	}()
 
-	baseQuery := r.db.WithContext(ctx).
+	baseQuery := r.conn(ctx).
 		Model(&entity.Envelope{}).
 		Where("is_active = ? AND deleted_at IS NULL", true)
 
 	span.SetAttributes(
 		attribute.Int("page", int(req.Page)),
 		attribute.Int("limit", int(req.Limit)),
-		attribute.Float64("fromTotalAmount", req.FromTotalAmount),
-		attribute.Float64("toTotalAmount", req.ToTotalAmount),
+		attribute.String("fromTotalAmount", req.FromTotalAmount.String()),
+		attribute.String("toTotalAmount", req.ToTotalAmount.String()),
 		attribute.String("userID", req.UserID),
 		attribute.String("envelopeType", req.EnvelopeType),
 		attribute.Int("maxNumReceived", int(req.MaxNumReceived)),
@@ -319,7 +325,7 @@ func (r *walletMonitoringRepositoryImpl) GetEnvelopeDetail(
 
 	span.SetAttributes(attribute.Int64("envelopeIDReq", req.EnvelopeID))
 
-	baseQuery := r.db.WithContext(ctx).
+	baseQuery := r.conn(ctx).
 		Where("envelope_id = ? AND is_active = ? AND deleted_at IS NULL", req.EnvelopeID, true)
 
 	err = baseQuery.First(&envelope).Error
@@ -362,7 +368,7 @@ func (r *walletMonitoringRepositoryImpl) applyEnvelopeDetailsQuery(
 
 	span.SetAttributes(attribute.Int64("envelopeIDReq", req.EnvelopeID))
 
-	query := r.db.WithContext(ctx).
+	query := r.conn(ctx).
 		Model(&entity.EnvelopeDetail{}).
 		Where("envelope_id = ? AND is_active = ? AND deleted_at IS NULL", req.EnvelopeID, true)
 
@@ -405,7 +411,7 @@ func (r *walletMonitoringRepositoryImpl) GetTransferHistory(
 		span.End()
 	}()
 
-	baseQuery := r.db.WithContext(ctx).
+	baseQuery := r.conn(ctx).
 		Model(&entity.Transfer{}).
 		Where("is_active = ? AND deleted_at IS NULL", true)
 
@@ -550,7 +556,7 @@ func (r *walletMonitoringRepositoryImpl) GetTop10Users(
 
 	var results []*entity.UserStatResult
 
-	dbQuery := r.db.WithContext(ctx).Raw(query, queryArgs...)
+	dbQuery := r.conn(ctx).Raw(query, queryArgs...)
 	err = dbQuery.Scan(&results).Error
 	if err != nil {
 		return nil, "", errs.ErrArgs.WithDetail(fmt.Sprintf("failed to execute query: %v", err)).Wrap()
@@ -600,18 +606,24 @@ func applyTop10UsersQuery(timeCondition string, sortByTotalAmount bool) (q strin
 				SUM(CASE WHEN entry_type = 'credit' AND transaction_type = 'envelope_fixed' THEN 1 ELSE 0 END) as credit_envelope_fixed,
 				SUM(CASE WHEN entry_type = 'credit' AND transaction_type = 'envelope_lucky' THEN 1 ELSE 0 END) as credit_envelope_lucky,
 				SUM(CASE WHEN entry_type = 'credit' AND transaction_type = 'envelope_single' THEN 1 ELSE 0 END) as credit_envelope_single,
+				SUM(CASE WHEN entry_type = 'credit' AND transaction_type = 'envelope_exclusive' THEN 1 ELSE 0 END) as credit_envelope_exclusive,
 				SUM(CASE WHEN entry_type = 'credit' AND transaction_type = 'deposit' THEN 1 ELSE 0 END) as credit_deposit,
 				SUM(CASE WHEN entry_type = 'credit' AND transaction_type = 'refund_envelope' THEN 1 ELSE 0 END) as credit_refund_envelope,
 				SUM(CASE WHEN entry_type = 'credit' AND transaction_type = 'refund_transfer' THEN 1 ELSE 0 END) as credit_refund_transfer,
 				SUM(CASE WHEN entry_type = 'credit' AND transaction_type = 'system_adjustment' THEN 1 ELSE 0 END) as credit_system_adjustment,
+				SUM(CASE WHEN entry_type = 'credit' AND transaction_type = 'decline_transfer' THEN 1 ELSE 0 END) as credit_decline_transfer,
+				SUM(CASE WHEN entry_type = 'credit' AND transaction_type = 'cancel_transfer' THEN 1 ELSE 0 END) as credit_cancel_transfer,
 				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'transfer' THEN 1 ELSE 0 END) as debit_transfer,
 				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'envelope_fixed' THEN 1 ELSE 0 END) as debit_envelope_fixed,
 				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'envelope_lucky' THEN 1 ELSE 0 END) as debit_envelope_lucky,
 				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'envelope_single' THEN 1 ELSE 0 END) as debit_envelope_single,
+				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'envelope_exclusive' THEN 1 ELSE 0 END) as debit_envelope_exclusive,
 				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'deposit' THEN 1 ELSE 0 END) as debit_deposit,
 				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'refund_envelope' THEN 1 ELSE 0 END) as debit_refund_envelope,
 				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'refund_transfer' THEN 1 ELSE 0 END) as debit_refund_transfer,
 				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'system_adjustment' THEN 1 ELSE 0 END) as debit_system_adjustment,
+				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'decline_transfer' THEN 1 ELSE 0 END) as debit_decline_transfer,
+				SUM(CASE WHEN entry_type = 'debit' AND transaction_type = 'cancel_transfer' THEN 1 ELSE 0 END) as debit_cancel_transfer,
 				COUNT(*) as transaction_frequency
 			FROM wallet_transactions 
 			WHERE is_active = true AND deleted_at IS NULL %s
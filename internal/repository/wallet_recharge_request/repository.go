@@ -15,6 +15,16 @@ type Repository interface {
 	CreateDeposit(
 		ctx context.Context, tx *gorm.DB, deposit *entity.WalletRechargeRequest,
 	) (depositID int64, err error)
+	// GetDepositForUpdate locks the deposit row until the transaction carried by ctx ends.
+	GetDepositForUpdate(
+		ctx context.Context, depositID int64,
+	) (deposit *entity.WalletRechargeRequest, err error)
+	UpdateDeposit(ctx context.Context, deposit *entity.WalletRechargeRequest) (err error)
+	// UpdateStatusRequest moves the deposit from one status to another, updated is false when
+	// the deposit was no longer in the from status.
+	UpdateStatusRequest(
+		ctx context.Context, depositID int64, from, to entity.StatusRequest, operatedBy string,
+	) (updated bool, err error)
 	GetListDeposit(
 		ctx context.Context, arg *domain.GetListDepositRequest,
 	) (deposit []*entity.WalletRechargeRequest, total int64, err error)
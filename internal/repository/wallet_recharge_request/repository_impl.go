@@ -2,17 +2,21 @@ package wallet_recharge_request
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/1nterdigital/aka-im-tools/log"
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	"github.com/1nterdigital/aka-im-wallet/internal/domain"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
 )
 
 type repositoryImpl struct {
@@ -23,6 +27,11 @@ func New(db *gorm.DB) Repository {
 	return &repositoryImpl{db: db}
 }
 
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
 func (r *repositoryImpl) CreateDeposit(
 	ctx context.Context, tx *gorm.DB, deposit *entity.WalletRechargeRequest,
 ) (depositID int64, err error) {
@@ -42,7 +51,7 @@ func (r *repositoryImpl) CreateDeposit(
 
 	switch tx {
 	case nil:
-		err = r.db.WithContext(ctx).Create(deposit).Error
+		err = r.conn(ctx).Create(deposit).Error
 	default:
 		err = tx.WithContext(ctx).Create(deposit).Error
 	}
@@ -54,7 +63,7 @@ func (r *repositoryImpl) CreateDeposit(
 
 	span.SetAttributes(
 		attribute.Int64("walletID", deposit.WalletID),
-		attribute.Float64("amount", deposit.Amount),
+		attribute.String("amount", deposit.Amount.String()),
 		attribute.Int64("walletRechargeRequestID", deposit.WalletRechargeRequestID),
 	)
 
@@ -78,7 +87,7 @@ func (r *repositoryImpl) GetListDeposit(
 		span.End()
 	}()
 
-	query := r.db.WithContext(ctx).
+	query := r.conn(ctx).
 		Model(&entity.WalletRechargeRequest{}).
 		Where("is_active IS TRUE AND deleted_at IS NULL")
 
@@ -87,6 +96,11 @@ func (r *repositoryImpl) GetListDeposit(
 		span.SetAttributes(attribute.Int64("walletID", arg.WalletID))
 	}
 
+	if arg.StatusRequest != "" {
+		query = query.Where("status_request = ?", arg.StatusRequest)
+		span.SetAttributes(attribute.String("statusRequest", arg.StatusRequest))
+	}
+
 	if arg.FilterDateBy != "" {
 		query = query.Where(
 			fmt.Sprintf("%s BETWEEN ? AND ?", arg.FilterDateBy),
@@ -119,3 +133,108 @@ func (r *repositoryImpl) GetListDeposit(
 	span.SetAttributes(attribute.Int64("total", total))
 	return deposits, total, nil
 }
+
+func (r *repositoryImpl) UpdateStatusRequest(
+	ctx context.Context, depositID int64, from, to entity.StatusRequest, operatedBy string,
+) (updated bool, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	result := r.conn(ctx).Model(&entity.WalletRechargeRequest{}).
+		Where("wallet_recharge_request_id = ? AND status_request = ?", depositID, from).
+		Updates(map[string]interface{}{
+			"status_request": to,
+			"operated_by":    operatedBy,
+			"updated_by":     operatedBy,
+		})
+	if result.Error != nil {
+		log.ZError(ctx, "while update status deposit", result.Error)
+		return false, result.Error
+	}
+
+	span.SetAttributes(
+		attribute.Int64("depositID", depositID),
+		attribute.String("statusRequest", to.String()),
+		attribute.Int64("rowsAffected", result.RowsAffected),
+	)
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repositoryImpl) GetDepositForUpdate(
+	ctx context.Context, depositID int64,
+) (deposit *entity.WalletRechargeRequest, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("depositID", depositID))
+
+	deposit = &entity.WalletRechargeRequest{}
+	err = r.conn(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("wallet_recharge_request_id = ? AND is_active IS TRUE", depositID).
+		First(deposit).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrDepositNotFound
+		}
+
+		log.ZError(ctx, "while lock deposit", err)
+		return nil, err
+	}
+
+	return deposit, nil
+}
+
+func (r *repositoryImpl) UpdateDeposit(ctx context.Context, deposit *entity.WalletRechargeRequest) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("depositID", deposit.WalletRechargeRequestID),
+		attribute.String("statusRequest", deposit.StatusRequest.String()),
+	)
+
+	err = r.conn(ctx).Model(&entity.WalletRechargeRequest{}).
+		Where("wallet_recharge_request_id = ?", deposit.WalletRechargeRequestID).
+		Updates(deposit).Error
+	if err != nil {
+		log.ZError(ctx, "while update deposit", err)
+		return err
+	}
+
+	return nil
+}
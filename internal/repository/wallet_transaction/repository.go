@@ -4,6 +4,7 @@ package wallet_transaction
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -12,12 +13,19 @@ import (
 )
 
 type Repository interface {
-	FindAllByWalletID(walletID int64) ([]entity.WalletTransaction, error)
-	FindByWalletTransactionID(walletTransactionID int64) (entity.WalletTransaction, error)
+	// GetTransactionForUpdate locks the transaction row until the transaction carried by ctx ends.
+	GetTransactionForUpdate(
+		ctx context.Context, walletTransactionID int64,
+	) (transaction *entity.WalletTransaction, err error)
 	CreateTransaction(
 		ctx context.Context, tx *gorm.DB, transaction *entity.WalletTransaction,
 	) (transactionID int64, err error)
 	GetListTransaction(
 		ctx context.Context, req *domain.GetListTransactionRequest,
 	) (trans []*entity.WalletTransaction, count int64, err error)
+	// SumDebitSince adds up, as a positive amount, the debits of the given types booked on the
+	// wallet from since on.
+	SumDebitSince(
+		ctx context.Context, walletID int64, types []entity.TransactionType, since time.Time,
+	) (total entity.Money, err error)
 }
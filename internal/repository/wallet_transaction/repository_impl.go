@@ -2,16 +2,21 @@ package wallet_transaction
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/1nterdigital/aka-im-tools/log"
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	"github.com/1nterdigital/aka-im-wallet/internal/domain"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
 )
 
 type repositoryImpl struct {
@@ -22,19 +27,42 @@ func New(db *gorm.DB) Repository {
 	return &repositoryImpl{db: db}
 }
 
-func (r *repositoryImpl) FindAllByWalletID(walletID int64) ([]entity.WalletTransaction, error) {
-	var transactions []entity.WalletTransaction
-	if err := r.db.Where("wallet_id = ?", walletID).Find(&transactions).Error; err != nil {
-		return nil, err
-	}
-
-	return transactions, nil
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
 }
 
-func (r *repositoryImpl) FindByWalletTransactionID(walletTransactionID int64) (entity.WalletTransaction, error) {
-	var transaction entity.WalletTransaction
-	if err := r.db.Where("wallet_transaction_id = ?", walletTransactionID).Find(&transaction).Error; err != nil {
-		return entity.WalletTransaction{}, err
+func (r *repositoryImpl) GetTransactionForUpdate(
+	ctx context.Context, walletTransactionID int64,
+) (transaction *entity.WalletTransaction, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("walletTransactionID", walletTransactionID))
+
+	transaction = &entity.WalletTransaction{}
+	err = r.conn(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("wallet_transaction_id = ? AND is_active IS TRUE", walletTransactionID).
+		First(transaction).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrTransactionNotFound
+		}
+
+		log.ZError(ctx, "while lock wallet transaction", err)
+		return nil, err
 	}
 
 	return transaction, nil
@@ -59,7 +87,7 @@ func (r *repositoryImpl) CreateTransaction(
 
 	switch tx {
 	case nil:
-		err = r.db.WithContext(ctx).Create(transaction).Error
+		err = r.conn(ctx).Create(transaction).Error
 	default:
 		err = tx.WithContext(ctx).Create(transaction).Error
 	}
@@ -97,7 +125,7 @@ func (r *repositoryImpl) GetListTransaction(
 		attribute.String("walletID", req.EndDate.String()),
 	)
 
-	query := r.db.WithContext(ctx).
+	query := r.conn(ctx).
 		Model(&entity.WalletTransaction{}).
 		Where("wallet_id = ? AND is_active IS TRUE AND is_shown IS TRUE", req.WalletID)
 
@@ -122,3 +150,40 @@ func (r *repositoryImpl) GetListTransaction(
 
 	return transactions, total, nil
 }
+
+func (r *repositoryImpl) SumDebitSince(
+	ctx context.Context, walletID int64, types []entity.TransactionType, since time.Time,
+) (total entity.Money, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("walletID", walletID),
+		attribute.String("since", since.Format(time.RFC3339)),
+	)
+
+	// debits are stored as negative amounts
+	err = r.conn(ctx).
+		Model(&entity.WalletTransaction{}).
+		Select("COALESCE(-SUM(amount), 0)").
+		Where("wallet_id = ? AND entry_type = ? AND transaction_type IN ? AND transaction_date >= ? AND is_active IS TRUE",
+			walletID, entity.EntryTypeDebit, types, since).
+		Scan(&total).Error
+	if err != nil {
+		log.ZError(ctx, "while sum wallet debits", err, "walletID", walletID)
+		return 0, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,24 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package wallet_withdrawal_request
+
+import (
+	"context"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+type Repository interface {
+	CreateWithdrawal(
+		ctx context.Context, withdrawal *entity.WalletWithdrawalRequest,
+	) (withdrawalID int64, err error)
+	// GetWithdrawalForUpdate locks the withdrawal row until the transaction carried by ctx ends.
+	GetWithdrawalForUpdate(
+		ctx context.Context, withdrawalID int64,
+	) (withdrawal *entity.WalletWithdrawalRequest, err error)
+	UpdateWithdrawal(ctx context.Context, withdrawal *entity.WalletWithdrawalRequest) (err error)
+	GetListWithdrawal(
+		ctx context.Context, arg *domain.GetListWithdrawalRequest,
+	) (withdrawals []*entity.WalletWithdrawalRequest, total int64, err error)
+}
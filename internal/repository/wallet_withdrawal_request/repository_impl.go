@@ -0,0 +1,199 @@
+package wallet_withdrawal_request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	trx "github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *repositoryImpl) conn(ctx context.Context) *gorm.DB {
+	return trx.Conn(ctx, r.db)
+}
+
+func (r *repositoryImpl) CreateWithdrawal(
+	ctx context.Context, withdrawal *entity.WalletWithdrawalRequest,
+) (withdrawalID int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	err = r.conn(ctx).Create(withdrawal).Error
+	if err != nil {
+		log.ZError(ctx, "while create withdrawal", err)
+		return 0, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", withdrawal.WalletID),
+		attribute.String("amount", withdrawal.Amount.String()),
+		attribute.Int64("walletWithdrawalRequestID", withdrawal.WalletWithdrawalRequestID),
+	)
+
+	return withdrawal.WalletWithdrawalRequestID, nil
+}
+
+func (r *repositoryImpl) GetWithdrawalForUpdate(
+	ctx context.Context, withdrawalID int64,
+) (withdrawal *entity.WalletWithdrawalRequest, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("walletWithdrawalRequestID", withdrawalID))
+
+	withdrawal = &entity.WalletWithdrawalRequest{}
+	err = r.conn(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("wallet_withdrawal_request_id = ? AND is_active IS TRUE", withdrawalID).
+		First(withdrawal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrWithdrawalNotFound
+		}
+
+		log.ZError(ctx, "while lock withdrawal", err)
+		return nil, err
+	}
+
+	return withdrawal, nil
+}
+
+func (r *repositoryImpl) UpdateWithdrawal(
+	ctx context.Context, withdrawal *entity.WalletWithdrawalRequest,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("walletWithdrawalRequestID", withdrawal.WalletWithdrawalRequestID),
+		attribute.String("statusWithdrawal", withdrawal.StatusWithdrawal.String()),
+	)
+
+	err = r.conn(ctx).Model(&entity.WalletWithdrawalRequest{}).
+		Where("wallet_withdrawal_request_id = ?", withdrawal.WalletWithdrawalRequestID).
+		Updates(withdrawal).Error
+	if err != nil {
+		log.ZError(ctx, "while update withdrawal", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *repositoryImpl) GetListWithdrawal(
+	ctx context.Context, arg *domain.GetListWithdrawalRequest,
+) (withdrawals []*entity.WalletWithdrawalRequest, total int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelRepository)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	query := r.conn(ctx).
+		Model(&entity.WalletWithdrawalRequest{}).
+		Where("is_active IS TRUE AND deleted_at IS NULL")
+
+	if arg.WalletID > 0 {
+		query = query.Where("wallet_id = ?", arg.WalletID)
+		span.SetAttributes(attribute.Int64("walletID", arg.WalletID))
+	}
+
+	if arg.StatusWithdrawal != "" {
+		query = query.Where("status_withdrawal = ?", arg.StatusWithdrawal)
+		span.SetAttributes(attribute.String("statusWithdrawal", arg.StatusWithdrawal))
+	}
+
+	if arg.FilterDateBy != "" {
+		query = query.Where(
+			fmt.Sprintf("%s BETWEEN ? AND ?", arg.FilterDateBy),
+			arg.StartDate, arg.EndDate,
+		)
+		span.SetAttributes(attribute.String("filterDateBy", arg.FilterDateBy))
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	span.SetAttributes(
+		attribute.String("sortBy", arg.SortBy),
+		attribute.String("sortOder", arg.SortOrder),
+		attribute.Int("page", int(arg.Page)),
+		attribute.Int("limit", int(arg.Limit)),
+	)
+
+	offset := (arg.Page - 1) * arg.Limit
+	err = query.
+		Order(fmt.Sprintf("%s %s", arg.SortBy, arg.SortOrder)).
+		Limit(int(arg.Limit)).
+		Offset(int(offset)).
+		Find(&withdrawals).Error
+	if err != nil {
+		log.ZError(ctx, "while repositoryImpl GetListWithdrawal", err)
+		return nil, 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("total", total))
+	return withdrawals, total, nil
+}
@@ -47,3 +47,63 @@ func (a *Api) WalletMonitoringUseCase() *usecase.UseCase {
 func (a *Api) BalanceAdjustmentUsecase() *usecase.UseCase {
 	return a.uc
 }
+
+func (a *Api) IdempotencyUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) LedgerUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) ReconciliationUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) WalletFreezeUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) WalletHoldUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) TransactionReversalUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) WithdrawalUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) ApprovalUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) LimitUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) RiskUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) ScheduledTransferUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) RecurringTransferUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) PaymentRequestUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) TransferBatchUseCase() *usecase.UseCase {
+	return a.uc
+}
+
+func (a *Api) PaymentPinUseCase() *usecase.UseCase {
+	return a.uc
+}
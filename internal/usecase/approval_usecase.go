@@ -0,0 +1,295 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/approval_request"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+	"github.com/1nterdigital/aka-im-wallet/pkg/util/convert"
+)
+
+type (
+	ApprovalSvcImpl struct {
+		repo         approval_request.Repository
+		txRepo       tx.Repository
+		adjustmentUc BalanceAdjustmentSvc
+		depositUc    WalletRechargeRequestSvc
+	}
+
+	ApprovalSvc interface {
+		// ApproveRequest posts the operation of the maker, the checker has to be a different admin.
+		ApproveRequest(
+			ctx context.Context, req *domain.ProcessApprovalRequest,
+		) (resp *domain.ApprovalResponse, err error)
+		// RejectRequest drops a pending operation without moving funds, the maker may withdraw their own.
+		RejectRequest(
+			ctx context.Context, req *domain.ProcessApprovalRequest,
+		) (resp *domain.ApprovalResponse, err error)
+		GetListApprovalRequest(
+			ctx context.Context, req *domain.GetListApprovalRequest,
+		) (resp *domain.GetListApprovalResponse, err error)
+	}
+)
+
+func NewApprovalUseCase(
+	repo approval_request.Repository,
+	txRepo tx.Repository,
+	adjustmentUc BalanceAdjustmentSvc,
+	depositUc WalletRechargeRequestSvc,
+) ApprovalSvc {
+	return &ApprovalSvcImpl{
+		repo:         repo,
+		txRepo:       txRepo,
+		adjustmentUc: adjustmentUc,
+		depositUc:    depositUc,
+	}
+}
+
+// requiresApproval reports whether an admin operation over amount has to wait for a second
+// admin. A zero threshold disables maker-checker, and a replayed approval never waits again.
+func requiresApproval(threshold, amount entity.Money, approvedBy string) bool {
+	return threshold.IsPositive() && approvedBy == "" && amount.Abs() > threshold
+}
+
+// submitForApproval parks the request of the maker in the approval inbox instead of posting it.
+func submitForApproval(
+	ctx context.Context, repo approval_request.Repository, operation entity.ApprovalOperation,
+	wallet *entity.Wallet, amount entity.Money, maker string, request any,
+) (approvalRequestID int64, err error) {
+	var payload []byte
+	payload, err = json.Marshal(request)
+	if err != nil {
+		return 0, err
+	}
+
+	approvalRequestID, err = repo.CreateApprovalRequest(ctx, &entity.ApprovalRequest{
+		Operation:      operation,
+		WalletID:       wallet.WalletID,
+		Amount:         amount,
+		Currency:       wallet.Currency,
+		Payload:        string(payload),
+		StatusApproval: entity.StatusApprovalPending,
+		CreatedBy:      maker,
+		UpdatedBy:      maker,
+	})
+	if err != nil {
+		log.ZError(ctx, "while create approval request", err, "operation", operation, "walletID", wallet.WalletID)
+		return 0, err
+	}
+
+	return approvalRequestID, nil
+}
+
+func (s *ApprovalSvcImpl) ApproveRequest(
+	ctx context.Context, req *domain.ProcessApprovalRequest,
+) (resp *domain.ApprovalResponse, err error) {
+	return s.processRequest(ctx, req, entity.StatusApprovalApproved)
+}
+
+func (s *ApprovalSvcImpl) RejectRequest(
+	ctx context.Context, req *domain.ProcessApprovalRequest,
+) (resp *domain.ApprovalResponse, err error) {
+	if req.Reason == "" {
+		return resp, errors.New("reason is required")
+	}
+
+	return s.processRequest(ctx, req, entity.StatusApprovalRejected)
+}
+
+func (s *ApprovalSvcImpl) processRequest(
+	ctx context.Context, req *domain.ProcessApprovalRequest, status entity.StatusApproval,
+) (resp *domain.ApprovalResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var approval *entity.ApprovalRequest
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		approval, err = s.repo.GetApprovalRequestForUpdate(ctx, req.ApprovalRequestID)
+		if err != nil {
+			log.ZError(ctx, "while lock approval request", err, "approvalRequestID", req.ApprovalRequestID)
+			return err
+		}
+
+		if approval.StatusApproval != entity.StatusApprovalPending {
+			return eerrs.ErrApprovalStatusConflict
+		}
+
+		if status == entity.StatusApprovalApproved {
+			if approval.CreatedBy == req.OperatedBy {
+				return eerrs.ErrMakerCannotApprove
+			}
+
+			var operationID int64
+			operationID, err = s.post(ctx, approval, req.OperatedBy)
+			if err != nil {
+				return err
+			}
+			approval.OperationID = &operationID
+		} else {
+			approval.RejectReason = req.Reason
+		}
+
+		approval.StatusApproval = status
+		approval.CheckedBy = &req.OperatedBy
+		approval.CheckedAt = convert.PtrTime(time.Now())
+		approval.UpdatedBy = req.OperatedBy
+		err = s.repo.UpdateApprovalRequest(ctx, &entity.ApprovalRequest{
+			ApprovalRequestID: approval.ApprovalRequestID,
+			StatusApproval:    approval.StatusApproval,
+			OperationID:       approval.OperationID,
+			RejectReason:      approval.RejectReason,
+			CheckedBy:         approval.CheckedBy,
+			CheckedAt:         approval.CheckedAt,
+			UpdatedBy:         approval.UpdatedBy,
+		})
+		if err != nil {
+			log.ZError(ctx, "while update approval request", err, "approvalRequestID", approval.ApprovalRequestID)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.ZError(ctx, "while process approval request", err, "request", req, "status", status)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("approvalRequestID", approval.ApprovalRequestID),
+		attribute.String("operation", approval.Operation.String()),
+		attribute.String("statusApproval", approval.StatusApproval.String()),
+	)
+
+	return dtoApprovalResponse(approval), nil
+}
+
+// post replays the request of the maker on behalf of the checker, inside the transaction
+// that locks the approval so a failed posting leaves the request pending.
+func (s *ApprovalSvcImpl) post(
+	ctx context.Context, approval *entity.ApprovalRequest, checker string,
+) (operationID int64, err error) {
+	switch approval.Operation {
+	case entity.ApprovalOperationBalanceAdjustment:
+		var arg domain.BalanceAdjustmentByAdminRequest
+		if err = json.Unmarshal([]byte(approval.Payload), &arg); err != nil {
+			return 0, err
+		}
+		arg.ApprovedBy = checker
+
+		var resp *domain.BalanceAdjustmentByAdminResponse
+		resp, err = s.adjustmentUc.BalanceAdjustmentByAdmin(ctx, &arg)
+		if err != nil {
+			log.ZError(ctx, "while post balance adjustment", err, "approvalRequestID", approval.ApprovalRequestID)
+			return 0, err
+		}
+
+		return resp.BalanceAdjustmentID, nil
+	case entity.ApprovalOperationDeposit:
+		var arg domain.ProcessDepositByAdminRequest
+		if err = json.Unmarshal([]byte(approval.Payload), &arg); err != nil {
+			return 0, err
+		}
+		arg.ApprovedBy = checker
+
+		var resp *domain.ProcessDepositByAdminResponse
+		resp, err = s.depositUc.ProcessDepositByAdmin(ctx, &arg)
+		if err != nil {
+			log.ZError(ctx, "while post deposit", err, "approvalRequestID", approval.ApprovalRequestID)
+			return 0, err
+		}
+
+		return resp.WalletRechargeRequestID, nil
+	case entity.ApprovalOperationDepositRequest:
+		var arg domain.ProcessDepositRequest
+		if err = json.Unmarshal([]byte(approval.Payload), &arg); err != nil {
+			return 0, err
+		}
+		// the deposit is credited on behalf of the checker
+		arg.OperatedBy = checker
+		arg.ApprovedBy = checker
+
+		var resp *domain.DepositResponse
+		resp, err = s.depositUc.ApproveDeposit(ctx, &arg)
+		if err != nil {
+			log.ZError(ctx, "while approve deposit request", err, "approvalRequestID", approval.ApprovalRequestID)
+			return 0, err
+		}
+
+		return resp.WalletRechargeRequestID, nil
+	default:
+		return 0, eerrs.ErrInvalidApprovalOperation
+	}
+}
+
+func (s *ApprovalSvcImpl) GetListApprovalRequest(
+	ctx context.Context, req *domain.GetListApprovalRequest,
+) (resp *domain.GetListApprovalResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		log.ZError(ctx, "while validate request", err, "request", req)
+		return resp, err
+	}
+
+	var (
+		approvals []*entity.ApprovalRequest
+		total     int64
+	)
+	approvals, total, err = s.repo.GetListApprovalRequest(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list approval request", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.String("statusApproval", req.StatusApproval),
+		attribute.Int64("total", total),
+	)
+
+	return &domain.GetListApprovalResponse{
+		TotalCount: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Approvals:  dtoApprovals(approvals),
+	}, nil
+}
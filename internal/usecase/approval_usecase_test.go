@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_approval_request"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_balance_adjustment"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_usecase"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet_recharge_request"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func TestApproval_BalanceAdjustmentThreshold(t *testing.T) {
+	testCases := []struct {
+		desc                 string
+		amount               entity.Money
+		expected             *domain.BalanceAdjustmentByAdminResponse
+		onMockApprovalRepo   func(mock *mock_approval_request.MockRepository)
+		onMockAdjustmentRepo func(mock *mock_balance_adjustment.MockRepository)
+		onMockTransactionUc  func(mock *mock_usecase.MockWalletTransactionSvc)
+	}{
+		{
+			desc:     "above_threshold_waits_for_approval",
+			amount:   entity.NewMoney(-600),
+			expected: &domain.BalanceAdjustmentByAdminResponse{ApprovalRequestID: 5, StatusApproval: "pending_approval"},
+			onMockApprovalRepo: func(mock *mock_approval_request.MockRepository) {
+				mock.EXPECT().
+					CreateApprovalRequest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, approval *entity.ApprovalRequest) (int64, error) {
+						assert.Equal(t, entity.ApprovalOperationBalanceAdjustment, approval.Operation)
+						assert.Equal(t, int64(7), approval.WalletID)
+						assert.Equal(t, entity.NewMoney(-600), approval.Amount)
+						assert.Equal(t, entity.StatusApprovalPending, approval.StatusApproval)
+						assert.Equal(t, "admin1-1", approval.CreatedBy)
+						assert.JSONEq(t,
+							`{"amount":-600.00,"currency":"","userID":"u1","reason":"fix","description":"","OperatedBy":"admin1-1"}`,
+							approval.Payload,
+						)
+						return 5, nil
+					})
+			},
+		},
+		{
+			desc:     "at_threshold_posts_right_away",
+			amount:   entity.NewMoney(500),
+			expected: &domain.BalanceAdjustmentByAdminResponse{BalanceAdjustmentID: 9},
+			onMockAdjustmentRepo: func(mock *mock_balance_adjustment.MockRepository) {
+				mock.EXPECT().CreateBalanceAdjustment(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(9), nil)
+			},
+			onMockTransactionUc: func(mock *mock_usecase.MockWalletTransactionSvc) {
+				mock.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(int64(11), nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			walletRepo.EXPECT().
+				GetWalletByUserID(gomock.Any(), "u1", entity.DefaultCurrency).
+				Return(&entity.Wallet{WalletID: 7, Currency: entity.DefaultCurrency, Balance: entity.NewMoney(1000)}, nil)
+			approvalRepo := mock_approval_request.NewMockRepository(ctrl)
+			if tC.onMockApprovalRepo != nil {
+				tC.onMockApprovalRepo(approvalRepo)
+			}
+			adjustmentRepo := mock_balance_adjustment.NewMockRepository(ctrl)
+			if tC.onMockAdjustmentRepo != nil {
+				tC.onMockAdjustmentRepo(adjustmentRepo)
+			}
+			transactionUc := mock_usecase.NewMockWalletTransactionSvc(ctrl)
+			if tC.onMockTransactionUc != nil {
+				tC.onMockTransactionUc(transactionUc)
+			}
+
+			svc := NewBalanceAdjustmentUseCase(
+				transactionUc, adjustmentRepo, walletRepo, newMockHoldTxRepo(ctrl), approvalRepo, entity.NewMoney(500),
+			)
+
+			got, err := svc.BalanceAdjustmentByAdmin(context.Background(), &domain.BalanceAdjustmentByAdminRequest{
+				Amount: tC.amount, UserID: "u1", Reason: "fix", OperatedBy: "admin1-1",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tC.expected, got)
+		})
+	}
+}
+
+func TestApproval_DepositRequestThreshold(t *testing.T) {
+	requested := &entity.WalletRechargeRequest{
+		WalletRechargeRequestID: 21,
+		WalletID:                7,
+		Amount:                  entity.NewMoney(600),
+		Currency:                entity.CurrencyCNY,
+		StatusRequest:           entity.StatusRequestRequested,
+	}
+	testCases := []struct {
+		desc               string
+		request            *domain.ProcessDepositRequest
+		expected           *domain.DepositResponse
+		onMockApprovalRepo func(mock *mock_approval_request.MockRepository)
+		onMockDepositRepo  func(mock *mock_wallet_recharge_request.MockRepository)
+		onMockWalletRepo   func(mock *mock_wallet.MockRepository)
+		onMockTxUc         func(mock *mock_usecase.MockWalletTransactionSvc)
+	}{
+		{
+			desc:    "above_threshold_is_not_credited",
+			request: &domain.ProcessDepositRequest{WalletRechargeRequestID: 21, OperatedBy: "admin1-1"},
+			expected: &domain.DepositResponse{
+				WalletRechargeRequestID: 21, WalletID: 7, StatusRequest: "requested",
+				ApprovalRequestID: 5, StatusApproval: "pending_approval",
+			},
+			onMockApprovalRepo: func(mock *mock_approval_request.MockRepository) {
+				mock.EXPECT().
+					CreateApprovalRequest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, approval *entity.ApprovalRequest) (int64, error) {
+						assert.Equal(t, entity.ApprovalOperationDepositRequest, approval.Operation)
+						assert.Equal(t, int64(7), approval.WalletID)
+						assert.Equal(t, entity.NewMoney(600), approval.Amount)
+						assert.Equal(t, "admin1-1", approval.CreatedBy)
+						assert.JSONEq(t, `{"walletRechargeRequestID":21,"reason":""}`, approval.Payload)
+						return 5, nil
+					})
+			},
+		},
+		{
+			desc: "second_admin_credits",
+			request: &domain.ProcessDepositRequest{
+				WalletRechargeRequestID: 21, OperatedBy: "admin2-1", ApprovedBy: "admin2-1",
+			},
+			expected: &domain.DepositResponse{WalletRechargeRequestID: 21, WalletID: 7, StatusRequest: "approved"},
+			onMockDepositRepo: func(mock *mock_wallet_recharge_request.MockRepository) {
+				mock.EXPECT().UpdateDeposit(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, UserID: "u1"}, nil)
+			},
+			onMockTxUc: func(mock *mock_usecase.MockWalletTransactionSvc) {
+				mock.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(int64(11), nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			depositRepo := mock_wallet_recharge_request.NewMockRepository(ctrl)
+			deposit := *requested
+			depositRepo.EXPECT().GetDepositForUpdate(gomock.Any(), int64(21)).Return(&deposit, nil)
+			if tC.onMockDepositRepo != nil {
+				tC.onMockDepositRepo(depositRepo)
+			}
+			approvalRepo := mock_approval_request.NewMockRepository(ctrl)
+			if tC.onMockApprovalRepo != nil {
+				tC.onMockApprovalRepo(approvalRepo)
+			}
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(walletRepo)
+			}
+			transactionUc := mock_usecase.NewMockWalletTransactionSvc(ctrl)
+			if tC.onMockTxUc != nil {
+				tC.onMockTxUc(transactionUc)
+			}
+
+			svc := NewWalletRechargeRequestUseCase(
+				nil, transactionUc, depositRepo, nil, walletRepo, newMockHoldTxRepo(ctrl), approvalRepo, entity.NewMoney(500),
+			)
+
+			got, err := svc.ApproveDeposit(context.Background(), tC.request)
+			require.NoError(t, err)
+			assert.Equal(t, tC.expected, got)
+		})
+	}
+}
+
+func TestApproval_ProcessRequest(t *testing.T) {
+	type expected struct {
+		resp *domain.ApprovalResponse
+		err  error
+	}
+	approvalIn := func(operation entity.ApprovalOperation, status entity.StatusApproval) *entity.ApprovalRequest {
+		return &entity.ApprovalRequest{
+			ApprovalRequestID: 5,
+			Operation:         operation,
+			WalletID:          7,
+			Amount:            entity.NewMoney(600),
+			Payload:           `{"amount":600,"userID":"u1","reason":"fix","OperatedBy":"admin1-1"}`,
+			StatusApproval:    status,
+			CreatedBy:         "admin1-1",
+		}
+	}
+	testCases := []struct {
+		desc               string
+		action             entity.StatusApproval
+		request            *domain.ProcessApprovalRequest
+		expected           expected
+		wantError          bool
+		onMockRepo         func(mock *mock_approval_request.MockRepository)
+		onMockAdjustmentUc func(mock *mock_usecase.MockBalanceAdjustmentSvc)
+		onMockDepositUc    func(mock *mock_usecase.MockWalletRechargeRequestSvc)
+	}{
+		{
+			desc:      "id_required",
+			action:    entity.StatusApprovalApproved,
+			request:   &domain.ProcessApprovalRequest{OperatedBy: "admin2-1"},
+			expected:  expected{err: errors.New("approvalRequestID is required")},
+			wantError: true,
+		},
+		{
+			desc:      "reject_reason_required",
+			action:    entity.StatusApprovalRejected,
+			request:   &domain.ProcessApprovalRequest{ApprovalRequestID: 5, OperatedBy: "admin2-1"},
+			expected:  expected{err: errors.New("reason is required")},
+			wantError: true,
+		},
+		{
+			desc:      "maker_cannot_approve",
+			action:    entity.StatusApprovalApproved,
+			request:   &domain.ProcessApprovalRequest{ApprovalRequestID: 5, OperatedBy: "admin1-1"},
+			expected:  expected{err: eerrs.ErrMakerCannotApprove},
+			wantError: true,
+			onMockRepo: func(mock *mock_approval_request.MockRepository) {
+				mock.EXPECT().
+					GetApprovalRequestForUpdate(gomock.Any(), int64(5)).
+					Return(approvalIn(entity.ApprovalOperationDeposit, entity.StatusApprovalPending), nil)
+			},
+		},
+		{
+			desc:      "already_decided",
+			action:    entity.StatusApprovalApproved,
+			request:   &domain.ProcessApprovalRequest{ApprovalRequestID: 5, OperatedBy: "admin2-1"},
+			expected:  expected{err: eerrs.ErrApprovalStatusConflict},
+			wantError: true,
+			onMockRepo: func(mock *mock_approval_request.MockRepository) {
+				mock.EXPECT().
+					GetApprovalRequestForUpdate(gomock.Any(), int64(5)).
+					Return(approvalIn(entity.ApprovalOperationDeposit, entity.StatusApprovalRejected), nil)
+			},
+		},
+		{
+			desc:      "posting_fails_stays_pending",
+			action:    entity.StatusApprovalApproved,
+			request:   &domain.ProcessApprovalRequest{ApprovalRequestID: 5, OperatedBy: "admin2-1"},
+			expected:  expected{err: eerrs.ErrWalletCreditFrozen},
+			wantError: true,
+			onMockRepo: func(mock *mock_approval_request.MockRepository) {
+				mock.EXPECT().
+					GetApprovalRequestForUpdate(gomock.Any(), int64(5)).
+					Return(approvalIn(entity.ApprovalOperationDeposit, entity.StatusApprovalPending), nil)
+			},
+			onMockDepositUc: func(mock *mock_usecase.MockWalletRechargeRequestSvc) {
+				mock.EXPECT().ProcessDepositByAdmin(gomock.Any(), gomock.Any()).Return(nil, eerrs.ErrWalletCreditFrozen)
+			},
+		},
+		{
+			desc:    "success_approve_deposit",
+			action:  entity.StatusApprovalApproved,
+			request: &domain.ProcessApprovalRequest{ApprovalRequestID: 5, OperatedBy: "admin2-1"},
+			expected: expected{resp: &domain.ApprovalResponse{
+				ApprovalRequestID: 5, Operation: "deposit", StatusApproval: "approved", OperationID: int64Ptr(21),
+			}},
+			onMockRepo: func(mock *mock_approval_request.MockRepository) {
+				mock.EXPECT().
+					GetApprovalRequestForUpdate(gomock.Any(), int64(5)).
+					Return(approvalIn(entity.ApprovalOperationDeposit, entity.StatusApprovalPending), nil)
+				mock.EXPECT().
+					UpdateApprovalRequest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, approval *entity.ApprovalRequest) error {
+						assert.Equal(t, "admin2-1", *approval.CheckedBy)
+						assert.NotNil(t, approval.CheckedAt)
+						return nil
+					})
+			},
+			onMockDepositUc: func(mock *mock_usecase.MockWalletRechargeRequestSvc) {
+				mock.EXPECT().
+					ProcessDepositByAdmin(gomock.Any(), &domain.ProcessDepositByAdminRequest{
+						Amount:     entity.NewMoney(600),
+						UserID:     "u1",
+						OperatedBy: "admin1-1",
+						ApprovedBy: "admin2-1",
+					}).
+					Return(&domain.ProcessDepositByAdminResponse{WalletRechargeRequestID: 21}, nil)
+			},
+		},
+		{
+			desc:    "success_approve_balance_adjustment",
+			action:  entity.StatusApprovalApproved,
+			request: &domain.ProcessApprovalRequest{ApprovalRequestID: 5, OperatedBy: "admin2-1"},
+			expected: expected{resp: &domain.ApprovalResponse{
+				ApprovalRequestID: 5, Operation: "balance_adjustment", StatusApproval: "approved", OperationID: int64Ptr(9),
+			}},
+			onMockRepo: func(mock *mock_approval_request.MockRepository) {
+				mock.EXPECT().
+					GetApprovalRequestForUpdate(gomock.Any(), int64(5)).
+					Return(approvalIn(entity.ApprovalOperationBalanceAdjustment, entity.StatusApprovalPending), nil)
+				mock.EXPECT().UpdateApprovalRequest(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			onMockAdjustmentUc: func(mock *mock_usecase.MockBalanceAdjustmentSvc) {
+				mock.EXPECT().
+					BalanceAdjustmentByAdmin(gomock.Any(), &domain.BalanceAdjustmentByAdminRequest{
+						Amount:     entity.NewMoney(600),
+						UserID:     "u1",
+						Reason:     "fix",
+						OperatedBy: "admin1-1",
+						ApprovedBy: "admin2-1",
+					}).
+					Return(&domain.BalanceAdjustmentByAdminResponse{BalanceAdjustmentID: 9}, nil)
+			},
+		},
+		{
+			desc:    "success_approve_deposit_request",
+			action:  entity.StatusApprovalApproved,
+			request: &domain.ProcessApprovalRequest{ApprovalRequestID: 5, OperatedBy: "admin2-1"},
+			expected: expected{resp: &domain.ApprovalResponse{
+				ApprovalRequestID: 5, Operation: "deposit_request", StatusApproval: "approved", OperationID: int64Ptr(21),
+			}},
+			onMockRepo: func(mock *mock_approval_request.MockRepository) {
+				approval := approvalIn(entity.ApprovalOperationDepositRequest, entity.StatusApprovalPending)
+				approval.Payload = `{"walletRechargeRequestID":21,"reason":""}`
+				mock.EXPECT().GetApprovalRequestForUpdate(gomock.Any(), int64(5)).Return(approval, nil)
+				mock.EXPECT().UpdateApprovalRequest(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			onMockDepositUc: func(mock *mock_usecase.MockWalletRechargeRequestSvc) {
+				mock.EXPECT().
+					ApproveDeposit(gomock.Any(), &domain.ProcessDepositRequest{
+						WalletRechargeRequestID: 21,
+						OperatedBy:              "admin2-1",
+						ApprovedBy:              "admin2-1",
+					}).
+					Return(&domain.DepositResponse{WalletRechargeRequestID: 21, StatusRequest: "approved"}, nil)
+			},
+		},
+		{
+			desc:    "success_maker_rejects_own",
+			action:  entity.StatusApprovalRejected,
+			request: &domain.ProcessApprovalRequest{ApprovalRequestID: 5, Reason: "typo", OperatedBy: "admin1-1"},
+			expected: expected{resp: &domain.ApprovalResponse{
+				ApprovalRequestID: 5, Operation: "deposit", StatusApproval: "rejected",
+			}},
+			onMockRepo: func(mock *mock_approval_request.MockRepository) {
+				mock.EXPECT().
+					GetApprovalRequestForUpdate(gomock.Any(), int64(5)).
+					Return(approvalIn(entity.ApprovalOperationDeposit, entity.StatusApprovalPending), nil)
+				mock.EXPECT().
+					UpdateApprovalRequest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, approval *entity.ApprovalRequest) error {
+						assert.Equal(t, "typo", approval.RejectReason)
+						return nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_approval_request.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+			adjustmentUc := mock_usecase.NewMockBalanceAdjustmentSvc(ctrl)
+			if tC.onMockAdjustmentUc != nil {
+				tC.onMockAdjustmentUc(adjustmentUc)
+			}
+			depositUc := mock_usecase.NewMockWalletRechargeRequestSvc(ctrl)
+			if tC.onMockDepositUc != nil {
+				tC.onMockDepositUc(depositUc)
+			}
+
+			svc := NewApprovalUseCase(repo, newMockHoldTxRepo(ctrl), adjustmentUc, depositUc)
+
+			var (
+				got *domain.ApprovalResponse
+				err error
+			)
+			if tC.action == entity.StatusApprovalApproved {
+				got, err = svc.ApproveRequest(context.Background(), tC.request)
+			} else {
+				got, err = svc.RejectRequest(context.Background(), tC.request)
+			}
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
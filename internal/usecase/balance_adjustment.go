@@ -1,9 +1,10 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
 package usecase
 
 import (
 	"context"
 	"fmt"
-	"math"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -15,6 +16,7 @@ import (
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	"github.com/1nterdigital/aka-im-wallet/internal/domain"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/approval_request"
 	ba "github.com/1nterdigital/aka-im-wallet/internal/repository/balance_adjustment"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
@@ -26,6 +28,9 @@ type (
 		repo          ba.Repository
 		walletRepo    wallet.Repository
 		trxRepo       tx.Repository
+		approvalRepo  approval_request.Repository
+		// approvalThreshold: adjustments above it wait for a second admin, zero disables it
+		approvalThreshold entity.Money
 	}
 
 	BalanceAdjustmentSvc interface {
@@ -43,12 +48,16 @@ func NewBalanceAdjustmentUseCase(
 	repo ba.Repository,
 	walletRepo wallet.Repository,
 	trxRepo tx.Repository,
+	approvalRepo approval_request.Repository,
+	approvalThreshold entity.Money,
 ) BalanceAdjustmentSvc {
 	return &BalanceAdjustmentSvcImpl{
-		transactionUc: transactionUc,
-		repo:          repo,
-		walletRepo:    walletRepo,
-		trxRepo:       trxRepo,
+		transactionUc:     transactionUc,
+		repo:              repo,
+		walletRepo:        walletRepo,
+		trxRepo:           trxRepo,
+		approvalRepo:      approvalRepo,
+		approvalThreshold: approvalThreshold,
 	}
 }
 
@@ -71,26 +80,48 @@ func (s *BalanceAdjustmentSvcImpl) BalanceAdjustmentByAdmin(
 
 	span.SetAttributes(attribute.String("userIDReq", arg.UserID))
 
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(arg.Currency)
+	if err != nil {
+		return resp, err
+	}
+
 	var targetWallet *entity.Wallet
-	targetWallet, err = s.walletRepo.GetWalletByUserID(ctx, arg.UserID)
+	targetWallet, err = s.walletRepo.GetWalletByUserID(ctx, arg.UserID, currency)
 	if err != nil {
 		log.ZError(ctx, "while get wallet target user", err, "UserID", arg.UserID)
 		return resp, err
 	}
 
 	if arg.Amount < 0 {
-		if targetWallet.Balance < math.Abs(arg.Amount) {
+		if targetWallet.Balance < arg.Amount.Abs() {
 			log.ZError(ctx, "while validate balance user", err)
 			return resp, fmt.Errorf("insufficient balance user %s", arg.UserID)
 		}
 	}
 
+	if requiresApproval(s.approvalThreshold, arg.Amount, arg.ApprovedBy) {
+		var approvalRequestID int64
+		approvalRequestID, err = submitForApproval(
+			ctx, s.approvalRepo, entity.ApprovalOperationBalanceAdjustment, targetWallet, arg.Amount, arg.OperatedBy, arg,
+		)
+		if err != nil {
+			return resp, err
+		}
+
+		return &domain.BalanceAdjustmentByAdminResponse{
+			ApprovalRequestID: approvalRequestID,
+			StatusApproval:    entity.StatusApprovalPending.String(),
+		}, nil
+	}
+
 	var balanceAdjustmentID int64
 
-	err = s.trxRepo.Do(ctx, func(tx *gorm.DB) error {
+	err = s.trxRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
 		balanceAdjustmentID, err = s.repo.CreateBalanceAdjustment(ctx, tx, &entity.BalanceAdjustments{
 			WalletID:    targetWallet.WalletID,
 			Amount:      arg.Amount,
+			Currency:    currency,
 			Reason:      arg.Reason,
 			Description: arg.Description,
 			CreatedAt:   time.Now(),
@@ -112,6 +143,7 @@ func (s *BalanceAdjustmentSvcImpl) BalanceAdjustmentByAdmin(
 		_, err = s.transactionUc.CreateTransaction(ctx, &domain.CreateTransactionReq{
 			WalletID:        targetWallet.WalletID,
 			Amount:          arg.Amount,
+			Currency:        currency.String(),
 			ImpactedItem:    balanceAdjustmentID,
 			TransactionType: string(entity.TransactionTypeSystemAdjustment),
 			Entrytype:       string(entryType),
@@ -134,7 +166,7 @@ func (s *BalanceAdjustmentSvcImpl) BalanceAdjustmentByAdmin(
 
 	span.SetAttributes(
 		attribute.Int64("walletIDResp", targetWallet.WalletID),
-		attribute.Float64("amountResp", arg.Amount),
+		attribute.String("amountResp", arg.Amount.String()),
 	)
 
 	return &domain.BalanceAdjustmentByAdminResponse{
@@ -160,8 +192,14 @@ func (s *BalanceAdjustmentSvcImpl) GetListBalanceAdjustment(
 	}()
 
 	if arg.UserID != "" {
+		var currency entity.Currency
+		currency, err = entity.ParseCurrency(arg.Currency)
+		if err != nil {
+			return resp, err
+		}
+
 		var walletUser *entity.Wallet
-		walletUser, err = s.walletRepo.GetWalletByUserID(ctx, arg.UserID)
+		walletUser, err = s.walletRepo.GetWalletByUserID(ctx, arg.UserID, currency)
 		if err != nil {
 			log.ZError(ctx, "while get wallet target user", err, "UserID", arg.UserID)
 			return resp, err
@@ -51,7 +51,7 @@ func Test_BalanceAdjustment(t *testing.T) {
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("wallet not found"))
 			},
 		},
@@ -71,7 +71,7 @@ func Test_BalanceAdjustment(t *testing.T) {
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "123456",
@@ -95,7 +95,7 @@ func Test_BalanceAdjustment(t *testing.T) {
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "123456",
@@ -105,8 +105,8 @@ func Test_BalanceAdjustment(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockAdjustmentRepo: func(mock *mock_balance_adjustment.MockRepository) {
@@ -131,7 +131,7 @@ func Test_BalanceAdjustment(t *testing.T) {
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "123456",
@@ -141,8 +141,8 @@ func Test_BalanceAdjustment(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockAdjustmentRepo: func(mock *mock_balance_adjustment.MockRepository) {
@@ -174,7 +174,7 @@ func Test_BalanceAdjustment(t *testing.T) {
 			wantError: false,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "123456",
@@ -184,8 +184,8 @@ func Test_BalanceAdjustment(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockAdjustmentRepo: func(mock *mock_balance_adjustment.MockRepository) {
@@ -217,7 +217,7 @@ func Test_BalanceAdjustment(t *testing.T) {
 			wantError: false,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "123456",
@@ -227,8 +227,8 @@ func Test_BalanceAdjustment(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockAdjustmentRepo: func(mock *mock_balance_adjustment.MockRepository) {
@@ -272,6 +272,8 @@ func Test_BalanceAdjustment(t *testing.T) {
 				onMockAdjustmentRepo,
 				onMockWalletRepo,
 				onMockTxRepo,
+				nil,
+				0,
 			)
 
 			got, err := svc.BalanceAdjustmentByAdmin(context.Background(), tC.req)
@@ -316,7 +318,7 @@ func Test_GetListBalanceAdjustment(t *testing.T) {
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("wallet not found"))
 			},
 		},
@@ -336,7 +338,7 @@ func Test_GetListBalanceAdjustment(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "123456",
@@ -411,7 +413,7 @@ func Test_GetListBalanceAdjustment(t *testing.T) {
 				tC.onMockWalletRepo(onMockWalletRepo)
 			}
 
-			svc := NewBalanceAdjustmentUseCase(nil, onMockAdjustmentRepo, onMockWalletRepo, nil)
+			svc := NewBalanceAdjustmentUseCase(nil, onMockAdjustmentRepo, onMockWalletRepo, nil, nil, 0)
 
 			got, err := svc.GetListBalanceAdjustment(context.Background(), tC.req)
 			if !tC.wantError {
@@ -1,7 +1,10 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
 package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	"github.com/1nterdigital/aka-im-wallet/internal/domain"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/approval_request"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_recharge_request"
@@ -30,6 +34,9 @@ type (
 		walletTransactionRepository wallet_transaction.Repository
 		walletRepository            wallet.Repository
 		trxRepo                     tx.Repository
+		approvalRepo                approval_request.Repository
+		// approvalThreshold: admin deposits above it wait for a second admin, zero disables it
+		approvalThreshold entity.Money
 	}
 
 	WalletRechargeRequestSvc interface {
@@ -39,6 +46,18 @@ type (
 		GetListDeposit(
 			ctx context.Context, arg *domain.GetListDepositRequest,
 		) (resp *domain.GetListDepositResponse, err error)
+		// RequestDeposit queues a deposit of the user for admin approval, nothing is credited yet.
+		RequestDeposit(
+			ctx context.Context, req *domain.WalletRechargeRequestCreate,
+		) (resp *domain.DepositResponse, err error)
+		// ApproveDeposit credits the wallet with a requested deposit, one above the approval
+		// threshold waits for a second admin instead.
+		ApproveDeposit(
+			ctx context.Context, req *domain.ProcessDepositRequest,
+		) (resp *domain.DepositResponse, err error)
+		RejectDeposit(
+			ctx context.Context, req *domain.ProcessDepositRequest,
+		) (resp *domain.DepositResponse, err error)
 	}
 )
 
@@ -49,6 +68,8 @@ func NewWalletRechargeRequestUseCase(
 	walletTransactionRepo wallet_transaction.Repository,
 	walletRepo wallet.Repository,
 	trxRepo tx.Repository,
+	approvalRepo approval_request.Repository,
+	approvalThreshold entity.Money,
 ) WalletRechargeRequestSvc {
 	return &WalletRechargeRequestSvcImpl{
 		trx:                         trx,
@@ -57,6 +78,8 @@ func NewWalletRechargeRequestUseCase(
 		walletTransactionRepository: walletTransactionRepo,
 		walletRepository:            walletRepo,
 		trxRepo:                     trxRepo,
+		approvalRepo:                approvalRepo,
+		approvalThreshold:           approvalThreshold,
 	}
 }
 
@@ -79,19 +102,46 @@ func (s *WalletRechargeRequestSvcImpl) ProcessDepositByAdmin(
 
 	span.SetAttributes(attribute.String("userID", arg.UserID))
 
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(arg.Currency)
+	if err != nil {
+		return resp, err
+	}
+
 	var targetWallet *entity.Wallet
-	targetWallet, err = s.walletRepository.GetWalletByUserID(ctx, arg.UserID)
+	targetWallet, err = s.walletRepository.GetWalletByUserID(ctx, arg.UserID, currency)
 	if err != nil {
 		log.ZError(ctx, "while get wallet target user", err, "UserID", arg.UserID)
 		return resp, err
 	}
 
+	if err = validateWalletCredit(targetWallet); err != nil {
+		log.ZError(ctx, "while validate wallet target user", err, "UserID", arg.UserID)
+		return resp, err
+	}
+
+	if requiresApproval(s.approvalThreshold, arg.Amount, arg.ApprovedBy) {
+		var approvalRequestID int64
+		approvalRequestID, err = submitForApproval(
+			ctx, s.approvalRepo, entity.ApprovalOperationDeposit, targetWallet, arg.Amount, arg.OperatedBy, arg,
+		)
+		if err != nil {
+			return resp, err
+		}
+
+		return &domain.ProcessDepositByAdminResponse{
+			ApprovalRequestID: approvalRequestID,
+			StatusApproval:    entity.StatusApprovalPending.String(),
+		}, nil
+	}
+
 	var depositID int64
 
-	err = s.trxRepo.Do(ctx, func(tx *gorm.DB) error {
+	err = s.trxRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
 		depositID, err = s.repo.CreateDeposit(ctx, tx, &entity.WalletRechargeRequest{
 			WalletID:      targetWallet.WalletID,
 			Amount:        arg.Amount,
+			Currency:      currency,
 			Description:   arg.Description,
 			StatusRequest: entity.StatusRequestApproved,
 			ApprovedAt:    convert.PtrTime(time.Now()),
@@ -108,6 +158,7 @@ func (s *WalletRechargeRequestSvcImpl) ProcessDepositByAdmin(
 		_, err = s.transactionUc.CreateTransaction(ctx, &domain.CreateTransactionReq{
 			WalletID:        targetWallet.WalletID,
 			Amount:          arg.Amount,
+			Currency:        currency.String(),
 			ImpactedItem:    depositID,
 			TransactionType: string(entity.TransactionTypeDeposit),
 			Entrytype:       string(entity.EntryTypeCredit),
@@ -122,7 +173,7 @@ func (s *WalletRechargeRequestSvcImpl) ProcessDepositByAdmin(
 		}
 		span.SetAttributes(
 			attribute.Int64("walletID", targetWallet.WalletID),
-			attribute.Float64("amount", arg.Amount),
+			attribute.String("amount", arg.Amount.String()),
 		)
 
 		return nil
@@ -155,8 +206,14 @@ func (s *WalletRechargeRequestSvcImpl) GetListDeposit(
 	}()
 
 	if arg.UserID != "" {
+		var currency entity.Currency
+		currency, err = entity.ParseCurrency(arg.Currency)
+		if err != nil {
+			return resp, err
+		}
+
 		var walletUser *entity.Wallet
-		walletUser, err = s.walletRepository.GetWalletByUserID(ctx, arg.UserID)
+		walletUser, err = s.walletRepository.GetWalletByUserID(ctx, arg.UserID, currency)
 		if err != nil {
 			log.ZError(ctx, "while get wallet target user", err, "UserID", arg.UserID)
 			return resp, err
@@ -197,3 +254,240 @@ func (s *WalletRechargeRequestSvcImpl) GetListDeposit(
 
 	return resp, nil
 }
+
+func (s *WalletRechargeRequestSvcImpl) RequestDeposit(
+	ctx context.Context, req *domain.WalletRechargeRequestCreate,
+) (resp *domain.DepositResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(req.Currency)
+	if err != nil {
+		return resp, err
+	}
+
+	var walletUser *entity.Wallet
+	walletUser, err = s.walletRepository.GetWalletByUserID(ctx, req.UserID, currency)
+	if err != nil {
+		log.ZError(ctx, "while get wallet user", err, "userID", req.UserID, "currency", currency)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp, eerrs.ErrWalletNotFound
+		}
+		return resp, err
+	}
+
+	if err = validateWalletCredit(walletUser); err != nil {
+		log.ZError(ctx, "while validate wallet user", err, "walletID", walletUser.WalletID)
+		return resp, err
+	}
+
+	deposit := &entity.WalletRechargeRequest{
+		WalletID:       walletUser.WalletID,
+		Amount:         req.Amount,
+		Currency:       currency,
+		StatusRequest:  entity.StatusRequestRequested,
+		Description:    req.Notes,
+		ProofReference: req.ProofReference,
+		IsActive:       true,
+		CreatedBy:      req.UserID,
+		UpdatedBy:      req.UserID,
+	}
+	_, err = s.repo.CreateDeposit(ctx, nil, deposit)
+	if err != nil {
+		log.ZError(ctx, "while create deposit", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", deposit.WalletID),
+		attribute.Int64("walletRechargeRequestID", deposit.WalletRechargeRequestID),
+		attribute.String("amount", deposit.Amount.String()),
+	)
+
+	return dtoDepositResponse(deposit), nil
+}
+
+func (s *WalletRechargeRequestSvcImpl) ApproveDeposit(
+	ctx context.Context, req *domain.ProcessDepositRequest,
+) (resp *domain.DepositResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return s.processDeposit(ctx, req, entity.StatusRequestApproved)
+}
+
+func (s *WalletRechargeRequestSvcImpl) RejectDeposit(
+	ctx context.Context, req *domain.ProcessDepositRequest,
+) (resp *domain.DepositResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if req.Reason == "" {
+		return resp, errors.New("reason is required")
+	}
+
+	return s.processDeposit(ctx, req, entity.StatusRequestRejected)
+}
+
+// processDeposit decides a requested deposit under its row lock, so a deposit is never
+// credited twice nor credited after it was rejected. An approval above the threshold only
+// parks the deposit in the approval inbox, it stays requested until a second admin approves.
+func (s *WalletRechargeRequestSvcImpl) processDeposit(
+	ctx context.Context, req *domain.ProcessDepositRequest, status entity.StatusRequest,
+) (resp *domain.DepositResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var (
+		deposit           *entity.WalletRechargeRequest
+		approvalRequestID int64
+	)
+	err = s.trxRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		deposit, err = s.repo.GetDepositForUpdate(ctx, req.WalletRechargeRequestID)
+		if err != nil {
+			log.ZError(ctx, "while lock deposit", err, "walletRechargeRequestID", req.WalletRechargeRequestID)
+			return err
+		}
+		if deposit.StatusRequest != entity.StatusRequestRequested {
+			return eerrs.ErrDepositStatusConflict
+		}
+
+		if status == entity.StatusRequestApproved && requiresApproval(s.approvalThreshold, deposit.Amount, req.ApprovedBy) {
+			approvalRequestID, err = submitForApproval(
+				ctx, s.approvalRepo, entity.ApprovalOperationDepositRequest,
+				&entity.Wallet{WalletID: deposit.WalletID, Currency: deposit.Currency}, deposit.Amount, req.OperatedBy, req,
+			)
+			return err
+		}
+
+		deposit.StatusRequest = status
+		deposit.OperatedBy = &req.OperatedBy
+		deposit.UpdatedBy = req.OperatedBy
+		if status == entity.StatusRequestApproved {
+			deposit.ApprovedAt = convert.PtrTime(time.Now())
+			if err = s.creditDeposit(ctx, tx, deposit); err != nil {
+				return err
+			}
+		} else {
+			deposit.RejectReason = req.Reason
+		}
+
+		err = s.repo.UpdateDeposit(ctx, &entity.WalletRechargeRequest{
+			WalletRechargeRequestID: deposit.WalletRechargeRequestID,
+			StatusRequest:           deposit.StatusRequest,
+			ApprovedAt:              deposit.ApprovedAt,
+			RejectReason:            deposit.RejectReason,
+			OperatedBy:              deposit.OperatedBy,
+			UpdatedBy:               deposit.UpdatedBy,
+		})
+		if err != nil {
+			log.ZError(ctx, "while update deposit", err, "walletRechargeRequestID", deposit.WalletRechargeRequestID)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.ZError(ctx, "while process deposit", err, "request", req, "status", status)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", deposit.WalletID),
+		attribute.Int64("walletRechargeRequestID", deposit.WalletRechargeRequestID),
+		attribute.String("statusRequest", deposit.StatusRequest.String()),
+	)
+
+	resp = dtoDepositResponse(deposit)
+	if approvalRequestID != 0 {
+		resp.ApprovalRequestID = approvalRequestID
+		resp.StatusApproval = entity.StatusApprovalPending.String()
+	}
+
+	return resp, nil
+}
+
+func (s *WalletRechargeRequestSvcImpl) creditDeposit(
+	ctx context.Context, tx *gorm.DB, deposit *entity.WalletRechargeRequest,
+) (err error) {
+	var targetWallet *entity.Wallet
+	targetWallet, err = s.walletRepository.GetWalletByWalletIDTx(ctx, tx, deposit.WalletID)
+	if err != nil {
+		log.ZError(ctx, "while GetWalletByWalletIDTx", err, "walletID", deposit.WalletID)
+		return err
+	}
+
+	_, err = s.transactionUc.CreateTransaction(ctx, &domain.CreateTransactionReq{
+		WalletID:        deposit.WalletID,
+		Amount:          deposit.Amount,
+		Currency:        deposit.Currency.String(),
+		ImpactedItem:    deposit.WalletRechargeRequestID,
+		TransactionType: string(entity.TransactionTypeDeposit),
+		Entrytype:       string(entity.EntryTypeCredit),
+		CreatedBy:       deposit.UpdatedBy,
+		ReferenceCode: fmt.Sprintf(
+			"#DEP-%s-%s-%d", nowToStringYYYYMMDD(), targetWallet.UserID, deposit.WalletRechargeRequestID,
+		),
+		DescriptionEn: "Deposit user " + targetWallet.UserID,
+		DescriptionZh: "存款用戶 " + targetWallet.UserID,
+	})
+	if err != nil {
+		log.ZError(ctx, "while create transaction", err, "walletRechargeRequestID", deposit.WalletRechargeRequestID)
+		return err
+	}
+
+	return nil
+}
@@ -46,7 +46,7 @@ func Test_ProcessDepositByAdmin(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("wallet not found"))
 			},
 			expected: expected{
@@ -64,7 +64,7 @@ func Test_ProcessDepositByAdmin(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						Balance:  1000.0,
@@ -74,8 +74,8 @@ func Test_ProcessDepositByAdmin(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockDepositRepo: func(mock *mock_wallet_recharge_request.MockRepository) {
@@ -98,7 +98,7 @@ func Test_ProcessDepositByAdmin(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						Balance:  1000.0,
@@ -108,8 +108,8 @@ func Test_ProcessDepositByAdmin(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockDepositRepo: func(mock *mock_wallet_recharge_request.MockRepository) {
@@ -137,7 +137,7 @@ func Test_ProcessDepositByAdmin(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						Balance:  1000.0,
@@ -147,8 +147,8 @@ func Test_ProcessDepositByAdmin(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockDepositRepo: func(mock *mock_wallet_recharge_request.MockRepository) {
@@ -200,6 +200,8 @@ func Test_ProcessDepositByAdmin(t *testing.T) {
 				nil,
 				onMockWalletRepo,
 				onMockTxRepo,
+				nil,
+				0,
 			)
 
 			got, err := svc.ProcessDepositByAdmin(context.Background(), tC.req)
@@ -240,7 +242,7 @@ func Test_GetListDeposit(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, eerrs.ErrWalletNotFound)
 			},
 			expected: expected{
@@ -344,7 +346,7 @@ func Test_GetListDeposit(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						Balance:  1000.0,
@@ -418,6 +420,8 @@ func Test_GetListDeposit(t *testing.T) {
 				nil,
 				onMockWalletRepo,
 				nil,
+				nil,
+				0,
 			)
 
 			got, err := svc.GetListDeposit(context.Background(), tC.req)
@@ -434,3 +438,293 @@ func Test_GetListDeposit(t *testing.T) {
 		})
 	}
 }
+
+func Test_RequestDeposit(t *testing.T) {
+	type expected struct {
+		resp *domain.DepositResponse
+		err  error
+	}
+
+	testCases := []struct {
+		desc              string
+		req               *domain.WalletRechargeRequestCreate
+		expected          expected
+		wantError         bool
+		onMockDepositRepo func(mock *mock_wallet_recharge_request.MockRepository)
+		onMockWalletRepo  func(mock *mock_wallet.MockRepository)
+	}{
+		{
+			desc:      "amount_required",
+			wantError: true,
+			req:       &domain.WalletRechargeRequestCreate{UserID: "1"},
+			expected:  expected{err: errors.New("amount is required")},
+		},
+		{
+			desc:      "ErrWalletNotFound",
+			wantError: true,
+			req:       &domain.WalletRechargeRequestCreate{UserID: "1", Amount: entity.NewMoney(100)},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+			expected: expected{err: eerrs.ErrWalletNotFound},
+		},
+		{
+			desc:      "ErrWalletCreditFrozen",
+			wantError: true,
+			req:       &domain.WalletRechargeRequestCreate{UserID: "1", Amount: entity.NewMoney(100)},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 7, FreezeStatus: entity.FreezeStatusCreditFrozen}, nil)
+			},
+			expected: expected{err: eerrs.ErrWalletCreditFrozen},
+		},
+		{
+			desc: "success",
+			req: &domain.WalletRechargeRequestCreate{
+				UserID: "1", Amount: entity.NewMoney(100), Notes: "bank transfer", ProofReference: "TRX-001",
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 7, FreezeStatus: entity.FreezeStatusNone}, nil)
+			},
+			onMockDepositRepo: func(mock *mock_wallet_recharge_request.MockRepository) {
+				mock.EXPECT().
+					CreateDeposit(gomock.Any(), nil, &entity.WalletRechargeRequest{
+						WalletID:       7,
+						Amount:         entity.NewMoney(100),
+						Currency:       entity.CurrencyCNY,
+						StatusRequest:  entity.StatusRequestRequested,
+						Description:    "bank transfer",
+						ProofReference: "TRX-001",
+						IsActive:       true,
+						CreatedBy:      "1",
+						UpdatedBy:      "1",
+					}).
+					DoAndReturn(func(_ context.Context, _ *gorm.DB, deposit *entity.WalletRechargeRequest) (int64, error) {
+						deposit.WalletRechargeRequestID = 5
+						return 5, nil
+					})
+			},
+			expected: expected{resp: &domain.DepositResponse{WalletRechargeRequestID: 5, WalletID: 7, StatusRequest: "requested"}},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			onMockDepositRepo := mock_wallet_recharge_request.NewMockRepository(ctrl)
+			if tC.onMockDepositRepo != nil {
+				tC.onMockDepositRepo(onMockDepositRepo)
+			}
+
+			onMockWalletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(onMockWalletRepo)
+			}
+
+			svc := NewWalletRechargeRequestUseCase(
+				nil,
+				mock_usecase.NewMockWalletTransactionSvc(ctrl),
+				onMockDepositRepo,
+				nil,
+				onMockWalletRepo,
+				mock_tx.NewMockRepository(ctrl),
+				nil,
+				0,
+			)
+
+			got, err := svc.RequestDeposit(context.Background(), tC.req)
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func Test_ProcessDeposit(t *testing.T) {
+	type expected struct {
+		resp *domain.DepositResponse
+		err  error
+	}
+	requested := func() *entity.WalletRechargeRequest {
+		return &entity.WalletRechargeRequest{
+			WalletRechargeRequestID: 5,
+			WalletID:                7,
+			Amount:                  entity.NewMoney(100),
+			Currency:                entity.CurrencyCNY,
+			StatusRequest:           entity.StatusRequestRequested,
+		}
+	}
+
+	testCases := []struct {
+		desc                           string
+		approve                        bool
+		req                            *domain.ProcessDepositRequest
+		expected                       expected
+		wantError                      bool
+		onMockWalletTransactionUsecase func(mock *mock_usecase.MockWalletTransactionSvc)
+		onMockDepositRepo              func(mock *mock_wallet_recharge_request.MockRepository)
+		onMockWalletRepo               func(mock *mock_wallet.MockRepository)
+	}{
+		{
+			desc:      "ErrDepositNotFound",
+			approve:   true,
+			wantError: true,
+			req:       &domain.ProcessDepositRequest{WalletRechargeRequestID: 5},
+			onMockDepositRepo: func(mock *mock_wallet_recharge_request.MockRepository) {
+				mock.EXPECT().GetDepositForUpdate(gomock.Any(), int64(5)).Return(nil, eerrs.ErrDepositNotFound)
+			},
+			expected: expected{err: eerrs.ErrDepositNotFound},
+		},
+		{
+			desc:      "ErrDepositStatusConflict_already_approved",
+			approve:   true,
+			wantError: true,
+			req:       &domain.ProcessDepositRequest{WalletRechargeRequestID: 5},
+			onMockDepositRepo: func(mock *mock_wallet_recharge_request.MockRepository) {
+				deposit := requested()
+				deposit.StatusRequest = entity.StatusRequestApproved
+				mock.EXPECT().GetDepositForUpdate(gomock.Any(), int64(5)).Return(deposit, nil)
+			},
+			expected: expected{err: eerrs.ErrDepositStatusConflict},
+		},
+		{
+			desc:      "approve_ErrWalletCreditFrozen",
+			approve:   true,
+			wantError: true,
+			req:       &domain.ProcessDepositRequest{WalletRechargeRequestID: 5, OperatedBy: "admin"},
+			onMockDepositRepo: func(mock *mock_wallet_recharge_request.MockRepository) {
+				mock.EXPECT().GetDepositForUpdate(gomock.Any(), int64(5)).Return(requested(), nil)
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, UserID: "1"}, nil)
+			},
+			onMockWalletTransactionUsecase: func(mock *mock_usecase.MockWalletTransactionSvc) {
+				mock.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(int64(0), eerrs.ErrWalletCreditFrozen)
+			},
+			expected: expected{err: eerrs.ErrWalletCreditFrozen},
+		},
+		{
+			desc:    "approve_success",
+			approve: true,
+			req:     &domain.ProcessDepositRequest{WalletRechargeRequestID: 5, OperatedBy: "admin"},
+			onMockDepositRepo: func(mock *mock_wallet_recharge_request.MockRepository) {
+				mock.EXPECT().GetDepositForUpdate(gomock.Any(), int64(5)).Return(requested(), nil)
+				mock.EXPECT().
+					UpdateDeposit(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, deposit *entity.WalletRechargeRequest) error {
+						if deposit.StatusRequest != entity.StatusRequestApproved || deposit.ApprovedAt == nil ||
+							deposit.OperatedBy == nil || *deposit.OperatedBy != "admin" {
+							return errors.New("unexpected deposit update")
+						}
+						return nil
+					})
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, UserID: "1"}, nil)
+			},
+			onMockWalletTransactionUsecase: func(mock *mock_usecase.MockWalletTransactionSvc) {
+				mock.EXPECT().
+					CreateTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *domain.CreateTransactionReq) (int64, error) {
+						if req.WalletID != 7 || req.Amount != entity.NewMoney(100) || req.ImpactedItem != 5 ||
+							req.TransactionType != "deposit" || req.Entrytype != "credit" {
+							return 0, errors.New("unexpected deposit transaction")
+						}
+						return 9, nil
+					})
+			},
+			expected: expected{resp: &domain.DepositResponse{WalletRechargeRequestID: 5, WalletID: 7, StatusRequest: "approved"}},
+		},
+		{
+			desc:      "reject_reason_required",
+			wantError: true,
+			req:       &domain.ProcessDepositRequest{WalletRechargeRequestID: 5},
+			expected:  expected{err: errors.New("reason is required")},
+		},
+		{
+			desc: "reject_success",
+			req:  &domain.ProcessDepositRequest{WalletRechargeRequestID: 5, Reason: "no payment received", OperatedBy: "admin"},
+			onMockDepositRepo: func(mock *mock_wallet_recharge_request.MockRepository) {
+				mock.EXPECT().GetDepositForUpdate(gomock.Any(), int64(5)).Return(requested(), nil)
+				mock.EXPECT().
+					UpdateDeposit(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, deposit *entity.WalletRechargeRequest) error {
+						if deposit.StatusRequest != entity.StatusRequestRejected || deposit.ApprovedAt != nil ||
+							deposit.RejectReason != "no payment received" {
+							return errors.New("unexpected deposit update")
+						}
+						return nil
+					})
+			},
+			expected: expected{resp: &domain.DepositResponse{WalletRechargeRequestID: 5, WalletID: 7, StatusRequest: "rejected"}},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			onMockDepositRepo := mock_wallet_recharge_request.NewMockRepository(ctrl)
+			if tC.onMockDepositRepo != nil {
+				tC.onMockDepositRepo(onMockDepositRepo)
+			}
+
+			onMockWalletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(onMockWalletRepo)
+			}
+
+			onMockWalletTransactionUsecase := mock_usecase.NewMockWalletTransactionSvc(ctrl)
+			if tC.onMockWalletTransactionUsecase != nil {
+				tC.onMockWalletTransactionUsecase(onMockWalletTransactionUsecase)
+			}
+
+			onMockTxRepo := mock_tx.NewMockRepository(ctrl)
+			onMockTxRepo.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+					return fn(ctx, &gorm.DB{})
+				}).AnyTimes()
+
+			svc := NewWalletRechargeRequestUseCase(
+				nil,
+				onMockWalletTransactionUsecase,
+				onMockDepositRepo,
+				nil,
+				onMockWalletRepo,
+				onMockTxRepo,
+				nil,
+				0,
+			)
+
+			var (
+				got *domain.DepositResponse
+				err error
+			)
+			if tC.approve {
+				got, err = svc.ApproveDeposit(context.Background(), tC.req)
+			} else {
+				got, err = svc.RejectDeposit(context.Background(), tC.req)
+			}
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
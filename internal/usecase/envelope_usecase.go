@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
 	"math/rand"
 	"reflect"
 	"time"
@@ -22,6 +21,7 @@ import (
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
 	"github.com/1nterdigital/aka-im-wallet/pkg/common/db/kafka"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/imapi"
 	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
 	"github.com/1nterdigital/aka-im-wallet/pkg/helper"
 )
@@ -34,10 +34,14 @@ type (
 		txRepo                   tx.Repository
 		envelopeRepo             envelope.Repository
 		walletRepo               wallet.Repository
+		limitUc                  LimitSvc
+		riskUc                   RiskSvc
+		imCaller                 imapi.CallerInterface
+		expiry                   e.ExpiryPolicy
 	}
 
 	EnvelopeSvc interface {
-		GetAllEnvelopesByUserID(userID int64) (resp []*d.EnvelopeDomain, err error)
+		GetAllEnvelopesByUserID(ctx context.Context, userID int64) (resp []*d.EnvelopeDomain, err error)
 		FindOne(ctx context.Context, envelopeID int64) (resp *e.Envelope, err error)
 		CreateWalletTransaction(ctx context.Context, tx *d.TransactionAction, env *e.Envelope) (err error)
 		CreateEnvelope(ctx context.Context, req *d.EnvelopeCreateRequest) (resp *d.EnvelopeCreateResponse, err error)
@@ -60,6 +64,10 @@ func NewEnvelopeUseCase(
 	txRepo tx.Repository,
 	envelopeRepo envelope.Repository,
 	walletRepo wallet.Repository,
+	limitUc LimitSvc,
+	riskUc RiskSvc,
+	imCaller imapi.CallerInterface,
+	expiry e.ExpiryPolicy,
 ) EnvelopeSvc {
 	return &EnvelopeSvcImpl{
 		expiredEnvelopePublisher: expiredEnvelopePublisher,
@@ -68,11 +76,17 @@ func NewEnvelopeUseCase(
 		txRepo:                   txRepo,
 		envelopeRepo:             envelopeRepo,
 		walletRepo:               walletRepo,
+		limitUc:                  limitUc,
+		riskUc:                   riskUc,
+		imCaller:                 imCaller,
+		expiry:                   expiry,
 	}
 }
 
-func (uc *EnvelopeSvcImpl) GetAllEnvelopesByUserID(userID int64) (resp []*d.EnvelopeDomain, err error) {
-	envelopes, err := uc.envelopeRepo.GetAllEnvelopesByUserID(userID)
+func (uc *EnvelopeSvcImpl) GetAllEnvelopesByUserID(
+	ctx context.Context, userID int64,
+) (resp []*d.EnvelopeDomain, err error) {
+	envelopes, err := uc.envelopeRepo.GetAllEnvelopesByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +152,7 @@ func (uc *EnvelopeSvcImpl) CreateWalletTransaction(ctx context.Context, act *d.T
 		TransactionType: act.TransactionType,
 		Entrytype:       act.EntryType,
 		Amount:          act.Amount,
+		Currency:        env.Currency.String(),
 		DescriptionEn:   fmt.Sprintf(format.En, act.UserID, env.EnvelopeID, act.Amount),
 		DescriptionZh:   fmt.Sprintf(format.Zh, act.UserID, env.EnvelopeID, act.Amount),
 		ReferenceCode:   fmt.Sprintf("#%s-%s-%s-%d", format.RefPrefix, nowToStringYYYYMMDD(), act.UserID, env.EnvelopeID),
@@ -154,7 +169,7 @@ func (uc *EnvelopeSvcImpl) CreateWalletTransaction(ctx context.Context, act *d.T
 		attribute.Int64("walletID", act.WalletID),
 		attribute.String("transactionType", act.TransactionType),
 		attribute.String("entryType", act.EntryType),
-		attribute.Float64("amount", act.Amount),
+		attribute.String("amount", act.Amount.String()),
 	)
 
 	return nil
@@ -178,7 +193,19 @@ func (uc *EnvelopeSvcImpl) validateCreateEnvelope(ctx context.Context, walletUse
 	if walletUser.UserID != req.UserID {
 		return eerrs.ErrUnauthorizedUserID
 	}
-	if walletUser.Balance < req.TotalAmount {
+	if err = validateWalletDebit(walletUser); err != nil {
+		return err
+	}
+	var limits e.Limits
+	limits, err = uc.limitUc.GetUserLimits(ctx, req.UserID)
+	if err != nil {
+		log.ZError(ctx, "while get user limits", err, "userID", req.UserID)
+		return err
+	}
+	if req.TotalAmount < d.EnvelopeMinimumAmount || req.TotalAmount > limits.MaxSendEnvelopeAmount {
+		return eerrs.ErrAmountRange(d.EnvelopeMinimumAmount, limits.MaxSendEnvelopeAmount)
+	}
+	if walletUser.AvailableBalance() < req.TotalAmount {
 		return eerrs.ErrAmountExceedsWalletBalance
 	}
 	var countToday int64
@@ -187,9 +214,63 @@ func (uc *EnvelopeSvcImpl) validateCreateEnvelope(ctx context.Context, walletUse
 		log.ZError(ctx, "while get countSentEnvelope", err, "userID", req.UserID)
 		return err
 	}
-	if countToday >= e.MaxEnvelopeSendPerDay {
+	if countToday >= limits.EnvelopeSendPerDay {
 		return eerrs.ErrSendingDailyLimit
 	}
+	return uc.validateEnvelopeGroup(ctx, req)
+}
+
+// validateEnvelopeGroup checks a group envelope against its IM group: the sender has to be a
+// member and the group needs at least TotalClaimer members to claim every share.
+func (uc *EnvelopeSvcImpl) validateEnvelopeGroup(ctx context.Context, req *d.EnvelopeCreateRequest) (err error) {
+	if req.GroupID == "" {
+		return nil
+	}
+
+	var members int64
+	members, err = uc.imCaller.GetGroupMemberCount(ctx, req.GroupID)
+	if errors.Is(err, imapi.ErrGroupNotFound) {
+		return eerrs.ErrEnvelopeGroupNotFound
+	}
+	if err != nil {
+		log.ZError(ctx, "while get group member count", err, "groupID", req.GroupID)
+		return err
+	}
+	if int64(req.TotalClaimer) > members {
+		return eerrs.ErrTotalClaimerExceedsGroup(members)
+	}
+	if err = uc.checkGroupMember(ctx, req.GroupID, req.UserID); err != nil {
+		return err
+	}
+	if len(req.ToUserIDs) == 0 {
+		return nil
+	}
+
+	// a recipient outside the group could never claim the share reserved for them
+	var memberIDs []string
+	memberIDs, err = uc.imCaller.FilterGroupMembers(ctx, req.GroupID, req.ToUserIDs)
+	if err != nil {
+		log.ZError(ctx, "while filter group members", err, "groupID", req.GroupID)
+		return err
+	}
+	if len(memberIDs) != len(req.ToUserIDs) {
+		return eerrs.ErrNotEnvelopeGroupMember
+	}
+
+	return nil
+}
+
+func (uc *EnvelopeSvcImpl) checkGroupMember(ctx context.Context, groupID, userID string) (err error) {
+	var ok bool
+	ok, err = uc.imCaller.IsGroupMember(ctx, groupID, userID)
+	if err != nil {
+		log.ZError(ctx, "while check group member", err, "groupID", groupID, "userID", userID)
+		return err
+	}
+	if !ok {
+		return eerrs.ErrNotEnvelopeGroupMember
+	}
+
 	return nil
 }
 
@@ -218,8 +299,16 @@ func (uc *EnvelopeSvcImpl) CreateEnvelope(
 		userID       = req.UserID
 		walletID     = req.WalletID
 	)
+	if err = req.ValidateRecipients(); err != nil {
+		return nil, err
+	}
+	var expiry time.Duration
+	expiry, err = resolveExpiry(uc.expiry, req.ExpiresInMinutes)
+	if err != nil {
+		return nil, err
+	}
 	var walletDB *e.Wallet
-	walletDB, err = uc.walletRepo.FindByWalletID(walletID)
+	walletDB, err = uc.walletRepo.FindByWalletID(ctx, walletID)
 	if err != nil {
 		log.ZError(ctx, "while get findByWalletID", err, "walletID", walletID)
 		return nil, err
@@ -229,22 +318,23 @@ func (uc *EnvelopeSvcImpl) CreateEnvelope(
 		log.ZError(ctx, "while get validateCreateEnvelope", err, "userID", req.UserID)
 		return nil, err
 	}
-	dayDuration := 24 * time.Hour
 	newEnvelope := &e.Envelope{
 		UserID:              userID,
 		WalletID:            walletID,
 		TotalAmount:         amount,
+		Currency:            walletDB.Currency,
 		TotalAmountClaimed:  0,
 		TotalAmountRefunded: 0,
 		Remarks:             remarks,
 		MaxNumReceived:      totalClaimer,
 		EnvelopeType:        types,
-		ExpiredAt:           d.GenerateExpiredAt(dayDuration),
+		GroupID:             req.GroupID,
+		ExpiredAt:           d.GenerateExpiredAt(expiry),
 		IsActive:            true,
 		CreatedAt:           time.Now(),
 		CreatedBy:           userID,
 	}
-	err = uc.createEnvelopeTx(ctx, newEnvelope, req.ToUserID)
+	err = uc.createEnvelopeTx(ctx, newEnvelope, req)
 	if err != nil {
 		log.ZError(ctx, "while get createEnvelopeTx", err, "userID", req.UserID)
 		return nil, err
@@ -253,10 +343,11 @@ func (uc *EnvelopeSvcImpl) CreateEnvelope(
 	span.SetAttributes(
 		attribute.String("userID", userID),
 		attribute.Int64("walletID", walletID),
-		attribute.Float64("totalAmount", amount),
+		attribute.String("totalAmount", amount.String()),
 		attribute.Int("totalClaimer", totalClaimer),
 		attribute.String("type", types),
 		attribute.String("toUserId", req.ToUserID),
+		attribute.String("groupID", req.GroupID),
 	)
 
 	result := &d.EnvelopeCreateResponse{
@@ -264,10 +355,13 @@ func (uc *EnvelopeSvcImpl) CreateEnvelope(
 		UserID:              newEnvelope.UserID,
 		WalletID:            newEnvelope.WalletID,
 		TotalAmount:         newEnvelope.TotalAmount,
+		Currency:            newEnvelope.Currency.String(),
 		TotalAmountClaimed:  newEnvelope.TotalAmountClaimed,
 		TotalAmountRefunded: newEnvelope.TotalAmountRefunded,
 		MaxNumReceived:      newEnvelope.MaxNumReceived,
 		EnvelopeType:        newEnvelope.EnvelopeType,
+		GroupID:             newEnvelope.GroupID,
+		ToUserIDs:           req.ToUserIDs,
 		Remarks:             newEnvelope.Remarks,
 		ExpiredAt:           newEnvelope.ExpiredAt,
 		RefundedAt:          newEnvelope.RefundedAt,
@@ -288,7 +382,9 @@ func (uc *EnvelopeSvcImpl) CreateEnvelope(
 	return result, nil
 }
 
-func (uc *EnvelopeSvcImpl) createEnvelopeTx(ctx context.Context, envelopeTx *e.Envelope, toUserID string) (err error) {
+func (uc *EnvelopeSvcImpl) createEnvelopeTx(
+	ctx context.Context, envelopeTx *e.Envelope, req *d.EnvelopeCreateRequest,
+) (err error) {
 	var (
 		funcName = tracer.GetFullFunctionPath()
 		t        = otel.Tracer(tracer.LevelUsecase)
@@ -303,9 +399,9 @@ func (uc *EnvelopeSvcImpl) createEnvelopeTx(ctx context.Context, envelopeTx *e.E
 		span.End()
 	}()
 
-	return uc.envelopeRepo.WithTransaction(ctx, func(txRepo envelope.Repository) error {
+	return uc.envelopeRepo.WithTransaction(ctx, func(ctx context.Context, txRepo envelope.Repository) error {
 		var (
-			amounts []float64
+			amounts []e.Money
 			txType  e.TransactionType
 		)
 		txType, err = e.GetTransactionTypeByEnvelopeType(envelopeTx.EnvelopeType)
@@ -313,20 +409,31 @@ func (uc *EnvelopeSvcImpl) createEnvelopeTx(ctx context.Context, envelopeTx *e.E
 			log.ZError(ctx, "while get validateCreateEnvelope", err, "envelopeID", envelopeTx.EnvelopeID)
 			return err
 		}
-		amounts, err = splitAmounts(envelopeTx)
+		var split e.EnvelopeType
+		split, err = req.ShareSplit()
+		if err != nil {
+			return err
+		}
+		amounts, err = splitAmounts(envelopeTx, split)
 		if err != nil {
 			log.ZError(ctx, "while get splitAmounts", err, "envelopeID", envelopeTx.EnvelopeID)
 			return err
 		}
+		err = uc.limitUc.CheckSpendLimits(ctx, envelopeTx.UserID, envelopeTx.WalletID, envelopeTx.TotalAmount)
+		if err != nil {
+			log.ZError(ctx, "while check spend limits", err, "userID", envelopeTx.UserID)
+			return err
+		}
 		err = txRepo.CreateEnvelope(ctx, envelopeTx)
 		if err != nil {
 			log.ZError(ctx, "while get createEnvelope", err, "envelopeID", envelopeTx.EnvelopeID)
 			return err
 		}
 		envOpts := d.EnvelopeDetailOption{
-			Envelope:   envelopeTx,
-			Amounts:    amounts,
-			ReceiverID: toUserID,
+			Envelope:    envelopeTx,
+			Amounts:     amounts,
+			ReceiverID:  req.ToUserID,
+			ReceiverIDs: req.ToUserIDs,
 		}
 		err = createEnvelopeDetails(ctx, txRepo, envOpts)
 		if err != nil {
@@ -346,11 +453,25 @@ func (uc *EnvelopeSvcImpl) createEnvelopeTx(ctx context.Context, envelopeTx *e.E
 			log.ZError(ctx, "while get createWalletTransaction", err, "envelopeID", envelopeTx.EnvelopeID)
 			return err
 		}
+		_, err = uc.riskUc.Evaluate(ctx, &d.RiskEvent{
+			Operation:      e.RiskOperationEnvelopeCreate,
+			ReferenceID:    envelopeTx.EnvelopeID,
+			UserID:         envelopeTx.UserID,
+			CounterpartyID: req.ToUserID,
+			WalletID:       envelopeTx.WalletID,
+			Amount:         envelopeTx.TotalAmount,
+			Currency:       envelopeTx.Currency,
+			DeviceID:       req.DeviceID,
+		})
+		if err != nil {
+			log.ZError(ctx, "while evaluate risk", err, "envelopeID", envelopeTx.EnvelopeID)
+			return err
+		}
 
 		span.SetAttributes(
 			attribute.Int64("envelopeId", envelopeTx.EnvelopeID),
-			attribute.Float64("totalAmount", envelopeTx.TotalAmount),
-			attribute.String("toUserId", toUserID),
+			attribute.String("totalAmount", envelopeTx.TotalAmount.String()),
+			attribute.String("toUserId", req.ToUserID),
 		)
 
 		return nil
@@ -375,7 +496,7 @@ func createEnvelopeDetails(ctx context.Context, txRepo envelope.Repository, opts
 
 	var details []*e.EnvelopeDetail
 	now := time.Now()
-	for _, amount := range opts.Amounts {
+	for idx, amount := range opts.Amounts {
 		detail := &e.EnvelopeDetail{
 			EnvelopeID:           opts.Envelope.EnvelopeID,
 			Amount:               amount,
@@ -384,8 +505,12 @@ func createEnvelopeDetails(ctx context.Context, txRepo envelope.Repository, opts
 			CreatedAt:            now,
 			CreatedBy:            opts.Envelope.CreatedBy,
 		}
-		if e.EnvelopeType(opts.Envelope.EnvelopeType) == e.EnvelopeTypeSingle {
+		switch e.EnvelopeType(opts.Envelope.EnvelopeType) {
+		case e.EnvelopeTypeSingle:
 			detail.UserID = opts.ReceiverID
+		case e.EnvelopeTypeExclusive:
+			// reserved for its recipient, only they can claim it
+			detail.UserID = opts.ReceiverIDs[idx]
 		}
 		details = append(details, detail)
 	}
@@ -396,62 +521,57 @@ func createEnvelopeDetails(ctx context.Context, txRepo envelope.Repository, opts
 	return txRepo.CreateEnvelopeDetails(ctx, details)
 }
 
-func splitAmounts(env *e.Envelope) (amounts []float64, err error) {
-	switch env.EnvelopeType {
-	case string(e.EnvelopeTypeFixed):
+// splitAmounts splits the envelope into MaxNumReceived shares the way split says.
+func splitAmounts(env *e.Envelope, split e.EnvelopeType) (amounts []e.Money, err error) {
+	switch split {
+	case e.EnvelopeTypeFixed, e.EnvelopeTypeLucky:
+		// every share must hold at least the minimum amount
+		if env.MaxNumReceived < 1 || env.TotalAmount < d.EnvelopeMinimumAmount.MulInt(int64(env.MaxNumReceived)) {
+			return nil, eerrs.ErrAmountTooSmallToSplit
+		}
+	}
+
+	switch split {
+	case e.EnvelopeTypeFixed:
 		return equalSplit(env.TotalAmount, env.MaxNumReceived), nil
-	case string(e.EnvelopeTypeLucky):
+	case e.EnvelopeTypeLucky:
 		return luckySplit(env.TotalAmount, env.MaxNumReceived), nil
-	case string(e.EnvelopeTypeSingle):
-		return []float64{env.TotalAmount}, nil
+	case e.EnvelopeTypeSingle:
+		return []e.Money{env.TotalAmount}, nil
 	default:
 		return nil, eerrs.ErrUnsupportedEnvelopeType
 	}
 }
 
-func luckySplit(total float64, count int) (amounts []float64) {
-	shares := make([]float64, 0, count)
-	remain := total
-	var (
-		sum                 float64
-		minimumShareInCents float64 = 1
-		centsMultiplier     float64 = 100
-	)
-
-	minimumShare := minimumShareInCents / centsMultiplier
+// luckySplit splits total into count random shares using the double average method.
+// All math is done in minor units so the shares always sum exactly to total, and every
+// share keeps at least the envelope minimum. The caller must ensure total >= count minimums.
+func luckySplit(total e.Money, count int) (amounts []e.Money) {
+	shares := make([]e.Money, 0, count)
+	remain := total.Minor()
+	floor := d.EnvelopeMinimumAmount.Minor()
 
 	for i := range count - 1 {
-		_max := (remain / float64(count-i)) * 2
-		//nolint:gosec // weak random is fine here since it's not for security
-		part := rand.Float64()*(_max-minimumShare) + minimumShare
-		if part > remain {
-			part = remain
-		}
-		part = math.Floor(part*centsMultiplier) / centsMultiplier
-		shares = append(shares, part)
-		sum += part
+		left := int64(count - i)
+		// leave the minimum for each share that is still to come
+		upper := min(remain/left*2, remain-(left-1)*floor)
+		part := floor
+		if upper > floor {
+			//nolint:gosec // weak random is fine here since it's not for security
+			part += rand.Int63n(upper - floor)
+		}
+		shares = append(shares, e.NewMoneyFromMinor(part))
 		remain -= part
 	}
 
-	last := total - sum
-	last = math.Round(last*centsMultiplier) / centsMultiplier
-	shares = append(shares, last)
+	shares = append(shares, e.NewMoneyFromMinor(remain))
 
 	return shares
 }
 
-func equalSplit(total float64, count int) (amounts []float64) {
-	var centsMultiplier float64 = 100
-	shares := make([]float64, count)
-	share := math.Floor((total/float64(count))*centsMultiplier) / centsMultiplier
-	remaining := total
-
-	for i := range count - 1 {
-		shares[i] = share
-		remaining -= share
-	}
-	shares[count-1] = math.Round(remaining*centsMultiplier) / centsMultiplier
-	return shares
+// equalSplit splits total into count shares that differ by at most one minor unit.
+func equalSplit(total e.Money, count int) (amounts []e.Money) {
+	return total.Split(count)
 }
 
 func (uc *EnvelopeSvcImpl) validateClaimEnvelope(
@@ -475,17 +595,29 @@ func (uc *EnvelopeSvcImpl) validateClaimEnvelope(
 	if walletUser.UserID != userID {
 		return eerrs.ErrUnauthorizedUserID
 	}
+	if walletUser.Currency != env.Currency {
+		return eerrs.ErrCurrencyMismatch
+	}
+	if err = validateWalletCredit(walletUser); err != nil {
+		return err
+	}
 	now := time.Now()
 	if env.ExpiredAt != nil && now.After(*env.ExpiredAt) {
 		return eerrs.ErrExpiredEnvelope
 	}
+	var limits e.Limits
+	limits, err = uc.limitUc.GetUserLimits(ctx, userID)
+	if err != nil {
+		log.ZError(ctx, "while get user limits", err, "userID", userID)
+		return err
+	}
 	var claimCountToday int64
 	claimCountToday, err = uc.envelopeRepo.CountClaimedEnvelope(ctx, req.UserID, time.Now())
 	if err != nil {
 		log.ZError(ctx, "while get countClaimedEnvelope", err, "userID", userID)
 		return err
 	}
-	if claimCountToday > e.MaxEnvelopeClaimPerDay {
+	if claimCountToday > limits.EnvelopeClaimPerDay {
 		return eerrs.ErrClaimingDailyLimit
 	}
 	var status *e.ClaimStatus
@@ -532,7 +664,20 @@ func (uc *EnvelopeSvcImpl) Claim(
 		span.End()
 	}()
 
-	errTrx := uc.envelopeRepo.WithTransaction(ctx, func(txRepo envelope.Repository) error {
+	// the IM server is asked before the envelope is locked, the group of an envelope never changes
+	var env *e.Envelope
+	env, err = uc.envelopeRepo.GetEnvelope(ctx, envelopeID)
+	if err != nil {
+		log.ZError(ctx, "while get envelope", err, "userID", userID, "envelopeID", envelopeID)
+		return nil, err
+	}
+	if env.GroupID != "" {
+		if err = uc.checkGroupMember(ctx, env.GroupID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	errTrx := uc.envelopeRepo.WithTransaction(ctx, func(ctx context.Context, txRepo envelope.Repository) error {
 		lockedEnv, errs := txRepo.LockEnvelopeByID(ctx, envelopeID)
 		if errs != nil {
 			log.ZError(ctx, "while get lockEnvelopeByID", errs, "userID", userID, "envelopeID", envelopeID)
@@ -541,7 +686,7 @@ func (uc *EnvelopeSvcImpl) Claim(
 		if !lockedEnv.IsActive {
 			return eerrs.ErrEnvelopeNotActive
 		}
-		walletDB, errs := uc.walletRepo.FindByWalletID(walletID)
+		walletDB, errs := uc.walletRepo.FindByWalletID(ctx, walletID)
 		if errs != nil {
 			log.ZError(ctx, "while get findByWalletID", errs, "userID", userID, "walletID", walletID, "envelopeID", envelopeID)
 			return errs
@@ -552,19 +697,11 @@ func (uc *EnvelopeSvcImpl) Claim(
 			return errs
 		}
 
-		detail, errs := txRepo.ClaimNextLuckyShare(ctx, envelopeID)
+		detail, errs := nextShare(ctx, txRepo, lockedEnv, userID)
 		if errs != nil {
-			log.ZError(ctx, "while get claimNextLuckyShare", errs, "userID", userID, "envelopeID", envelopeID)
+			log.ZError(ctx, "while get nextShare", errs, "userID", userID, "envelopeID", envelopeID)
 			return errs
 		}
-		if e.EnvelopeType(lockedEnv.EnvelopeType) == e.EnvelopeTypeSingle {
-			if detail.UserID != userID {
-				return eerrs.ErrUnauthorizedClaimer
-			}
-		}
-		if detail == nil {
-			return eerrs.ErrNoMoreSharesToClaim
-		}
 
 		now := time.Now()
 		detail.UserID = userID
@@ -606,6 +743,21 @@ func (uc *EnvelopeSvcImpl) Claim(
 			return errs
 		}
 
+		_, errs = uc.riskUc.Evaluate(ctx, &d.RiskEvent{
+			Operation:      e.RiskOperationEnvelopeClaim,
+			ReferenceID:    envelopeID,
+			UserID:         userID,
+			CounterpartyID: lockedEnv.UserID,
+			WalletID:       walletID,
+			Amount:         detail.Amount,
+			Currency:       lockedEnv.Currency,
+			DeviceID:       req.DeviceID,
+		})
+		if errs != nil {
+			log.ZError(ctx, "while evaluate risk", errs, "userID", userID, "envelopeID", envelopeID)
+			return errs
+		}
+
 		claimedDetail = &d.EnvelopeClaimResponse{
 			EnvelopeDetailID:     detail.EnvelopeDetailID,
 			EnvelopeID:           detail.EnvelopeID,
@@ -629,7 +781,7 @@ func (uc *EnvelopeSvcImpl) Claim(
 			attribute.Int64("walletId", walletID),
 			attribute.String("envelopeType", lockedEnv.EnvelopeType),
 			attribute.String("transactionType", string(txType)),
-			attribute.Float64("claimAmount", detail.Amount),
+			attribute.String("claimAmount", detail.Amount.String()),
 			attribute.String("claimStatus", detail.EnvelopeDetailStatus.String()),
 		)
 
@@ -644,6 +796,35 @@ func (uc *EnvelopeSvcImpl) Claim(
 	return claimedDetail, nil
 }
 
+// nextShare locks the share userID claims: the one reserved for them on an exclusive envelope,
+// the next pending one otherwise.
+func nextShare(ctx context.Context, txRepo envelope.Repository, env *e.Envelope, userID string) (*e.EnvelopeDetail, error) {
+	if e.EnvelopeType(env.EnvelopeType) == e.EnvelopeTypeExclusive {
+		detail, err := txRepo.ClaimReservedShare(ctx, env.EnvelopeID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if detail == nil {
+			return nil, eerrs.ErrUnauthorizedClaimer
+		}
+
+		return detail, nil
+	}
+
+	detail, err := txRepo.ClaimNextLuckyShare(ctx, env.EnvelopeID)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil {
+		return nil, eerrs.ErrNoMoreSharesToClaim
+	}
+	if e.EnvelopeType(env.EnvelopeType) == e.EnvelopeTypeSingle && detail.UserID != userID {
+		return nil, eerrs.ErrUnauthorizedClaimer
+	}
+
+	return detail, nil
+}
+
 func (uc *EnvelopeSvcImpl) AutoRefund(ctx context.Context) (err error) {
 	var (
 		funcName = tracer.GetFullFunctionPath()
@@ -736,7 +917,7 @@ func (uc *EnvelopeSvcImpl) Refund(ctx context.Context, env *e.Envelope) (resp *e
 		return nil, eerrs.ErrNoRemainingAmountToRefund
 	}
 
-	errTx := uc.envelopeRepo.WithTransaction(ctx, func(txRepo envelope.Repository) error {
+	errTx := uc.envelopeRepo.WithTransaction(ctx, func(ctx context.Context, txRepo envelope.Repository) error {
 		if err = txRepo.RefundEnvelope(ctx, env.EnvelopeID, remaining); err != nil {
 			log.ZError(ctx, "while get refundEnvelope", err, "userID", env.UserID, "envelopeID", env.EnvelopeID)
 			return err
@@ -765,9 +946,9 @@ func (uc *EnvelopeSvcImpl) Refund(ctx context.Context, env *e.Envelope) (resp *e
 		attribute.Int64("envelopeId", env.EnvelopeID),
 		attribute.String("userId", env.UserID),
 		attribute.Int64("walletId", env.WalletID),
-		attribute.Float64("totalAmount", env.TotalAmount),
-		attribute.Float64("claimedAmount", env.TotalAmountClaimed),
-		attribute.Float64("remainingAmount", remaining),
+		attribute.String("totalAmount", env.TotalAmount.String()),
+		attribute.String("claimedAmount", env.TotalAmountClaimed.String()),
+		attribute.String("remainingAmount", remaining.String()),
 	)
 
 	if errTx != nil {
@@ -819,7 +1000,7 @@ func (uc *EnvelopeSvcImpl) ProcessExpiredEnvelopes(
 		span.SetAttributes(attribute.String("userID", env.UserID))
 
 		var walletUser *e.Wallet
-		walletUser, err = uc.walletRepo.GetWalletByUserID(ctx, env.UserID)
+		walletUser, err = uc.walletRepo.GetWalletByUserID(ctx, env.UserID, env.Currency)
 		if err != nil {
 			if !errors.Is(err, gorm.ErrRecordNotFound) {
 				log.ZWarn(ctx, "while GetWalletByUserID", err,
@@ -849,7 +1030,7 @@ func (uc *EnvelopeSvcImpl) ProcessExpiredEnvelopes(
 			continue
 		}
 
-		txErr := uc.txRepo.Do(ctx, func(tx *gorm.DB) error {
+		txErr := uc.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
 			var errx error
 			refundedAt := time.Now()
 			env.RefundedAt = &refundedAt
@@ -883,6 +1064,7 @@ func (uc *EnvelopeSvcImpl) ProcessExpiredEnvelopes(
 				TransactionType: string(e.TransactionTypeRefundTransfer),
 				Entrytype:       string(e.EntryTypeDebit),
 				Amount:          env.TotalAmount - env.TotalAmountClaimed,
+				Currency:        env.Currency.String(),
 				DescriptionEn:   fmt.Sprintf(d.TransactionRefundEnvelopeEN, env.EnvelopeID),
 				DescriptionZh:   fmt.Sprintf(d.TransactionRefundEnvelopeZN, env.EnvelopeID),
 				ReferenceCode:   fmt.Sprintf(d.FormatReferenceCodeRefundEnvelope, env.EnvelopeID, walletUser.WalletID),
@@ -897,7 +1079,7 @@ func (uc *EnvelopeSvcImpl) ProcessExpiredEnvelopes(
 				return errx
 			}
 
-			span.SetAttributes(attribute.Float64("amount", env.TotalAmount-env.TotalAmountClaimed))
+			span.SetAttributes(attribute.String("amount", (env.TotalAmount - env.TotalAmountClaimed).String()))
 
 			return nil
 		})
@@ -944,7 +1126,7 @@ func (uc *EnvelopeSvcImpl) processRefundEnvelopeDetail(
 		}
 		span.SetAttributes(
 			attribute.Int64("envelopeDetailID", detail.EnvelopeDetailID),
-			attribute.Float64("envelopeDetailID", detail.Amount),
+			attribute.String("envelopeDetailID", detail.Amount.String()),
 		)
 	}
 	return nil
@@ -0,0 +1,427 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_envelope"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/imapi"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func sumMoney(amounts []entity.Money) (total entity.Money) {
+	for _, amount := range amounts {
+		total += amount
+	}
+
+	return total
+}
+
+func TestEnvelope_SplitAmounts(t *testing.T) {
+	type expected struct {
+		shares int
+		err    error
+	}
+	testCases := []struct {
+		desc      string
+		env       *entity.Envelope
+		expected  expected
+		wantError bool
+	}{
+		{
+			desc: "fixed_split_with_remainder",
+			env: &entity.Envelope{
+				EnvelopeType:   string(entity.EnvelopeTypeFixed),
+				TotalAmount:    entity.NewMoneyFromMinor(1000),
+				MaxNumReceived: 3,
+			},
+			expected: expected{shares: 3},
+		},
+		{
+			desc: "lucky_split_one_minor_unit_each",
+			env: &entity.Envelope{
+				EnvelopeType:   string(entity.EnvelopeTypeLucky),
+				TotalAmount:    entity.NewMoneyFromMinor(5),
+				MaxNumReceived: 5,
+			},
+			expected: expected{shares: 5},
+		},
+		{
+			desc: "single_keeps_total",
+			env: &entity.Envelope{
+				EnvelopeType:   string(entity.EnvelopeTypeSingle),
+				TotalAmount:    entity.NewMoney(10),
+				MaxNumReceived: 1,
+			},
+			expected: expected{shares: 1},
+		},
+		{
+			desc: "ErrAmountTooSmallToSplit",
+			env: &entity.Envelope{
+				EnvelopeType:   string(entity.EnvelopeTypeLucky),
+				TotalAmount:    entity.NewMoneyFromMinor(4),
+				MaxNumReceived: 5,
+			},
+			expected:  expected{err: eerrs.ErrAmountTooSmallToSplit},
+			wantError: true,
+		},
+		{
+			desc: "ErrUnsupportedEnvelopeType",
+			env: &entity.Envelope{
+				EnvelopeType:   "unknown",
+				TotalAmount:    entity.NewMoney(10),
+				MaxNumReceived: 1,
+			},
+			expected:  expected{err: eerrs.ErrUnsupportedEnvelopeType},
+			wantError: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			amounts, err := splitAmounts(tC.env, entity.EnvelopeType(tC.env.EnvelopeType))
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Len(t, amounts, tC.expected.shares)
+				assert.Equal(t, tC.env.TotalAmount, sumMoney(amounts))
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
+
+// TestEnvelope_SplitProperties checks for random totals and claimer counts that every
+// split sums exactly to the envelope total and never produces a share below the minimum.
+func TestEnvelope_SplitProperties(t *testing.T) {
+	cfg := &quick.Config{
+		MaxCount: 2000,
+		Rand:     rand.New(rand.NewSource(1)), //nolint:gosec // deterministic seed for tests
+	}
+
+	property := func(envelopeType entity.EnvelopeType) func(totalMinor uint32, claimers uint16) bool {
+		return func(totalMinor uint32, claimers uint16) bool {
+			count := int(claimers%500) + 1
+			total := entity.NewMoneyFromMinor(int64(totalMinor%(1000*100)) + int64(count))
+
+			amounts, err := splitAmounts(&entity.Envelope{
+				EnvelopeType:   string(envelopeType),
+				TotalAmount:    total,
+				MaxNumReceived: count,
+			}, envelopeType)
+			if err != nil || len(amounts) != count || sumMoney(amounts) != total {
+				return false
+			}
+
+			lowest, highest := amounts[0], amounts[0]
+			for _, amount := range amounts {
+				if amount < domain.EnvelopeMinimumAmount {
+					return false
+				}
+				lowest, highest = min(lowest, amount), max(highest, amount)
+			}
+
+			// a fixed envelope gives everyone the same amount, give or take the last cent
+			return envelopeType != entity.EnvelopeTypeFixed || highest-lowest <= 1
+		}
+	}
+
+	require.NoError(t, quick.Check(property(entity.EnvelopeTypeLucky), cfg))
+	require.NoError(t, quick.Check(property(entity.EnvelopeTypeFixed), cfg))
+}
+
+// stubIMCaller is an IM server that knows the groups in members, keyed by groupID. The texts it
+// is sent land in sent, keyed by recvID.
+type stubIMCaller struct {
+	members map[string][]string
+	sent    map[string][]string
+	err     error
+}
+
+func (s *stubIMCaller) GetGroupMemberCount(_ context.Context, groupID string) (int64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	members, ok := s.members[groupID]
+	if !ok {
+		return 0, imapi.ErrGroupNotFound
+	}
+
+	return int64(len(members)), nil
+}
+
+func (s *stubIMCaller) IsGroupMember(_ context.Context, groupID, userID string) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	for _, member := range s.members[groupID] {
+		if member == userID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *stubIMCaller) FilterGroupMembers(ctx context.Context, groupID string, userIDs []string) ([]string, error) {
+	var memberIDs []string
+	for _, userID := range userIDs {
+		ok, err := s.IsGroupMember(ctx, groupID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			memberIDs = append(memberIDs, userID)
+		}
+	}
+
+	return memberIDs, nil
+}
+
+func (s *stubIMCaller) SendTextMessage(_ context.Context, recvID, text string) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.sent == nil {
+		s.sent = map[string][]string{}
+	}
+	s.sent[recvID] = append(s.sent[recvID], text)
+
+	return nil
+}
+
+func TestEnvelope_ValidateEnvelopeGroup(t *testing.T) {
+	im := &stubIMCaller{members: map[string][]string{"g1": {"1", "2", "3"}}}
+	testCases := []struct {
+		desc      string
+		request   *domain.EnvelopeCreateRequest
+		im        imapi.CallerInterface
+		wantError error
+	}{
+		{
+			desc:    "outside_group",
+			request: &domain.EnvelopeCreateRequest{UserID: "9", TotalClaimer: 10},
+		},
+		{
+			desc:      "group_not_found",
+			request:   &domain.EnvelopeCreateRequest{UserID: "1", GroupID: "g2", TotalClaimer: 1},
+			im:        im,
+			wantError: eerrs.ErrEnvelopeGroupNotFound,
+		},
+		{
+			desc:      "more_claimers_than_members",
+			request:   &domain.EnvelopeCreateRequest{UserID: "1", GroupID: "g1", TotalClaimer: 4},
+			im:        im,
+			wantError: eerrs.ErrTotalClaimerExceedsGroup(3),
+		},
+		{
+			desc:      "sender_not_member",
+			request:   &domain.EnvelopeCreateRequest{UserID: "9", GroupID: "g1", TotalClaimer: 3},
+			im:        im,
+			wantError: eerrs.ErrNotEnvelopeGroupMember,
+		},
+		{
+			desc:      "im_unavailable",
+			request:   &domain.EnvelopeCreateRequest{UserID: "1", GroupID: "g1", TotalClaimer: 3},
+			im:        &stubIMCaller{err: errors.New("im api /group/get_groups_info: status 502")},
+			wantError: errors.New("im api /group/get_groups_info: status 502"),
+		},
+		{
+			desc: "exclusive_recipient_outside_group",
+			request: &domain.EnvelopeCreateRequest{
+				UserID: "1", GroupID: "g1", TotalClaimer: 2, ToUserIDs: []string{"2", "9"},
+			},
+			im:        im,
+			wantError: eerrs.ErrNotEnvelopeGroupMember,
+		},
+		{
+			desc:    "every_member_can_claim",
+			request: &domain.EnvelopeCreateRequest{UserID: "1", GroupID: "g1", TotalClaimer: 3},
+			im:      im,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			uc := &EnvelopeSvcImpl{imCaller: tC.im}
+
+			err := uc.validateEnvelopeGroup(context.Background(), tC.request)
+			if tC.wantError != nil {
+				assert.EqualError(t, err, tC.wantError.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEnvelope_ClaimOutsideGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	envelopeRepo := mock_envelope.NewMockRepository(ctrl)
+	envelopeRepo.EXPECT().
+		GetEnvelope(gomock.Any(), int64(7)).
+		Return(&entity.Envelope{EnvelopeID: 7, UserID: "1", GroupID: "g1", IsActive: true}, nil)
+
+	uc := NewEnvelopeUseCase(
+		nil, nil, nil, nil, envelopeRepo, nil, nil, nil,
+		&stubIMCaller{members: map[string][]string{"g1": {"1", "2"}}},
+		entity.DefaultEnvelopeExpiry,
+	)
+
+	// the envelope is never locked for a claimer outside its group
+	_, err := uc.Claim(context.Background(), &domain.EnvelopeClaimRequest{UserID: "3", EnvelopeID: 7, WalletID: 30})
+	assert.Equal(t, eerrs.ErrNotEnvelopeGroupMember, err)
+}
+
+func TestEnvelope_ValidateRecipients(t *testing.T) {
+	exclusive := func(totalClaimer int, toUserIDs ...string) *domain.EnvelopeCreateRequest {
+		return &domain.EnvelopeCreateRequest{
+			UserID: "1", EnvelopeType: "exclusive", TotalClaimer: totalClaimer, ToUserIDs: toUserIDs,
+		}
+	}
+	errRecipients := eerrs.ErrExclusiveRecipients(domain.MaxExclusiveRecipients)
+	testCases := []struct {
+		desc      string
+		request   *domain.EnvelopeCreateRequest
+		wantError error
+	}{
+		{desc: "lucky", request: &domain.EnvelopeCreateRequest{UserID: "1", EnvelopeType: "lucky", TotalClaimer: 3}},
+		{
+			desc:      "recipients_on_lucky",
+			request:   &domain.EnvelopeCreateRequest{UserID: "1", EnvelopeType: "lucky", ToUserIDs: []string{"2"}},
+			wantError: errors.New("toUserIds and splitType are only for exclusive envelopes"),
+		},
+		{desc: "no_recipients", request: exclusive(0), wantError: errRecipients},
+		{desc: "sender_as_recipient", request: exclusive(2, "2", "1"), wantError: errRecipients},
+		{desc: "duplicate_recipient", request: exclusive(2, "2", "2"), wantError: errRecipients},
+		{
+			desc:      "claimer_count_mismatch",
+			request:   exclusive(3, "2", "3"),
+			wantError: errors.New("totalClaimer must equal the number of toUserIds"),
+		},
+		{
+			desc: "invalid_split",
+			request: &domain.EnvelopeCreateRequest{
+				UserID: "1", EnvelopeType: "exclusive", TotalClaimer: 1, ToUserIDs: []string{"2"}, SplitType: "single",
+			},
+			wantError: eerrs.ErrInvalidEnvelopeSplitType,
+		},
+		{desc: "exclusive", request: exclusive(2, "2", "3")},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := tC.request.ValidateRecipients()
+			if tC.wantError != nil {
+				assert.EqualError(t, err, tC.wantError.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEnvelope_CreateExclusiveEnvelopeDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	env := &entity.Envelope{
+		EnvelopeID: 7, UserID: "1", EnvelopeType: "exclusive", TotalAmount: entity.NewMoney(10), MaxNumReceived: 3,
+	}
+	amounts, err := splitAmounts(env, entity.EnvelopeTypeLucky)
+	require.NoError(t, err)
+	require.Len(t, amounts, 3)
+
+	txRepo := mock_envelope.NewMockRepository(ctrl)
+	txRepo.EXPECT().
+		CreateEnvelopeDetails(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, details []*entity.EnvelopeDetail) error {
+			var total entity.Money
+			for idx, detail := range details {
+				assert.Equal(t, []string{"2", "3", "4"}[idx], detail.UserID)
+				assert.Equal(t, entity.EnvelopePending, detail.EnvelopeDetailStatus)
+				total += detail.Amount
+			}
+			assert.Equal(t, env.TotalAmount, total)
+			return nil
+		})
+
+	err = createEnvelopeDetails(context.Background(), txRepo, domain.EnvelopeDetailOption{
+		Envelope: env, Amounts: amounts, ReceiverIDs: []string{"2", "3", "4"},
+	})
+	require.NoError(t, err)
+}
+
+func TestEnvelope_NextShare(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		envelopeType entity.EnvelopeType
+		userID       string
+		wantError    error
+		onMockRepo   func(mock *mock_envelope.MockRepository)
+	}{
+		{
+			desc:         "exclusive_recipient",
+			envelopeType: entity.EnvelopeTypeExclusive,
+			userID:       "2",
+			onMockRepo: func(mock *mock_envelope.MockRepository) {
+				mock.EXPECT().
+					ClaimReservedShare(gomock.Any(), int64(7), "2").
+					Return(&entity.EnvelopeDetail{EnvelopeDetailID: 70, UserID: "2"}, nil)
+			},
+		},
+		{
+			desc:         "exclusive_outsider",
+			envelopeType: entity.EnvelopeTypeExclusive,
+			userID:       "9",
+			wantError:    eerrs.ErrUnauthorizedClaimer,
+			onMockRepo: func(mock *mock_envelope.MockRepository) {
+				mock.EXPECT().ClaimReservedShare(gomock.Any(), int64(7), "9").Return(nil, nil)
+			},
+		},
+		{
+			desc:         "single_other_user",
+			envelopeType: entity.EnvelopeTypeSingle,
+			userID:       "9",
+			wantError:    eerrs.ErrUnauthorizedClaimer,
+			onMockRepo: func(mock *mock_envelope.MockRepository) {
+				mock.EXPECT().
+					ClaimNextLuckyShare(gomock.Any(), int64(7)).
+					Return(&entity.EnvelopeDetail{EnvelopeDetailID: 70, UserID: "2"}, nil)
+			},
+		},
+		{
+			desc:         "lucky_no_share_left",
+			envelopeType: entity.EnvelopeTypeLucky,
+			userID:       "9",
+			wantError:    eerrs.ErrNoMoreSharesToClaim,
+			onMockRepo: func(mock *mock_envelope.MockRepository) {
+				mock.EXPECT().ClaimNextLuckyShare(gomock.Any(), int64(7)).Return(nil, nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			txRepo := mock_envelope.NewMockRepository(ctrl)
+			tC.onMockRepo(txRepo)
+
+			env := &entity.Envelope{EnvelopeID: 7, EnvelopeType: tC.envelopeType.String()}
+			detail, err := nextShare(context.Background(), txRepo, env, tC.userID)
+			if tC.wantError != nil {
+				assert.Equal(t, tC.wantError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(70), detail.EnvelopeDetailID)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/idempotency"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	IdempotencySvcImpl struct {
+		repo idempotency.Repository
+	}
+
+	IdempotencySvc interface {
+		// Begin claims the idempotency key for a request. When the key already completed
+		// with the same request the stored result is returned for replay, a key reused
+		// with a different request or still being processed is rejected.
+		Begin(
+			ctx context.Context, arg *domain.BeginIdempotentRequest,
+		) (resp *domain.BeginIdempotentResponse, err error)
+		Complete(ctx context.Context, idempotencyKeyID int64, responseCode int, responseBody []byte) (err error)
+		// Release forgets a key whose request failed, so the client can retry it.
+		Release(ctx context.Context, idempotencyKeyID int64) (err error)
+	}
+)
+
+func NewIdempotencyUseCase(repo idempotency.Repository) IdempotencySvc {
+	return &IdempotencySvcImpl{
+		repo: repo,
+	}
+}
+
+func (s *IdempotencySvcImpl) Begin(
+	ctx context.Context, arg *domain.BeginIdempotentRequest,
+) (resp *domain.BeginIdempotentResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("userID", arg.UserID),
+		attribute.String("idempotencyKey", arg.IdempotencyKey),
+		attribute.String("endpoint", arg.Endpoint),
+	)
+
+	if arg.IdempotencyKey == "" || len(arg.IdempotencyKey) > entity.MaxIdempotencyKeyLength {
+		return resp, eerrs.ErrInvalidIdempotencyKey
+	}
+
+	now := time.Now()
+	key := &entity.IdempotencyKey{
+		IdempotencyKey:    arg.IdempotencyKey,
+		UserID:            arg.UserID,
+		Endpoint:          arg.Endpoint,
+		RequestHash:       arg.RequestHash,
+		StatusIdempotency: entity.StatusIdempotencyProcessing,
+		ExpiredAt:         now.Add(entity.IdempotencyKeyTTL),
+	}
+
+	var created bool
+	created, err = s.repo.CreateIdempotencyKey(ctx, key)
+	if err != nil {
+		return resp, err
+	}
+	if created {
+		return &domain.BeginIdempotentResponse{IdempotencyKeyID: key.IdempotencyKeyID}, nil
+	}
+
+	var existing *entity.IdempotencyKey
+	existing, err = s.repo.GetIdempotencyKey(ctx, arg.UserID, arg.IdempotencyKey)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// released or expired by a concurrent request between the insert and the lookup
+			return resp, eerrs.ErrIdempotencyKeyInProgress
+		}
+		return resp, err
+	}
+
+	if existing.IsExpired(now) {
+		return s.reclaim(ctx, existing, key)
+	}
+
+	return replay(existing, arg)
+}
+
+// reclaim replaces an expired key so it can be used for a new request.
+func (s *IdempotencySvcImpl) reclaim(
+	ctx context.Context, expired, key *entity.IdempotencyKey,
+) (resp *domain.BeginIdempotentResponse, err error) {
+	err = s.repo.DeleteIdempotencyKey(ctx, expired.IdempotencyKeyID)
+	if err != nil {
+		return resp, err
+	}
+
+	var created bool
+	created, err = s.repo.CreateIdempotencyKey(ctx, key)
+	if err != nil {
+		return resp, err
+	}
+	if !created {
+		return resp, eerrs.ErrIdempotencyKeyInProgress
+	}
+
+	return &domain.BeginIdempotentResponse{IdempotencyKeyID: key.IdempotencyKeyID}, nil
+}
+
+func replay(
+	existing *entity.IdempotencyKey, arg *domain.BeginIdempotentRequest,
+) (resp *domain.BeginIdempotentResponse, err error) {
+	if existing.Endpoint != arg.Endpoint || existing.RequestHash != arg.RequestHash {
+		return resp, eerrs.ErrIdempotencyKeyConflict
+	}
+
+	if existing.StatusIdempotency != entity.StatusIdempotencyCompleted {
+		return resp, eerrs.ErrIdempotencyKeyInProgress
+	}
+
+	return &domain.BeginIdempotentResponse{
+		IdempotencyKeyID: existing.IdempotencyKeyID,
+		Replay:           true,
+		ResponseCode:     existing.ResponseCode,
+		ResponseBody:     existing.ResponseBody,
+	}, nil
+}
+
+func (s *IdempotencySvcImpl) Complete(
+	ctx context.Context, idempotencyKeyID int64, responseCode int, responseBody []byte,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("idempotencyKeyID", idempotencyKeyID))
+
+	err = s.repo.CompleteIdempotencyKey(ctx, idempotencyKeyID, responseCode, responseBody)
+	if err != nil {
+		log.ZError(ctx, "while complete idempotency key", err, "idempotencyKeyID", idempotencyKeyID)
+		return err
+	}
+
+	return nil
+}
+
+func (s *IdempotencySvcImpl) Release(ctx context.Context, idempotencyKeyID int64) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("idempotencyKeyID", idempotencyKeyID))
+
+	err = s.repo.DeleteIdempotencyKey(ctx, idempotencyKeyID)
+	if err != nil {
+		log.ZError(ctx, "while release idempotency key", err, "idempotencyKeyID", idempotencyKeyID)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_idempotency"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func TestIdempotency_Begin(t *testing.T) {
+	request := &domain.BeginIdempotentRequest{
+		UserID:         "1123453",
+		IdempotencyKey: "8f0d9a0e-6a53-4b2c-9f8e-6b1f1d0c2a11",
+		Endpoint:       "POST /transfer/create",
+		RequestHash:    "hash",
+	}
+	stored := func(status entity.StatusIdempotency) *entity.IdempotencyKey {
+		return &entity.IdempotencyKey{
+			IdempotencyKeyID:  7,
+			IdempotencyKey:    request.IdempotencyKey,
+			UserID:            request.UserID,
+			Endpoint:          request.Endpoint,
+			RequestHash:       request.RequestHash,
+			StatusIdempotency: status,
+			ResponseCode:      200,
+			ResponseBody:      []byte(`{"errCode":0}`),
+			ExpiredAt:         time.Now().Add(time.Hour),
+		}
+	}
+
+	type expected struct {
+		resp *domain.BeginIdempotentResponse
+		err  error
+	}
+	testCases := []struct {
+		desc      string
+		arg       *domain.BeginIdempotentRequest
+		expected  expected
+		wantError bool
+		onMock    func(mock *mock_idempotency.MockRepository)
+	}{
+		{
+			desc: "ErrInvalidIdempotencyKey",
+			arg: &domain.BeginIdempotentRequest{
+				UserID:         request.UserID,
+				IdempotencyKey: strings.Repeat("k", entity.MaxIdempotencyKeyLength+1),
+			},
+			expected:  expected{err: eerrs.ErrInvalidIdempotencyKey},
+			wantError: true,
+		},
+		{
+			desc:      "error_while_CreateIdempotencyKey",
+			arg:       request,
+			expected:  expected{err: errors.New("something went wrong")},
+			wantError: true,
+			onMock: func(mock *mock_idempotency.MockRepository) {
+				mock.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Return(false, errors.New("something went wrong"))
+			},
+		},
+		{
+			desc:     "success_new_key",
+			arg:      request,
+			expected: expected{resp: &domain.BeginIdempotentResponse{IdempotencyKeyID: 1}},
+			onMock: func(mock *mock_idempotency.MockRepository) {
+				mock.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, key *entity.IdempotencyKey) (bool, error) {
+						key.IdempotencyKeyID = 1
+						return true, nil
+					})
+			},
+		},
+		{
+			desc: "success_replay_completed",
+			arg:  request,
+			expected: expected{resp: &domain.BeginIdempotentResponse{
+				IdempotencyKeyID: 7,
+				Replay:           true,
+				ResponseCode:     200,
+				ResponseBody:     []byte(`{"errCode":0}`),
+			}},
+			onMock: func(mock *mock_idempotency.MockRepository) {
+				mock.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil)
+				mock.EXPECT().
+					GetIdempotencyKey(gomock.Any(), request.UserID, request.IdempotencyKey).
+					Return(stored(entity.StatusIdempotencyCompleted), nil)
+			},
+		},
+		{
+			desc: "ErrIdempotencyKeyConflict",
+			arg: &domain.BeginIdempotentRequest{
+				UserID:         request.UserID,
+				IdempotencyKey: request.IdempotencyKey,
+				Endpoint:       request.Endpoint,
+				RequestHash:    "other",
+			},
+			expected:  expected{err: eerrs.ErrIdempotencyKeyConflict},
+			wantError: true,
+			onMock: func(mock *mock_idempotency.MockRepository) {
+				mock.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil)
+				mock.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(stored(entity.StatusIdempotencyCompleted), nil)
+			},
+		},
+		{
+			desc:      "ErrIdempotencyKeyInProgress",
+			arg:       request,
+			expected:  expected{err: eerrs.ErrIdempotencyKeyInProgress},
+			wantError: true,
+			onMock: func(mock *mock_idempotency.MockRepository) {
+				mock.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil)
+				mock.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(stored(entity.StatusIdempotencyProcessing), nil)
+			},
+		},
+		{
+			desc:      "ErrIdempotencyKeyInProgress_released_concurrently",
+			arg:       request,
+			expected:  expected{err: eerrs.ErrIdempotencyKeyInProgress},
+			wantError: true,
+			onMock: func(mock *mock_idempotency.MockRepository) {
+				mock.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil)
+				mock.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			desc:     "success_reclaim_expired_key",
+			arg:      request,
+			expected: expected{resp: &domain.BeginIdempotentResponse{IdempotencyKeyID: 8}},
+			onMock: func(mock *mock_idempotency.MockRepository) {
+				expired := stored(entity.StatusIdempotencyCompleted)
+				expired.ExpiredAt = time.Now().Add(-time.Minute)
+				expired.RequestHash = "other"
+
+				gomock.InOrder(
+					mock.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Return(false, nil),
+					mock.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any(), gomock.Any()).Return(expired, nil),
+					mock.EXPECT().DeleteIdempotencyKey(gomock.Any(), expired.IdempotencyKeyID).Return(nil),
+					mock.EXPECT().
+						CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, key *entity.IdempotencyKey) (bool, error) {
+							key.IdempotencyKeyID = 8
+							return true, nil
+						}),
+				)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_idempotency.NewMockRepository(ctrl)
+			if tC.onMock != nil {
+				tC.onMock(repo)
+			}
+
+			svc := NewIdempotencyUseCase(repo)
+
+			got, err := svc.Begin(context.Background(), tC.arg)
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
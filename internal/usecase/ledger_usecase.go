@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/journal"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
+)
+
+type (
+	LedgerSvcImpl struct {
+		journalRepo journal.Repository
+		walletRepo  wallet.Repository
+	}
+
+	LedgerSvc interface {
+		// GetLedgerBalances reports the ledger of a single currency, amounts in different
+		// currencies never net against each other.
+		GetLedgerBalances(ctx context.Context, currencyCode string) (resp *domain.GetLedgerBalancesResponse, err error)
+	}
+)
+
+func NewLedgerUseCase(
+	journalRepo journal.Repository,
+	walletRepo wallet.Repository,
+) LedgerSvc {
+	return &LedgerSvcImpl{
+		journalRepo: journalRepo,
+		walletRepo:  walletRepo,
+	}
+}
+
+func (s *LedgerSvcImpl) GetLedgerBalances(
+	ctx context.Context, currencyCode string,
+) (resp *domain.GetLedgerBalancesResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(currencyCode)
+	if err != nil {
+		return resp, err
+	}
+
+	var totalWalletBalance entity.Money
+	totalWalletBalance, err = s.walletRepo.GetTotalBalance(ctx, currency)
+	if err != nil {
+		log.ZError(ctx, "while get total wallet balance", err)
+		return resp, err
+	}
+
+	var balances []*entity.SystemAccountBalance
+	balances, err = s.journalRepo.GetSystemAccountBalances(ctx, currency)
+	if err != nil {
+		log.ZError(ctx, "while get system account balances", err)
+		return resp, err
+	}
+
+	balanceByAccount := make(map[entity.SystemAccount]entity.Money, len(balances))
+	for _, balance := range balances {
+		balanceByAccount[balance.SystemAccount] += balance.Balance
+	}
+
+	resp = &domain.GetLedgerBalancesResponse{
+		Currency:           currency.String(),
+		TotalWalletBalance: totalWalletBalance,
+		Imbalance:          totalWalletBalance,
+	}
+	for _, account := range entity.SystemAccounts() {
+		balance := balanceByAccount[account]
+		resp.SystemAccounts = append(resp.SystemAccounts, &domain.SystemAccountBalance{
+			SystemAccount: account.String(),
+			Balance:       balance,
+		})
+		resp.Imbalance += balance
+
+		if account.IsEscrow() {
+			resp.OutstandingLiabilities += balance
+		}
+	}
+
+	span.SetAttributes(
+		attribute.String("currency", currency.String()),
+		attribute.String("totalWalletBalance", resp.TotalWalletBalance.String()),
+		attribute.String("outstandingLiabilities", resp.OutstandingLiabilities.String()),
+		attribute.String("imbalance", resp.Imbalance.String()),
+	)
+
+	if !resp.Imbalance.IsZero() {
+		log.ZWarn(ctx, "ledger is out of balance", nil, "imbalance", resp.Imbalance.String())
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_journal"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+)
+
+func TestLedger_GetLedgerBalances(t *testing.T) {
+	type expected struct {
+		resp *domain.GetLedgerBalancesResponse
+		err  error
+	}
+	testCases := []struct {
+		desc              string
+		expected          expected
+		wantError         bool
+		onMockWalletRepo  func(mock *mock_wallet.MockRepository)
+		onMockJournalRepo func(mock *mock_journal.MockRepository)
+	}{
+		{
+			desc:      "error_while_GetTotalBalance",
+			expected:  expected{err: errors.New("something went wrong")},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().GetTotalBalance(gomock.Any(), entity.CurrencyCNY).Return(entity.Money(0), errors.New("something went wrong"))
+			},
+		},
+		{
+			desc:      "error_while_GetSystemAccountBalances",
+			expected:  expected{err: errors.New("something went wrong")},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().GetTotalBalance(gomock.Any(), entity.CurrencyCNY).Return(entity.NewMoney(100), nil)
+			},
+			onMockJournalRepo: func(mock *mock_journal.MockRepository) {
+				mock.EXPECT().GetSystemAccountBalances(gomock.Any(), entity.CurrencyCNY).Return(nil, errors.New("something went wrong"))
+			},
+		},
+		{
+			// 150 deposited, 30 sitting in a pending transfer and 20 in an unclaimed envelope
+			desc: "success_balanced",
+			expected: expected{resp: &domain.GetLedgerBalancesResponse{
+				Currency:           "CNY",
+				TotalWalletBalance: entity.NewMoney(100),
+				SystemAccounts: []*domain.SystemAccountBalance{
+					{SystemAccount: "transfer_escrow", Balance: entity.NewMoney(30)},
+					{SystemAccount: "envelope_escrow", Balance: entity.NewMoney(20)},
+					{SystemAccount: "deposit_funding", Balance: entity.NewMoney(-150)},
+					{SystemAccount: "adjustment_expense", Balance: 0},
+					{SystemAccount: "hold_settlement", Balance: 0},
+					{SystemAccount: "withdrawal_payout", Balance: 0},
+				},
+				OutstandingLiabilities: entity.NewMoney(50),
+				Imbalance:              0,
+			}},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().GetTotalBalance(gomock.Any(), entity.CurrencyCNY).Return(entity.NewMoney(100), nil)
+			},
+			onMockJournalRepo: func(mock *mock_journal.MockRepository) {
+				mock.EXPECT().GetSystemAccountBalances(gomock.Any(), entity.CurrencyCNY).Return([]*entity.SystemAccountBalance{
+					{SystemAccount: entity.SystemAccountDepositFunding, Balance: entity.NewMoney(-150)},
+					{SystemAccount: entity.SystemAccountTransferEscrow, Balance: entity.NewMoney(30)},
+					{SystemAccount: entity.SystemAccountEnvelopeEscrow, Balance: entity.NewMoney(20)},
+				}, nil)
+			},
+		},
+		{
+			desc: "success_reports_imbalance",
+			expected: expected{resp: &domain.GetLedgerBalancesResponse{
+				Currency:           "CNY",
+				TotalWalletBalance: entity.NewMoney(100),
+				SystemAccounts: []*domain.SystemAccountBalance{
+					{SystemAccount: "transfer_escrow", Balance: 0},
+					{SystemAccount: "envelope_escrow", Balance: 0},
+					{SystemAccount: "deposit_funding", Balance: entity.NewMoney(-90)},
+					{SystemAccount: "adjustment_expense", Balance: 0},
+					{SystemAccount: "hold_settlement", Balance: 0},
+					{SystemAccount: "withdrawal_payout", Balance: 0},
+				},
+				Imbalance: entity.NewMoney(10),
+			}},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().GetTotalBalance(gomock.Any(), entity.CurrencyCNY).Return(entity.NewMoney(100), nil)
+			},
+			onMockJournalRepo: func(mock *mock_journal.MockRepository) {
+				mock.EXPECT().GetSystemAccountBalances(gomock.Any(), entity.CurrencyCNY).Return([]*entity.SystemAccountBalance{
+					{SystemAccount: entity.SystemAccountDepositFunding, Balance: entity.NewMoney(-90)},
+				}, nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(walletRepo)
+			}
+			journalRepo := mock_journal.NewMockRepository(ctrl)
+			if tC.onMockJournalRepo != nil {
+				tC.onMockJournalRepo(journalRepo)
+			}
+
+			svc := NewLedgerUseCase(journalRepo, walletRepo)
+
+			got, err := svc.GetLedgerBalances(context.Background(), "")
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/limit_override"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_transaction"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	LimitSvcImpl struct {
+		defaults        entity.Limits
+		repo            limit_override.Repository
+		walletRepo      wallet.Repository
+		transactionRepo wallet_transaction.Repository
+	}
+
+	LimitSvc interface {
+		// GetUserLimits resolves the limits validation applies to a user: the defaults, overridden
+		// by the tier of the user, overridden by the user's own override.
+		GetUserLimits(ctx context.Context, userID string) (limits entity.Limits, err error)
+		// CheckSpendLimits rejects a debit of amount when it would take what the user sent from
+		// the wallet over any rolling window past the spend limit. It must run in the transaction
+		// that books the debit, the wallet stays locked so concurrent sends are counted in turn.
+		CheckSpendLimits(ctx context.Context, userID string, walletID int64, amount entity.Money) (err error)
+		GetLimits(
+			ctx context.Context, req *domain.GetLimitsRequest,
+		) (resp *domain.GetLimitsResponse, err error)
+		SetLimitOverride(
+			ctx context.Context, req *domain.SetLimitOverrideRequest,
+		) (resp *domain.LimitOverride, err error)
+		GetListLimitOverride(
+			ctx context.Context, req *domain.GetListLimitOverrideRequest,
+		) (resp *domain.GetListLimitOverrideResponse, err error)
+	}
+)
+
+func NewLimitUseCase(
+	defaults entity.Limits,
+	repo limit_override.Repository,
+	walletRepo wallet.Repository,
+	transactionRepo wallet_transaction.Repository,
+) LimitSvc {
+	return &LimitSvcImpl{
+		defaults:        defaults,
+		repo:            repo,
+		walletRepo:      walletRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// getOverride returns nil when the scope has no override.
+func (s *LimitSvcImpl) getOverride(
+	ctx context.Context, scope entity.LimitScope, scopeID string,
+) (override *entity.LimitOverride, err error) {
+	override, err = s.repo.GetLimitOverride(ctx, scope, scopeID)
+	if errors.Is(err, eerrs.ErrLimitOverrideNotFound) {
+		return nil, nil
+	}
+
+	return override, err
+}
+
+// resolve looks up the override of the user and of the tier the user is in.
+func (s *LimitSvcImpl) resolve(
+	ctx context.Context, userID string,
+) (tierOverride, userOverride *entity.LimitOverride, err error) {
+	userOverride, err = s.getOverride(ctx, entity.LimitScopeUser, userID)
+	if err != nil {
+		log.ZError(ctx, "while get user limit override", err, "userID", userID)
+		return nil, nil, err
+	}
+
+	if userOverride == nil || userOverride.Tier == nil {
+		return nil, userOverride, nil
+	}
+
+	tierOverride, err = s.getOverride(ctx, entity.LimitScopeTier, *userOverride.Tier)
+	if err != nil {
+		log.ZError(ctx, "while get tier limit override", err, "tier", *userOverride.Tier)
+		return nil, nil, err
+	}
+
+	return tierOverride, userOverride, nil
+}
+
+func (s *LimitSvcImpl) GetUserLimits(ctx context.Context, userID string) (limits entity.Limits, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.String("userID", userID))
+
+	var tierOverride, userOverride *entity.LimitOverride
+	tierOverride, userOverride, err = s.resolve(ctx, userID)
+	if err != nil {
+		return limits, err
+	}
+
+	return s.defaults.Apply(tierOverride).Apply(userOverride), nil
+}
+
+func (s *LimitSvcImpl) CheckSpendLimits(
+	ctx context.Context, userID string, walletID int64, amount entity.Money,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("userID", userID),
+		attribute.Int64("walletID", walletID),
+		attribute.String("amount", amount.String()),
+	)
+
+	var limits entity.Limits
+	limits, err = s.GetUserLimits(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.walletRepo.GetWalletByWalletIDTx(ctx, nil, walletID); err != nil {
+		log.ZError(ctx, "while lock wallet", err, "walletID", walletID)
+		return err
+	}
+
+	now := time.Now()
+	for _, window := range entity.SpendWindows {
+		limit := limits.SpendLimit(window)
+		if limit.IsZero() {
+			continue
+		}
+
+		var spent entity.Money
+		spent, err = s.transactionRepo.SumDebitSince(ctx, walletID, entity.SpendTransactionTypes, now.Add(-window.Duration()))
+		if err != nil {
+			log.ZError(ctx, "while sum spent amount", err, "walletID", walletID, "window", window)
+			return err
+		}
+
+		if spent+amount > limit {
+			return errSpendLimit(window, max(limit-spent, 0))
+		}
+	}
+
+	return nil
+}
+
+// errSpendLimit reports the amount the user may still send over the window.
+func errSpendLimit(window entity.SpendWindow, remaining entity.Money) error {
+	switch window {
+	case entity.SpendWindowHour:
+		return eerrs.ErrHourlySpendLimit(remaining)
+	case entity.SpendWindowDay:
+		return eerrs.ErrDailySpendLimit(remaining)
+	default:
+		return eerrs.Err30DaySpendLimit(remaining)
+	}
+}
+
+func (s *LimitSvcImpl) GetLimits(
+	ctx context.Context, req *domain.GetLimitsRequest,
+) (resp *domain.GetLimitsResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	resp = &domain.GetLimitsResponse{
+		Defaults:  dtoLimits(s.defaults),
+		Effective: dtoLimits(s.defaults),
+	}
+	if req.UserID == "" {
+		return resp, nil
+	}
+
+	span.SetAttributes(attribute.String("userID", req.UserID))
+
+	var tierOverride, userOverride *entity.LimitOverride
+	tierOverride, userOverride, err = s.resolve(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userOverride != nil {
+		resp.Tier = userOverride.Tier
+		resp.UserOverride = dtoLimitOverride(userOverride)
+	}
+	if tierOverride != nil {
+		resp.TierOverride = dtoLimitOverride(tierOverride)
+	}
+	resp.Effective = dtoLimits(s.defaults.Apply(tierOverride).Apply(userOverride))
+
+	return resp, nil
+}
+
+func (s *LimitSvcImpl) SetLimitOverride(
+	ctx context.Context, req *domain.SetLimitOverrideRequest,
+) (resp *domain.LimitOverride, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.String("scope", req.Scope),
+		attribute.String("scopeID", req.ScopeID),
+	)
+
+	err = s.repo.UpsertLimitOverride(ctx, &entity.LimitOverride{
+		Scope:                 entity.LimitScope(req.Scope),
+		ScopeID:               req.ScopeID,
+		Tier:                  req.Tier,
+		TransferSendPerDay:    req.TransferSendPerDay,
+		TransferClaimPerDay:   req.TransferClaimPerDay,
+		MaxSendTransferAmount: req.MaxSendTransferAmount,
+		EnvelopeSendPerDay:    req.EnvelopeSendPerDay,
+		EnvelopeClaimPerDay:   req.EnvelopeClaimPerDay,
+		MaxSendEnvelopeAmount: req.MaxSendEnvelopeAmount,
+		SpendPerHour:          req.SpendPerHour,
+		SpendPerDay:           req.SpendPerDay,
+		SpendPer30Days:        req.SpendPer30Days,
+		CreatedBy:             req.OperatedBy,
+		UpdatedBy:             req.OperatedBy,
+	})
+	if err != nil {
+		log.ZError(ctx, "while upsert limit override", err, "request", req)
+		return resp, err
+	}
+
+	// read the row back, the upsert does not report the id of an updated override
+	var override *entity.LimitOverride
+	override, err = s.repo.GetLimitOverride(ctx, entity.LimitScope(req.Scope), req.ScopeID)
+	if err != nil {
+		log.ZError(ctx, "while get limit override", err, "request", req)
+		return resp, err
+	}
+
+	return dtoLimitOverride(override), nil
+}
+
+func (s *LimitSvcImpl) GetListLimitOverride(
+	ctx context.Context, req *domain.GetListLimitOverrideRequest,
+) (resp *domain.GetListLimitOverrideResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		log.ZError(ctx, "while validate request", err, "request", req)
+		return resp, err
+	}
+
+	var (
+		overrides []*entity.LimitOverride
+		total     int64
+	)
+	overrides, total, err = s.repo.GetListLimitOverride(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list limit override", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int64("total", total))
+
+	resp = &domain.GetListLimitOverrideResponse{
+		TotalCount: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}
+	for _, override := range overrides {
+		resp.LimitOverrides = append(resp.LimitOverrides, dtoLimitOverride(override))
+	}
+
+	return resp, nil
+}
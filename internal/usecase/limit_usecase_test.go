@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_limit_override"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_usecase"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet_transaction"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/config"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// newMockDefaultLimits applies the built-in default limits to every user.
+func newMockDefaultLimits(ctrl *gomock.Controller) *mock_usecase.MockLimitSvc {
+	limitUc := mock_usecase.NewMockLimitSvc(ctrl)
+	limitUc.EXPECT().GetUserLimits(gomock.Any(), gomock.Any()).Return(entity.DefaultLimits, nil).AnyTimes()
+	limitUc.EXPECT().CheckSpendLimits(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	return limitUc
+}
+
+func TestLimit_LimitsFromConfig(t *testing.T) {
+	var cfg config.Limits
+	limits, err := limitsFromConfig(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DefaultLimits, limits)
+
+	cfg.Transfer.SendPerDay = 10
+	cfg.Envelope.MaxSendAmount = "250.50"
+	limits, err = limitsFromConfig(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), limits.TransferSendPerDay)
+	assert.Equal(t, entity.NewMoneyFromMinor(25050), limits.MaxSendEnvelopeAmount)
+	assert.Equal(t, entity.DefaultLimits.TransferClaimPerDay, limits.TransferClaimPerDay)
+
+	cfg.Transfer.MaxSendAmount = "ten"
+	_, err = limitsFromConfig(cfg)
+	require.Error(t, err)
+}
+
+func TestLimit_ExpiryFromConfig(t *testing.T) {
+	var cfg config.Expiry
+	policy, err := expiryFromConfig("transfer", cfg, entity.DefaultTransferExpiry)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DefaultTransferExpiry, policy)
+
+	cfg.DefaultMinutes = 30
+	cfg.MinMinutes = 10
+	policy, err = expiryFromConfig("transfer", cfg, entity.DefaultTransferExpiry)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, policy.Default)
+	assert.Equal(t, 10*time.Minute, policy.Min)
+	assert.Equal(t, entity.DefaultTransferExpiry.Max, policy.Max)
+
+	cfg.MaxMinutes = 20
+	_, err = expiryFromConfig("transfer", cfg, entity.DefaultTransferExpiry)
+	require.Error(t, err)
+}
+
+func TestLimit_GetUserLimits(t *testing.T) {
+	var (
+		vip          = "vip"
+		five   int64 = 5
+		twenty int64 = 20
+		amount       = entity.NewMoney(20000)
+	)
+	testCases := []struct {
+		desc       string
+		expected   entity.Limits
+		wantError  bool
+		onMockRepo func(mock *mock_limit_override.MockRepository)
+	}{
+		{
+			desc:     "no_override",
+			expected: entity.DefaultLimits,
+			onMockRepo: func(mock *mock_limit_override.MockRepository) {
+				mock.EXPECT().
+					GetLimitOverride(gomock.Any(), entity.LimitScopeUser, "u1").
+					Return(nil, eerrs.ErrLimitOverrideNotFound)
+			},
+		},
+		{
+			desc: "user_over_tier_over_default",
+			expected: func() entity.Limits {
+				limits := entity.DefaultLimits
+				limits.TransferSendPerDay = five
+				limits.EnvelopeSendPerDay = twenty
+				limits.MaxSendTransferAmount = amount
+				return limits
+			}(),
+			onMockRepo: func(mock *mock_limit_override.MockRepository) {
+				mock.EXPECT().
+					GetLimitOverride(gomock.Any(), entity.LimitScopeUser, "u1").
+					Return(&entity.LimitOverride{Tier: &vip, TransferSendPerDay: &five}, nil)
+				mock.EXPECT().
+					GetLimitOverride(gomock.Any(), entity.LimitScopeTier, "vip").
+					Return(&entity.LimitOverride{
+						TransferSendPerDay:    &twenty,
+						EnvelopeSendPerDay:    &twenty,
+						MaxSendTransferAmount: &amount,
+					}, nil)
+			},
+		},
+		{
+			desc:     "tier_without_override",
+			expected: entity.DefaultLimits,
+			onMockRepo: func(mock *mock_limit_override.MockRepository) {
+				mock.EXPECT().
+					GetLimitOverride(gomock.Any(), entity.LimitScopeUser, "u1").
+					Return(&entity.LimitOverride{Tier: &vip}, nil)
+				mock.EXPECT().
+					GetLimitOverride(gomock.Any(), entity.LimitScopeTier, "vip").
+					Return(nil, eerrs.ErrLimitOverrideNotFound)
+			},
+		},
+		{
+			desc:      "repo_error",
+			wantError: true,
+			onMockRepo: func(mock *mock_limit_override.MockRepository) {
+				mock.EXPECT().
+					GetLimitOverride(gomock.Any(), entity.LimitScopeUser, "u1").
+					Return(nil, errors.New("something wrong"))
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_limit_override.NewMockRepository(ctrl)
+			tC.onMockRepo(repo)
+
+			svc := NewLimitUseCase(entity.DefaultLimits, repo, nil, nil)
+
+			got, err := svc.GetUserLimits(context.Background(), "u1")
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected, got)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestLimit_CheckSpendLimits(t *testing.T) {
+	var (
+		amount           = entity.NewMoney(1000)
+		hourly           = entity.NewMoney(1500)
+		zero             = entity.Money(0)
+		spendTypes       = entity.SpendTransactionTypes
+		walletID   int64 = 7
+	)
+	testCases := []struct {
+		desc                  string
+		override              *entity.LimitOverride
+		expected              error
+		onMockTransactionRepo func(mock *mock_wallet_transaction.MockRepository)
+	}{
+		{
+			desc: "within_every_window",
+			onMockTransactionRepo: func(mock *mock_wallet_transaction.MockRepository) {
+				mock.EXPECT().
+					SumDebitSince(gomock.Any(), walletID, spendTypes, gomock.Any()).
+					Return(entity.NewMoney(500), nil).Times(3)
+			},
+		},
+		{
+			desc:     "hourly_exceeded",
+			override: &entity.LimitOverride{SpendPerHour: &hourly},
+			expected: eerrs.ErrHourlySpendLimit(entity.NewMoney(300)),
+			onMockTransactionRepo: func(mock *mock_wallet_transaction.MockRepository) {
+				mock.EXPECT().
+					SumDebitSince(gomock.Any(), walletID, spendTypes, gomock.Any()).
+					Return(entity.NewMoney(1200), nil)
+			},
+		},
+		{
+			desc:     "daily_exceeded_reports_no_allowance_left",
+			expected: eerrs.ErrDailySpendLimit(entity.Money(0)),
+			onMockTransactionRepo: func(mock *mock_wallet_transaction.MockRepository) {
+				gomock.InOrder(
+					mock.EXPECT().
+						SumDebitSince(gomock.Any(), walletID, spendTypes, gomock.Any()).
+						Return(entity.NewMoney(0), nil),
+					mock.EXPECT().
+						SumDebitSince(gomock.Any(), walletID, spendTypes, gomock.Any()).
+						Return(entity.DefaultLimits.SpendPerDay, nil),
+				)
+			},
+		},
+		{
+			desc:     "disabled_windows_skipped",
+			override: &entity.LimitOverride{SpendPerHour: &zero, SpendPerDay: &zero},
+			expected: eerrs.Err30DaySpendLimit(entity.NewMoney(500)),
+			onMockTransactionRepo: func(mock *mock_wallet_transaction.MockRepository) {
+				mock.EXPECT().
+					SumDebitSince(gomock.Any(), walletID, spendTypes, gomock.Any()).
+					Return(entity.DefaultLimits.SpendPer30Days-entity.NewMoney(500), nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_limit_override.NewMockRepository(ctrl)
+			repo.EXPECT().
+				GetLimitOverride(gomock.Any(), entity.LimitScopeUser, "u1").
+				DoAndReturn(func(context.Context, entity.LimitScope, string) (*entity.LimitOverride, error) {
+					if tC.override == nil {
+						return nil, eerrs.ErrLimitOverrideNotFound
+					}
+					return tC.override, nil
+				})
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			walletRepo.EXPECT().
+				GetWalletByWalletIDTx(gomock.Any(), nil, walletID).
+				Return(&entity.Wallet{WalletID: walletID}, nil)
+
+			transactionRepo := mock_wallet_transaction.NewMockRepository(ctrl)
+			tC.onMockTransactionRepo(transactionRepo)
+
+			svc := NewLimitUseCase(entity.DefaultLimits, repo, walletRepo, transactionRepo)
+
+			err := svc.CheckSpendLimits(context.Background(), "u1", walletID, amount)
+			if tC.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tC.expected)
+				assert.Equal(t, tC.expected.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestLimit_SetLimitOverride(t *testing.T) {
+	var (
+		vip            = "vip"
+		negative int64 = -1
+	)
+	testCases := []struct {
+		desc       string
+		request    *domain.SetLimitOverrideRequest
+		expected   error
+		onMockRepo func(mock *mock_limit_override.MockRepository)
+	}{
+		{
+			desc:     "invalid_scope",
+			request:  &domain.SetLimitOverrideRequest{Scope: "group", ScopeID: "g1"},
+			expected: eerrs.ErrInvalidLimitScope,
+		},
+		{
+			desc:     "tier_on_tier",
+			request:  &domain.SetLimitOverrideRequest{Scope: "tier", ScopeID: "vip", Tier: &vip},
+			expected: errors.New("tier can only be set on a user"),
+		},
+		{
+			desc:     "negative_limit",
+			request:  &domain.SetLimitOverrideRequest{Scope: "user", ScopeID: "u1", TransferClaimPerDay: &negative},
+			expected: errors.New("daily limits cannot be negative"),
+		},
+		{
+			desc:    "success",
+			request: &domain.SetLimitOverrideRequest{Scope: "user", ScopeID: "u1", Tier: &vip, OperatedBy: "admin-1"},
+			onMockRepo: func(mock *mock_limit_override.MockRepository) {
+				mock.EXPECT().
+					UpsertLimitOverride(gomock.Any(), &entity.LimitOverride{
+						Scope: entity.LimitScopeUser, ScopeID: "u1", Tier: &vip, CreatedBy: "admin-1", UpdatedBy: "admin-1",
+					}).
+					Return(nil)
+				mock.EXPECT().
+					GetLimitOverride(gomock.Any(), entity.LimitScopeUser, "u1").
+					Return(&entity.LimitOverride{LimitOverrideID: 3, Scope: entity.LimitScopeUser, ScopeID: "u1", Tier: &vip}, nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_limit_override.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+
+			svc := NewLimitUseCase(entity.DefaultLimits, repo, nil, nil)
+
+			got, err := svc.SetLimitOverride(context.Background(), tC.request)
+			if tC.expected == nil {
+				require.NoError(t, err)
+				assert.Equal(t, int64(3), got.LimitOverrideID)
+				assert.Equal(t, &vip, got.Tier)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.Error(), err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,393 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/payment_pin"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/db/cache"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	PaymentPinSvcImpl struct {
+		repo     payment_pin.Repository
+		txRepo   tx.Repository
+		attempts cache.PaymentPinAttemptInterface
+		policy   entity.PaymentPinPolicy
+	}
+
+	PaymentPinSvc interface {
+		GetPaymentPinStatus(ctx context.Context, userID string) (resp *domain.PaymentPinStatusResponse, err error)
+		SetPaymentPin(ctx context.Context, req *domain.SetPaymentPinRequest) (err error)
+		// ChangePaymentPin replaces the PIN, a wrong old PIN counts towards the lockout like any other.
+		ChangePaymentPin(ctx context.Context, req *domain.ChangePaymentPinRequest) (err error)
+		ResetPaymentPin(ctx context.Context, req *domain.ResetPaymentPinRequest) (err error)
+		// VerifyPaymentPin checks the PIN a user sends money out with. Wrong PINs in a row are
+		// counted in redis and lock the PIN once the policy allows no more.
+		VerifyPaymentPin(ctx context.Context, req *domain.VerifyPaymentPinRequest) (err error)
+		GetListPaymentPinAudit(
+			ctx context.Context, req *domain.GetListPaymentPinAuditRequest,
+		) (resp *domain.GetListPaymentPinAuditResponse, err error)
+	}
+)
+
+func NewPaymentPinUseCase(
+	repo payment_pin.Repository,
+	txRepo tx.Repository,
+	attempts cache.PaymentPinAttemptInterface,
+	policy entity.PaymentPinPolicy,
+) PaymentPinSvc {
+	return &PaymentPinSvcImpl{
+		repo:     repo,
+		txRepo:   txRepo,
+		attempts: attempts,
+		policy:   policy,
+	}
+}
+
+func (s *PaymentPinSvcImpl) GetPaymentPinStatus(
+	ctx context.Context, userID string,
+) (resp *domain.PaymentPinStatusResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.String("userID", userID))
+
+	resp = &domain.PaymentPinStatusResponse{IsSet: true}
+	_, err = s.repo.GetPaymentPin(ctx, userID)
+	if errors.Is(err, eerrs.ErrPaymentPinNotSet) {
+		return &domain.PaymentPinStatusResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var failures int64
+	failures, _, err = s.attempts.GetFailures(ctx, userID)
+	if err != nil {
+		log.ZError(ctx, "while get payment pin failures", err, "userID", userID)
+		return nil, err
+	}
+	resp.IsLocked = failures >= s.policy.MaxAttempts
+
+	return resp, nil
+}
+
+func (s *PaymentPinSvcImpl) SetPaymentPin(ctx context.Context, req *domain.SetPaymentPinRequest) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return err
+	}
+
+	span.SetAttributes(attribute.String("userID", req.UserID))
+
+	_, err = s.repo.GetPaymentPin(ctx, req.UserID)
+	if err == nil {
+		return eerrs.ErrPaymentPinAlreadySet
+	}
+	if !errors.Is(err, eerrs.ErrPaymentPinNotSet) {
+		return err
+	}
+
+	var pinHash []byte
+	pinHash, err = bcrypt.GenerateFromPassword([]byte(req.Pin), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	return s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		err = s.repo.CreatePaymentPin(ctx, &entity.PaymentPin{
+			UserID:    req.UserID,
+			PinHash:   string(pinHash),
+			CreatedBy: req.OperatedBy,
+			UpdatedBy: req.OperatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.repo.CreateAudit(ctx, &entity.PaymentPinAudit{
+			UserID:    req.UserID,
+			Event:     entity.PaymentPinEventSet,
+			DeviceID:  req.DeviceID,
+			CreatedBy: req.OperatedBy,
+		})
+	})
+}
+
+func (s *PaymentPinSvcImpl) ChangePaymentPin(ctx context.Context, req *domain.ChangePaymentPinRequest) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return err
+	}
+
+	span.SetAttributes(attribute.String("userID", req.UserID))
+
+	err = s.VerifyPaymentPin(ctx, &domain.VerifyPaymentPinRequest{
+		UserID:   req.UserID,
+		Pin:      req.OldPin,
+		Endpoint: "change payment pin",
+		DeviceID: req.DeviceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var pinHash []byte
+	pinHash, err = bcrypt.GenerateFromPassword([]byte(req.NewPin), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	return s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		err = s.repo.UpdatePaymentPinHash(ctx, req.UserID, string(pinHash), req.OperatedBy)
+		if err != nil {
+			return err
+		}
+
+		return s.repo.CreateAudit(ctx, &entity.PaymentPinAudit{
+			UserID:    req.UserID,
+			Event:     entity.PaymentPinEventChanged,
+			DeviceID:  req.DeviceID,
+			CreatedBy: req.OperatedBy,
+		})
+	})
+}
+
+func (s *PaymentPinSvcImpl) ResetPaymentPin(ctx context.Context, req *domain.ResetPaymentPinRequest) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return err
+	}
+
+	span.SetAttributes(attribute.String("userID", req.UserID))
+
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		var deleted bool
+		deleted, err = s.repo.DeletePaymentPin(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return eerrs.ErrPaymentPinNotSet
+		}
+
+		return s.repo.CreateAudit(ctx, &entity.PaymentPinAudit{
+			UserID:    req.UserID,
+			Event:     entity.PaymentPinEventReset,
+			CreatedBy: req.OperatedBy,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// a lock left over from before the reset would keep the new PIN locked
+	if err = s.attempts.ResetFailures(ctx, req.UserID); err != nil {
+		log.ZError(ctx, "while reset payment pin failures", err, "userID", req.UserID)
+		return err
+	}
+
+	return nil
+}
+
+func (s *PaymentPinSvcImpl) VerifyPaymentPin(ctx context.Context, req *domain.VerifyPaymentPinRequest) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("userID", req.UserID),
+		attribute.String("endpoint", req.Endpoint),
+	)
+
+	// a malformed PIN can't match, it is rejected without counting as an attempt
+	if len(req.Pin) != entity.PaymentPinLength {
+		return eerrs.ErrInvalidPaymentPin
+	}
+
+	failures, lockedFor, err := s.attempts.GetFailures(ctx, req.UserID)
+	if err != nil {
+		log.ZError(ctx, "while get payment pin failures", err, "userID", req.UserID)
+		return err
+	}
+	if failures >= s.policy.MaxAttempts {
+		return eerrs.ErrPaymentPinLocked(lockedFor)
+	}
+
+	var pin *entity.PaymentPin
+	pin, err = s.repo.GetPaymentPin(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	// the attempt is reserved before the compare, parallel requests that all passed the check
+	// above get a count each, and the ones past the last attempt never reach bcrypt
+	var attempt int64
+	attempt, err = s.attempts.IncrFailures(ctx, req.UserID, s.policy.LockDuration)
+	if err != nil {
+		log.ZError(ctx, "while count payment pin attempt", err, "userID", req.UserID)
+		return err
+	}
+	if attempt > s.policy.MaxAttempts {
+		return eerrs.ErrPaymentPinLocked(s.policy.LockDuration)
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(pin.PinHash), []byte(req.Pin)) != nil {
+		return s.auditFailure(ctx, req, attempt)
+	}
+
+	if err = s.attempts.ResetFailures(ctx, req.UserID); err != nil {
+		log.ZError(ctx, "while reset payment pin failures", err, "userID", req.UserID)
+		return err
+	}
+
+	return nil
+}
+
+// auditFailure audits a wrong PIN already counted as attempt, the one that uses up the last
+// attempt locks the PIN.
+func (s *PaymentPinSvcImpl) auditFailure(
+	ctx context.Context, req *domain.VerifyPaymentPinRequest, failures int64,
+) (err error) {
+	locked := failures >= s.policy.MaxAttempts
+	audit := &entity.PaymentPinAudit{
+		UserID:    req.UserID,
+		Event:     entity.PaymentPinEventVerifyFailed,
+		Endpoint:  req.Endpoint,
+		DeviceID:  req.DeviceID,
+		CreatedBy: req.UserID,
+	}
+	if locked {
+		audit.Event = entity.PaymentPinEventLocked
+	}
+	// the wrong PIN is already counted, a lost audit row must not let the request through
+	if errx := s.repo.CreateAudit(ctx, audit); errx != nil {
+		log.ZError(ctx, "while audit payment pin failure", errx, "userID", req.UserID, "event", audit.Event)
+	}
+
+	if locked {
+		log.ZWarn(ctx, "payment pin locked", nil, "userID", req.UserID, "failures", failures)
+		return eerrs.ErrPaymentPinLocked(s.policy.LockDuration)
+	}
+
+	return eerrs.ErrPaymentPinMismatch(s.policy.MaxAttempts - failures)
+}
+
+func (s *PaymentPinSvcImpl) GetListPaymentPinAudit(
+	ctx context.Context, req *domain.GetListPaymentPinAuditRequest,
+) (resp *domain.GetListPaymentPinAuditResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var (
+		audits []*entity.PaymentPinAudit
+		total  int64
+	)
+	audits, total, err = s.repo.GetListAudit(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list payment pin audit", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.String("userID", req.UserID),
+		attribute.Int64("total", total),
+	)
+
+	return &domain.GetListPaymentPinAuditResponse{
+		TotalCount: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Audits:     dtoPaymentPinAudits(audits),
+	}, nil
+}
@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_cache"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_payment_pin"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/config"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// storedPaymentPin is the row of a user whose PIN is 246810.
+func storedPaymentPin(t *testing.T) *entity.PaymentPin {
+	hash, err := bcrypt.GenerateFromPassword([]byte("246810"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	return &entity.PaymentPin{PaymentPinID: 1, UserID: "1", PinHash: string(hash)}
+}
+
+func TestPaymentPin_ValidatePaymentPin(t *testing.T) {
+	testCases := []struct {
+		pin       string
+		wantError error
+	}{
+		{pin: "246810"},
+		{pin: "13579", wantError: eerrs.ErrInvalidPaymentPin},
+		{pin: "12a456", wantError: eerrs.ErrInvalidPaymentPin},
+		{pin: "777777", wantError: eerrs.ErrWeakPaymentPin},
+		{pin: "345678", wantError: eerrs.ErrWeakPaymentPin},
+		{pin: "987654", wantError: eerrs.ErrWeakPaymentPin},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.pin, func(t *testing.T) {
+			assert.Equal(t, tC.wantError, domain.ValidatePaymentPin(tC.pin))
+		})
+	}
+}
+
+func TestPaymentPin_PolicyFromConfig(t *testing.T) {
+	policy, err := paymentPinPolicyFromConfig(config.PaymentPin{})
+	require.NoError(t, err)
+	assert.Equal(t, entity.DefaultPaymentPinPolicy, policy)
+
+	policy, err = paymentPinPolicyFromConfig(config.PaymentPin{MaxAttempts: 3, LockMinutes: 60})
+	require.NoError(t, err)
+	assert.Equal(t, entity.PaymentPinPolicy{MaxAttempts: 3, LockDuration: time.Hour}, policy)
+
+	_, err = paymentPinPolicyFromConfig(config.PaymentPin{MaxAttempts: -1})
+	assert.Error(t, err)
+}
+
+func TestPaymentPin_SetPaymentPin(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		pin        string
+		wantError  error
+		onMockRepo func(mock *mock_payment_pin.MockRepository)
+	}{
+		{
+			desc:      "weak_pin",
+			pin:       "111111",
+			wantError: eerrs.ErrWeakPaymentPin,
+		},
+		{
+			desc:      "already_set",
+			pin:       "246810",
+			wantError: eerrs.ErrPaymentPinAlreadySet,
+			onMockRepo: func(mock *mock_payment_pin.MockRepository) {
+				mock.EXPECT().GetPaymentPin(gomock.Any(), "1").Return(&entity.PaymentPin{UserID: "1"}, nil)
+			},
+		},
+		{
+			desc: "success",
+			pin:  "246810",
+			onMockRepo: func(mock *mock_payment_pin.MockRepository) {
+				mock.EXPECT().GetPaymentPin(gomock.Any(), "1").Return(nil, eerrs.ErrPaymentPinNotSet)
+				mock.EXPECT().
+					CreatePaymentPin(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, pin *entity.PaymentPin) error {
+						assert.NotEqual(t, "246810", pin.PinHash)
+						assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(pin.PinHash), []byte("246810")))
+						return nil
+					})
+				mock.EXPECT().
+					CreateAudit(gomock.Any(), &entity.PaymentPinAudit{
+						UserID: "1", Event: entity.PaymentPinEventSet, DeviceID: "device-1", CreatedBy: "1-app",
+					}).
+					Return(nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_payment_pin.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+
+			uc := NewPaymentPinUseCase(
+				repo, newMockHoldTxRepo(ctrl), mock_cache.NewMockPaymentPinAttemptInterface(ctrl), entity.DefaultPaymentPinPolicy,
+			)
+			err := uc.SetPaymentPin(context.Background(), &domain.SetPaymentPinRequest{
+				Pin: tC.pin, UserID: "1", OperatedBy: "1-app", DeviceID: "device-1",
+			})
+			assert.Equal(t, tC.wantError, err)
+		})
+	}
+}
+
+func TestPaymentPin_VerifyPaymentPin(t *testing.T) {
+	stored := storedPaymentPin(t)
+	testCases := []struct {
+		desc           string
+		pin            string
+		wantError      error
+		onMockRepo     func(mock *mock_payment_pin.MockRepository)
+		onMockAttempts func(mock *mock_cache.MockPaymentPinAttemptInterface)
+	}{
+		{
+			desc:      "missing_pin_is_not_counted",
+			pin:       "",
+			wantError: eerrs.ErrInvalidPaymentPin,
+		},
+		{
+			desc:      "locked",
+			pin:       "246810",
+			wantError: eerrs.ErrPaymentPinLocked(12 * time.Minute),
+			onMockAttempts: func(mock *mock_cache.MockPaymentPinAttemptInterface) {
+				mock.EXPECT().GetFailures(gomock.Any(), "1").Return(int64(5), 12*time.Minute, nil)
+			},
+		},
+		{
+			desc:      "not_set",
+			pin:       "246810",
+			wantError: eerrs.ErrPaymentPinNotSet,
+			onMockRepo: func(mock *mock_payment_pin.MockRepository) {
+				mock.EXPECT().GetPaymentPin(gomock.Any(), "1").Return(nil, eerrs.ErrPaymentPinNotSet)
+			},
+			onMockAttempts: func(mock *mock_cache.MockPaymentPinAttemptInterface) {
+				mock.EXPECT().GetFailures(gomock.Any(), "1").Return(int64(0), time.Duration(0), nil)
+			},
+		},
+		{
+			desc:      "redis_down_fails_closed",
+			pin:       "246810",
+			wantError: errors.New("redis: connection refused"),
+			onMockAttempts: func(mock *mock_cache.MockPaymentPinAttemptInterface) {
+				mock.EXPECT().
+					GetFailures(gomock.Any(), "1").
+					Return(int64(0), time.Duration(0), errors.New("redis: connection refused"))
+			},
+		},
+		{
+			desc:      "mismatch",
+			pin:       "135790",
+			wantError: eerrs.ErrPaymentPinMismatch(3),
+			onMockRepo: func(mock *mock_payment_pin.MockRepository) {
+				mock.EXPECT().GetPaymentPin(gomock.Any(), "1").Return(stored, nil)
+				mock.EXPECT().
+					CreateAudit(gomock.Any(), &entity.PaymentPinAudit{
+						UserID: "1", Event: entity.PaymentPinEventVerifyFailed, Endpoint: "POST /transfer/create", CreatedBy: "1",
+					}).
+					Return(nil)
+			},
+			onMockAttempts: func(mock *mock_cache.MockPaymentPinAttemptInterface) {
+				mock.EXPECT().GetFailures(gomock.Any(), "1").Return(int64(1), 20*time.Minute, nil)
+				mock.EXPECT().IncrFailures(gomock.Any(), "1", 30*time.Minute).Return(int64(2), nil)
+			},
+		},
+		{
+			desc:      "last_attempt_locks",
+			pin:       "135790",
+			wantError: eerrs.ErrPaymentPinLocked(30 * time.Minute),
+			onMockRepo: func(mock *mock_payment_pin.MockRepository) {
+				mock.EXPECT().GetPaymentPin(gomock.Any(), "1").Return(stored, nil)
+				mock.EXPECT().
+					CreateAudit(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, audit *entity.PaymentPinAudit) error {
+						assert.Equal(t, entity.PaymentPinEventLocked, audit.Event)
+						return errors.New("audit table unavailable")
+					})
+			},
+			onMockAttempts: func(mock *mock_cache.MockPaymentPinAttemptInterface) {
+				mock.EXPECT().GetFailures(gomock.Any(), "1").Return(int64(4), 20*time.Minute, nil)
+				mock.EXPECT().IncrFailures(gomock.Any(), "1", 30*time.Minute).Return(int64(5), nil)
+			},
+		},
+		{
+			desc:      "parallel_attempt_past_the_last_is_refused",
+			pin:       "246810",
+			wantError: eerrs.ErrPaymentPinLocked(30 * time.Minute),
+			onMockRepo: func(mock *mock_payment_pin.MockRepository) {
+				mock.EXPECT().GetPaymentPin(gomock.Any(), "1").Return(stored, nil)
+			},
+			onMockAttempts: func(mock *mock_cache.MockPaymentPinAttemptInterface) {
+				// another request took the last attempt after this one read the count,
+				// so even the right PIN is refused and nothing is reset
+				mock.EXPECT().GetFailures(gomock.Any(), "1").Return(int64(4), 20*time.Minute, nil)
+				mock.EXPECT().IncrFailures(gomock.Any(), "1", 30*time.Minute).Return(int64(6), nil)
+			},
+		},
+		{
+			desc: "match_clears_failures",
+			pin:  "246810",
+			onMockRepo: func(mock *mock_payment_pin.MockRepository) {
+				mock.EXPECT().GetPaymentPin(gomock.Any(), "1").Return(stored, nil)
+			},
+			onMockAttempts: func(mock *mock_cache.MockPaymentPinAttemptInterface) {
+				gomock.InOrder(
+					mock.EXPECT().GetFailures(gomock.Any(), "1").Return(int64(2), 20*time.Minute, nil),
+					mock.EXPECT().IncrFailures(gomock.Any(), "1", 30*time.Minute).Return(int64(3), nil),
+					mock.EXPECT().ResetFailures(gomock.Any(), "1").Return(nil),
+				)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_payment_pin.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+
+			attempts := mock_cache.NewMockPaymentPinAttemptInterface(ctrl)
+			if tC.onMockAttempts != nil {
+				tC.onMockAttempts(attempts)
+			}
+
+			uc := NewPaymentPinUseCase(repo, newMockHoldTxRepo(ctrl), attempts, entity.DefaultPaymentPinPolicy)
+			err := uc.VerifyPaymentPin(context.Background(), &domain.VerifyPaymentPinRequest{
+				UserID: "1", Pin: tC.pin, Endpoint: "POST /transfer/create",
+			})
+			if tC.wantError != nil {
+				require.Error(t, err)
+				assert.Equal(t, tC.wantError.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPaymentPin_ChangePaymentPin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := mock_payment_pin.NewMockRepository(ctrl)
+	repo.EXPECT().GetPaymentPin(gomock.Any(), "1").Return(storedPaymentPin(t), nil)
+	repo.EXPECT().
+		UpdatePaymentPinHash(gomock.Any(), "1", gomock.Any(), "1-app").
+		DoAndReturn(func(_ context.Context, _, pinHash, _ string) error {
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(pinHash), []byte("924613")))
+			return nil
+		})
+	repo.EXPECT().
+		CreateAudit(gomock.Any(), &entity.PaymentPinAudit{UserID: "1", Event: entity.PaymentPinEventChanged, CreatedBy: "1-app"}).
+		Return(nil)
+
+	attempts := mock_cache.NewMockPaymentPinAttemptInterface(ctrl)
+	attempts.EXPECT().GetFailures(gomock.Any(), "1").Return(int64(0), time.Duration(0), nil)
+	attempts.EXPECT().IncrFailures(gomock.Any(), "1", 30*time.Minute).Return(int64(1), nil)
+	attempts.EXPECT().ResetFailures(gomock.Any(), "1").Return(nil)
+
+	uc := NewPaymentPinUseCase(repo, newMockHoldTxRepo(ctrl), attempts, entity.DefaultPaymentPinPolicy)
+
+	err := uc.ChangePaymentPin(context.Background(), &domain.ChangePaymentPinRequest{
+		OldPin: "246810", NewPin: "246810", UserID: "1", OperatedBy: "1-app",
+	})
+	require.Error(t, err)
+
+	err = uc.ChangePaymentPin(context.Background(), &domain.ChangePaymentPinRequest{
+		OldPin: "246810", NewPin: "924613", UserID: "1", OperatedBy: "1-app",
+	})
+	require.NoError(t, err)
+}
+
+func TestPaymentPin_ResetPaymentPin(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		deleted   bool
+		wantError error
+	}{
+		{desc: "not_set", wantError: eerrs.ErrPaymentPinNotSet},
+		{desc: "success", deleted: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_payment_pin.NewMockRepository(ctrl)
+			repo.EXPECT().DeletePaymentPin(gomock.Any(), "1").Return(tC.deleted, nil)
+
+			attempts := mock_cache.NewMockPaymentPinAttemptInterface(ctrl)
+			if tC.deleted {
+				repo.EXPECT().
+					CreateAudit(gomock.Any(), &entity.PaymentPinAudit{UserID: "1", Event: entity.PaymentPinEventReset, CreatedBy: "admin-bo"}).
+					Return(nil)
+				attempts.EXPECT().ResetFailures(gomock.Any(), "1").Return(nil)
+			}
+
+			uc := NewPaymentPinUseCase(repo, newMockHoldTxRepo(ctrl), attempts, entity.DefaultPaymentPinPolicy)
+			err := uc.ResetPaymentPin(context.Background(), &domain.ResetPaymentPinRequest{UserID: "1", OperatedBy: "admin-bo"})
+			assert.Equal(t, tC.wantError, err)
+		})
+	}
+}
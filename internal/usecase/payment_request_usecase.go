@@ -0,0 +1,322 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/payment_request"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+	"github.com/1nterdigital/aka-im-wallet/pkg/util/convert"
+)
+
+type (
+	PaymentRequestSvcImpl struct {
+		repo       payment_request.Repository
+		txRepo     tx.Repository
+		transferUc TransferSvc
+	}
+
+	PaymentRequestSvc interface {
+		CreatePaymentRequest(
+			ctx context.Context, req *domain.CreatePaymentRequestRequest,
+		) (resp *domain.PaymentRequestResponse, err error)
+		// PayPaymentRequest sends the requested amount from the payer to the requester. The
+		// transfer is claimed for the requester in the same transaction, nothing is left pending.
+		PayPaymentRequest(
+			ctx context.Context, req *domain.PaymentRequestActionRequest,
+		) (resp *domain.PaymentRequestResponse, err error)
+		DeclinePaymentRequest(
+			ctx context.Context, req *domain.PaymentRequestActionRequest,
+		) (resp *domain.PaymentRequestResponse, err error)
+		GetListPaymentRequest(
+			ctx context.Context, req *domain.GetListPaymentRequestRequest,
+		) (resp *domain.GetListPaymentRequestResponse, err error)
+		ExpirePaymentRequests(ctx context.Context, operatedBy string) (expired int64, err error)
+	}
+)
+
+func NewPaymentRequestUseCase(
+	repo payment_request.Repository,
+	txRepo tx.Repository,
+	transferUc TransferSvc,
+) PaymentRequestSvc {
+	return &PaymentRequestSvcImpl{
+		repo:       repo,
+		txRepo:     txRepo,
+		transferUc: transferUc,
+	}
+}
+
+func (s *PaymentRequestSvcImpl) CreatePaymentRequest(
+	ctx context.Context, req *domain.CreatePaymentRequestRequest,
+) (resp *domain.PaymentRequestResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(req.Currency)
+	if err != nil {
+		return resp, err
+	}
+	req.Currency = currency.String()
+
+	if err = req.Validate(time.Now()); err != nil {
+		return resp, err
+	}
+
+	request := &entity.PaymentRequest{
+		RequesterUserID:      req.RequesterUserID,
+		PayerUserID:          req.PayerUserID,
+		Amount:               req.Amount,
+		Currency:             currency,
+		Remark:               req.Remark,
+		StatusPaymentRequest: entity.StatusPaymentRequestPending,
+		ExpiredAt:            *req.ExpiredAt,
+		IsActive:             true,
+		CreatedBy:            req.OperatedBy,
+		UpdatedBy:            req.OperatedBy,
+	}
+	_, err = s.repo.CreatePaymentRequest(ctx, request)
+	if err != nil {
+		log.ZError(ctx, "while create payment request", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("paymentRequestID", request.PaymentRequestID),
+		attribute.String("payerUserID", req.PayerUserID),
+	)
+
+	return dtoPaymentRequestResponse(request), nil
+}
+
+func (s *PaymentRequestSvcImpl) PayPaymentRequest(
+	ctx context.Context, req *domain.PaymentRequestActionRequest,
+) (resp *domain.PaymentRequestResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int64("paymentRequestID", req.PaymentRequestID))
+
+	var request *entity.PaymentRequest
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		request, err = s.lockPending(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		var transfer *domain.CreateTransferResponse
+		transfer, err = s.transferUc.CreateTransfer(ctx, &domain.CreateTransferRequest{
+			FromUserID: request.PayerUserID,
+			ToUserID:   request.RequesterUserID,
+			Amount:     request.Amount,
+			Currency:   request.Currency.String(),
+			Remark:     request.Remark,
+			CreatedBy:  req.OperatedBy,
+			DeviceID:   req.DeviceID,
+		})
+		if err != nil {
+			log.ZError(ctx, "while create payment request transfer", err, "paymentRequestID", req.PaymentRequestID)
+			return err
+		}
+
+		err = s.transferUc.ClaimTransfer(ctx, &domain.ClaimTransferRequest{
+			TransferID:    transfer.TransferID,
+			ClaimerUserID: request.RequesterUserID,
+			OperateBy:     req.OperatedBy,
+		})
+		if err != nil {
+			log.ZError(ctx, "while settle payment request transfer", err, "paymentRequestID", req.PaymentRequestID)
+			return err
+		}
+
+		request.StatusPaymentRequest = entity.StatusPaymentRequestPaid
+		request.TransferID = &transfer.TransferID
+		request.PaidAt = convert.PtrTime(time.Now())
+		request.UpdatedBy = req.OperatedBy
+
+		return s.repo.UpdatePaymentRequest(ctx, request)
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return dtoPaymentRequestResponse(request), nil
+}
+
+func (s *PaymentRequestSvcImpl) DeclinePaymentRequest(
+	ctx context.Context, req *domain.PaymentRequestActionRequest,
+) (resp *domain.PaymentRequestResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int64("paymentRequestID", req.PaymentRequestID))
+
+	var request *entity.PaymentRequest
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		request, err = s.lockPending(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		request.StatusPaymentRequest = entity.StatusPaymentRequestDeclined
+		request.DeclinedAt = convert.PtrTime(time.Now())
+		request.UpdatedBy = req.OperatedBy
+
+		return s.repo.UpdatePaymentRequest(ctx, request)
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return dtoPaymentRequestResponse(request), nil
+}
+
+// lockPending locks a request addressed to req.UserID that can still be paid or declined. The
+// expiry is checked here as well, the publisher only marks expired requests once an hour.
+func (s *PaymentRequestSvcImpl) lockPending(
+	ctx context.Context, req *domain.PaymentRequestActionRequest,
+) (request *entity.PaymentRequest, err error) {
+	request, err = s.repo.GetPaymentRequestForUpdate(ctx, req.PaymentRequestID)
+	if err != nil {
+		log.ZError(ctx, "while lock payment request", err, "paymentRequestID", req.PaymentRequestID)
+		return nil, err
+	}
+
+	if request.PayerUserID != req.UserID {
+		return nil, eerrs.ErrPaymentRequestNotFound
+	}
+	if request.StatusPaymentRequest == entity.StatusPaymentRequestExpired ||
+		(request.StatusPaymentRequest == entity.StatusPaymentRequestPending && !request.ExpiredAt.After(time.Now())) {
+		return nil, eerrs.ErrPaymentRequestExpired
+	}
+	if request.StatusPaymentRequest != entity.StatusPaymentRequestPending {
+		return nil, eerrs.ErrPaymentRequestNotPending
+	}
+
+	return request, nil
+}
+
+func (s *PaymentRequestSvcImpl) GetListPaymentRequest(
+	ctx context.Context, req *domain.GetListPaymentRequestRequest,
+) (resp *domain.GetListPaymentRequestResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var (
+		requests []*entity.PaymentRequest
+		total    int64
+	)
+	requests, total, err = s.repo.GetListPaymentRequest(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list payment request", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.String("userID", req.UserID),
+		attribute.Int64("total", total),
+	)
+
+	return &domain.GetListPaymentRequestResponse{
+		TotalCount:      total,
+		Page:            req.Page,
+		Limit:           req.Limit,
+		PaymentRequests: dtoPaymentRequests(requests),
+	}, nil
+}
+
+func (s *PaymentRequestSvcImpl) ExpirePaymentRequests(
+	ctx context.Context, operatedBy string,
+) (expired int64, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	expired, err = s.repo.ExpirePaymentRequests(ctx, time.Now(), operatedBy)
+	if err != nil {
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("expired", expired))
+
+	return expired, nil
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_payment_request"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_usecase"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func TestPaymentRequest_CreatePaymentRequest(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		request    *domain.CreatePaymentRequestRequest
+		wantError  error
+		onMockRepo func(mock *mock_payment_request.MockRepository)
+	}{
+		{
+			desc:      "self_request",
+			request:   &domain.CreatePaymentRequestRequest{RequesterUserID: "1", PayerUserID: "1", Amount: entity.NewMoney(10)},
+			wantError: errors.New("payer and requester must be different"),
+		},
+		{
+			desc: "ErrInvalidPaymentRequestExpiry",
+			request: &domain.CreatePaymentRequestRequest{
+				RequesterUserID: "1", PayerUserID: "2", Amount: entity.NewMoney(10),
+				ExpiredAt: func() *time.Time { at := time.Now().Add(8 * 24 * time.Hour); return &at }(),
+			},
+			wantError: eerrs.ErrInvalidPaymentRequestExpiry,
+		},
+		{
+			desc:    "success_default_expiry",
+			request: &domain.CreatePaymentRequestRequest{RequesterUserID: "1", PayerUserID: "2", Amount: entity.NewMoney(10)},
+			onMockRepo: func(mock *mock_payment_request.MockRepository) {
+				mock.EXPECT().
+					CreatePaymentRequest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, request *entity.PaymentRequest) (int64, error) {
+						assert.Equal(t, entity.StatusPaymentRequestPending, request.StatusPaymentRequest)
+						assert.Equal(t, entity.CurrencyCNY, request.Currency)
+						assert.WithinDuration(t, time.Now().Add(domain.DefaultPaymentRequestExpiry), request.ExpiredAt, time.Minute)
+						request.PaymentRequestID = 5
+						return 5, nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_payment_request.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+
+			uc := NewPaymentRequestUseCase(repo, newMockHoldTxRepo(ctrl), mock_usecase.NewMockTransferSvc(ctrl))
+			resp, err := uc.CreatePaymentRequest(context.Background(), tC.request)
+			if tC.wantError != nil {
+				require.Error(t, err)
+				assert.Equal(t, tC.wantError.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), resp.PaymentRequestID)
+			assert.Equal(t, "pending", resp.StatusPaymentRequest)
+		})
+	}
+}
+
+func TestPaymentRequest_PayPaymentRequest(t *testing.T) {
+	pending := func() *entity.PaymentRequest {
+		return &entity.PaymentRequest{
+			PaymentRequestID:     5,
+			RequesterUserID:      "1",
+			PayerUserID:          "2",
+			Amount:               entity.NewMoney(10),
+			Currency:             entity.CurrencyCNY,
+			Remark:               "dinner",
+			StatusPaymentRequest: entity.StatusPaymentRequestPending,
+			ExpiredAt:            time.Now().Add(time.Hour),
+		}
+	}
+	testCases := []struct {
+		desc             string
+		request          *entity.PaymentRequest
+		wantError        error
+		onMockTransferUc func(mock *mock_usecase.MockTransferSvc)
+	}{
+		{
+			desc: "other_user",
+			request: func() *entity.PaymentRequest {
+				request := pending()
+				request.PayerUserID = "3"
+				return request
+			}(),
+			wantError: eerrs.ErrPaymentRequestNotFound,
+		},
+		{
+			desc: "already_declined",
+			request: func() *entity.PaymentRequest {
+				request := pending()
+				request.StatusPaymentRequest = entity.StatusPaymentRequestDeclined
+				return request
+			}(),
+			wantError: eerrs.ErrPaymentRequestNotPending,
+		},
+		{
+			desc: "expired_not_swept_yet",
+			request: func() *entity.PaymentRequest {
+				request := pending()
+				request.ExpiredAt = time.Now().Add(-time.Minute)
+				return request
+			}(),
+			wantError: eerrs.ErrPaymentRequestExpired,
+		},
+		{
+			desc:      "insufficient_balance",
+			request:   pending(),
+			wantError: eerrs.ErrInsufficientBalance,
+			onMockTransferUc: func(mock *mock_usecase.MockTransferSvc) {
+				mock.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(nil, eerrs.ErrInsufficientBalance)
+			},
+		},
+		{
+			desc:    "paid_and_settled",
+			request: pending(),
+			onMockTransferUc: func(mock *mock_usecase.MockTransferSvc) {
+				gomock.InOrder(
+					mock.EXPECT().
+						CreateTransfer(gomock.Any(), &domain.CreateTransferRequest{
+							FromUserID: "2",
+							ToUserID:   "1",
+							Amount:     entity.NewMoney(10),
+							Currency:   "CNY",
+							Remark:     "dinner",
+							CreatedBy:  "2-user",
+							DeviceID:   "device-1",
+						}).
+						Return(&domain.CreateTransferResponse{TransferID: 9}, nil),
+					mock.EXPECT().
+						ClaimTransfer(gomock.Any(), &domain.ClaimTransferRequest{
+							TransferID:    9,
+							ClaimerUserID: "1",
+							OperateBy:     "2-user",
+						}).
+						Return(nil),
+				)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_payment_request.NewMockRepository(ctrl)
+			repo.EXPECT().GetPaymentRequestForUpdate(gomock.Any(), int64(5)).Return(tC.request, nil)
+			if tC.wantError == nil {
+				repo.EXPECT().
+					UpdatePaymentRequest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, request *entity.PaymentRequest) error {
+						assert.Equal(t, entity.StatusPaymentRequestPaid, request.StatusPaymentRequest)
+						assert.NotNil(t, request.PaidAt)
+						return nil
+					})
+			}
+			transferUc := mock_usecase.NewMockTransferSvc(ctrl)
+			if tC.onMockTransferUc != nil {
+				tC.onMockTransferUc(transferUc)
+			}
+
+			uc := NewPaymentRequestUseCase(repo, newMockHoldTxRepo(ctrl), transferUc)
+			resp, err := uc.PayPaymentRequest(context.Background(), &domain.PaymentRequestActionRequest{
+				PaymentRequestID: 5,
+				UserID:           "2",
+				OperatedBy:       "2-user",
+				DeviceID:         "device-1",
+			})
+			if tC.wantError != nil {
+				require.ErrorIs(t, err, tC.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "paid", resp.StatusPaymentRequest)
+			assert.Equal(t, int64Ptr(9), resp.TransferID)
+		})
+	}
+}
+
+func TestPaymentRequest_DeclinePaymentRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := mock_payment_request.NewMockRepository(ctrl)
+	repo.EXPECT().
+		GetPaymentRequestForUpdate(gomock.Any(), int64(5)).
+		Return(&entity.PaymentRequest{
+			PaymentRequestID:     5,
+			RequesterUserID:      "1",
+			PayerUserID:          "2",
+			StatusPaymentRequest: entity.StatusPaymentRequestPending,
+			ExpiredAt:            time.Now().Add(time.Hour),
+		}, nil)
+	repo.EXPECT().
+		UpdatePaymentRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *entity.PaymentRequest) error {
+			assert.Equal(t, entity.StatusPaymentRequestDeclined, request.StatusPaymentRequest)
+			assert.NotNil(t, request.DeclinedAt)
+			assert.Nil(t, request.TransferID)
+			return nil
+		})
+
+	uc := NewPaymentRequestUseCase(repo, newMockHoldTxRepo(ctrl), mock_usecase.NewMockTransferSvc(ctrl))
+	resp, err := uc.DeclinePaymentRequest(context.Background(), &domain.PaymentRequestActionRequest{
+		PaymentRequestID: 5,
+		UserID:           "2",
+		OperatedBy:       "2-user",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "declined", resp.StatusPaymentRequest)
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/reconciliation"
+)
+
+type (
+	ReconciliationSvcImpl struct {
+		repo reconciliation.Repository
+	}
+
+	ReconciliationSvc interface {
+		// RunReconciliation checks every wallet and envelope against the rows that make up
+		// its amounts and persists each discrepancy found under a new reconciliation run.
+		RunReconciliation(ctx context.Context, operatedBy string) (resp *domain.ReconciliationRun, err error)
+		GetReconciliation(
+			ctx context.Context, req *domain.GetReconciliationRequest,
+		) (resp *domain.GetReconciliationResponse, err error)
+	}
+)
+
+func NewReconciliationUseCase(repo reconciliation.Repository) ReconciliationSvc {
+	return &ReconciliationSvcImpl{
+		repo: repo,
+	}
+}
+
+func (s *ReconciliationSvcImpl) RunReconciliation(
+	ctx context.Context, operatedBy string,
+) (resp *domain.ReconciliationRun, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	run := &entity.ReconciliationRun{
+		StatusReconciliation: entity.StatusReconciliationRunning,
+		StartedAt:            time.Now(),
+		CreatedBy:            operatedBy,
+	}
+	_, err = s.repo.CreateRun(ctx, run)
+	if err != nil {
+		log.ZError(ctx, "while create reconciliation run", err)
+		return resp, err
+	}
+
+	err = s.reconcileWallets(ctx, run)
+	if err == nil {
+		err = s.reconcileEnvelopes(ctx, run)
+	}
+
+	run.StatusReconciliation = entity.StatusReconciliationFinished
+	if err != nil {
+		log.ZError(ctx, "while reconcile", err, "reconciliationRunID", run.ReconciliationRunID)
+		run.StatusReconciliation = entity.StatusReconciliationFailed
+	}
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+
+	if errUpdate := s.repo.UpdateRun(ctx, run); errUpdate != nil {
+		log.ZError(ctx, "while update reconciliation run", errUpdate, "reconciliationRunID", run.ReconciliationRunID)
+		if err == nil {
+			err = errUpdate
+		}
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("reconciliationRunID", run.ReconciliationRunID),
+		attribute.Int64("walletsChecked", run.WalletsChecked),
+		attribute.Int64("envelopesChecked", run.EnvelopesChecked),
+		attribute.Int64("discrepancyCount", run.DiscrepancyCount),
+	)
+
+	return dtoReconciliationRun(run), nil
+}
+
+// reconcileWallets walks every wallet in batches, comparing its balance with the sum of its
+// wallet transactions and checking that the transactions chain from one balance to the next.
+func (s *ReconciliationSvcImpl) reconcileWallets(ctx context.Context, run *entity.ReconciliationRun) error {
+	var afterWalletID int64
+	for {
+		sums, err := s.repo.GetWalletBalanceSums(ctx, afterWalletID, entity.ReconciliationBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(sums) == 0 {
+			return nil
+		}
+
+		var (
+			discrepancies []*entity.ReconciliationDiscrepancy
+			walletIDs     = make([]int64, 0, len(sums))
+		)
+		for _, sum := range sums {
+			walletIDs = append(walletIDs, sum.WalletID)
+			if sum.Balance == sum.TransactionTotal {
+				continue
+			}
+
+			discrepancies = append(discrepancies, &entity.ReconciliationDiscrepancy{
+				ReconciliationRunID: run.ReconciliationRunID,
+				DiscrepancyType:     entity.DiscrepancyTypeWalletBalance,
+				WalletID:            &sum.WalletID,
+				Expected:            sum.TransactionTotal,
+				Actual:              sum.Balance,
+			})
+		}
+
+		breaks, err := s.repo.GetBalanceChainBreaks(ctx, walletIDs)
+		if err != nil {
+			return err
+		}
+		for _, chainBreak := range breaks {
+			discrepancies = append(discrepancies, &entity.ReconciliationDiscrepancy{
+				ReconciliationRunID: run.ReconciliationRunID,
+				DiscrepancyType:     entity.DiscrepancyTypeBalanceChain,
+				WalletID:            &chainBreak.WalletID,
+				WalletTransactionID: &chainBreak.WalletTransactionID,
+				Expected:            chainBreak.PreviousAfterBalance,
+				Actual:              chainBreak.BeforeBalance,
+			})
+		}
+
+		if err = s.repo.CreateDiscrepancies(ctx, discrepancies); err != nil {
+			return err
+		}
+
+		run.WalletsChecked += int64(len(sums))
+		run.DiscrepancyCount += int64(len(discrepancies))
+		afterWalletID = sums[len(sums)-1].WalletID
+
+		if len(sums) < entity.ReconciliationBatchSize {
+			return nil
+		}
+	}
+}
+
+// reconcileEnvelopes walks every envelope in batches, comparing its claimed total with the sum
+// of its claimed details.
+func (s *ReconciliationSvcImpl) reconcileEnvelopes(ctx context.Context, run *entity.ReconciliationRun) error {
+	var afterEnvelopeID int64
+	for {
+		sums, err := s.repo.GetEnvelopeClaimSums(ctx, afterEnvelopeID, entity.ReconciliationBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(sums) == 0 {
+			return nil
+		}
+
+		var discrepancies []*entity.ReconciliationDiscrepancy
+		for _, sum := range sums {
+			if sum.TotalAmountClaimed == sum.ClaimedTotal {
+				continue
+			}
+
+			discrepancies = append(discrepancies, &entity.ReconciliationDiscrepancy{
+				ReconciliationRunID: run.ReconciliationRunID,
+				DiscrepancyType:     entity.DiscrepancyTypeEnvelopeClaimed,
+				EnvelopeID:          &sum.EnvelopeID,
+				Expected:            sum.ClaimedTotal,
+				Actual:              sum.TotalAmountClaimed,
+			})
+		}
+
+		if err = s.repo.CreateDiscrepancies(ctx, discrepancies); err != nil {
+			return err
+		}
+
+		run.EnvelopesChecked += int64(len(sums))
+		run.DiscrepancyCount += int64(len(discrepancies))
+		afterEnvelopeID = sums[len(sums)-1].EnvelopeID
+
+		if len(sums) < entity.ReconciliationBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *ReconciliationSvcImpl) GetReconciliation(
+	ctx context.Context, req *domain.GetReconciliationRequest,
+) (resp *domain.GetReconciliationResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var run *entity.ReconciliationRun
+	run, err = s.repo.GetRun(ctx, req.ReconciliationRunID)
+	if err != nil {
+		log.ZError(ctx, "while get reconciliation run", err, "reconciliationRunID", req.ReconciliationRunID)
+		return resp, err
+	}
+	req.ReconciliationRunID = run.ReconciliationRunID
+
+	var (
+		discrepancies []*entity.ReconciliationDiscrepancy
+		total         int64
+	)
+	discrepancies, total, err = s.repo.GetListDiscrepancy(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list reconciliation discrepancy", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("reconciliationRunID", run.ReconciliationRunID),
+		attribute.Int64("total", total),
+	)
+
+	return &domain.GetReconciliationResponse{
+		Run:           dtoReconciliationRun(run),
+		TotalCount:    total,
+		Page:          req.Page,
+		Limit:         req.Limit,
+		Discrepancies: dtoReconciliationDiscrepancies(discrepancies),
+	}, nil
+}
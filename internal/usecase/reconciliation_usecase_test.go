@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_reconciliation"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func TestReconciliation_RunReconciliation(t *testing.T) {
+	type expected struct {
+		run            *domain.ReconciliationRun
+		discrepancies  []*entity.ReconciliationDiscrepancy
+		finishedStatus entity.StatusReconciliation
+		err            error
+	}
+	testCases := []struct {
+		desc      string
+		expected  expected
+		wantError bool
+		onMock    func(mock *mock_reconciliation.MockRepository)
+	}{
+		{
+			desc:      "error_while_CreateRun",
+			expected:  expected{err: errors.New("something went wrong")},
+			wantError: true,
+			onMock: func(mock *mock_reconciliation.MockRepository) {
+				mock.EXPECT().CreateRun(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("something went wrong"))
+			},
+		},
+		{
+			desc: "error_while_GetWalletBalanceSums_marks_run_failed",
+			expected: expected{
+				err:            errors.New("something went wrong"),
+				finishedStatus: entity.StatusReconciliationFailed,
+			},
+			wantError: true,
+			onMock: func(mock *mock_reconciliation.MockRepository) {
+				mock.EXPECT().GetWalletBalanceSums(gomock.Any(), int64(0), entity.ReconciliationBatchSize).
+					Return(nil, errors.New("something went wrong"))
+			},
+		},
+		{
+			desc: "success_no_discrepancy",
+			expected: expected{
+				run: &domain.ReconciliationRun{
+					ReconciliationRunID: 1,
+					Status:              "finished",
+					WalletsChecked:      2,
+					EnvelopesChecked:    1,
+				},
+				finishedStatus: entity.StatusReconciliationFinished,
+			},
+			onMock: func(mock *mock_reconciliation.MockRepository) {
+				mock.EXPECT().GetWalletBalanceSums(gomock.Any(), int64(0), entity.ReconciliationBatchSize).
+					Return([]*entity.WalletBalanceSum{
+						{WalletID: 1, Balance: entity.NewMoney(10), TransactionTotal: entity.NewMoney(10)},
+						{WalletID: 2},
+					}, nil)
+				mock.EXPECT().GetBalanceChainBreaks(gomock.Any(), []int64{1, 2}).Return(nil, nil)
+				mock.EXPECT().GetEnvelopeClaimSums(gomock.Any(), int64(0), entity.ReconciliationBatchSize).
+					Return([]*entity.EnvelopeClaimSum{
+						{EnvelopeID: 7, TotalAmountClaimed: entity.NewMoney(5), ClaimedTotal: entity.NewMoney(5)},
+					}, nil)
+			},
+		},
+		{
+			desc: "success_with_discrepancies",
+			expected: expected{
+				run: &domain.ReconciliationRun{
+					ReconciliationRunID: 1,
+					Status:              "finished",
+					WalletsChecked:      2,
+					EnvelopesChecked:    1,
+					DiscrepancyCount:    3,
+				},
+				discrepancies: []*entity.ReconciliationDiscrepancy{
+					{
+						ReconciliationRunID: 1,
+						DiscrepancyType:     entity.DiscrepancyTypeWalletBalance,
+						WalletID:            int64Ptr(2),
+						Expected:            entity.NewMoney(40),
+						Actual:              entity.NewMoney(50),
+					},
+					{
+						ReconciliationRunID: 1,
+						DiscrepancyType:     entity.DiscrepancyTypeBalanceChain,
+						WalletID:            int64Ptr(2),
+						WalletTransactionID: int64Ptr(9),
+						Expected:            entity.NewMoney(20),
+						Actual:              entity.NewMoney(30),
+					},
+					{
+						ReconciliationRunID: 1,
+						DiscrepancyType:     entity.DiscrepancyTypeEnvelopeClaimed,
+						EnvelopeID:          int64Ptr(7),
+						Expected:            entity.NewMoney(3),
+						Actual:              entity.NewMoney(5),
+					},
+				},
+				finishedStatus: entity.StatusReconciliationFinished,
+			},
+			onMock: func(mock *mock_reconciliation.MockRepository) {
+				mock.EXPECT().GetWalletBalanceSums(gomock.Any(), int64(0), entity.ReconciliationBatchSize).
+					Return([]*entity.WalletBalanceSum{
+						{WalletID: 1, Balance: entity.NewMoney(10), TransactionTotal: entity.NewMoney(10)},
+						{WalletID: 2, Balance: entity.NewMoney(50), TransactionTotal: entity.NewMoney(40)},
+					}, nil)
+				mock.EXPECT().GetBalanceChainBreaks(gomock.Any(), []int64{1, 2}).
+					Return([]*entity.BalanceChainBreak{
+						{
+							WalletTransactionID:  9,
+							WalletID:             2,
+							BeforeBalance:        entity.NewMoney(30),
+							PreviousAfterBalance: entity.NewMoney(20),
+						},
+					}, nil)
+				mock.EXPECT().GetEnvelopeClaimSums(gomock.Any(), int64(0), entity.ReconciliationBatchSize).
+					Return([]*entity.EnvelopeClaimSum{
+						{EnvelopeID: 7, TotalAmountClaimed: entity.NewMoney(5), ClaimedTotal: entity.NewMoney(3)},
+					}, nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			var (
+				discrepancies []*entity.ReconciliationDiscrepancy
+				updated       *entity.ReconciliationRun
+			)
+			repo := mock_reconciliation.NewMockRepository(ctrl)
+			tC.onMock(repo)
+			repo.EXPECT().CreateRun(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, run *entity.ReconciliationRun) (int64, error) {
+					run.ReconciliationRunID = 1
+					return 1, nil
+				}).MaxTimes(1)
+			repo.EXPECT().CreateDiscrepancies(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, rows []*entity.ReconciliationDiscrepancy) error {
+					discrepancies = append(discrepancies, rows...)
+					return nil
+				}).AnyTimes()
+			repo.EXPECT().UpdateRun(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, run *entity.ReconciliationRun) error {
+					updated = run
+					return nil
+				}).MaxTimes(1)
+
+			svc := NewReconciliationUseCase(repo)
+
+			got, err := svc.RunReconciliation(context.Background(), "admin")
+			if tC.expected.finishedStatus != "" {
+				require.NotNil(t, updated)
+				assert.Equal(t, tC.expected.finishedStatus, updated.StatusReconciliation)
+				assert.NotNil(t, updated.FinishedAt)
+			}
+			if !tC.wantError {
+				require.NoError(t, err)
+				got.StartedAt, got.FinishedAt = tC.expected.run.StartedAt, tC.expected.run.FinishedAt
+				assert.Equal(t, tC.expected.run, got)
+				assert.Equal(t, tC.expected.discrepancies, discrepancies)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestReconciliation_GetReconciliation(t *testing.T) {
+	type expected struct {
+		resp *domain.GetReconciliationResponse
+		err  error
+	}
+	testCases := []struct {
+		desc      string
+		request   *domain.GetReconciliationRequest
+		expected  expected
+		wantError bool
+		onMock    func(mock *mock_reconciliation.MockRepository)
+	}{
+		{
+			desc:      "invalid_discrepancy_type",
+			request:   &domain.GetReconciliationRequest{DiscrepancyType: "unknown", Page: 1, Limit: 10},
+			expected:  expected{err: eerrs.ErrInvalidDiscrepancyType},
+			wantError: true,
+		},
+		{
+			desc:      "run_not_found",
+			request:   &domain.GetReconciliationRequest{ReconciliationRunID: 3, Page: 1, Limit: 10},
+			expected:  expected{err: eerrs.ErrReconciliationRunNotFound},
+			wantError: true,
+			onMock: func(mock *mock_reconciliation.MockRepository) {
+				mock.EXPECT().GetRun(gomock.Any(), int64(3)).Return(nil, eerrs.ErrReconciliationRunNotFound)
+			},
+		},
+		{
+			desc:    "success_latest_run",
+			request: &domain.GetReconciliationRequest{DiscrepancyType: "wallet_balance", Page: 1, Limit: 10},
+			expected: expected{resp: &domain.GetReconciliationResponse{
+				Run: &domain.ReconciliationRun{
+					ReconciliationRunID: 5,
+					Status:              "finished",
+					DiscrepancyCount:    1,
+				},
+				TotalCount: 1,
+				Page:       1,
+				Limit:      10,
+				Discrepancies: []*domain.ReconciliationDiscrepancy{
+					{
+						ReconciliationDiscrepancyID: 11,
+						DiscrepancyType:             "wallet_balance",
+						WalletID:                    int64Ptr(2),
+						Expected:                    entity.NewMoney(40),
+						Actual:                      entity.NewMoney(50),
+					},
+				},
+			}},
+			onMock: func(mock *mock_reconciliation.MockRepository) {
+				mock.EXPECT().GetRun(gomock.Any(), int64(0)).Return(&entity.ReconciliationRun{
+					ReconciliationRunID:  5,
+					StatusReconciliation: entity.StatusReconciliationFinished,
+					DiscrepancyCount:     1,
+				}, nil)
+				mock.EXPECT().GetListDiscrepancy(gomock.Any(), &domain.GetReconciliationRequest{
+					ReconciliationRunID: 5, DiscrepancyType: "wallet_balance", Page: 1, Limit: 10,
+				}).Return([]*entity.ReconciliationDiscrepancy{
+					{
+						ReconciliationDiscrepancyID: 11,
+						ReconciliationRunID:         5,
+						DiscrepancyType:             entity.DiscrepancyTypeWalletBalance,
+						WalletID:                    int64Ptr(2),
+						Expected:                    entity.NewMoney(40),
+						Actual:                      entity.NewMoney(50),
+					},
+				}, int64(1), nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_reconciliation.NewMockRepository(ctrl)
+			if tC.onMock != nil {
+				tC.onMock(repo)
+			}
+
+			svc := NewReconciliationUseCase(repo)
+
+			got, err := svc.GetReconciliation(context.Background(), tC.request)
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.ErrorIs(t, err, tC.expected.err)
+			}
+		})
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
@@ -0,0 +1,570 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/errs"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/recurring_transfer"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/imapi"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+	"github.com/1nterdigital/aka-im-wallet/pkg/util/convert"
+)
+
+// the IM message telling the sender a run was skipped, in English and Chinese
+const (
+	recurringRunSkippedEn = "Your recurring transfer #%d of %s %s to %s was skipped: %s"
+	recurringRunSkippedZh = "您的定期转账 #%d（%s %s 转给 %s）已跳过：%s"
+)
+
+type (
+	RecurringTransferSvcImpl struct {
+		repo       recurring_transfer.Repository
+		txRepo     tx.Repository
+		transferUc TransferSvc
+		imCaller   imapi.CallerInterface
+	}
+
+	RecurringTransferSvc interface {
+		CreateRecurringTransfer(
+			ctx context.Context, req *domain.CreateRecurringTransferRequest,
+		) (resp *domain.RecurringTransferResponse, err error)
+		PauseRecurringTransfer(
+			ctx context.Context, req *domain.RecurringTransferActionRequest,
+		) (resp *domain.RecurringTransferResponse, err error)
+		// ResumeRecurringTransfer picks a paused order up again from its next run after now, the
+		// runs missed while paused are not made up for.
+		ResumeRecurringTransfer(
+			ctx context.Context, req *domain.RecurringTransferActionRequest,
+		) (resp *domain.RecurringTransferResponse, err error)
+		CancelRecurringTransfer(
+			ctx context.Context, req *domain.RecurringTransferActionRequest,
+		) (resp *domain.RecurringTransferResponse, err error)
+		GetListRecurringTransfer(
+			ctx context.Context, req *domain.GetListRecurringTransferRequest,
+		) (resp *domain.GetListRecurringTransferResponse, err error)
+		GetListRecurringTransferRun(
+			ctx context.Context, req *domain.GetListRecurringTransferRunRequest,
+		) (resp *domain.GetListRecurringTransferRunResponse, err error)
+		FetchDueRecurringTransfers(ctx context.Context) (msgs []*domain.MsgKafkaRecurringTransfer, err error)
+		// ExecuteRecurringTransfers makes the due run of each order through CreateTransfer. A run
+		// rejected by validation, e.g. for insufficient balance, is recorded as skipped with the
+		// reason, the sender is told over IM and the order moves on; the ones that hit any other
+		// error are returned to be tried again.
+		ExecuteRecurringTransfers(
+			ctx context.Context, msgs []*domain.MsgKafkaRecurringTransfer,
+		) (failed []*domain.MsgKafkaRecurringTransfer, err error)
+	}
+)
+
+func NewRecurringTransferUseCase(
+	repo recurring_transfer.Repository,
+	txRepo tx.Repository,
+	transferUc TransferSvc,
+	imCaller imapi.CallerInterface,
+) RecurringTransferSvc {
+	return &RecurringTransferSvcImpl{
+		repo:       repo,
+		txRepo:     txRepo,
+		transferUc: transferUc,
+		imCaller:   imCaller,
+	}
+}
+
+func (s *RecurringTransferSvcImpl) CreateRecurringTransfer(
+	ctx context.Context, req *domain.CreateRecurringTransferRequest,
+) (resp *domain.RecurringTransferResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(req.Currency)
+	if err != nil {
+		return resp, err
+	}
+	req.Currency = currency.String()
+
+	if err = req.Validate(time.Now()); err != nil {
+		return resp, err
+	}
+
+	recurring := &entity.RecurringTransfer{
+		FromUserID:              req.FromUserID,
+		ToUserID:                req.ToUserID,
+		Amount:                  req.Amount,
+		Currency:                currency,
+		Remark:                  req.Remark,
+		Frequency:               entity.RecurringFrequency(req.Frequency),
+		StartAt:                 req.StartAt,
+		EndAt:                   req.EndAt,
+		MaxOccurrences:          req.MaxOccurrences,
+		NextRunAt:               req.StartAt,
+		StatusRecurringTransfer: entity.StatusRecurringTransferActive,
+		IsActive:                true,
+		CreatedBy:               req.OperatedBy,
+		UpdatedBy:               req.OperatedBy,
+	}
+	_, err = s.repo.CreateRecurringTransfer(ctx, recurring)
+	if err != nil {
+		log.ZError(ctx, "while create recurring transfer", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("recurringTransferID", recurring.RecurringTransferID),
+		attribute.String("frequency", req.Frequency),
+	)
+
+	return dtoRecurringTransferResponse(recurring), nil
+}
+
+func (s *RecurringTransferSvcImpl) PauseRecurringTransfer(
+	ctx context.Context, req *domain.RecurringTransferActionRequest,
+) (resp *domain.RecurringTransferResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return s.changeStatus(ctx, req, func(recurring *entity.RecurringTransfer) error {
+		if recurring.StatusRecurringTransfer != entity.StatusRecurringTransferActive {
+			return eerrs.ErrRecurringTransferStatusConflict
+		}
+		recurring.StatusRecurringTransfer = entity.StatusRecurringTransferPaused
+
+		return nil
+	})
+}
+
+func (s *RecurringTransferSvcImpl) ResumeRecurringTransfer(
+	ctx context.Context, req *domain.RecurringTransferActionRequest,
+) (resp *domain.RecurringTransferResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	now := time.Now()
+
+	return s.changeStatus(ctx, req, func(recurring *entity.RecurringTransfer) error {
+		if recurring.StatusRecurringTransfer != entity.StatusRecurringTransferPaused {
+			return eerrs.ErrRecurringTransferStatusConflict
+		}
+		recurring.StatusRecurringTransfer = entity.StatusRecurringTransferActive
+
+		for recurring.NextRunAt.Before(now) {
+			recurring.NextRunAt = recurring.Frequency.Next(recurring.NextRunAt, recurring.StartAt.Day())
+		}
+		if recurring.EndAt != nil && recurring.NextRunAt.After(*recurring.EndAt) {
+			recurring.StatusRecurringTransfer = entity.StatusRecurringTransferCompleted
+		}
+
+		return nil
+	})
+}
+
+func (s *RecurringTransferSvcImpl) CancelRecurringTransfer(
+	ctx context.Context, req *domain.RecurringTransferActionRequest,
+) (resp *domain.RecurringTransferResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return s.changeStatus(ctx, req, func(recurring *entity.RecurringTransfer) error {
+		if recurring.StatusRecurringTransfer != entity.StatusRecurringTransferActive &&
+			recurring.StatusRecurringTransfer != entity.StatusRecurringTransferPaused {
+			return eerrs.ErrRecurringTransferStatusConflict
+		}
+		recurring.StatusRecurringTransfer = entity.StatusRecurringTransferCanceled
+		recurring.CanceledAt = convert.PtrTime(time.Now())
+
+		return nil
+	})
+}
+
+// changeStatus locks the order of req.UserID and stores it once apply has moved it to its new status.
+func (s *RecurringTransferSvcImpl) changeStatus(
+	ctx context.Context, req *domain.RecurringTransferActionRequest, apply func(*entity.RecurringTransfer) error,
+) (resp *domain.RecurringTransferResponse, err error) {
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var recurring *entity.RecurringTransfer
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		recurring, err = s.repo.GetRecurringTransferForUpdate(ctx, req.RecurringTransferID)
+		if err != nil {
+			log.ZError(ctx, "while lock recurring transfer", err, "recurringTransferID", req.RecurringTransferID)
+			return err
+		}
+
+		if recurring.FromUserID != req.UserID {
+			return eerrs.ErrRecurringTransferNotFound
+		}
+		if err = apply(recurring); err != nil {
+			return err
+		}
+
+		recurring.UpdatedBy = req.OperatedBy
+		err = s.repo.UpdateRecurringTransfer(ctx, recurring)
+		if err != nil {
+			log.ZError(ctx, "while update recurring transfer", err, "recurringTransferID", req.RecurringTransferID)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return dtoRecurringTransferResponse(recurring), nil
+}
+
+func (s *RecurringTransferSvcImpl) GetListRecurringTransfer(
+	ctx context.Context, req *domain.GetListRecurringTransferRequest,
+) (resp *domain.GetListRecurringTransferResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var (
+		recurring []*entity.RecurringTransfer
+		total     int64
+	)
+	recurring, total, err = s.repo.GetListRecurringTransfer(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list recurring transfer", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.String("userID", req.UserID),
+		attribute.Int64("total", total),
+	)
+
+	return &domain.GetListRecurringTransferResponse{
+		TotalCount:         total,
+		Page:               req.Page,
+		Limit:              req.Limit,
+		RecurringTransfers: dtoRecurringTransfers(recurring),
+	}, nil
+}
+
+func (s *RecurringTransferSvcImpl) GetListRecurringTransferRun(
+	ctx context.Context, req *domain.GetListRecurringTransferRunRequest,
+) (resp *domain.GetListRecurringTransferRunResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var recurring *entity.RecurringTransfer
+	recurring, err = s.repo.GetRecurringTransfer(ctx, req.RecurringTransferID)
+	if err != nil {
+		return resp, err
+	}
+	if recurring.FromUserID != req.UserID {
+		return resp, eerrs.ErrRecurringTransferNotFound
+	}
+
+	var (
+		runs  []*entity.RecurringTransferRun
+		total int64
+	)
+	runs, total, err = s.repo.GetListRun(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list recurring transfer run", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("recurringTransferID", req.RecurringTransferID),
+		attribute.Int64("total", total),
+	)
+
+	return &domain.GetListRecurringTransferRunResponse{
+		TotalCount: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Runs:       dtoRecurringTransferRuns(runs),
+	}, nil
+}
+
+func (s *RecurringTransferSvcImpl) FetchDueRecurringTransfers(
+	ctx context.Context,
+) (msgs []*domain.MsgKafkaRecurringTransfer, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var recurring []*entity.RecurringTransfer
+	recurring, err = s.repo.FetchDueRecurringTransfers(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range recurring {
+		msgs = append(msgs, &domain.MsgKafkaRecurringTransfer{
+			RecurringTransferID: recurring[idx].RecurringTransferID,
+		})
+	}
+
+	span.SetAttributes(attribute.Int("total", len(msgs)))
+
+	return msgs, nil
+}
+
+func (s *RecurringTransferSvcImpl) ExecuteRecurringTransfers(
+	ctx context.Context, msgs []*domain.MsgKafkaRecurringTransfer,
+) (failed []*domain.MsgKafkaRecurringTransfer, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	retryAttemptsProcess := 4
+
+	for idx := range msgs {
+		msgs[idx].Counter += 1
+		if msgs[idx].Counter >= retryAttemptsProcess {
+			// the run stays due, the next publisher run picks it up again
+			continue
+		}
+
+		errx := s.execute(ctx, msgs[idx])
+		if errx == nil || errors.Is(errx, eerrs.ErrRecurringTransferNotFound) {
+			continue
+		}
+
+		var codeErr errs.CodeError
+		if errors.As(errx, &codeErr) {
+			errx = s.skipRun(ctx, msgs[idx], codeErr)
+			if errx == nil {
+				continue
+			}
+		}
+
+		log.ZError(ctx, "while execute recurring transfer", errx,
+			"recurringTransferID", msgs[idx].RecurringTransferID,
+			"operatedBy", msgs[idx].OperatedBy,
+		)
+		failed = append(failed, msgs[idx])
+	}
+
+	span.SetAttributes(attribute.Int("failed", len(failed)))
+
+	return failed, nil
+}
+
+// execute makes the due run of the order. A run missed while the consumer was down is made on its
+// own, the next one is left for the following message.
+func (s *RecurringTransferSvcImpl) execute(ctx context.Context, msg *domain.MsgKafkaRecurringTransfer) (err error) {
+	return s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		var recurring *entity.RecurringTransfer
+		recurring, err = s.repo.GetRecurringTransferForUpdate(ctx, msg.RecurringTransferID)
+		if err != nil {
+			return err
+		}
+
+		// paused, canceled or already run since the message was queued
+		if recurring.StatusRecurringTransfer != entity.StatusRecurringTransferActive ||
+			recurring.NextRunAt.After(time.Now()) {
+			return nil
+		}
+
+		var transfer *domain.CreateTransferResponse
+		transfer, err = s.transferUc.CreateTransfer(ctx, &domain.CreateTransferRequest{
+			FromUserID: recurring.FromUserID,
+			ToUserID:   recurring.ToUserID,
+			Amount:     recurring.Amount,
+			Currency:   recurring.Currency.String(),
+			Remark:     recurring.Remark,
+			CreatedBy:  msg.OperatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.recordRun(ctx, recurring, &entity.RecurringTransferRun{
+			StatusRun:  entity.StatusRecurringRunExecuted,
+			TransferID: &transfer.TransferID,
+			CreatedBy:  msg.OperatedBy,
+		})
+	})
+}
+
+// skipRun records why the run was not sent so the sender can see it in the run history and
+// tells the sender over IM once it is stored, the order carries on with its next run.
+func (s *RecurringTransferSvcImpl) skipRun(
+	ctx context.Context, msg *domain.MsgKafkaRecurringTransfer, cause errs.CodeError,
+) (err error) {
+	var recurring *entity.RecurringTransfer
+	skipped := false
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		recurring, err = s.repo.GetRecurringTransferForUpdate(ctx, msg.RecurringTransferID)
+		if err != nil {
+			return err
+		}
+
+		if recurring.StatusRecurringTransfer != entity.StatusRecurringTransferActive ||
+			recurring.NextRunAt.After(time.Now()) {
+			return nil
+		}
+
+		log.ZWarn(ctx, "recurring transfer run skipped", fmt.Errorf("%d: %s", cause.Code(), cause.Msg()),
+			"recurringTransferID", recurring.RecurringTransferID,
+			"fromUserID", recurring.FromUserID,
+			"occurrence", recurring.Occurrences+1,
+		)
+
+		skipped = true
+		return s.recordRun(ctx, recurring, &entity.RecurringTransferRun{
+			StatusRun:     entity.StatusRecurringRunSkipped,
+			FailureCode:   cause.Code(),
+			FailureReason: cause.Msg(),
+			CreatedBy:     msg.OperatedBy,
+		})
+	})
+	if err != nil || !skipped {
+		return err
+	}
+
+	s.notifySkippedRun(ctx, recurring, cause)
+
+	return nil
+}
+
+// notifySkippedRun sends the sender an IM message about the skipped run. The run is already
+// recorded, a message that does not go through is only logged.
+func (s *RecurringTransferSvcImpl) notifySkippedRun(
+	ctx context.Context, recurring *entity.RecurringTransfer, cause errs.CodeError,
+) {
+	args := []any{
+		recurring.RecurringTransferID, recurring.Amount.String(), recurring.Currency.String(), recurring.ToUserID, cause.Msg(),
+	}
+	text := fmt.Sprintf(recurringRunSkippedEn, args...) + "\n" + fmt.Sprintf(recurringRunSkippedZh, args...)
+	if err := s.imCaller.SendTextMessage(ctx, recurring.FromUserID, text); err != nil {
+		log.ZWarn(ctx, "while notify skipped recurring transfer run", err,
+			"recurringTransferID", recurring.RecurringTransferID,
+			"fromUserID", recurring.FromUserID,
+		)
+	}
+}
+
+// recordRun stores the run due at NextRunAt and moves the order on to the following one.
+func (s *RecurringTransferSvcImpl) recordRun(
+	ctx context.Context, recurring *entity.RecurringTransfer, run *entity.RecurringTransferRun,
+) (err error) {
+	run.RecurringTransferID = recurring.RecurringTransferID
+	run.Occurrence = recurring.Occurrences + 1
+	run.ScheduledAt = recurring.NextRunAt
+	if err = s.repo.CreateRun(ctx, run); err != nil {
+		return err
+	}
+
+	recurring.Advance()
+	recurring.LastRunAt = convert.PtrTime(time.Now())
+	recurring.UpdatedBy = run.CreatedBy
+
+	return s.repo.UpdateRecurringTransfer(ctx, recurring)
+}
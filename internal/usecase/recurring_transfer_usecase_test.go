@@ -0,0 +1,366 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_recurring_transfer"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_usecase"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func TestRecurringTransfer_CreateRecurringTransfer(t *testing.T) {
+	startAt := time.Now().Add(time.Hour)
+	testCases := []struct {
+		desc       string
+		request    *domain.CreateRecurringTransferRequest
+		wantError  error
+		onMockRepo func(mock *mock_recurring_transfer.MockRepository)
+	}{
+		{
+			desc: "ErrInvalidRecurringFrequency",
+			request: &domain.CreateRecurringTransferRequest{
+				FromUserID: "1", ToUserID: "2", Amount: entity.NewMoney(10), Frequency: "yearly", StartAt: startAt,
+			},
+			wantError: eerrs.ErrInvalidRecurringFrequency,
+		},
+		{
+			desc: "ErrInvalidRecurringSchedule_start_in_past",
+			request: &domain.CreateRecurringTransferRequest{
+				FromUserID: "1", ToUserID: "2", Amount: entity.NewMoney(10), Frequency: "daily",
+				StartAt: time.Now().Add(-time.Minute),
+			},
+			wantError: eerrs.ErrInvalidRecurringSchedule,
+		},
+		{
+			desc: "ErrInvalidRecurringSchedule_end_before_start",
+			request: &domain.CreateRecurringTransferRequest{
+				FromUserID: "1", ToUserID: "2", Amount: entity.NewMoney(10), Frequency: "daily",
+				StartAt: startAt, EndAt: &time.Time{},
+			},
+			wantError: eerrs.ErrInvalidRecurringSchedule,
+		},
+		{
+			desc: "success",
+			request: &domain.CreateRecurringTransferRequest{
+				FromUserID: "1", ToUserID: "2", Amount: entity.NewMoney(10), Frequency: "monthly",
+				StartAt: startAt, MaxOccurrences: 12,
+			},
+			onMockRepo: func(mock *mock_recurring_transfer.MockRepository) {
+				mock.EXPECT().
+					CreateRecurringTransfer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, recurring *entity.RecurringTransfer) (int64, error) {
+						if !recurring.NextRunAt.Equal(startAt) || recurring.Currency != entity.CurrencyCNY ||
+							recurring.StatusRecurringTransfer != entity.StatusRecurringTransferActive {
+							return 0, errors.New("unexpected recurring transfer")
+						}
+						recurring.RecurringTransferID = 5
+						return 5, nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_recurring_transfer.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+
+			uc := NewRecurringTransferUseCase(repo, newMockHoldTxRepo(ctrl), mock_usecase.NewMockTransferSvc(ctrl), nil)
+			resp, err := uc.CreateRecurringTransfer(context.Background(), tC.request)
+			if tC.wantError != nil {
+				require.ErrorIs(t, err, tC.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &domain.RecurringTransferResponse{
+				RecurringTransferID: 5, StatusRecurringTransfer: "active", NextRunAt: startAt,
+			}, resp)
+		})
+	}
+}
+
+func TestRecurringTransfer_ChangeStatus(t *testing.T) {
+	type action func(RecurringTransferSvc, context.Context, *domain.RecurringTransferActionRequest) (
+		*domain.RecurringTransferResponse, error)
+	startAt := time.Now().Add(-50 * time.Hour)
+	order := func(status entity.StatusRecurringTransfer) *entity.RecurringTransfer {
+		return &entity.RecurringTransfer{
+			RecurringTransferID:     5,
+			FromUserID:              "1",
+			Frequency:               entity.RecurringFrequencyDaily,
+			StartAt:                 startAt,
+			NextRunAt:               startAt,
+			StatusRecurringTransfer: status,
+		}
+	}
+	testCases := []struct {
+		desc           string
+		recurring      *entity.RecurringTransfer
+		action         action
+		wantError      error
+		expectedStatus string
+		expectedNext   time.Time
+	}{
+		{
+			desc:      "other_user",
+			recurring: &entity.RecurringTransfer{RecurringTransferID: 5, FromUserID: "2"},
+			action:    RecurringTransferSvc.PauseRecurringTransfer,
+			wantError: eerrs.ErrRecurringTransferNotFound,
+		},
+		{
+			desc:      "pause_not_active",
+			recurring: order(entity.StatusRecurringTransferPaused),
+			action:    RecurringTransferSvc.PauseRecurringTransfer,
+			wantError: eerrs.ErrRecurringTransferStatusConflict,
+		},
+		{
+			desc:           "pause",
+			recurring:      order(entity.StatusRecurringTransferActive),
+			action:         RecurringTransferSvc.PauseRecurringTransfer,
+			expectedStatus: "paused",
+			expectedNext:   startAt,
+		},
+		{
+			desc:      "resume_not_paused",
+			recurring: order(entity.StatusRecurringTransferActive),
+			action:    RecurringTransferSvc.ResumeRecurringTransfer,
+			wantError: eerrs.ErrRecurringTransferStatusConflict,
+		},
+		{
+			desc:           "resume_skips_missed_runs",
+			recurring:      order(entity.StatusRecurringTransferPaused),
+			action:         RecurringTransferSvc.ResumeRecurringTransfer,
+			expectedStatus: "active",
+			expectedNext:   startAt.AddDate(0, 0, 3),
+		},
+		{
+			desc:      "cancel_completed",
+			recurring: order(entity.StatusRecurringTransferCompleted),
+			action:    RecurringTransferSvc.CancelRecurringTransfer,
+			wantError: eerrs.ErrRecurringTransferStatusConflict,
+		},
+		{
+			desc:           "cancel_paused",
+			recurring:      order(entity.StatusRecurringTransferPaused),
+			action:         RecurringTransferSvc.CancelRecurringTransfer,
+			expectedStatus: "canceled",
+			expectedNext:   startAt,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_recurring_transfer.NewMockRepository(ctrl)
+			repo.EXPECT().GetRecurringTransferForUpdate(gomock.Any(), int64(5)).Return(tC.recurring, nil)
+			if tC.wantError == nil {
+				repo.EXPECT().UpdateRecurringTransfer(gomock.Any(), tC.recurring).Return(nil)
+			}
+
+			uc := NewRecurringTransferUseCase(repo, newMockHoldTxRepo(ctrl), mock_usecase.NewMockTransferSvc(ctrl), nil)
+			resp, err := tC.action(uc, context.Background(), &domain.RecurringTransferActionRequest{
+				RecurringTransferID: 5,
+				UserID:              "1",
+				OperatedBy:          "1-user",
+			})
+			if tC.wantError != nil {
+				require.ErrorIs(t, err, tC.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tC.expectedStatus, resp.StatusRecurringTransfer)
+			assert.Equal(t, tC.expectedNext, resp.NextRunAt)
+		})
+	}
+}
+
+func TestRecurringTransfer_ExecuteRecurringTransfers(t *testing.T) {
+	nextRunAt := time.Now().Add(-time.Minute)
+	due := func() *entity.RecurringTransfer {
+		return &entity.RecurringTransfer{
+			RecurringTransferID:     5,
+			FromUserID:              "1",
+			ToUserID:                "2",
+			Amount:                  entity.NewMoney(10),
+			Currency:                entity.CurrencyCNY,
+			Frequency:               entity.RecurringFrequencyWeekly,
+			StartAt:                 nextRunAt,
+			NextRunAt:               nextRunAt,
+			MaxOccurrences:          2,
+			Occurrences:             1,
+			StatusRecurringTransfer: entity.StatusRecurringTransferActive,
+		}
+	}
+	testCases := []struct {
+		desc             string
+		msg              *domain.MsgKafkaRecurringTransfer
+		retried          bool
+		imErr            error
+		notified         bool
+		onMockRepo       func(mock *mock_recurring_transfer.MockRepository)
+		onMockTransferUc func(mock *mock_usecase.MockTransferSvc)
+	}{
+		{
+			desc: "executed_and_completed",
+			msg:  &domain.MsgKafkaRecurringTransfer{RecurringTransferID: 5, OperatedBy: domain.KafkaProducerOperator},
+			onMockRepo: func(mock *mock_recurring_transfer.MockRepository) {
+				mock.EXPECT().GetRecurringTransferForUpdate(gomock.Any(), int64(5)).Return(due(), nil)
+				mock.EXPECT().
+					CreateRun(gomock.Any(), &entity.RecurringTransferRun{
+						RecurringTransferID: 5,
+						Occurrence:          2,
+						ScheduledAt:         nextRunAt,
+						StatusRun:           entity.StatusRecurringRunExecuted,
+						TransferID:          int64Ptr(9),
+						CreatedBy:           domain.KafkaProducerOperator,
+					}).
+					Return(nil)
+				mock.EXPECT().
+					UpdateRecurringTransfer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, recurring *entity.RecurringTransfer) error {
+						assert.Equal(t, 2, recurring.Occurrences)
+						assert.Equal(t, nextRunAt.AddDate(0, 0, 7), recurring.NextRunAt)
+						assert.Equal(t, entity.StatusRecurringTransferCompleted, recurring.StatusRecurringTransfer)
+						assert.NotNil(t, recurring.LastRunAt)
+						return nil
+					})
+			},
+			onMockTransferUc: func(mock *mock_usecase.MockTransferSvc) {
+				mock.EXPECT().
+					CreateTransfer(gomock.Any(), &domain.CreateTransferRequest{
+						FromUserID: "1",
+						ToUserID:   "2",
+						Amount:     entity.NewMoney(10),
+						Currency:   "CNY",
+						CreatedBy:  domain.KafkaProducerOperator,
+					}).
+					Return(&domain.CreateTransferResponse{TransferID: 9}, nil)
+			},
+		},
+		{
+			desc:     "insufficient_balance_skipped",
+			msg:      &domain.MsgKafkaRecurringTransfer{RecurringTransferID: 5, OperatedBy: domain.KafkaProducerOperator},
+			notified: true,
+			onMockRepo: func(mock *mock_recurring_transfer.MockRepository) {
+				mock.EXPECT().GetRecurringTransferForUpdate(gomock.Any(), int64(5)).Return(due(), nil).Times(2)
+				mock.EXPECT().
+					CreateRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, run *entity.RecurringTransferRun) error {
+						assert.Equal(t, entity.StatusRecurringRunSkipped, run.StatusRun)
+						assert.Nil(t, run.TransferID)
+						assert.Equal(t, eerrs.ErrorCodeInsufficientBalance, run.FailureCode)
+						assert.Equal(t, "insufficient balance", run.FailureReason)
+						return nil
+					})
+				mock.EXPECT().
+					UpdateRecurringTransfer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, recurring *entity.RecurringTransfer) error {
+						assert.Equal(t, 2, recurring.Occurrences)
+						return nil
+					})
+			},
+			onMockTransferUc: func(mock *mock_usecase.MockTransferSvc) {
+				mock.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(nil, eerrs.ErrInsufficientBalance)
+			},
+		},
+		{
+			desc: "insufficient_balance_skipped_im_unavailable",
+			msg:  &domain.MsgKafkaRecurringTransfer{RecurringTransferID: 5, OperatedBy: domain.KafkaProducerOperator},
+			// the run is recorded either way, a message that does not go through is not retried
+			imErr: errors.New("im api /msg/send_msg: status 502"),
+			onMockRepo: func(mock *mock_recurring_transfer.MockRepository) {
+				mock.EXPECT().GetRecurringTransferForUpdate(gomock.Any(), int64(5)).Return(due(), nil).Times(2)
+				mock.EXPECT().
+					CreateRun(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, run *entity.RecurringTransferRun) error {
+						assert.Equal(t, entity.StatusRecurringRunSkipped, run.StatusRun)
+						assert.Nil(t, run.TransferID)
+						assert.Equal(t, eerrs.ErrorCodeInsufficientBalance, run.FailureCode)
+						assert.Equal(t, "insufficient balance", run.FailureReason)
+						return nil
+					})
+				mock.EXPECT().
+					UpdateRecurringTransfer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, recurring *entity.RecurringTransfer) error {
+						assert.Equal(t, 2, recurring.Occurrences)
+						return nil
+					})
+			},
+			onMockTransferUc: func(mock *mock_usecase.MockTransferSvc) {
+				mock.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(nil, eerrs.ErrInsufficientBalance)
+			},
+		},
+		{
+			desc:    "transient_error_retried",
+			msg:     &domain.MsgKafkaRecurringTransfer{RecurringTransferID: 5},
+			retried: true,
+			onMockRepo: func(mock *mock_recurring_transfer.MockRepository) {
+				mock.EXPECT().GetRecurringTransferForUpdate(gomock.Any(), int64(5)).Return(nil, errors.New("connection reset"))
+			},
+		},
+		{
+			desc: "paused_meanwhile",
+			msg:  &domain.MsgKafkaRecurringTransfer{RecurringTransferID: 5},
+			onMockRepo: func(mock *mock_recurring_transfer.MockRepository) {
+				recurring := due()
+				recurring.StatusRecurringTransfer = entity.StatusRecurringTransferPaused
+				mock.EXPECT().GetRecurringTransferForUpdate(gomock.Any(), int64(5)).Return(recurring, nil)
+			},
+		},
+		{
+			desc: "already_run",
+			msg:  &domain.MsgKafkaRecurringTransfer{RecurringTransferID: 5},
+			onMockRepo: func(mock *mock_recurring_transfer.MockRepository) {
+				recurring := due()
+				recurring.NextRunAt = time.Now().Add(time.Hour)
+				mock.EXPECT().GetRecurringTransferForUpdate(gomock.Any(), int64(5)).Return(recurring, nil)
+			},
+		},
+		{
+			desc: "retries_exhausted",
+			msg:  &domain.MsgKafkaRecurringTransfer{RecurringTransferID: 5, Counter: 3},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_recurring_transfer.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+			transferUc := mock_usecase.NewMockTransferSvc(ctrl)
+			if tC.onMockTransferUc != nil {
+				tC.onMockTransferUc(transferUc)
+			}
+
+			im := &stubIMCaller{err: tC.imErr}
+			uc := NewRecurringTransferUseCase(repo, newMockHoldTxRepo(ctrl), transferUc, im)
+			failed, err := uc.ExecuteRecurringTransfers(context.Background(), []*domain.MsgKafkaRecurringTransfer{tC.msg})
+			require.NoError(t, err)
+			if tC.retried {
+				assert.Equal(t, []*domain.MsgKafkaRecurringTransfer{tC.msg}, failed)
+			} else {
+				assert.Empty(t, failed)
+			}
+			if tC.notified {
+				require.Len(t, im.sent["1"], 1)
+				assert.Contains(t, im.sent["1"][0], "Your recurring transfer #5 of 10.00 CNY to 2 was skipped: insufficient balance")
+			} else {
+				assert.Empty(t, im.sent)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/risk_decision"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/config"
+)
+
+const (
+	RiskRuleVelocity             = "velocity"
+	RiskRuleNewWalletLargeAmount = "new_wallet_large_amount"
+	RiskRuleCircularTransfer     = "circular_transfer"
+	RiskRuleClaimCluster         = "claim_cluster"
+)
+
+// riskClaimOperations are the movements the claim cluster rule groups by device.
+var riskClaimOperations = []entity.RiskOperation{
+	entity.RiskOperationTransferClaim,
+	entity.RiskOperationEnvelopeClaim,
+}
+
+// velocityRule fires when the user already made maxCount movements within the window.
+type velocityRule struct {
+	window   time.Duration
+	maxCount int64
+	decision entity.RiskDecisionType
+	repo     risk_decision.Repository
+}
+
+func (r *velocityRule) Name() string {
+	return RiskRuleVelocity
+}
+
+func (r *velocityRule) Evaluate(
+	ctx context.Context, event *domain.RiskEvent,
+) (decision entity.RiskDecisionType, err error) {
+	var count int64
+	count, err = r.repo.CountUserDecisionsSince(ctx, event.UserID, time.Now().Add(-r.window))
+	if err != nil {
+		return entity.RiskDecisionAllow, err
+	}
+	if count >= r.maxCount {
+		return r.decision, nil
+	}
+
+	return entity.RiskDecisionAllow, nil
+}
+
+// newWalletLargeAmountRule fires when at least amount moves through a wallet younger than maxAge.
+type newWalletLargeAmountRule struct {
+	maxAge     time.Duration
+	amount     entity.Money
+	decision   entity.RiskDecisionType
+	walletRepo wallet.Repository
+}
+
+func (r *newWalletLargeAmountRule) Name() string {
+	return RiskRuleNewWalletLargeAmount
+}
+
+func (r *newWalletLargeAmountRule) Evaluate(
+	ctx context.Context, event *domain.RiskEvent,
+) (decision entity.RiskDecisionType, err error) {
+	if event.Amount < r.amount {
+		return entity.RiskDecisionAllow, nil
+	}
+
+	// the movement books on this wallet anyway, locking it here costs nothing extra
+	var w *entity.Wallet
+	w, err = r.walletRepo.GetWalletByWalletIDTx(ctx, nil, event.WalletID)
+	if err != nil {
+		return entity.RiskDecisionAllow, err
+	}
+	if time.Since(w.CreatedAt) < r.maxAge {
+		return r.decision, nil
+	}
+
+	return entity.RiskDecisionAllow, nil
+}
+
+// circularTransferRule fires on a transfer to a user who already sent reverseTransfers
+// transfers back to the sender within the window.
+type circularTransferRule struct {
+	window           time.Duration
+	reverseTransfers int64
+	decision         entity.RiskDecisionType
+	repo             risk_decision.Repository
+}
+
+func (r *circularTransferRule) Name() string {
+	return RiskRuleCircularTransfer
+}
+
+func (r *circularTransferRule) Evaluate(
+	ctx context.Context, event *domain.RiskEvent,
+) (decision entity.RiskDecisionType, err error) {
+	if event.Operation != entity.RiskOperationTransferCreate {
+		return entity.RiskDecisionAllow, nil
+	}
+
+	var count int64
+	count, err = r.repo.CountPairDecisionsSince(
+		ctx, entity.RiskOperationTransferCreate, event.CounterpartyID, event.UserID, time.Now().Add(-r.window),
+	)
+	if err != nil {
+		return entity.RiskDecisionAllow, err
+	}
+	if count >= r.reverseTransfers {
+		return r.decision, nil
+	}
+
+	return entity.RiskDecisionAllow, nil
+}
+
+// claimClusterRule fires on a claim from a device that maxAccounts other users already
+// claimed from within the window. Claims without a device share one bucket, leaving out the
+// header does not get around the rule.
+type claimClusterRule struct {
+	window      time.Duration
+	maxAccounts int64
+	decision    entity.RiskDecisionType
+	repo        risk_decision.Repository
+}
+
+func (r *claimClusterRule) Name() string {
+	return RiskRuleClaimCluster
+}
+
+func (r *claimClusterRule) Evaluate(
+	ctx context.Context, event *domain.RiskEvent,
+) (decision entity.RiskDecisionType, err error) {
+	if event.Operation != entity.RiskOperationTransferClaim && event.Operation != entity.RiskOperationEnvelopeClaim {
+		return entity.RiskDecisionAllow, nil
+	}
+
+	var count int64
+	count, err = r.repo.CountDeviceUsersSince(
+		ctx, riskClaimOperations, event.DeviceID, event.UserID, time.Now().Add(-r.window),
+	)
+	if err != nil {
+		return entity.RiskDecisionAllow, err
+	}
+	if count >= r.maxAccounts {
+		return r.decision, nil
+	}
+
+	return entity.RiskDecisionAllow, nil
+}
+
+// parseRiskDecision reads the decision of a rule, a rule that fires has to review or deny.
+func parseRiskDecision(rule, value string) (decision entity.RiskDecisionType, err error) {
+	decision = entity.RiskDecisionType(value)
+	if decision != entity.RiskDecisionReview && decision != entity.RiskDecisionDeny {
+		return decision, fmt.Errorf("invalid decision %q of risk rule %s, expected review or deny", value, rule)
+	}
+
+	return decision, nil
+}
+
+// riskRulesFromConfig builds the enabled rules of risk.yml, none when the engine is disabled.
+func riskRulesFromConfig(
+	cfg config.Risk, repo risk_decision.Repository, walletRepo wallet.Repository,
+) (rules []RiskRule, err error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	var decision entity.RiskDecisionType
+	if cfg.Velocity.Enabled {
+		if decision, err = parseRiskDecision(RiskRuleVelocity, cfg.Velocity.Decision); err != nil {
+			return nil, err
+		}
+		rules = append(rules, &velocityRule{
+			window:   time.Duration(cfg.Velocity.WindowMinutes) * time.Minute,
+			maxCount: cfg.Velocity.MaxCount,
+			decision: decision,
+			repo:     repo,
+		})
+	}
+
+	if cfg.NewWalletLargeAmount.Enabled {
+		if decision, err = parseRiskDecision(RiskRuleNewWalletLargeAmount, cfg.NewWalletLargeAmount.Decision); err != nil {
+			return nil, err
+		}
+		var amount entity.Money
+		if amount, err = entity.ParseMoney(cfg.NewWalletLargeAmount.Amount); err != nil {
+			return nil, fmt.Errorf("invalid amount of risk rule %s: %w", RiskRuleNewWalletLargeAmount, err)
+		}
+		rules = append(rules, &newWalletLargeAmountRule{
+			maxAge:     time.Duration(cfg.NewWalletLargeAmount.MaxWalletAgeHours) * time.Hour,
+			amount:     amount,
+			decision:   decision,
+			walletRepo: walletRepo,
+		})
+	}
+
+	if cfg.CircularTransfer.Enabled {
+		if decision, err = parseRiskDecision(RiskRuleCircularTransfer, cfg.CircularTransfer.Decision); err != nil {
+			return nil, err
+		}
+		rules = append(rules, &circularTransferRule{
+			window:           time.Duration(cfg.CircularTransfer.WindowMinutes) * time.Minute,
+			reverseTransfers: cfg.CircularTransfer.ReverseTransfers,
+			decision:         decision,
+			repo:             repo,
+		})
+	}
+
+	if cfg.ClaimCluster.Enabled {
+		if decision, err = parseRiskDecision(RiskRuleClaimCluster, cfg.ClaimCluster.Decision); err != nil {
+			return nil, err
+		}
+		rules = append(rules, &claimClusterRule{
+			window:      time.Duration(cfg.ClaimCluster.WindowMinutes) * time.Minute,
+			maxAccounts: cfg.ClaimCluster.MaxAccounts,
+			decision:    decision,
+			repo:        repo,
+		})
+	}
+
+	return rules, nil
+}
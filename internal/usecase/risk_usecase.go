@@ -0,0 +1,181 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/risk_decision"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	// RiskRule judges a single aspect of a money movement, a rule that does not fire allows it.
+	RiskRule interface {
+		Name() string
+		Evaluate(ctx context.Context, event *domain.RiskEvent) (decision entity.RiskDecisionType, err error)
+	}
+
+	RiskSvcImpl struct {
+		rules []RiskRule
+		repo  risk_decision.Repository
+	}
+
+	RiskSvc interface {
+		// Evaluate runs the rules on a movement inside the transaction that books it, right
+		// before it commits, and records the most severe decision. A review lets the movement
+		// through, a deny is recorded outside the transaction and returned as ErrRiskDenied
+		// so the caller rolls the movement back.
+		Evaluate(ctx context.Context, event *domain.RiskEvent) (decision entity.RiskDecisionType, err error)
+		GetListRiskDecision(
+			ctx context.Context, req *domain.GetListRiskDecisionRequest,
+		) (resp *domain.GetListRiskDecisionResponse, err error)
+	}
+)
+
+// NewRiskUseCase evaluates the given rules, without any rule every movement is allowed and
+// nothing is recorded.
+func NewRiskUseCase(
+	rules []RiskRule,
+	repo risk_decision.Repository,
+) RiskSvc {
+	return &RiskSvcImpl{
+		rules: rules,
+		repo:  repo,
+	}
+}
+
+func (s *RiskSvcImpl) Evaluate(
+	ctx context.Context, event *domain.RiskEvent,
+) (decision entity.RiskDecisionType, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	decision = entity.RiskDecisionAllow
+	if len(s.rules) == 0 {
+		return decision, nil
+	}
+
+	span.SetAttributes(
+		attribute.String("operation", event.Operation.String()),
+		attribute.Int64("referenceID", event.ReferenceID),
+		attribute.String("userID", event.UserID),
+	)
+
+	var fired []string
+	for _, rule := range s.rules {
+		var ruleDecision entity.RiskDecisionType
+		ruleDecision, err = rule.Evaluate(ctx, event)
+		if err != nil {
+			log.ZError(ctx, "while evaluate risk rule", err, "rule", rule.Name(), "event", event)
+			return decision, err
+		}
+		if ruleDecision == entity.RiskDecisionAllow {
+			continue
+		}
+
+		fired = append(fired, rule.Name())
+		if ruleDecision.MoreSevere(decision) {
+			decision = ruleDecision
+		}
+	}
+
+	span.SetAttributes(attribute.String("decision", decision.String()))
+
+	recordCtx := ctx
+	if decision == entity.RiskDecisionDeny {
+		// the caller rolls back, the deny must outlive its transaction
+		recordCtx = tx.Detach(ctx)
+	}
+
+	_, err = s.repo.CreateRiskDecision(recordCtx, &entity.RiskDecision{
+		Operation:      event.Operation,
+		ReferenceID:    event.ReferenceID,
+		UserID:         event.UserID,
+		CounterpartyID: event.CounterpartyID,
+		WalletID:       event.WalletID,
+		Amount:         event.Amount,
+		Currency:       event.Currency,
+		DeviceID:       event.DeviceID,
+		Decision:       decision,
+		Rules:          strings.Join(fired, ","),
+	})
+	if err != nil {
+		log.ZError(ctx, "while record risk decision", err, "event", event, "decision", decision)
+		return decision, err
+	}
+
+	if decision == entity.RiskDecisionDeny {
+		log.ZWarn(ctx, "movement denied by risk rules", nil, "event", event, "rules", fired)
+		return decision, eerrs.ErrRiskDenied
+	}
+
+	return decision, nil
+}
+
+func (s *RiskSvcImpl) GetListRiskDecision(
+	ctx context.Context, req *domain.GetListRiskDecisionRequest,
+) (resp *domain.GetListRiskDecisionResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		log.ZError(ctx, "while validate request", err, "request", req)
+		return resp, err
+	}
+
+	var (
+		decisions []*entity.RiskDecision
+		total     int64
+	)
+	decisions, total, err = s.repo.GetListRiskDecision(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list risk decision", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int64("total", total))
+
+	resp = &domain.GetListRiskDecisionResponse{
+		TotalCount: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}
+	for _, decision := range decisions {
+		resp.RiskDecisions = append(resp.RiskDecisions, dtoRiskDecision(decision))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_risk_decision"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_usecase"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/config"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// newMockAllowRisk lets every movement through the risk rules.
+func newMockAllowRisk(ctrl *gomock.Controller) *mock_usecase.MockRiskSvc {
+	riskUc := mock_usecase.NewMockRiskSvc(ctrl)
+	riskUc.EXPECT().Evaluate(gomock.Any(), gomock.Any()).Return(entity.RiskDecisionAllow, nil).AnyTimes()
+
+	return riskUc
+}
+
+type stubRiskRule struct {
+	name     string
+	decision entity.RiskDecisionType
+}
+
+func (r *stubRiskRule) Name() string {
+	return r.name
+}
+
+func (r *stubRiskRule) Evaluate(context.Context, *domain.RiskEvent) (entity.RiskDecisionType, error) {
+	return r.decision, nil
+}
+
+func TestRisk_Evaluate(t *testing.T) {
+	event := &domain.RiskEvent{
+		Operation:   entity.RiskOperationTransferCreate,
+		ReferenceID: 11,
+		UserID:      "u1",
+		WalletID:    7,
+		Amount:      entity.NewMoney(100),
+	}
+	testCases := []struct {
+		desc      string
+		rules     []RiskRule
+		expected  entity.RiskDecisionType
+		wantError error
+		recorded  bool
+		// firedRules: the rules the stored decision names
+		firedRules string
+	}{
+		{
+			desc:     "no_rules_allows_without_record",
+			expected: entity.RiskDecisionAllow,
+		},
+		{
+			desc:     "quiet_rules_record_allow",
+			rules:    []RiskRule{&stubRiskRule{name: "a", decision: entity.RiskDecisionAllow}},
+			expected: entity.RiskDecisionAllow,
+			recorded: true,
+		},
+		{
+			desc: "review_lets_movement_through",
+			rules: []RiskRule{
+				&stubRiskRule{name: "a", decision: entity.RiskDecisionAllow},
+				&stubRiskRule{name: "b", decision: entity.RiskDecisionReview},
+			},
+			expected:   entity.RiskDecisionReview,
+			recorded:   true,
+			firedRules: "b",
+		},
+		{
+			desc: "deny_wins_over_review",
+			rules: []RiskRule{
+				&stubRiskRule{name: "a", decision: entity.RiskDecisionDeny},
+				&stubRiskRule{name: "b", decision: entity.RiskDecisionReview},
+			},
+			expected:   entity.RiskDecisionDeny,
+			wantError:  eerrs.ErrRiskDenied,
+			recorded:   true,
+			firedRules: "a,b",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_risk_decision.NewMockRepository(ctrl)
+			if tC.recorded {
+				repo.EXPECT().
+					CreateRiskDecision(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, decision *entity.RiskDecision) (int64, error) {
+						assert.Equal(t, tC.expected, decision.Decision)
+						assert.Equal(t, tC.firedRules, decision.Rules)
+						assert.Equal(t, event.ReferenceID, decision.ReferenceID)
+
+						// a deny is kept even though the movement rolls back
+						_, inTx := tx.FromContext(ctx)
+						assert.Equal(t, tC.expected != entity.RiskDecisionDeny, inTx)
+						return 1, nil
+					})
+			}
+
+			ctx := tx.NewContext(context.Background(), &gorm.DB{})
+			decision, err := NewRiskUseCase(tC.rules, repo).Evaluate(ctx, event)
+			if tC.wantError != nil {
+				require.ErrorIs(t, err, tC.wantError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tC.expected, decision)
+		})
+	}
+}
+
+func TestRisk_CircularTransferRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock_risk_decision.NewMockRepository(ctrl)
+	rule := &circularTransferRule{reverseTransfers: 2, decision: entity.RiskDecisionReview, repo: repo}
+
+	// the reverse direction is counted: transfers from the receiver back to the sender
+	repo.EXPECT().
+		CountPairDecisionsSince(gomock.Any(), entity.RiskOperationTransferCreate, "u2", "u1", gomock.Any()).
+		Return(int64(2), nil)
+
+	decision, err := rule.Evaluate(context.Background(), &domain.RiskEvent{
+		Operation:      entity.RiskOperationTransferCreate,
+		UserID:         "u1",
+		CounterpartyID: "u2",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.RiskDecisionReview, decision)
+
+	decision, err = rule.Evaluate(context.Background(), &domain.RiskEvent{
+		Operation:      entity.RiskOperationTransferClaim,
+		UserID:         "u1",
+		CounterpartyID: "u2",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.RiskDecisionAllow, decision)
+}
+
+func TestRisk_ClaimClusterRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock_risk_decision.NewMockRepository(ctrl)
+	rule := &claimClusterRule{maxAccounts: 3, decision: entity.RiskDecisionDeny, repo: repo}
+
+	gomock.InOrder(
+		repo.EXPECT().
+			CountDeviceUsersSince(gomock.Any(), riskClaimOperations, "d1", "u1", gomock.Any()).
+			Return(int64(2), nil),
+		repo.EXPECT().
+			CountDeviceUsersSince(gomock.Any(), riskClaimOperations, "d1", "u1", gomock.Any()).
+			Return(int64(3), nil),
+		repo.EXPECT().
+			CountDeviceUsersSince(gomock.Any(), riskClaimOperations, "", "u1", gomock.Any()).
+			Return(int64(3), nil),
+	)
+
+	event := &domain.RiskEvent{Operation: entity.RiskOperationEnvelopeClaim, UserID: "u1", DeviceID: "d1"}
+	decision, err := rule.Evaluate(context.Background(), event)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RiskDecisionAllow, decision)
+
+	decision, err = rule.Evaluate(context.Background(), event)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RiskDecisionDeny, decision)
+
+	// claims without a device are grouped together
+	decision, err = rule.Evaluate(context.Background(), &domain.RiskEvent{
+		Operation: entity.RiskOperationEnvelopeClaim,
+		UserID:    "u1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.RiskDecisionDeny, decision)
+}
+
+func TestRisk_RulesFromConfig(t *testing.T) {
+	var cfg config.Risk
+	cfg.Velocity.Enabled = true
+	cfg.Velocity.Decision = "review"
+	cfg.ClaimCluster.Enabled = true
+	cfg.ClaimCluster.Decision = "deny"
+
+	rules, err := riskRulesFromConfig(cfg, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	cfg.Enabled = true
+	rules, err = riskRulesFromConfig(cfg, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, RiskRuleVelocity, rules[0].Name())
+	assert.Equal(t, RiskRuleClaimCluster, rules[1].Name())
+
+	cfg.Velocity.Decision = "allow"
+	_, err = riskRulesFromConfig(cfg, nil, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,439 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/errs"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/scheduled_transfer"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+	"github.com/1nterdigital/aka-im-wallet/pkg/util/convert"
+)
+
+type (
+	ScheduledTransferSvcImpl struct {
+		repo       scheduled_transfer.Repository
+		txRepo     tx.Repository
+		transferUc TransferSvc
+		holdUc     WalletHoldSvc
+	}
+
+	ScheduledTransferSvc interface {
+		// ScheduleTransfer stores a transfer to be sent at req.ExecuteAt, nothing is debited until
+		// then. With req.Hold the amount is held on the wallet of the sender in the meantime.
+		ScheduleTransfer(
+			ctx context.Context, req *domain.CreateScheduledTransferRequest,
+		) (resp *domain.ScheduledTransferResponse, err error)
+		CancelScheduledTransfer(
+			ctx context.Context, req *domain.CancelScheduledTransferRequest,
+		) (resp *domain.ScheduledTransferResponse, err error)
+		GetListScheduledTransfer(
+			ctx context.Context, req *domain.GetListScheduledTransferRequest,
+		) (resp *domain.GetListScheduledTransferResponse, err error)
+		FetchDueScheduledTransfers(ctx context.Context) (msgs []*domain.MsgKafkaScheduledTransfer, err error)
+		// ExecuteScheduledTransfers sends the due transfers through CreateTransfer. A transfer
+		// rejected by validation is marked failed with the reason, the ones that hit any other
+		// error are returned to be tried again.
+		ExecuteScheduledTransfers(
+			ctx context.Context, msgs []*domain.MsgKafkaScheduledTransfer,
+		) (failed []*domain.MsgKafkaScheduledTransfer, err error)
+	}
+)
+
+func NewScheduledTransferUseCase(
+	repo scheduled_transfer.Repository,
+	txRepo tx.Repository,
+	transferUc TransferSvc,
+	holdUc WalletHoldSvc,
+) ScheduledTransferSvc {
+	return &ScheduledTransferSvcImpl{
+		repo:       repo,
+		txRepo:     txRepo,
+		transferUc: transferUc,
+		holdUc:     holdUc,
+	}
+}
+
+func (s *ScheduledTransferSvcImpl) ScheduleTransfer(
+	ctx context.Context, req *domain.CreateScheduledTransferRequest,
+) (resp *domain.ScheduledTransferResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(req.Currency)
+	if err != nil {
+		return resp, err
+	}
+	req.Currency = currency.String()
+
+	if err = req.Validate(time.Now()); err != nil {
+		return resp, err
+	}
+
+	scheduled := &entity.ScheduledTransfer{
+		FromUserID:              req.FromUserID,
+		ToUserID:                req.ToUserID,
+		Amount:                  req.Amount,
+		Currency:                currency,
+		Remark:                  req.Remark,
+		ExecuteAt:               req.ExecuteAt,
+		StatusScheduledTransfer: entity.StatusScheduledTransferScheduled,
+		IsActive:                true,
+		CreatedBy:               req.OperatedBy,
+		UpdatedBy:               req.OperatedBy,
+	}
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		if req.Hold {
+			var hold *domain.WalletHoldResponse
+			hold, err = s.holdUc.CreateHold(ctx, &domain.CreateWalletHoldRequest{
+				UserID:     req.FromUserID,
+				Currency:   req.Currency,
+				Amount:     req.Amount,
+				Reason:     "scheduled transfer to " + req.ToUserID,
+				OperatedBy: req.OperatedBy,
+			})
+			if err != nil {
+				log.ZError(ctx, "while hold scheduled transfer amount", err, "request", req)
+				return err
+			}
+			scheduled.WalletHoldID = &hold.WalletHoldID
+		}
+
+		_, err = s.repo.CreateScheduledTransfer(ctx, scheduled)
+		if err != nil {
+			log.ZError(ctx, "while create scheduled transfer", err, "request", req)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("scheduledTransferID", scheduled.ScheduledTransferID),
+		attribute.String("executeAt", req.ExecuteAt.Format(time.RFC3339)),
+	)
+
+	return dtoScheduledTransferResponse(scheduled), nil
+}
+
+func (s *ScheduledTransferSvcImpl) CancelScheduledTransfer(
+	ctx context.Context, req *domain.CancelScheduledTransferRequest,
+) (resp *domain.ScheduledTransferResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int64("scheduledTransferID", req.ScheduledTransferID))
+
+	var scheduled *entity.ScheduledTransfer
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		scheduled, err = s.repo.GetScheduledTransferForUpdate(ctx, req.ScheduledTransferID)
+		if err != nil {
+			log.ZError(ctx, "while lock scheduled transfer", err, "scheduledTransferID", req.ScheduledTransferID)
+			return err
+		}
+
+		if scheduled.FromUserID != req.UserID {
+			return eerrs.ErrScheduledTransferNotFound
+		}
+		if scheduled.StatusScheduledTransfer != entity.StatusScheduledTransferScheduled {
+			return eerrs.ErrScheduledTransferNotCancelable
+		}
+
+		if err = s.releaseHold(ctx, scheduled, req.OperatedBy); err != nil {
+			return err
+		}
+
+		scheduled.StatusScheduledTransfer = entity.StatusScheduledTransferCanceled
+		scheduled.CanceledAt = convert.PtrTime(time.Now())
+		scheduled.UpdatedBy = req.OperatedBy
+		err = s.repo.UpdateScheduledTransfer(ctx, &entity.ScheduledTransfer{
+			ScheduledTransferID:     scheduled.ScheduledTransferID,
+			StatusScheduledTransfer: scheduled.StatusScheduledTransfer,
+			CanceledAt:              scheduled.CanceledAt,
+			UpdatedBy:               scheduled.UpdatedBy,
+		})
+		if err != nil {
+			log.ZError(ctx, "while update scheduled transfer", err, "scheduledTransferID", req.ScheduledTransferID)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	return dtoScheduledTransferResponse(scheduled), nil
+}
+
+// releaseHold gives the amount kept aside for the schedule back to the available balance.
+func (s *ScheduledTransferSvcImpl) releaseHold(
+	ctx context.Context, scheduled *entity.ScheduledTransfer, operatedBy string,
+) (err error) {
+	if scheduled.WalletHoldID == nil {
+		return nil
+	}
+
+	_, err = s.holdUc.ReleaseHold(ctx, &domain.WalletHoldActionRequest{
+		WalletHoldID: *scheduled.WalletHoldID,
+		OperatedBy:   operatedBy,
+	})
+	if err != nil {
+		log.ZError(ctx, "while release scheduled transfer hold", err,
+			"scheduledTransferID", scheduled.ScheduledTransferID,
+			"walletHoldID", *scheduled.WalletHoldID,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (s *ScheduledTransferSvcImpl) GetListScheduledTransfer(
+	ctx context.Context, req *domain.GetListScheduledTransferRequest,
+) (resp *domain.GetListScheduledTransferResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var (
+		scheduled []*entity.ScheduledTransfer
+		total     int64
+	)
+	scheduled, total, err = s.repo.GetListScheduledTransfer(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list scheduled transfer", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.String("userID", req.UserID),
+		attribute.Int64("total", total),
+	)
+
+	return &domain.GetListScheduledTransferResponse{
+		TotalCount:         total,
+		Page:               req.Page,
+		Limit:              req.Limit,
+		ScheduledTransfers: dtoScheduledTransfers(scheduled),
+	}, nil
+}
+
+func (s *ScheduledTransferSvcImpl) FetchDueScheduledTransfers(
+	ctx context.Context,
+) (msgs []*domain.MsgKafkaScheduledTransfer, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var scheduled []*entity.ScheduledTransfer
+	scheduled, err = s.repo.FetchDueScheduledTransfers(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range scheduled {
+		msgs = append(msgs, &domain.MsgKafkaScheduledTransfer{
+			ScheduledTransferID: scheduled[idx].ScheduledTransferID,
+		})
+	}
+
+	span.SetAttributes(attribute.Int("total", len(msgs)))
+
+	return msgs, nil
+}
+
+func (s *ScheduledTransferSvcImpl) ExecuteScheduledTransfers(
+	ctx context.Context, msgs []*domain.MsgKafkaScheduledTransfer,
+) (failed []*domain.MsgKafkaScheduledTransfer, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	retryAttemptsProcess := 4
+
+	for idx := range msgs {
+		msgs[idx].Counter += 1
+		if msgs[idx].Counter >= retryAttemptsProcess {
+			// still scheduled, the next publisher run picks it up again
+			continue
+		}
+
+		errx := s.execute(ctx, msgs[idx])
+		if errx == nil || errors.Is(errx, eerrs.ErrScheduledTransferNotFound) {
+			continue
+		}
+
+		var codeErr errs.CodeError
+		if errors.As(errx, &codeErr) {
+			errx = s.markFailed(ctx, msgs[idx], codeErr)
+			if errx == nil {
+				continue
+			}
+		}
+
+		log.ZError(ctx, "while execute scheduled transfer", errx,
+			"scheduledTransferID", msgs[idx].ScheduledTransferID,
+			"operatedBy", msgs[idx].OperatedBy,
+		)
+		failed = append(failed, msgs[idx])
+	}
+
+	span.SetAttributes(attribute.Int("failed", len(failed)))
+
+	return failed, nil
+}
+
+// execute sends a due transfer. The hold is released in the same transaction so the amount is
+// available to CreateTransfer, a rejected transfer rolls the release back with it.
+func (s *ScheduledTransferSvcImpl) execute(ctx context.Context, msg *domain.MsgKafkaScheduledTransfer) (err error) {
+	return s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		var scheduled *entity.ScheduledTransfer
+		scheduled, err = s.repo.GetScheduledTransferForUpdate(ctx, msg.ScheduledTransferID)
+		if err != nil {
+			return err
+		}
+
+		// ran, failed or canceled since the message was queued
+		if scheduled.StatusScheduledTransfer != entity.StatusScheduledTransferScheduled ||
+			scheduled.ExecuteAt.After(time.Now()) {
+			return nil
+		}
+
+		if err = s.releaseHold(ctx, scheduled, msg.OperatedBy); err != nil {
+			return err
+		}
+
+		var transfer *domain.CreateTransferResponse
+		transfer, err = s.transferUc.CreateTransfer(ctx, &domain.CreateTransferRequest{
+			FromUserID: scheduled.FromUserID,
+			ToUserID:   scheduled.ToUserID,
+			Amount:     scheduled.Amount,
+			Currency:   scheduled.Currency.String(),
+			Remark:     scheduled.Remark,
+			CreatedBy:  msg.OperatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.repo.UpdateScheduledTransfer(ctx, &entity.ScheduledTransfer{
+			ScheduledTransferID:     scheduled.ScheduledTransferID,
+			StatusScheduledTransfer: entity.StatusScheduledTransferExecuted,
+			TransferID:              &transfer.TransferID,
+			ExecutedAt:              convert.PtrTime(time.Now()),
+			UpdatedBy:               msg.OperatedBy,
+		})
+	})
+}
+
+// markFailed records why the transfer was rejected so the sender can see it, and releases the hold.
+func (s *ScheduledTransferSvcImpl) markFailed(
+	ctx context.Context, msg *domain.MsgKafkaScheduledTransfer, cause errs.CodeError,
+) (err error) {
+	return s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		var scheduled *entity.ScheduledTransfer
+		scheduled, err = s.repo.GetScheduledTransferForUpdate(ctx, msg.ScheduledTransferID)
+		if err != nil {
+			return err
+		}
+
+		if scheduled.StatusScheduledTransfer != entity.StatusScheduledTransferScheduled {
+			return nil
+		}
+
+		if err = s.releaseHold(ctx, scheduled, msg.OperatedBy); err != nil {
+			return err
+		}
+
+		log.ZWarn(ctx, "scheduled transfer failed", fmt.Errorf("%d: %s", cause.Code(), cause.Msg()),
+			"scheduledTransferID", scheduled.ScheduledTransferID,
+		)
+
+		return s.repo.UpdateScheduledTransfer(ctx, &entity.ScheduledTransfer{
+			ScheduledTransferID:     scheduled.ScheduledTransferID,
+			StatusScheduledTransfer: entity.StatusScheduledTransferFailed,
+			FailureCode:             cause.Code(),
+			FailureReason:           cause.Msg(),
+			UpdatedBy:               msg.OperatedBy,
+		})
+	})
+}
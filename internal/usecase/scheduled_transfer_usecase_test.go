@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_scheduled_transfer"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_usecase"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func TestScheduledTransfer_ScheduleTransfer(t *testing.T) {
+	executeAt := time.Now().Add(time.Hour)
+	testCases := []struct {
+		desc         string
+		request      *domain.CreateScheduledTransferRequest
+		expected     *domain.ScheduledTransferResponse
+		wantError    error
+		onMockHoldUc func(mock *mock_usecase.MockWalletHoldSvc)
+		onMockRepo   func(mock *mock_scheduled_transfer.MockRepository)
+	}{
+		{
+			desc: "ErrInvalidScheduleTime",
+			request: &domain.CreateScheduledTransferRequest{
+				FromUserID: "1", ToUserID: "2", Amount: entity.NewMoney(10), ExecuteAt: time.Now().Add(-time.Minute),
+			},
+			wantError: eerrs.ErrInvalidScheduleTime,
+		},
+		{
+			desc: "hold_rejected",
+			request: &domain.CreateScheduledTransferRequest{
+				FromUserID: "1", ToUserID: "2", Amount: entity.NewMoney(10), ExecuteAt: executeAt, Hold: true,
+			},
+			wantError: eerrs.ErrInsufficientBalance,
+			onMockHoldUc: func(mock *mock_usecase.MockWalletHoldSvc) {
+				mock.EXPECT().CreateHold(gomock.Any(), gomock.Any()).Return(nil, eerrs.ErrInsufficientBalance)
+			},
+		},
+		{
+			desc: "success_without_hold",
+			request: &domain.CreateScheduledTransferRequest{
+				FromUserID: "1", ToUserID: "2", Amount: entity.NewMoney(10), ExecuteAt: executeAt,
+			},
+			expected: &domain.ScheduledTransferResponse{ScheduledTransferID: 5, StatusScheduledTransfer: "scheduled"},
+			onMockRepo: func(mock *mock_scheduled_transfer.MockRepository) {
+				mock.EXPECT().
+					CreateScheduledTransfer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, scheduled *entity.ScheduledTransfer) (int64, error) {
+						if scheduled.WalletHoldID != nil || scheduled.Currency != entity.CurrencyCNY {
+							return 0, errors.New("unexpected scheduled transfer")
+						}
+						scheduled.ScheduledTransferID = 5
+						return 5, nil
+					})
+			},
+		},
+		{
+			desc: "success_with_hold",
+			request: &domain.CreateScheduledTransferRequest{
+				FromUserID: "1", ToUserID: "2", Amount: entity.NewMoney(10), ExecuteAt: executeAt, Hold: true,
+				OperatedBy: "1-user",
+			},
+			expected: &domain.ScheduledTransferResponse{
+				ScheduledTransferID: 5, StatusScheduledTransfer: "scheduled", WalletHoldID: int64Ptr(3),
+			},
+			onMockHoldUc: func(mock *mock_usecase.MockWalletHoldSvc) {
+				mock.EXPECT().
+					CreateHold(gomock.Any(), &domain.CreateWalletHoldRequest{
+						UserID:     "1",
+						Currency:   "CNY",
+						Amount:     entity.NewMoney(10),
+						Reason:     "scheduled transfer to 2",
+						OperatedBy: "1-user",
+					}).
+					Return(&domain.WalletHoldResponse{WalletHoldID: 3, StatusHold: "active"}, nil)
+			},
+			onMockRepo: func(mock *mock_scheduled_transfer.MockRepository) {
+				mock.EXPECT().
+					CreateScheduledTransfer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, scheduled *entity.ScheduledTransfer) (int64, error) {
+						scheduled.ScheduledTransferID = 5
+						return 5, nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_scheduled_transfer.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+			holdUc := mock_usecase.NewMockWalletHoldSvc(ctrl)
+			if tC.onMockHoldUc != nil {
+				tC.onMockHoldUc(holdUc)
+			}
+
+			uc := NewScheduledTransferUseCase(repo, newMockHoldTxRepo(ctrl), mock_usecase.NewMockTransferSvc(ctrl), holdUc)
+			resp, err := uc.ScheduleTransfer(context.Background(), tC.request)
+			if tC.wantError != nil {
+				require.ErrorIs(t, err, tC.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tC.expected, resp)
+		})
+	}
+}
+
+func TestScheduledTransfer_CancelScheduledTransfer(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		scheduled    *entity.ScheduledTransfer
+		wantError    error
+		onMockHoldUc func(mock *mock_usecase.MockWalletHoldSvc)
+	}{
+		{
+			desc: "other_user",
+			scheduled: &entity.ScheduledTransfer{
+				ScheduledTransferID: 5, FromUserID: "2", StatusScheduledTransfer: entity.StatusScheduledTransferScheduled,
+			},
+			wantError: eerrs.ErrScheduledTransferNotFound,
+		},
+		{
+			desc: "already_executed",
+			scheduled: &entity.ScheduledTransfer{
+				ScheduledTransferID: 5, FromUserID: "1", StatusScheduledTransfer: entity.StatusScheduledTransferExecuted,
+			},
+			wantError: eerrs.ErrScheduledTransferNotCancelable,
+		},
+		{
+			desc: "success_releases_hold",
+			scheduled: &entity.ScheduledTransfer{
+				ScheduledTransferID: 5, FromUserID: "1", StatusScheduledTransfer: entity.StatusScheduledTransferScheduled,
+				WalletHoldID: int64Ptr(3),
+			},
+			onMockHoldUc: func(mock *mock_usecase.MockWalletHoldSvc) {
+				mock.EXPECT().
+					ReleaseHold(gomock.Any(), &domain.WalletHoldActionRequest{WalletHoldID: 3, OperatedBy: "1-user"}).
+					Return(&domain.WalletHoldResponse{WalletHoldID: 3, StatusHold: "released"}, nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_scheduled_transfer.NewMockRepository(ctrl)
+			repo.EXPECT().GetScheduledTransferForUpdate(gomock.Any(), int64(5)).Return(tC.scheduled, nil)
+			if tC.wantError == nil {
+				repo.EXPECT().
+					UpdateScheduledTransfer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, scheduled *entity.ScheduledTransfer) error {
+						assert.Equal(t, entity.StatusScheduledTransferCanceled, scheduled.StatusScheduledTransfer)
+						assert.NotNil(t, scheduled.CanceledAt)
+						return nil
+					})
+			}
+			holdUc := mock_usecase.NewMockWalletHoldSvc(ctrl)
+			if tC.onMockHoldUc != nil {
+				tC.onMockHoldUc(holdUc)
+			}
+
+			uc := NewScheduledTransferUseCase(repo, newMockHoldTxRepo(ctrl), mock_usecase.NewMockTransferSvc(ctrl), holdUc)
+			resp, err := uc.CancelScheduledTransfer(context.Background(), &domain.CancelScheduledTransferRequest{
+				ScheduledTransferID: 5,
+				UserID:              "1",
+				OperatedBy:          "1-user",
+			})
+			if tC.wantError != nil {
+				require.ErrorIs(t, err, tC.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "canceled", resp.StatusScheduledTransfer)
+		})
+	}
+}
+
+func TestScheduledTransfer_ExecuteScheduledTransfers(t *testing.T) {
+	due := func() *entity.ScheduledTransfer {
+		return &entity.ScheduledTransfer{
+			ScheduledTransferID:     5,
+			FromUserID:              "1",
+			ToUserID:                "2",
+			Amount:                  entity.NewMoney(10),
+			Currency:                entity.CurrencyCNY,
+			ExecuteAt:               time.Now().Add(-time.Minute),
+			StatusScheduledTransfer: entity.StatusScheduledTransferScheduled,
+			WalletHoldID:            int64Ptr(3),
+		}
+	}
+	testCases := []struct {
+		desc             string
+		msg              *domain.MsgKafkaScheduledTransfer
+		retried          bool
+		onMockRepo       func(mock *mock_scheduled_transfer.MockRepository)
+		onMockHoldUc     func(mock *mock_usecase.MockWalletHoldSvc)
+		onMockTransferUc func(mock *mock_usecase.MockTransferSvc)
+	}{
+		{
+			desc: "executed",
+			msg:  &domain.MsgKafkaScheduledTransfer{ScheduledTransferID: 5, OperatedBy: domain.KafkaProducerOperator},
+			onMockRepo: func(mock *mock_scheduled_transfer.MockRepository) {
+				mock.EXPECT().GetScheduledTransferForUpdate(gomock.Any(), int64(5)).Return(due(), nil)
+				mock.EXPECT().
+					UpdateScheduledTransfer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, scheduled *entity.ScheduledTransfer) error {
+						assert.Equal(t, entity.StatusScheduledTransferExecuted, scheduled.StatusScheduledTransfer)
+						assert.Equal(t, int64Ptr(9), scheduled.TransferID)
+						return nil
+					})
+			},
+			onMockHoldUc: func(mock *mock_usecase.MockWalletHoldSvc) {
+				mock.EXPECT().ReleaseHold(gomock.Any(), gomock.Any()).Return(&domain.WalletHoldResponse{}, nil)
+			},
+			onMockTransferUc: func(mock *mock_usecase.MockTransferSvc) {
+				mock.EXPECT().
+					CreateTransfer(gomock.Any(), &domain.CreateTransferRequest{
+						FromUserID: "1",
+						ToUserID:   "2",
+						Amount:     entity.NewMoney(10),
+						Currency:   "CNY",
+						CreatedBy:  domain.KafkaProducerOperator,
+					}).
+					Return(&domain.CreateTransferResponse{TransferID: 9}, nil)
+			},
+		},
+		{
+			desc: "insufficient_balance_marked_failed",
+			msg:  &domain.MsgKafkaScheduledTransfer{ScheduledTransferID: 5, OperatedBy: domain.KafkaProducerOperator},
+			onMockRepo: func(mock *mock_scheduled_transfer.MockRepository) {
+				mock.EXPECT().GetScheduledTransferForUpdate(gomock.Any(), int64(5)).Return(due(), nil).Times(2)
+				mock.EXPECT().
+					UpdateScheduledTransfer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, scheduled *entity.ScheduledTransfer) error {
+						assert.Equal(t, entity.StatusScheduledTransferFailed, scheduled.StatusScheduledTransfer)
+						assert.Equal(t, eerrs.ErrorCodeInsufficientBalance, scheduled.FailureCode)
+						assert.Equal(t, "insufficient balance", scheduled.FailureReason)
+						return nil
+					})
+			},
+			onMockHoldUc: func(mock *mock_usecase.MockWalletHoldSvc) {
+				// the release of the rejected run is rolled back and made again with the failure
+				mock.EXPECT().ReleaseHold(gomock.Any(), gomock.Any()).Return(&domain.WalletHoldResponse{}, nil).Times(2)
+			},
+			onMockTransferUc: func(mock *mock_usecase.MockTransferSvc) {
+				mock.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(nil, eerrs.ErrInsufficientBalance)
+			},
+		},
+		{
+			desc:    "transient_error_retried",
+			msg:     &domain.MsgKafkaScheduledTransfer{ScheduledTransferID: 5},
+			retried: true,
+			onMockRepo: func(mock *mock_scheduled_transfer.MockRepository) {
+				mock.EXPECT().GetScheduledTransferForUpdate(gomock.Any(), int64(5)).Return(nil, errors.New("connection reset"))
+			},
+		},
+		{
+			desc: "not_due_yet",
+			msg:  &domain.MsgKafkaScheduledTransfer{ScheduledTransferID: 5},
+			onMockRepo: func(mock *mock_scheduled_transfer.MockRepository) {
+				scheduled := due()
+				scheduled.ExecuteAt = time.Now().Add(time.Hour)
+				mock.EXPECT().GetScheduledTransferForUpdate(gomock.Any(), int64(5)).Return(scheduled, nil)
+			},
+		},
+		{
+			desc: "canceled_meanwhile",
+			msg:  &domain.MsgKafkaScheduledTransfer{ScheduledTransferID: 5},
+			onMockRepo: func(mock *mock_scheduled_transfer.MockRepository) {
+				scheduled := due()
+				scheduled.StatusScheduledTransfer = entity.StatusScheduledTransferCanceled
+				mock.EXPECT().GetScheduledTransferForUpdate(gomock.Any(), int64(5)).Return(scheduled, nil)
+			},
+		},
+		{
+			desc: "retries_exhausted",
+			msg:  &domain.MsgKafkaScheduledTransfer{ScheduledTransferID: 5, Counter: 3},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_scheduled_transfer.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+			holdUc := mock_usecase.NewMockWalletHoldSvc(ctrl)
+			if tC.onMockHoldUc != nil {
+				tC.onMockHoldUc(holdUc)
+			}
+			transferUc := mock_usecase.NewMockTransferSvc(ctrl)
+			if tC.onMockTransferUc != nil {
+				tC.onMockTransferUc(transferUc)
+			}
+
+			uc := NewScheduledTransferUseCase(repo, newMockHoldTxRepo(ctrl), transferUc, holdUc)
+			failed, err := uc.ExecuteScheduledTransfers(context.Background(), []*domain.MsgKafkaScheduledTransfer{tC.msg})
+			require.NoError(t, err)
+			if tC.retried {
+				assert.Equal(t, []*domain.MsgKafkaScheduledTransfer{tC.msg}, failed)
+			} else {
+				assert.Empty(t, failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/envelope"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/transaction_reversal"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/transfer"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_recharge_request"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_transaction"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	TransactionReversalSvcImpl struct {
+		repo                  transaction_reversal.Repository
+		walletTransactionRepo wallet_transaction.Repository
+		transferRepo          transfer.Repository
+		envelopeRepo          envelope.Repository
+		depositRepo           wallet_recharge_request.Repository
+		txRepo                tx.Repository
+		transactionUc         WalletTransactionSvc
+	}
+
+	TransactionReversalSvc interface {
+		// ReverseTransaction books the opposite of a posted wallet transaction and rolls back
+		// the transfer, envelope or deposit it moved, in one database transaction. Only the
+		// entry that started a transfer, an envelope or a deposit can be reversed, and only
+		// while nothing has happened to it since; claims and refunds are rejected with
+		// ErrTransactionNotReversible.
+		ReverseTransaction(
+			ctx context.Context, req *domain.ReverseTransactionRequest,
+		) (resp *domain.ReverseTransactionResponse, err error)
+	}
+)
+
+func NewTransactionReversalUseCase(
+	repo transaction_reversal.Repository,
+	walletTransactionRepo wallet_transaction.Repository,
+	transferRepo transfer.Repository,
+	envelopeRepo envelope.Repository,
+	depositRepo wallet_recharge_request.Repository,
+	txRepo tx.Repository,
+	transactionUc WalletTransactionSvc,
+) TransactionReversalSvc {
+	return &TransactionReversalSvcImpl{
+		repo:                  repo,
+		walletTransactionRepo: walletTransactionRepo,
+		transferRepo:          transferRepo,
+		envelopeRepo:          envelopeRepo,
+		depositRepo:           depositRepo,
+		txRepo:                txRepo,
+		transactionUc:         transactionUc,
+	}
+}
+
+func (s *TransactionReversalSvcImpl) ReverseTransaction(
+	ctx context.Context, req *domain.ReverseTransactionRequest,
+) (resp *domain.ReverseTransactionResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	reversal := &entity.TransactionReversal{
+		WalletTransactionID: req.WalletTransactionID,
+		Reason:              req.Reason,
+		CreatedBy:           req.OperatedBy,
+	}
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		// the row lock on the original serializes concurrent reversals of the same transaction
+		var original *entity.WalletTransaction
+		original, err = s.walletTransactionRepo.GetTransactionForUpdate(ctx, req.WalletTransactionID)
+		if err != nil {
+			log.ZError(ctx, "while lock wallet transaction", err, "walletTransactionID", req.WalletTransactionID)
+			return err
+		}
+		if original.ReversalOfID != nil {
+			return eerrs.ErrTransactionNotReversible
+		}
+
+		var reversed bool
+		reversed, err = s.repo.IsReversed(ctx, original.WalletTransactionID)
+		if err != nil {
+			log.ZError(ctx, "while check transaction reversal", err, "walletTransactionID", original.WalletTransactionID)
+			return err
+		}
+		if reversed {
+			return eerrs.ErrTransactionAlreadyReversed
+		}
+
+		if err = s.reverseRelatedState(ctx, original, req.OperatedBy); err != nil {
+			log.ZError(ctx, "while reverse related state", err, "walletTransactionID", original.WalletTransactionID)
+			return err
+		}
+
+		entryType := entity.EntryTypeCredit
+		if original.EntryType == entity.EntryTypeCredit {
+			entryType = entity.EntryTypeDebit
+		}
+		reversal.ReversalTransactionID, err = s.transactionUc.CreateTransaction(ctx, &domain.CreateTransactionReq{
+			WalletID:        original.WalletID,
+			Amount:          -original.Amount,
+			Currency:        original.Currency.String(),
+			TransactionType: original.TransactionType.String(),
+			Entrytype:       entryType.String(),
+			ImpactedItem:    original.ImpactedItem,
+			ReversalOfID:    original.WalletTransactionID,
+			CreatedBy:       req.OperatedBy,
+			ReferenceCode:   fmt.Sprintf("#REV-%s-%d", nowToStringYYYYMMDD(), original.WalletTransactionID),
+			DescriptionEn:   fmt.Sprintf("Reversal of transaction #%d %s", original.WalletTransactionID, req.Reason),
+			DescriptionZh:   fmt.Sprintf("冲正交易 #%d %s", original.WalletTransactionID, req.Reason),
+		})
+		if err != nil {
+			log.ZError(ctx, "while create reversal transaction", err, "walletTransactionID", original.WalletTransactionID)
+			return err
+		}
+
+		_, err = s.repo.CreateReversal(ctx, reversal)
+		if err != nil {
+			log.ZError(ctx, "while create transaction reversal", err, "walletTransactionID", original.WalletTransactionID)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.ZError(ctx, "while reverse transaction", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletTransactionID", reversal.WalletTransactionID),
+		attribute.Int64("reversalTransactionID", reversal.ReversalTransactionID),
+	)
+
+	return &domain.ReverseTransactionResponse{
+		TransactionReversalID: reversal.TransactionReversalID,
+		WalletTransactionID:   reversal.WalletTransactionID,
+		ReversalTransactionID: reversal.ReversalTransactionID,
+	}, nil
+}
+
+// reverseRelatedState undoes what the original transaction did to the transfer, envelope or
+// deposit it was booked for. Adjustments and hold captures have nothing besides the wallet
+// entry to undo.
+func (s *TransactionReversalSvcImpl) reverseRelatedState(
+	ctx context.Context, original *entity.WalletTransaction, operatedBy string,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.String("transactionType", original.TransactionType.String()),
+		attribute.String("entryType", original.EntryType.String()),
+		attribute.Int64("impactedItem", original.ImpactedItem),
+	)
+
+	var updated bool
+	switch original.TransactionType {
+	case entity.TransactionTypeDeposit:
+		updated, err = s.depositRepo.UpdateStatusRequest(
+			ctx, original.ImpactedItem, entity.StatusRequestApproved, entity.StatusRequestReversed, operatedBy,
+		)
+	case entity.TransactionTypeTransfer:
+		// a credit is the claim, the money then left the escrow for good
+		if original.EntryType != entity.EntryTypeDebit {
+			return eerrs.ErrTransactionNotReversible
+		}
+		updated, err = s.transferRepo.UpdateStatusTransfer(
+			ctx, original.ImpactedItem, entity.StatusTransferPending, entity.StatusTransferReversed, operatedBy,
+		)
+	case entity.TransactionTypeEnvelopeFixed, entity.TransactionTypeEnvelopeLucky, entity.TransactionTypeEnvelopeSingle,
+		entity.TransactionTypeEnvelopeExclusive:
+		if original.EntryType != entity.EntryTypeDebit {
+			return eerrs.ErrTransactionNotReversible
+		}
+		updated, err = s.reverseEnvelope(ctx, original.ImpactedItem, operatedBy)
+	case entity.TransactionTypeSystemAdjustment, entity.TransactionTypeHoldCapture:
+		updated = true
+	default:
+		return eerrs.ErrTransactionNotReversible
+	}
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return eerrs.ErrTransactionNotReversible
+	}
+
+	return nil
+}
+
+// reverseEnvelope closes an envelope nobody has claimed from yet, as if its whole amount had
+// been refunded, so no share of it can be claimed after the creation is reversed.
+func (s *TransactionReversalSvcImpl) reverseEnvelope(
+	ctx context.Context, envelopeID int64, operatedBy string,
+) (updated bool, err error) {
+	var env *entity.Envelope
+	env, err = s.envelopeRepo.LockEnvelopeByID(ctx, envelopeID)
+	if err != nil {
+		log.ZError(ctx, "while lock envelope", err, "envelopeID", envelopeID)
+		return false, err
+	}
+	if !env.TotalAmountClaimed.IsZero() || !env.TotalAmountRefunded.IsZero() || env.RefundedAt != nil {
+		return false, nil
+	}
+
+	refundedAt := time.Now()
+	env.TotalAmountRefunded = env.TotalAmount
+	env.RefundedAt = &refundedAt
+	env.UpdatedAt = refundedAt
+	env.UpdatedBy = operatedBy
+	if err = s.envelopeRepo.Update(ctx, env, nil); err != nil {
+		log.ZError(ctx, "while update envelope", err, "envelopeID", envelopeID)
+		return false, err
+	}
+
+	if err = s.envelopeRepo.DeactivateUnclaimedDetails(ctx, envelopeID); err != nil {
+		log.ZError(ctx, "while deactivate unclaimed envelope details", err, "envelopeID", envelopeID)
+		return false, eerrs.ErrDeactivateDetails(err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_envelope"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_transaction_reversal"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_transfer"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_tx"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_usecase"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet_recharge_request"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet_transaction"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type reversalMocks struct {
+	repo                  *mock_transaction_reversal.MockRepository
+	walletTransactionRepo *mock_wallet_transaction.MockRepository
+	transferRepo          *mock_transfer.MockRepository
+	envelopeRepo          *mock_envelope.MockRepository
+	depositRepo           *mock_wallet_recharge_request.MockRepository
+	transactionUc         *mock_usecase.MockWalletTransactionSvc
+}
+
+func TestTransactionReversal_ReverseTransaction(t *testing.T) {
+	type expected struct {
+		resp *domain.ReverseTransactionResponse
+		err  error
+	}
+	transferSend := &entity.WalletTransaction{
+		WalletTransactionID: 9,
+		WalletID:            2,
+		Amount:              entity.NewMoney(-30),
+		Currency:            entity.CurrencyCNY,
+		TransactionType:     entity.TransactionTypeTransfer,
+		EntryType:           entity.EntryTypeDebit,
+		ImpactedItem:        4,
+	}
+	testCases := []struct {
+		desc      string
+		request   *domain.ReverseTransactionRequest
+		expected  expected
+		wantError bool
+		onMock    func(m *reversalMocks)
+	}{
+		{
+			desc:      "reason_required",
+			request:   &domain.ReverseTransactionRequest{WalletTransactionID: 9},
+			expected:  expected{err: errors.New("reason is required")},
+			wantError: true,
+		},
+		{
+			desc:      "ErrTransactionNotFound",
+			request:   &domain.ReverseTransactionRequest{WalletTransactionID: 9, Reason: "typo"},
+			expected:  expected{err: eerrs.ErrTransactionNotFound},
+			wantError: true,
+			onMock: func(m *reversalMocks) {
+				m.walletTransactionRepo.EXPECT().GetTransactionForUpdate(gomock.Any(), int64(9)).
+					Return(nil, eerrs.ErrTransactionNotFound)
+			},
+		},
+		{
+			desc:      "ErrTransactionNotReversible_reversal",
+			request:   &domain.ReverseTransactionRequest{WalletTransactionID: 10, Reason: "typo"},
+			expected:  expected{err: eerrs.ErrTransactionNotReversible},
+			wantError: true,
+			onMock: func(m *reversalMocks) {
+				m.walletTransactionRepo.EXPECT().GetTransactionForUpdate(gomock.Any(), int64(10)).
+					Return(&entity.WalletTransaction{WalletTransactionID: 10, ReversalOfID: int64Ptr(9)}, nil)
+			},
+		},
+		{
+			desc:      "ErrTransactionAlreadyReversed",
+			request:   &domain.ReverseTransactionRequest{WalletTransactionID: 9, Reason: "typo"},
+			expected:  expected{err: eerrs.ErrTransactionAlreadyReversed},
+			wantError: true,
+			onMock: func(m *reversalMocks) {
+				m.walletTransactionRepo.EXPECT().GetTransactionForUpdate(gomock.Any(), int64(9)).Return(transferSend, nil)
+				m.repo.EXPECT().IsReversed(gomock.Any(), int64(9)).Return(true, nil)
+			},
+		},
+		{
+			desc:      "ErrTransactionNotReversible_transfer_claim",
+			request:   &domain.ReverseTransactionRequest{WalletTransactionID: 9, Reason: "typo"},
+			expected:  expected{err: eerrs.ErrTransactionNotReversible},
+			wantError: true,
+			onMock: func(m *reversalMocks) {
+				m.walletTransactionRepo.EXPECT().GetTransactionForUpdate(gomock.Any(), int64(9)).
+					Return(&entity.WalletTransaction{
+						WalletTransactionID: 9,
+						TransactionType:     entity.TransactionTypeTransfer,
+						EntryType:           entity.EntryTypeCredit,
+					}, nil)
+				m.repo.EXPECT().IsReversed(gomock.Any(), int64(9)).Return(false, nil)
+			},
+		},
+		{
+			desc:      "ErrTransactionNotReversible_transfer_claimed",
+			request:   &domain.ReverseTransactionRequest{WalletTransactionID: 9, Reason: "typo"},
+			expected:  expected{err: eerrs.ErrTransactionNotReversible},
+			wantError: true,
+			onMock: func(m *reversalMocks) {
+				m.walletTransactionRepo.EXPECT().GetTransactionForUpdate(gomock.Any(), int64(9)).Return(transferSend, nil)
+				m.repo.EXPECT().IsReversed(gomock.Any(), int64(9)).Return(false, nil)
+				m.transferRepo.EXPECT().
+					UpdateStatusTransfer(gomock.Any(), int64(4), entity.StatusTransferPending, entity.StatusTransferReversed, "admin").
+					Return(false, nil)
+			},
+		},
+		{
+			desc:      "ErrTransactionNotReversible_envelope_claimed",
+			request:   &domain.ReverseTransactionRequest{WalletTransactionID: 9, Reason: "typo"},
+			expected:  expected{err: eerrs.ErrTransactionNotReversible},
+			wantError: true,
+			onMock: func(m *reversalMocks) {
+				m.walletTransactionRepo.EXPECT().GetTransactionForUpdate(gomock.Any(), int64(9)).
+					Return(&entity.WalletTransaction{
+						WalletTransactionID: 9,
+						TransactionType:     entity.TransactionTypeEnvelopeLucky,
+						EntryType:           entity.EntryTypeDebit,
+						ImpactedItem:        5,
+					}, nil)
+				m.repo.EXPECT().IsReversed(gomock.Any(), int64(9)).Return(false, nil)
+				m.envelopeRepo.EXPECT().LockEnvelopeByID(gomock.Any(), int64(5)).
+					Return(&entity.Envelope{EnvelopeID: 5, TotalAmount: entity.NewMoney(10), TotalAmountClaimed: entity.NewMoney(2)}, nil)
+			},
+		},
+		{
+			desc:    "success_transfer_send",
+			request: &domain.ReverseTransactionRequest{WalletTransactionID: 9, Reason: "wrong receiver"},
+			expected: expected{resp: &domain.ReverseTransactionResponse{
+				TransactionReversalID: 1,
+				WalletTransactionID:   9,
+				ReversalTransactionID: 12,
+			}},
+			onMock: func(m *reversalMocks) {
+				m.walletTransactionRepo.EXPECT().GetTransactionForUpdate(gomock.Any(), int64(9)).Return(transferSend, nil)
+				m.repo.EXPECT().IsReversed(gomock.Any(), int64(9)).Return(false, nil)
+				m.transferRepo.EXPECT().
+					UpdateStatusTransfer(gomock.Any(), int64(4), entity.StatusTransferPending, entity.StatusTransferReversed, "admin").
+					Return(true, nil)
+				m.transactionUc.EXPECT().
+					CreateTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *domain.CreateTransactionReq) (int64, error) {
+						if req.WalletID != 2 || req.Amount != entity.NewMoney(30) || req.Entrytype != "credit" ||
+							req.TransactionType != "transfer" || req.ImpactedItem != 4 || req.ReversalOfID != 9 {
+							return 0, errors.New("unexpected reversal transaction")
+						}
+						return 12, nil
+					})
+				m.repo.EXPECT().
+					CreateReversal(gomock.Any(), &entity.TransactionReversal{
+						WalletTransactionID:   9,
+						ReversalTransactionID: 12,
+						Reason:                "wrong receiver",
+						CreatedBy:             "admin",
+					}).
+					DoAndReturn(func(_ context.Context, reversal *entity.TransactionReversal) (int64, error) {
+						reversal.TransactionReversalID = 1
+						return 1, nil
+					})
+			},
+		},
+		{
+			desc:    "success_deposit",
+			request: &domain.ReverseTransactionRequest{WalletTransactionID: 9, Reason: "duplicate deposit"},
+			expected: expected{resp: &domain.ReverseTransactionResponse{
+				TransactionReversalID: 1,
+				WalletTransactionID:   9,
+				ReversalTransactionID: 12,
+			}},
+			onMock: func(m *reversalMocks) {
+				m.walletTransactionRepo.EXPECT().GetTransactionForUpdate(gomock.Any(), int64(9)).
+					Return(&entity.WalletTransaction{
+						WalletTransactionID: 9,
+						WalletID:            2,
+						Amount:              entity.NewMoney(100),
+						TransactionType:     entity.TransactionTypeDeposit,
+						EntryType:           entity.EntryTypeCredit,
+						ImpactedItem:        6,
+					}, nil)
+				m.repo.EXPECT().IsReversed(gomock.Any(), int64(9)).Return(false, nil)
+				m.depositRepo.EXPECT().
+					UpdateStatusRequest(gomock.Any(), int64(6), entity.StatusRequestApproved, entity.StatusRequestReversed, "admin").
+					Return(true, nil)
+				m.transactionUc.EXPECT().
+					CreateTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *domain.CreateTransactionReq) (int64, error) {
+						if req.Amount != entity.NewMoney(-100) || req.Entrytype != "debit" || req.ReversalOfID != 9 {
+							return 0, errors.New("unexpected reversal transaction")
+						}
+						return 12, nil
+					})
+				m.repo.EXPECT().
+					CreateReversal(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, reversal *entity.TransactionReversal) (int64, error) {
+						reversal.TransactionReversalID = 1
+						return 1, nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			m := &reversalMocks{
+				repo:                  mock_transaction_reversal.NewMockRepository(ctrl),
+				walletTransactionRepo: mock_wallet_transaction.NewMockRepository(ctrl),
+				transferRepo:          mock_transfer.NewMockRepository(ctrl),
+				envelopeRepo:          mock_envelope.NewMockRepository(ctrl),
+				depositRepo:           mock_wallet_recharge_request.NewMockRepository(ctrl),
+				transactionUc:         mock_usecase.NewMockWalletTransactionSvc(ctrl),
+			}
+			if tC.onMock != nil {
+				tC.onMock(m)
+			}
+			txRepo := mock_tx.NewMockRepository(ctrl)
+			txRepo.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+					return fn(ctx, &gorm.DB{})
+				}).AnyTimes()
+
+			svc := NewTransactionReversalUseCase(
+				m.repo, m.walletTransactionRepo, m.transferRepo, m.envelopeRepo, m.depositRepo, txRepo, m.transactionUc,
+			)
+
+			tC.request.OperatedBy = "admin"
+			got, err := svc.ReverseTransaction(context.Background(), tC.request)
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
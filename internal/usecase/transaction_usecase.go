@@ -4,7 +4,6 @@ package usecase
 
 import (
 	"context"
-	"math"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -16,6 +15,7 @@ import (
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	"github.com/1nterdigital/aka-im-wallet/internal/domain"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/journal"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_transaction"
@@ -24,12 +24,17 @@ import (
 
 type (
 	WalletTransactionSvcImpl struct {
-		repo       wallet_transaction.Repository
-		walletRepo wallet.Repository
-		txRepo     tx.Repository
+		repo        wallet_transaction.Repository
+		walletRepo  wallet.Repository
+		journalRepo journal.Repository
+		txRepo      tx.Repository
 	}
 
 	WalletTransactionSvc interface {
+		// CreateTransaction books req on the wallet and the opposite amount on the system
+		// account matching its transaction type, in one database transaction. A req naming a
+		// currency other than the wallet's is rejected with ErrCurrencyMismatch, a debit may
+		// not take more than the available balance, i.e. the balance minus active holds.
 		CreateTransaction(
 			ctx context.Context, req *domain.CreateTransactionReq,
 		) (transactionID int64, err error)
@@ -42,12 +47,14 @@ type (
 func NewWalletTransactionUseCase(
 	repo wallet_transaction.Repository,
 	walletRepo wallet.Repository,
+	journalRepo journal.Repository,
 	txRepo tx.Repository,
 ) WalletTransactionSvc {
 	return &WalletTransactionSvcImpl{
-		repo:       repo,
-		walletRepo: walletRepo,
-		txRepo:     txRepo,
+		repo:        repo,
+		walletRepo:  walletRepo,
+		journalRepo: journalRepo,
+		txRepo:      txRepo,
 	}
 }
 
@@ -72,7 +79,12 @@ func (u *WalletTransactionSvcImpl) CreateTransaction(
 		return transactionID, err
 	}
 
-	errTrx := u.txRepo.Do(ctx, func(tx *gorm.DB) error {
+	var reversalOfID *int64
+	if req.ReversalOfID != 0 {
+		reversalOfID = &req.ReversalOfID
+	}
+
+	errTrx := u.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
 		var wallet *entity.Wallet
 		wallet, err = u.walletRepo.GetWalletByWalletIDTx(ctx, tx, req.WalletID)
 		if err != nil {
@@ -80,16 +92,24 @@ func (u *WalletTransactionSvcImpl) CreateTransaction(
 			return err
 		}
 
-		if req.Entrytype == entity.EntryTypeDebit.String() && math.Abs(req.Amount) > wallet.Balance {
+		if req.Currency != "" && entity.Currency(req.Currency) != wallet.Currency {
+			log.ZError(ctx, "while validate currency", eerrs.ErrCurrencyMismatch,
+				"walletCurrency", wallet.Currency, "currency", req.Currency)
+			return eerrs.ErrCurrencyMismatch
+		}
+
+		if err = validateWalletFreeze(wallet, req); err != nil {
+			log.ZError(ctx, "while validate wallet freeze", err,
+				"walletID", wallet.WalletID, "freezeStatus", wallet.FreezeStatus)
+			return err
+		}
+
+		if req.Entrytype == entity.EntryTypeDebit.String() && req.Amount.Abs() > wallet.AvailableBalance() {
 			log.ZError(ctx, "while validate balance user", eerrs.ErrInsufficientBalance)
 			return eerrs.ErrInsufficientBalance
 		}
 
-		if err = u.walletRepo.UpdateWallet(ctx, tx, &entity.Wallet{
-			WalletID:  req.WalletID,
-			Balance:   wallet.Balance + req.Amount,
-			UpdatedAt: time.Now(),
-		}); err != nil {
+		if err = u.walletRepo.UpdateBalance(ctx, req.WalletID, wallet.Balance+req.Amount); err != nil {
 			log.ZError(ctx, "while update wallet based on transaction", err)
 			return err
 		}
@@ -97,12 +117,14 @@ func (u *WalletTransactionSvcImpl) CreateTransaction(
 		transactionID, err = u.repo.CreateTransaction(ctx, tx, &entity.WalletTransaction{
 			WalletID:        req.WalletID,
 			Amount:          req.Amount,
+			Currency:        wallet.Currency,
 			TransactionType: entity.TransactionType(req.TransactionType),
 			EntryType:       entity.EntryType(req.Entrytype),
 			BeforeBalance:   wallet.Balance,
 			AfterBalance:    wallet.Balance + req.Amount,
 			ReferenceCode:   req.ReferenceCode,
 			ImpactedItem:    req.ImpactedItem,
+			ReversalOfID:    reversalOfID,
 			DescriptionEN:   req.DescriptionEn,
 			DescriptionZH:   req.DescriptionZh,
 			TransactionDate: time.Now(),
@@ -112,7 +134,7 @@ func (u *WalletTransactionSvcImpl) CreateTransaction(
 		})
 		span.SetAttributes(
 			attribute.Int64("walletID", req.WalletID),
-			attribute.Float64("amount", req.Amount),
+			attribute.String("amount", req.Amount.String()),
 			attribute.String("transactionType", req.TransactionType),
 		)
 
@@ -121,6 +143,18 @@ func (u *WalletTransactionSvcImpl) CreateTransaction(
 			return err
 		}
 
+		_, err = u.journalRepo.CreateJournalEntry(ctx, tx, &entity.JournalEntry{
+			WalletTransactionID: transactionID,
+			SystemAccount:       entity.TransactionType(req.TransactionType).CounterAccount(),
+			Currency:            wallet.Currency,
+			Amount:              -req.Amount,
+			CreatedBy:           req.CreatedBy,
+		})
+		if err != nil {
+			log.ZError(ctx, "while create journal entry", err, "walletTransactionID", transactionID)
+			return err
+		}
+
 		return nil
 	})
 	if errTrx != nil {
@@ -149,8 +183,14 @@ func (u *WalletTransactionSvcImpl) GetListTransaction(
 		span.End()
 	}()
 
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(req.Currency)
+	if err != nil {
+		return resp, err
+	}
+
 	var wallets *entity.Wallet
-	wallets, err = u.walletRepo.GetWalletByUserID(ctx, req.UserID)
+	wallets, err = u.walletRepo.GetWalletByUserID(ctx, req.UserID, currency)
 	if err != nil {
 		log.ZError(ctx, "while walletRepo.GetWalletByUserID", err)
 		return resp, err
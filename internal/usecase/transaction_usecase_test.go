@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_journal"
 	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_tx"
 	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet"
 	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet_transaction"
@@ -33,6 +34,7 @@ func Test_CreateTransaction(t *testing.T) {
 		onMockTransactionRepo func(mock *mock_wallet_transaction.MockRepository)
 		onMockTxRepo          func(mock *mock_tx.MockRepository)
 		onMockWalletRepo      func(mock *mock_wallet.MockRepository)
+		onMockJournalRepo     func(mock *mock_journal.MockRepository)
 	}{
 		{
 			desc: "ErrWalletIDRequired",
@@ -148,8 +150,8 @@ func Test_CreateTransaction(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
@@ -159,6 +161,109 @@ func Test_CreateTransaction(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			desc: "ErrCurrencyMismatch",
+			req: &domain.CreateTransactionReq{
+				WalletID:        1,
+				Entrytype:       "credit",
+				TransactionType: "deposit",
+				Amount:          1000.0,
+				Currency:        "USD",
+				DescriptionEn:   "description_en",
+				DescriptionZh:   "description_zh",
+				ReferenceCode:   "reference_code",
+				ImpactedItem:    1,
+			},
+			expected: expected{
+				transactionID: 0,
+				err:           eerrs.ErrCurrencyMismatch,
+			},
+			onMockTxRepo: func(mock *mock_tx.MockRepository) {
+				mock.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
+					})
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&entity.Wallet{
+						WalletID: 1,
+						Balance:  100.0,
+						Currency: entity.CurrencyCNY,
+					}, nil)
+			},
+			wantError: true,
+		},
+		{
+			desc: "ErrWalletDebitFrozen",
+			req: &domain.CreateTransactionReq{
+				WalletID:        1,
+				Entrytype:       "debit",
+				TransactionType: "transfer",
+				Amount:          -10.0,
+				DescriptionEn:   "description_en",
+				DescriptionZh:   "description_zh",
+				ReferenceCode:   "reference_code",
+				ImpactedItem:    1,
+			},
+			expected: expected{
+				transactionID: 0,
+				err:           eerrs.ErrWalletDebitFrozen,
+			},
+			onMockTxRepo: func(mock *mock_tx.MockRepository) {
+				mock.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
+					})
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&entity.Wallet{
+						WalletID:     1,
+						Balance:      100.0,
+						FreezeStatus: entity.FreezeStatusDebitFrozen,
+					}, nil)
+			},
+			wantError: true,
+		},
+		{
+			desc: "ErrWalletCreditFrozen",
+			req: &domain.CreateTransactionReq{
+				WalletID:        1,
+				Entrytype:       "credit",
+				TransactionType: "deposit",
+				Amount:          10.0,
+				DescriptionEn:   "description_en",
+				DescriptionZh:   "description_zh",
+				ReferenceCode:   "reference_code",
+				ImpactedItem:    1,
+			},
+			expected: expected{
+				transactionID: 0,
+				err:           eerrs.ErrWalletCreditFrozen,
+			},
+			onMockTxRepo: func(mock *mock_tx.MockRepository) {
+				mock.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
+					})
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&entity.Wallet{
+						WalletID:     1,
+						Balance:      100.0,
+						FreezeStatus: entity.FreezeStatusFrozen,
+					}, nil)
+			},
+			wantError: true,
+		},
 		{
 			desc: "ErrInsufficientBalance",
 			req: &domain.CreateTransactionReq{
@@ -178,8 +283,8 @@ func Test_CreateTransaction(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
@@ -192,6 +297,40 @@ func Test_CreateTransaction(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			desc: "ErrInsufficientBalance_held_balance",
+			req: &domain.CreateTransactionReq{
+				WalletID:        1,
+				Entrytype:       "debit",
+				TransactionType: "transfer",
+				Amount:          -80.0,
+				DescriptionEn:   "description_en",
+				DescriptionZh:   "description_zh",
+				ReferenceCode:   "reference_code",
+				ImpactedItem:    1,
+			},
+			expected: expected{
+				transactionID: 0,
+				err:           eerrs.ErrInsufficientBalance,
+			},
+			onMockTxRepo: func(mock *mock_tx.MockRepository) {
+				mock.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
+					})
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&entity.Wallet{
+						WalletID:    1,
+						Balance:     100.0,
+						HeldBalance: 30.0,
+					}, nil)
+			},
+			wantError: true,
+		},
 		{
 			desc: "error_while_update_wallet",
 			req: &domain.CreateTransactionReq{
@@ -211,8 +350,8 @@ func Test_CreateTransaction(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
@@ -223,7 +362,7 @@ func Test_CreateTransaction(t *testing.T) {
 						Balance:  2000.0,
 					}, nil)
 				mock.EXPECT().
-					UpdateWallet(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateBalance(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("something went wrong"))
 			},
 			wantError: true,
@@ -247,8 +386,8 @@ func Test_CreateTransaction(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
@@ -259,7 +398,7 @@ func Test_CreateTransaction(t *testing.T) {
 						Balance:  2000.0,
 					}, nil)
 				mock.EXPECT().
-					UpdateWallet(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateBalance(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 			onMockTransactionRepo: func(mock *mock_wallet_transaction.MockRepository) {
@@ -270,7 +409,7 @@ func Test_CreateTransaction(t *testing.T) {
 			wantError: true,
 		},
 		{
-			desc: "valid_transaction",
+			desc: "error_while_create_journal_entry",
 			req: &domain.CreateTransactionReq{
 				WalletID:        1,
 				Entrytype:       "debit",
@@ -281,6 +420,99 @@ func Test_CreateTransaction(t *testing.T) {
 				ReferenceCode:   "reference_code",
 				ImpactedItem:    1,
 			},
+			expected: expected{
+				transactionID: 1,
+				err:           errors.New("something went wrong"),
+			},
+			onMockTxRepo: func(mock *mock_tx.MockRepository) {
+				mock.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
+					})
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&entity.Wallet{
+						WalletID: 1,
+						Balance:  2000.0,
+					}, nil)
+				mock.EXPECT().
+					UpdateBalance(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			onMockTransactionRepo: func(mock *mock_wallet_transaction.MockRepository) {
+				mock.EXPECT().
+					CreateTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+			},
+			onMockJournalRepo: func(mock *mock_journal.MockRepository) {
+				mock.EXPECT().
+					CreateJournalEntry(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), errors.New("something went wrong"))
+			},
+			wantError: true,
+		},
+		{
+			desc: "debit_drains_wallet_to_zero",
+			req: &domain.CreateTransactionReq{
+				WalletID:        1,
+				Entrytype:       "debit",
+				TransactionType: "withdrawal",
+				Amount:          -2000,
+				DescriptionEn:   "description_en",
+				DescriptionZh:   "description_zh",
+				ReferenceCode:   "reference_code",
+				ImpactedItem:    1,
+			},
+			expected: expected{
+				transactionID: 1,
+			},
+			onMockTxRepo: func(mock *mock_tx.MockRepository) {
+				mock.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
+					})
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&entity.Wallet{
+						WalletID: 1,
+						Balance:  2000.0,
+					}, nil)
+				// the zero balance has to be written, not skipped as a zero value
+				mock.EXPECT().
+					UpdateBalance(gomock.Any(), int64(1), entity.Money(0)).
+					Return(nil)
+			},
+			onMockTransactionRepo: func(mock *mock_wallet_transaction.MockRepository) {
+				mock.EXPECT().
+					CreateTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+			},
+			onMockJournalRepo: func(mock *mock_journal.MockRepository) {
+				mock.EXPECT().
+					CreateJournalEntry(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+			},
+			wantError: false,
+		},
+		{
+			desc: "valid_transaction",
+			req: &domain.CreateTransactionReq{
+				WalletID:        1,
+				Entrytype:       "debit",
+				TransactionType: "transfer",
+				Amount:          -1000,
+				DescriptionEn:   "description_en",
+				DescriptionZh:   "description_zh",
+				ReferenceCode:   "reference_code",
+				ImpactedItem:    1,
+				CreatedBy:       "1123453-1",
+			},
 			expected: expected{
 				transactionID: 1,
 				err:           nil,
@@ -288,8 +520,8 @@ func Test_CreateTransaction(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
@@ -300,7 +532,7 @@ func Test_CreateTransaction(t *testing.T) {
 						Balance:  2000.0,
 					}, nil)
 				mock.EXPECT().
-					UpdateWallet(gomock.Any(), gomock.Any(), gomock.Any()).
+					UpdateBalance(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 			onMockTransactionRepo: func(mock *mock_wallet_transaction.MockRepository) {
@@ -308,6 +540,16 @@ func Test_CreateTransaction(t *testing.T) {
 					CreateTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(int64(1), nil)
 			},
+			onMockJournalRepo: func(mock *mock_journal.MockRepository) {
+				mock.EXPECT().
+					CreateJournalEntry(gomock.Any(), gomock.Any(), &entity.JournalEntry{
+						WalletTransactionID: 1,
+						SystemAccount:       entity.SystemAccountTransferEscrow,
+						Amount:              1000,
+						CreatedBy:           "1123453-1",
+					}).
+					Return(int64(1), nil)
+			},
 			wantError: false,
 		},
 	}
@@ -328,10 +570,15 @@ func Test_CreateTransaction(t *testing.T) {
 			if tC.onMockWalletRepo != nil {
 				tC.onMockWalletRepo(onMockWalletRepo)
 			}
+			onMockJournalRepo := mock_journal.NewMockRepository(ctrl)
+			if tC.onMockJournalRepo != nil {
+				tC.onMockJournalRepo(onMockJournalRepo)
+			}
 
 			svc := NewWalletTransactionUseCase(
 				onMockTransactionRepo,
 				onMockWalletRepo,
+				onMockJournalRepo,
 				onMockTxRepo,
 			)
 
@@ -376,7 +623,7 @@ func Test_GetListTransaction(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("something went wrong"))
 			},
 			wantError: true,
@@ -394,7 +641,7 @@ func Test_GetListTransaction(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("wallet not found"))
 			},
 			wantError: true,
@@ -412,7 +659,7 @@ func Test_GetListTransaction(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						Balance:  2000.0,
@@ -458,7 +705,7 @@ func Test_GetListTransaction(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						Balance:  3000.0,
@@ -506,6 +753,7 @@ func Test_GetListTransaction(t *testing.T) {
 				onMockTransactionRepo,
 				onMockWalletRepo,
 				nil,
+				nil,
 			)
 
 			got, err := svc.GetListTransaction(context.Background(), tC.req)
@@ -0,0 +1,341 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/errs"
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/transfer_batch"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+// failureReasonUnknown is kept on an item rejected for anything but a business error, the cause
+// itself is only logged.
+const failureReasonUnknown = "transfer could not be sent, try again later"
+
+type (
+	TransferBatchSvcImpl struct {
+		repo       transfer_batch.Repository
+		walletRepo wallet.Repository
+		txRepo     tx.Repository
+		transferUc TransferSvc
+	}
+
+	TransferBatchSvc interface {
+		// CreateTransferBatch sends a pending transfer to every recipient of the batch. The batch
+		// is checked as a whole first: the total against the sender balance and a wallet in the
+		// batch currency for every recipient.
+		CreateTransferBatch(
+			ctx context.Context, req *domain.CreateTransferBatchRequest,
+		) (resp *domain.TransferBatchResponse, err error)
+		GetDetailTransferBatch(
+			ctx context.Context, transferBatchID int64, userID string,
+		) (resp *domain.TransferBatchResponse, err error)
+	}
+)
+
+func NewTransferBatchUseCase(
+	repo transfer_batch.Repository,
+	walletRepo wallet.Repository,
+	txRepo tx.Repository,
+	transferUc TransferSvc,
+) TransferBatchSvc {
+	return &TransferBatchSvcImpl{
+		repo:       repo,
+		walletRepo: walletRepo,
+		txRepo:     txRepo,
+		transferUc: transferUc,
+	}
+}
+
+func (s *TransferBatchSvcImpl) CreateTransferBatch(
+	ctx context.Context, req *domain.CreateTransferBatchRequest,
+) (resp *domain.TransferBatchResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(req.Currency)
+	if err != nil {
+		return resp, err
+	}
+	req.Currency = currency.String()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var rejected map[int]error
+	rejected, err = s.validateBatch(ctx, req, currency)
+	if err != nil {
+		log.ZError(ctx, "while validate transfer batch", err, "fromUserID", req.FromUserID)
+		return resp, err
+	}
+
+	batch := &entity.TransferBatch{
+		FromUserID:          req.FromUserID,
+		Currency:            currency,
+		Mode:                entity.TransferBatchMode(req.Mode),
+		TotalAmount:         req.TotalAmount(),
+		ItemCount:           len(req.Items),
+		StatusTransferBatch: entity.StatusTransferBatchProcessing,
+		IsActive:            true,
+		CreatedBy:           req.OperatedBy,
+		UpdatedBy:           req.OperatedBy,
+	}
+
+	var items []*entity.TransferBatchItem
+	if batch.Mode == entity.TransferBatchModeAtomic {
+		items, err = s.sendAtomic(ctx, req, batch)
+	} else {
+		items, err = s.sendPartial(ctx, req, batch, rejected)
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("transferBatchID", batch.TransferBatchID),
+		attribute.String("mode", batch.Mode.String()),
+		attribute.Int("succeeded", batch.SucceededCount),
+		attribute.Int("failed", batch.FailedCount),
+	)
+
+	return dtoTransferBatchResponse(batch, items), nil
+}
+
+// validateBatch checks the batch as a whole before anything is sent. A recipient without a wallet
+// in the batch currency rejects an atomic batch, a partial batch only gets the item rejected.
+func (s *TransferBatchSvcImpl) validateBatch(
+	ctx context.Context, req *domain.CreateTransferBatchRequest, currency entity.Currency,
+) (rejected map[int]error, err error) {
+	var sourceWallet *entity.Wallet
+	sourceWallet, err = s.walletRepo.GetWalletByUserID(ctx, req.FromUserID, currency)
+	if err != nil {
+		log.ZError(ctx, "while get wallet source user", err, "fromUserID", req.FromUserID)
+		return nil, eerrs.ErrWalletNotFound
+	}
+
+	if err = validateWalletDebit(sourceWallet); err != nil {
+		return nil, err
+	}
+
+	if sourceWallet.AvailableBalance() < req.TotalAmount() {
+		return nil, eerrs.ErrInsufficientBalance
+	}
+
+	rejected = make(map[int]error)
+	hasWallet := make(map[string]bool, len(req.Items))
+	for idx, item := range req.Items {
+		found, checked := hasWallet[item.ToUserID]
+		if !checked {
+			_, errx := s.walletRepo.GetWalletByUserID(ctx, item.ToUserID, currency)
+			found = errx == nil
+			hasWallet[item.ToUserID] = found
+		}
+		if found {
+			continue
+		}
+
+		if req.Mode == entity.TransferBatchModeAtomic.String() {
+			return nil, eerrs.ErrBatchRecipientWalletNotFound(item.ToUserID)
+		}
+		rejected[idx] = eerrs.ErrBatchRecipientWalletNotFound(item.ToUserID)
+	}
+
+	return rejected, nil
+}
+
+// sendAtomic sends every item in one transaction, the first rejected item rolls the whole batch
+// back and nothing is kept.
+func (s *TransferBatchSvcImpl) sendAtomic(
+	ctx context.Context, req *domain.CreateTransferBatchRequest, batch *entity.TransferBatch,
+) (items []*entity.TransferBatchItem, err error) {
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		if _, err = s.repo.CreateTransferBatch(ctx, batch); err != nil {
+			return err
+		}
+
+		for idx, item := range req.Items {
+			batchItem := newTransferBatchItem(batch, item, req.OperatedBy)
+			if err = s.send(ctx, req, batchItem); err != nil {
+				log.ZError(ctx, "while send transfer batch item", err,
+					"fromUserID", req.FromUserID,
+					"toUserID", item.ToUserID,
+					"item", idx,
+				)
+				return err
+			}
+
+			items = append(items, batchItem)
+			batch.SucceededCount++
+		}
+
+		batch.Finish()
+
+		return s.repo.UpdateTransferBatch(ctx, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// sendPartial sends every item in a transaction of its own and records the rejected ones with
+// their cause, the batch carries on with the next item.
+func (s *TransferBatchSvcImpl) sendPartial(
+	ctx context.Context, req *domain.CreateTransferBatchRequest, batch *entity.TransferBatch, rejected map[int]error,
+) (items []*entity.TransferBatchItem, err error) {
+	if _, err = s.repo.CreateTransferBatch(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	for idx, item := range req.Items {
+		batchItem := newTransferBatchItem(batch, item, req.OperatedBy)
+
+		cause := rejected[idx]
+		if cause == nil {
+			cause = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+				return s.send(ctx, req, batchItem)
+			})
+		}
+		if cause == nil {
+			items = append(items, batchItem)
+			batch.SucceededCount++
+			continue
+		}
+
+		log.ZWarn(ctx, "transfer batch item rejected", cause,
+			"transferBatchID", batch.TransferBatchID,
+			"toUserID", item.ToUserID,
+			"item", idx,
+		)
+		// send may have filled the item in before its transaction was rolled back
+		batchItem.TransferBatchItemID = 0
+		batchItem.StatusTransferBatchItem = entity.StatusTransferBatchItemFailed
+		batchItem.TransferID = nil
+		batchItem.FailureCode, batchItem.FailureReason = failureOf(cause)
+		if err = s.repo.CreateTransferBatchItem(ctx, batchItem); err != nil {
+			return nil, err
+		}
+
+		items = append(items, batchItem)
+		batch.FailedCount++
+	}
+
+	batch.Finish()
+	if err = s.repo.UpdateTransferBatch(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// send creates the transfer of one item and records the item as sent.
+func (s *TransferBatchSvcImpl) send(
+	ctx context.Context, req *domain.CreateTransferBatchRequest, batchItem *entity.TransferBatchItem,
+) (err error) {
+	var transfer *domain.CreateTransferResponse
+	transfer, err = s.transferUc.CreateTransfer(ctx, &domain.CreateTransferRequest{
+		FromUserID: req.FromUserID,
+		ToUserID:   batchItem.ToUserID,
+		Amount:     batchItem.Amount,
+		Currency:   req.Currency,
+		Remark:     batchItem.Remark,
+		CreatedBy:  req.OperatedBy,
+		DeviceID:   req.DeviceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	batchItem.StatusTransferBatchItem = entity.StatusTransferBatchItemSucceeded
+	batchItem.TransferID = &transfer.TransferID
+
+	return s.repo.CreateTransferBatchItem(ctx, batchItem)
+}
+
+func newTransferBatchItem(
+	batch *entity.TransferBatch, item *domain.TransferBatchItemRequest, operatedBy string,
+) *entity.TransferBatchItem {
+	return &entity.TransferBatchItem{
+		TransferBatchID: batch.TransferBatchID,
+		ToUserID:        item.ToUserID,
+		Amount:          item.Amount,
+		Remark:          item.Remark,
+		CreatedBy:       operatedBy,
+	}
+}
+
+// failureOf is the code and message kept on a rejected item, only business errors are shown as is.
+func failureOf(cause error) (code int, reason string) {
+	var codeErr errs.CodeError
+	if errors.As(cause, &codeErr) {
+		return codeErr.Code(), codeErr.Msg()
+	}
+
+	return 0, failureReasonUnknown
+}
+
+func (s *TransferBatchSvcImpl) GetDetailTransferBatch(
+	ctx context.Context, transferBatchID int64, userID string,
+) (resp *domain.TransferBatchResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.Int64("transferBatchID", transferBatchID))
+
+	var batch *entity.TransferBatch
+	batch, err = s.repo.GetTransferBatch(ctx, transferBatchID)
+	if err != nil {
+		return resp, err
+	}
+
+	if batch.FromUserID != userID {
+		return resp, eerrs.ErrTransferBatchNotFound
+	}
+
+	var items []*entity.TransferBatchItem
+	items, err = s.repo.GetListTransferBatchItem(ctx, transferBatchID)
+	if err != nil {
+		return resp, err
+	}
+
+	return dtoTransferBatchResponse(batch, items), nil
+}
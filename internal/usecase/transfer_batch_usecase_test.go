@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_transfer_batch"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_usecase"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func TestTransferBatch_CreateTransferBatch(t *testing.T) {
+	items := func(toUserIDs ...string) (items []*domain.TransferBatchItemRequest) {
+		for _, toUserID := range toUserIDs {
+			items = append(items, &domain.TransferBatchItemRequest{ToUserID: toUserID, Amount: entity.NewMoney(10)})
+		}
+		return items
+	}
+	// onMockWallets gives the sender a balance of 100 and a wallet to every recipient but "3"
+	onMockWallets := func(mock *mock_wallet.MockRepository) {
+		mock.EXPECT().
+			GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+			Return(&entity.Wallet{WalletID: 1, UserID: "1", Balance: entity.NewMoney(100)}, nil)
+		mock.EXPECT().
+			GetWalletByUserID(gomock.Any(), gomock.Not("1"), entity.CurrencyCNY).
+			DoAndReturn(func(_ context.Context, userID string, _ entity.Currency) (*entity.Wallet, error) {
+				if userID == "3" {
+					return nil, errors.New("record not found")
+				}
+				return &entity.Wallet{UserID: userID}, nil
+			}).AnyTimes()
+	}
+	transferID := func(id int64) *domain.CreateTransferResponse {
+		return &domain.CreateTransferResponse{TransferID: id}
+	}
+	testCases := []struct {
+		desc             string
+		request          *domain.CreateTransferBatchRequest
+		wantError        error
+		expectedStatus   entity.StatusTransferBatch
+		expectedItems    []entity.StatusTransferBatchItem
+		onMockWalletRepo func(mock *mock_wallet.MockRepository)
+		onMockRepo       func(mock *mock_transfer_batch.MockRepository)
+		onMockTransferUc func(mock *mock_usecase.MockTransferSvc)
+	}{
+		{
+			desc:      "invalid_mode",
+			request:   &domain.CreateTransferBatchRequest{Mode: "all_or_some", Items: items("2")},
+			wantError: eerrs.ErrInvalidTransferBatchMode,
+		},
+		{
+			desc:      "too_many_items",
+			request:   &domain.CreateTransferBatchRequest{Items: make([]*domain.TransferBatchItemRequest, domain.MaxTransferBatchItems+1)},
+			wantError: eerrs.ErrTransferBatchSize(domain.MaxTransferBatchItems),
+		},
+		{
+			desc:      "self_transfer",
+			request:   &domain.CreateTransferBatchRequest{Items: items("2", "1")},
+			wantError: errors.New("items[1]: source user id and target user id must be different"),
+		},
+		{
+			desc: "total_over_balance",
+			request: &domain.CreateTransferBatchRequest{Items: []*domain.TransferBatchItemRequest{
+				{ToUserID: "2", Amount: entity.NewMoney(60)},
+				{ToUserID: "4", Amount: entity.NewMoney(60)},
+			}},
+			wantError:        eerrs.ErrInsufficientBalance,
+			onMockWalletRepo: onMockWallets,
+		},
+		{
+			desc:             "atomic_recipient_without_wallet",
+			request:          &domain.CreateTransferBatchRequest{Items: items("2", "3")},
+			wantError:        eerrs.ErrBatchRecipientWalletNotFound("3"),
+			onMockWalletRepo: onMockWallets,
+		},
+		{
+			desc:             "atomic_rejected_item_fails_the_batch",
+			request:          &domain.CreateTransferBatchRequest{Items: items("2", "4")},
+			wantError:        eerrs.ErrReceiverWalletLocked,
+			onMockWalletRepo: onMockWallets,
+			onMockRepo: func(mock *mock_transfer_batch.MockRepository) {
+				mock.EXPECT().CreateTransferBatch(gomock.Any(), gomock.Any()).Return(int64(9), nil)
+				mock.EXPECT().CreateTransferBatchItem(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			onMockTransferUc: func(mock *mock_usecase.MockTransferSvc) {
+				mock.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(transferID(20), nil)
+				mock.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(nil, eerrs.ErrReceiverWalletLocked)
+			},
+		},
+		{
+			desc:             "atomic_success",
+			request:          &domain.CreateTransferBatchRequest{Items: items("2", "4")},
+			expectedStatus:   entity.StatusTransferBatchCompleted,
+			expectedItems:    []entity.StatusTransferBatchItem{"succeeded", "succeeded"},
+			onMockWalletRepo: onMockWallets,
+			onMockRepo: func(mock *mock_transfer_batch.MockRepository) {
+				mock.EXPECT().CreateTransferBatch(gomock.Any(), gomock.Any()).Return(int64(9), nil)
+				mock.EXPECT().CreateTransferBatchItem(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				mock.EXPECT().UpdateTransferBatch(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			onMockTransferUc: func(mock *mock_usecase.MockTransferSvc) {
+				mock.EXPECT().
+					CreateTransfer(gomock.Any(), &domain.CreateTransferRequest{
+						FromUserID: "1", ToUserID: "2", Amount: entity.NewMoney(10), Currency: "CNY", CreatedBy: "1-app",
+					}).
+					Return(transferID(20), nil)
+				mock.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(transferID(21), nil)
+			},
+		},
+		{
+			desc: "partial_reports_rejected_items",
+			request: &domain.CreateTransferBatchRequest{
+				Mode:  entity.TransferBatchModePartial.String(),
+				Items: items("2", "3", "4"),
+			},
+			expectedStatus:   entity.StatusTransferBatchPartiallyCompleted,
+			expectedItems:    []entity.StatusTransferBatchItem{"succeeded", "failed", "failed"},
+			onMockWalletRepo: onMockWallets,
+			onMockRepo: func(mock *mock_transfer_batch.MockRepository) {
+				mock.EXPECT().CreateTransferBatch(gomock.Any(), gomock.Any()).Return(int64(9), nil)
+				mock.EXPECT().CreateTransferBatchItem(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+				mock.EXPECT().
+					UpdateTransferBatch(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, batch *entity.TransferBatch) error {
+						assert.Equal(t, 1, batch.SucceededCount)
+						assert.Equal(t, 2, batch.FailedCount)
+						return nil
+					})
+			},
+			onMockTransferUc: func(mock *mock_usecase.MockTransferSvc) {
+				mock.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(transferID(20), nil)
+				mock.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(nil, eerrs.ErrReceiverWalletLocked)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(walletRepo)
+			}
+
+			repo := mock_transfer_batch.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+
+			transferUc := mock_usecase.NewMockTransferSvc(ctrl)
+			if tC.onMockTransferUc != nil {
+				tC.onMockTransferUc(transferUc)
+			}
+
+			tC.request.FromUserID = "1"
+			tC.request.OperatedBy = "1-app"
+
+			uc := NewTransferBatchUseCase(repo, walletRepo, newMockHoldTxRepo(ctrl), transferUc)
+			resp, err := uc.CreateTransferBatch(context.Background(), tC.request)
+			if tC.wantError != nil {
+				require.Error(t, err)
+				assert.Equal(t, tC.wantError.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tC.expectedStatus.String(), resp.StatusTransferBatch)
+			assert.Equal(t, entity.NewMoney(10)*entity.Money(len(tC.request.Items)), resp.TotalAmount)
+			require.Len(t, resp.Items, len(tC.expectedItems))
+			for idx, status := range tC.expectedItems {
+				assert.Equal(t, status.String(), resp.Items[idx].StatusTransferBatchItem)
+			}
+		})
+	}
+}
+
+func TestTransferBatch_FailureOf(t *testing.T) {
+	code, reason := failureOf(eerrs.ErrBatchRecipientWalletNotFound("3"))
+	assert.Equal(t, eerrs.ErrorCodeBatchRecipientWalletNotFound, code)
+	assert.Equal(t, "recipient 3 has no wallet in the batch currency", reason)
+
+	code, reason = failureOf(errors.New("connection reset"))
+	assert.Equal(t, 0, code)
+	assert.Equal(t, failureReasonUnknown, reason)
+}
+
+func TestTransferBatch_GetDetailTransferBatch(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		userID    string
+		wantError error
+	}{
+		{desc: "other_user", userID: "2", wantError: eerrs.ErrTransferBatchNotFound},
+		{desc: "sender", userID: "1"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_transfer_batch.NewMockRepository(ctrl)
+			repo.EXPECT().
+				GetTransferBatch(gomock.Any(), int64(9)).
+				Return(&entity.TransferBatch{
+					TransferBatchID:     9,
+					FromUserID:          "1",
+					Mode:                entity.TransferBatchModePartial,
+					StatusTransferBatch: entity.StatusTransferBatchCompleted,
+				}, nil)
+			if tC.wantError == nil {
+				repo.EXPECT().
+					GetListTransferBatchItem(gomock.Any(), int64(9)).
+					Return([]*entity.TransferBatchItem{
+						{ToUserID: "2", StatusTransferBatchItem: entity.StatusTransferBatchItemSucceeded},
+					}, nil)
+			}
+
+			uc := NewTransferBatchUseCase(repo, nil, nil, nil)
+			resp, err := uc.GetDetailTransferBatch(context.Background(), 9, tC.userID)
+			if tC.wantError != nil {
+				require.ErrorIs(t, err, tC.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "partial", resp.Mode)
+			require.Len(t, resp.Items, 1)
+			assert.Equal(t, "2", resp.Items[0].ToUserID)
+		})
+	}
+}
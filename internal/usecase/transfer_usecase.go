@@ -31,15 +31,15 @@ type (
 		repo                     transfer.Repository
 		walletRepo               wallet.Repository
 		txRepo                   tx.Repository
+		limitUc                  LimitSvc
+		riskUc                   RiskSvc
+		expiry                   entity.ExpiryPolicy
 	}
 
 	TransferSvc interface {
 		CreateTransfer(
 			ctx context.Context, arg *domain.CreateTransferRequest,
 		) (resp *domain.CreateTransferResponse, err error)
-		validateTransfer(
-			ctx context.Context, arg *domain.CreateTransferRequest,
-		) (sourceWalletID int64, err error)
 		ProcessExpiredTransfers(
 			ctx context.Context, transfers []*domain.MsgKafkaExpiredTransfer,
 		) (failedRefund []*domain.MsgKafkaExpiredTransfer, err error)
@@ -49,6 +49,12 @@ type (
 		) ([]*domain.MsgKafkaExpiredTransfer, error)
 		ProcessManualRefund(ctx context.Context, transferIDs []int64) error
 		RefundTransfer(ctx context.Context, arg *domain.RefundTransferReq) (err error)
+		// CancelTransfer lets the sender revoke a transfer that has not been claimed or expired
+		// yet, the amount is credited back at once.
+		CancelTransfer(ctx context.Context, arg *domain.CancelTransferRequest) (err error)
+		// DeclineTransfer lets the receiver turn down a transfer instead of waiting for it to
+		// expire, the amount goes back to the sender at once.
+		DeclineTransfer(ctx context.Context, arg *domain.DeclineTransferRequest) (err error)
 		GetDetailTransfer(
 			ctx context.Context, transferID int64, userID string,
 		) (tranfer *domain.Transfer, err error)
@@ -61,6 +67,9 @@ func NewTransferUseCase(
 	repo transfer.Repository,
 	walletRepo wallet.Repository,
 	txRepo tx.Repository,
+	limitUc LimitSvc,
+	riskUc RiskSvc,
+	expiry entity.ExpiryPolicy,
 ) TransferSvc {
 	return &TransferSvcImpl{
 		expiredTransferPublisher: expiredTransferPublisher,
@@ -68,6 +77,9 @@ func NewTransferUseCase(
 		repo:                     repo,
 		walletRepo:               walletRepo,
 		txRepo:                   txRepo,
+		limitUc:                  limitUc,
+		riskUc:                   riskUc,
+		expiry:                   expiry,
 	}
 }
 
@@ -88,6 +100,19 @@ func (s *TransferSvcImpl) CreateTransfer(
 		span.End()
 	}()
 
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(arg.Currency)
+	if err != nil {
+		return resp, err
+	}
+	arg.Currency = currency.String()
+
+	var expiry time.Duration
+	expiry, err = resolveExpiry(s.expiry, arg.ExpiresInMinutes)
+	if err != nil {
+		return resp, err
+	}
+
 	var sourceWalletID int64
 	sourceWalletID, err = s.validateTransfer(ctx, arg)
 	if err != nil {
@@ -95,15 +120,21 @@ func (s *TransferSvcImpl) CreateTransfer(
 		return resp, err
 	}
 
-	dayDuration := time.Hour * 24
-	expiredAt := time.Now().Add(dayDuration)
+	expiredAt := time.Now().Add(expiry)
 
 	var transferID int64
-	errTrx := s.txRepo.Do(ctx, func(tx *gorm.DB) error {
+	errTrx := s.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		err = s.limitUc.CheckSpendLimits(ctx, arg.FromUserID, sourceWalletID, arg.Amount)
+		if err != nil {
+			log.ZError(ctx, "while check spend limits", err, "request", arg)
+			return err
+		}
+
 		transferID, err = s.repo.CreateTransfer(ctx, tx, &entity.Transfer{
 			FromUserID:     arg.FromUserID,
 			ToUserID:       arg.ToUserID,
 			Amount:         arg.Amount,
+			Currency:       currency,
 			StatusTransfer: entity.StatusTransferPending,
 			ExpiredAt:      &expiredAt,
 			Remark:         arg.Remark,
@@ -115,7 +146,8 @@ func (s *TransferSvcImpl) CreateTransfer(
 		span.SetAttributes(
 			attribute.String("fromUserID", arg.FromUserID),
 			attribute.String("toUserID", arg.ToUserID),
-			attribute.Float64("amount", arg.Amount),
+			attribute.String("amount", arg.Amount.String()),
+			attribute.String("currency", arg.Currency),
 			attribute.String("createdAt", time.Now().Format(time.RFC3339)),
 		)
 
@@ -127,6 +159,7 @@ func (s *TransferSvcImpl) CreateTransfer(
 		_, err = s.transactionUc.CreateTransaction(ctx, &domain.CreateTransactionReq{
 			WalletID:        sourceWalletID,
 			Amount:          -1 * arg.Amount,
+			Currency:        arg.Currency,
 			Entrytype:       entity.EntryTypeDebit.String(),
 			TransactionType: entity.TransactionTypeTransfer.String(),
 			ReferenceCode:   fmt.Sprintf("#TRF-%s-%s-%d", nowToStringYYYYMMDD(), arg.FromUserID, transferID),
@@ -140,6 +173,21 @@ func (s *TransferSvcImpl) CreateTransfer(
 			return err
 		}
 
+		_, err = s.riskUc.Evaluate(ctx, &domain.RiskEvent{
+			Operation:      entity.RiskOperationTransferCreate,
+			ReferenceID:    transferID,
+			UserID:         arg.FromUserID,
+			CounterpartyID: arg.ToUserID,
+			WalletID:       sourceWalletID,
+			Amount:         arg.Amount,
+			Currency:       currency,
+			DeviceID:       arg.DeviceID,
+		})
+		if err != nil {
+			log.ZError(ctx, "while evaluate risk", err, "transferID", transferID)
+			return err
+		}
+
 		span.SetAttributes(
 			attribute.Int64("sourceWalletID", sourceWalletID),
 		)
@@ -157,6 +205,32 @@ func (s *TransferSvcImpl) CreateTransfer(
 	}, nil
 }
 
+// validateSendLimits checks the transfer against the amount and daily count limits of the sender.
+func (s *TransferSvcImpl) validateSendLimits(ctx context.Context, arg *domain.CreateTransferRequest) (err error) {
+	var limits entity.Limits
+	limits, err = s.limitUc.GetUserLimits(ctx, arg.FromUserID)
+	if err != nil {
+		log.ZError(ctx, "while get user limits", err, "FromUserID", arg.FromUserID)
+		return err
+	}
+
+	if arg.Amount > limits.MaxSendTransferAmount {
+		return eerrs.ErrTransferLimitExceeded
+	}
+
+	var countToday int64
+	countToday, err = s.repo.CountSentTransferInDay(ctx, arg.FromUserID, time.Now())
+	if err != nil {
+		log.ZError(ctx, "while countSentTransferInDay", err, "FromUserID", arg.FromUserID)
+		return err
+	}
+	if countToday >= limits.TransferSendPerDay {
+		return eerrs.ErrSendingTransferDailyLimit
+	}
+
+	return nil
+}
+
 func (s *TransferSvcImpl) validateTransfer(
 	ctx context.Context, arg *domain.CreateTransferRequest,
 ) (sourceWalletID int64, err error) {
@@ -174,34 +248,51 @@ func (s *TransferSvcImpl) validateTransfer(
 		span.End()
 	}()
 
+	currency := entity.Currency(arg.Currency)
+
 	var sourceWallet *entity.Wallet
-	sourceWallet, err = s.walletRepo.GetWalletByUserID(ctx, arg.FromUserID)
+	sourceWallet, err = s.walletRepo.GetWalletByUserID(ctx, arg.FromUserID, currency)
 	if err != nil {
 		log.ZError(ctx, "while get wallet source user", err, "FromUserID", arg.FromUserID)
 		return sourceWalletID, eerrs.ErrWalletNotFound
 	}
 
-	var countToday int64
-	countToday, err = s.repo.CountSentTransferInDay(ctx, arg.FromUserID, time.Now())
-	if err != nil {
-		log.ZError(ctx, "while countSentTransferInDay", err, "FromUserID", arg.FromUserID)
+	if err = validateWalletDebit(sourceWallet); err != nil {
+		log.ZError(ctx, "while validate wallet source user", err, "FromUserID", arg.FromUserID)
 		return sourceWalletID, err
 	}
-	if countToday >= entity.MaxTransferSendPerDay {
-		return sourceWalletID, eerrs.ErrSendingTransferDailyLimit
+
+	if err = s.validateSendLimits(ctx, arg); err != nil {
+		return sourceWalletID, err
 	}
 
-	if sourceWallet.Balance < arg.Amount {
+	if sourceWallet.AvailableBalance() < arg.Amount {
 		log.ZError(ctx, "while validate balance source user", eerrs.ErrInsufficientBalance, "FromUserID", arg.FromUserID)
 		return sourceWalletID, eerrs.ErrInsufficientBalance
 	}
 
-	_, err = s.walletRepo.GetWalletByUserID(ctx, arg.ToUserID)
+	var targetWallets []*entity.Wallet
+	targetWallets, err = s.walletRepo.GetWalletsByUserID(ctx, arg.ToUserID)
 	if err != nil {
 		log.ZError(ctx, "while get wallet target user", err, "ToUserID", arg.ToUserID)
 		return sourceWalletID, eerrs.ErrReceiverWalletNotFound
 	}
 
+	var targetWallet *entity.Wallet
+	targetWallet, err = walletInCurrency(targetWallets, currency)
+	if err != nil {
+		log.ZError(ctx, "while validate wallet target user", err, "ToUserID", arg.ToUserID, "currency", currency)
+		if errors.Is(err, eerrs.ErrWalletNotFound) {
+			return sourceWalletID, eerrs.ErrReceiverWalletNotFound
+		}
+		return sourceWalletID, err
+	}
+
+	if !targetWallet.FreezeStatus.CanCredit() {
+		log.ZError(ctx, "while validate wallet target user", eerrs.ErrReceiverWalletLocked, "ToUserID", arg.ToUserID)
+		return sourceWalletID, eerrs.ErrReceiverWalletLocked
+	}
+
 	span.SetAttributes(attribute.Int64("walletID", sourceWallet.WalletID))
 
 	return sourceWallet.WalletID, nil
@@ -248,7 +339,7 @@ func (s *TransferSvcImpl) ProcessExpiredTransfers(
 		span.SetAttributes(attribute.Int64("transferID", transfers[idx].TransferID))
 
 		var walletUser *entity.Wallet
-		walletUser, err = s.walletRepo.GetWalletByUserID(ctx, transferDetail.FromUserID)
+		walletUser, err = s.walletRepo.GetWalletByUserID(ctx, transferDetail.FromUserID, transferDetail.Currency)
 		if err != nil {
 			if !errors.Is(err, gorm.ErrRecordNotFound) {
 				log.ZWarn(ctx, "while GetWalletByUserID", err,
@@ -263,12 +354,24 @@ func (s *TransferSvcImpl) ProcessExpiredTransfers(
 		span.SetAttributes(attribute.Int64("walletID", walletUser.WalletID))
 		span.SetAttributes(attribute.String("userID", walletUser.UserID))
 
-		if transferDetail.RefundedAt != nil {
+		// claimed, canceled or reversed since the message was queued
+		if transferDetail.RefundedAt != nil || transferDetail.StatusTransfer != entity.StatusTransferPending {
 			continue
 		}
 
-		txErr := s.txRepo.Do(ctx, func(tx *gorm.DB) error {
+		txErr := s.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
 			var errx error
+			// the read above is only a shortcut, a claim, cancel, decline or reversal may still
+			// commit before the refund, so it is checked again under the row lock
+			transferDetail, errx = s.repo.GetTransferForUpdate(ctx, transferDetail.TransferID)
+			if errx != nil {
+				log.ZError(ctx, "while lock transfer", errx, "transferID", transfers[idx].TransferID)
+				return errx
+			}
+			if transferDetail.RefundedAt != nil || transferDetail.StatusTransfer != entity.StatusTransferPending {
+				return nil
+			}
+
 			refundedAt := time.Now()
 			transferDetail.RefundedAt = &refundedAt
 			transferDetail.UpdatedAt = refundedAt
@@ -288,8 +391,9 @@ func (s *TransferSvcImpl) ProcessExpiredTransfers(
 				WalletID:        walletUser.WalletID,
 				ImpactedItem:    transferDetail.TransferID,
 				TransactionType: string(entity.TransactionTypeRefundTransfer),
-				Entrytype:       string(entity.EntryTypeDebit),
+				Entrytype:       string(entity.EntryTypeCredit),
 				Amount:          transferDetail.Amount,
+				Currency:        transferDetail.Currency.String(),
 				DescriptionEn:   fmt.Sprintf(domain.TransactionRefundTransferEN, transferDetail.TransferID),
 				DescriptionZh:   fmt.Sprintf(domain.TransactionRefundTransferZN, transferDetail.TransferID),
 				ReferenceCode:   fmt.Sprintf(domain.FormatReferenceCodeRefundTransfer, transferDetail.TransferID, walletUser.WalletID),
@@ -302,7 +406,7 @@ func (s *TransferSvcImpl) ProcessExpiredTransfers(
 				)
 				return errx
 			}
-			span.SetAttributes(attribute.Float64("amount", transferDetail.Amount))
+			span.SetAttributes(attribute.String("amount", transferDetail.Amount.String()))
 
 			return nil
 		})
@@ -336,13 +440,20 @@ func (s *TransferSvcImpl) validateClaimTransfer(
 
 	span.SetAttributes(attribute.String("userID", userID))
 
+	var limits entity.Limits
+	limits, err = s.limitUc.GetUserLimits(ctx, userID)
+	if err != nil {
+		log.ZError(ctx, "while get user limits", err, "claimerUserID", userID)
+		return err
+	}
+
 	var claimCountToday int64
 	claimCountToday, err = s.repo.CountClaimedTransferInDay(ctx, userID, time.Now())
 	if err != nil {
 		log.ZError(ctx, "while get countClaimedTransferInDay", err, "claimerUserID", userID)
 		return err
 	}
-	if claimCountToday > entity.MaxTransferClaimPerDay {
+	if claimCountToday > limits.TransferClaimPerDay {
 		return eerrs.ErrClaimTransferDailyLimit
 	}
 	return nil
@@ -365,9 +476,9 @@ func (s *TransferSvcImpl) ClaimTransfer(
 		span.End()
 	}()
 
-	var claimerWallet *entity.Wallet
-	claimerWallet, err = s.walletRepo.GetWalletByUserID(ctx, arg.ClaimerUserID)
-	if err != nil {
+	var claimerWallets []*entity.Wallet
+	claimerWallets, err = s.walletRepo.GetWalletsByUserID(ctx, arg.ClaimerUserID)
+	if err != nil || len(claimerWallets) == 0 {
 		log.ZError(ctx, "while get wallet claimer user", err, "claimerUserID", arg.ClaimerUserID)
 		return eerrs.ErrWalletNotFound
 	}
@@ -385,7 +496,31 @@ func (s *TransferSvcImpl) ClaimTransfer(
 		return eerrs.ErrNoEligibleTransfer
 	}
 
-	errTrx := s.txRepo.Do(ctx, func(tx *gorm.DB) error {
+	var claimerWallet *entity.Wallet
+	claimerWallet, err = walletInCurrency(claimerWallets, transferDetail.Currency)
+	if err != nil {
+		log.ZError(ctx, "while validate wallet claimer user", err,
+			"claimerUserID", arg.ClaimerUserID, "currency", transferDetail.Currency)
+		return err
+	}
+
+	if err = validateWalletCredit(claimerWallet); err != nil {
+		log.ZError(ctx, "while validate wallet claimer user", err, "claimerUserID", arg.ClaimerUserID)
+		return err
+	}
+
+	errTrx := s.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		var locked *entity.Transfer
+		locked, err = s.repo.GetTransferForUpdate(ctx, transferDetail.TransferID)
+		if err != nil {
+			log.ZError(ctx, "while lock transfer", err, "transferID", arg.TransferID)
+			return err
+		}
+		// the sender may have canceled it, or the receiver declined it, since it was read
+		if locked.StatusTransfer != entity.StatusTransferPending {
+			return eerrs.ErrNoEligibleTransfer
+		}
+
 		claimAt := time.Now()
 		transferDetail.ClaimedAt = &claimAt
 		transferDetail.UpdatedAt = claimAt
@@ -404,6 +539,7 @@ func (s *TransferSvcImpl) ClaimTransfer(
 			TransactionType: string(entity.TransactionTypeTransfer),
 			Entrytype:       string(entity.EntryTypeCredit),
 			Amount:          transferDetail.Amount,
+			Currency:        transferDetail.Currency.String(),
 			DescriptionEn:   "Claim transfer from " + transferDetail.FromUserID,
 			DescriptionZh:   "领取转账 " + transferDetail.FromUserID,
 			ReferenceCode:   fmt.Sprintf("#TRF-%s-%s-%d", nowToStringYYYYMMDD(), arg.OperateBy, transferDetail.TransferID),
@@ -413,9 +549,25 @@ func (s *TransferSvcImpl) ClaimTransfer(
 			log.ZError(ctx, "while create transaction", err, "transferID", arg.TransferID)
 			return err
 		}
+
+		_, err = s.riskUc.Evaluate(ctx, &domain.RiskEvent{
+			Operation:      entity.RiskOperationTransferClaim,
+			ReferenceID:    transferDetail.TransferID,
+			UserID:         arg.ClaimerUserID,
+			CounterpartyID: transferDetail.FromUserID,
+			WalletID:       claimerWallet.WalletID,
+			Amount:         transferDetail.Amount,
+			Currency:       transferDetail.Currency,
+			DeviceID:       arg.DeviceID,
+		})
+		if err != nil {
+			log.ZError(ctx, "while evaluate risk", err, "transferID", arg.TransferID)
+			return err
+		}
+
 		span.SetAttributes(
 			attribute.Int64("walletID", claimerWallet.WalletID),
-			attribute.Float64("amount", transferDetail.Amount),
+			attribute.String("amount", transferDetail.Amount.String()),
 		)
 
 		return nil
@@ -515,12 +667,6 @@ func (s *TransferSvcImpl) RefundTransfer(
 		span.End()
 	}()
 
-	var walletUser *entity.Wallet
-	walletUser, err = s.walletRepo.GetWalletByUserID(ctx, arg.UserID)
-	if err != nil {
-		log.ZError(ctx, "while get wallet user", err, "UserID", arg.UserID)
-		return err
-	}
 	span.SetAttributes(
 		attribute.Int64("argTransferID", arg.TransferID),
 		attribute.String("argUserID", arg.UserID),
@@ -533,7 +679,24 @@ func (s *TransferSvcImpl) RefundTransfer(
 		return err
 	}
 
-	errTrx := s.txRepo.Do(ctx, func(tx *gorm.DB) error {
+	var walletUser *entity.Wallet
+	walletUser, err = s.walletRepo.GetWalletByUserID(ctx, arg.UserID, transferDetail.Currency)
+	if err != nil {
+		log.ZError(ctx, "while get wallet user", err, "UserID", arg.UserID)
+		return err
+	}
+
+	errTrx := s.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		// a retried refund, the expiry job or a reversal may have settled it since the read above
+		transferDetail, err = s.repo.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			log.ZError(ctx, "while lock transfer", err, "transferID", arg.TransferID)
+			return err
+		}
+		if err = validateRefundTransfer(transferDetail, arg.UserID); err != nil {
+			return err
+		}
+
 		transferDetail.RefundedAt = convert.PtrTime(time.Now())
 		transferDetail.UpdatedAt = time.Now()
 		transferDetail.UpdatedBy = arg.OperatedBy
@@ -551,6 +714,7 @@ func (s *TransferSvcImpl) RefundTransfer(
 			TransactionType: string(entity.TransactionTypeRefundTransfer),
 			Entrytype:       string(entity.EntryTypeCredit),
 			Amount:          transferDetail.Amount,
+			Currency:        transferDetail.Currency.String(),
 			DescriptionEn:   fmt.Sprintf("Refund Transfer from %s", transferDetail.ToUserID),
 			DescriptionZh:   fmt.Sprintf("退款转账 %s", transferDetail.ToUserID),
 			ReferenceCode:   fmt.Sprintf("#RTRF-%s-%s-%d", nowToStringYYYYMMDD(), arg.OperatedBy, transferDetail.TransferID),
@@ -562,7 +726,7 @@ func (s *TransferSvcImpl) RefundTransfer(
 		}
 		span.SetAttributes(
 			attribute.Int64("refundTransferID", transferDetail.TransferID),
-			attribute.Float64("refundAmount", transferDetail.Amount),
+			attribute.String("refundAmount", transferDetail.Amount.String()),
 		)
 
 		return nil
@@ -576,6 +740,220 @@ func (s *TransferSvcImpl) RefundTransfer(
 	return nil
 }
 
+func (s *TransferSvcImpl) CancelTransfer(
+	ctx context.Context, arg *domain.CancelTransferRequest,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("transferID", arg.TransferID),
+		attribute.String("userID", arg.UserID),
+	)
+
+	errTrx := s.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		var transferDetail *entity.Transfer
+		transferDetail, err = s.repo.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			log.ZError(ctx, "while lock transfer", err, "transferID", arg.TransferID)
+			return err
+		}
+
+		if err = validateCancelTransfer(transferDetail, arg.UserID); err != nil {
+			return err
+		}
+
+		var walletUser *entity.Wallet
+		walletUser, err = s.walletRepo.GetWalletByUserID(ctx, arg.UserID, transferDetail.Currency)
+		if err != nil {
+			log.ZError(ctx, "while get wallet user", err, "UserID", arg.UserID)
+			return err
+		}
+
+		canceledAt := time.Now()
+		transferDetail.CanceledAt = &canceledAt
+		transferDetail.UpdatedAt = canceledAt
+		transferDetail.UpdatedBy = arg.OperatedBy
+		transferDetail.StatusTransfer = entity.StatusTransferCanceled
+
+		err = s.repo.Update(ctx, transferDetail, tx)
+		if err != nil {
+			log.ZError(ctx, "while update transfer", err, "transferID", arg.TransferID)
+			return err
+		}
+
+		_, err = s.transactionUc.CreateTransaction(ctx, &domain.CreateTransactionReq{
+			WalletID:        walletUser.WalletID,
+			ImpactedItem:    transferDetail.TransferID,
+			TransactionType: string(entity.TransactionTypeCancelTransfer),
+			Entrytype:       string(entity.EntryTypeCredit),
+			Amount:          transferDetail.Amount,
+			Currency:        transferDetail.Currency.String(),
+			DescriptionEn:   fmt.Sprintf("Cancel transfer to %s", transferDetail.ToUserID),
+			DescriptionZh:   fmt.Sprintf("取消转账 %s", transferDetail.ToUserID),
+			ReferenceCode:   fmt.Sprintf("#CTRF-%s-%s-%d", nowToStringYYYYMMDD(), arg.OperatedBy, transferDetail.TransferID),
+			CreatedBy:       arg.OperatedBy,
+		})
+		if err != nil {
+			log.ZError(ctx, "while create transaction", err, "transferID", arg.TransferID)
+			return err
+		}
+		span.SetAttributes(attribute.String("amount", transferDetail.Amount.String()))
+
+		return nil
+	})
+	if errTrx != nil {
+		log.ZError(ctx, "while do trx cancel transfer", errTrx)
+		err = errTrx
+		return err
+	}
+
+	return nil
+}
+
+// validateCancelTransfer only lets the sender revoke a transfer the receiver can still claim.
+// validateRefundTransfer checks the locked transfer still is what GetEligibleRefundTransfer selects.
+func validateRefundTransfer(transfer *entity.Transfer, userID string) error {
+	expired := transfer.ExpiredAt != nil && transfer.ExpiredAt.Before(time.Now())
+	if transfer.FromUserID != userID || transfer.StatusTransfer != entity.StatusTransferPending || !expired ||
+		transfer.ClaimedAt != nil || transfer.RefundedAt != nil || !transfer.IsActive {
+		return eerrs.ErrNoEligibleTransferRefund
+	}
+
+	return nil
+}
+
+func validateCancelTransfer(transfer *entity.Transfer, userID string) error {
+	if transfer.FromUserID != userID {
+		return eerrs.ErrTransferNotFound
+	}
+
+	if transfer.StatusTransfer == entity.StatusTransferClaimed {
+		return eerrs.ErrTransferAlreadyClaimed
+	}
+
+	expired := transfer.ExpiredAt != nil && !transfer.ExpiredAt.After(time.Now())
+	if transfer.StatusTransfer != entity.StatusTransferPending || !transfer.IsActive || expired {
+		return eerrs.ErrTransferNotCancelable
+	}
+
+	return nil
+}
+
+func (s *TransferSvcImpl) DeclineTransfer(
+	ctx context.Context, arg *domain.DeclineTransferRequest,
+) (err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(
+		attribute.Int64("transferID", arg.TransferID),
+		attribute.String("userID", arg.UserID),
+	)
+
+	errTrx := s.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		var transferDetail *entity.Transfer
+		transferDetail, err = s.repo.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			log.ZError(ctx, "while lock transfer", err, "transferID", arg.TransferID)
+			return err
+		}
+
+		if err = validateDeclineTransfer(transferDetail, arg.UserID); err != nil {
+			return err
+		}
+
+		var senderWallet *entity.Wallet
+		senderWallet, err = s.walletRepo.GetWalletByUserID(ctx, transferDetail.FromUserID, transferDetail.Currency)
+		if err != nil {
+			log.ZError(ctx, "while get wallet sender", err, "FromUserID", transferDetail.FromUserID)
+			return err
+		}
+
+		declinedAt := time.Now()
+		transferDetail.DeclinedAt = &declinedAt
+		transferDetail.UpdatedAt = declinedAt
+		transferDetail.UpdatedBy = arg.OperatedBy
+		transferDetail.StatusTransfer = entity.StatusTransferDeclined
+
+		err = s.repo.Update(ctx, transferDetail, tx)
+		if err != nil {
+			log.ZError(ctx, "while update transfer", err, "transferID", arg.TransferID)
+			return err
+		}
+
+		_, err = s.transactionUc.CreateTransaction(ctx, &domain.CreateTransactionReq{
+			WalletID:        senderWallet.WalletID,
+			ImpactedItem:    transferDetail.TransferID,
+			TransactionType: string(entity.TransactionTypeDeclineTransfer),
+			Entrytype:       string(entity.EntryTypeCredit),
+			Amount:          transferDetail.Amount,
+			Currency:        transferDetail.Currency.String(),
+			DescriptionEn:   fmt.Sprintf("Transfer declined by %s", transferDetail.ToUserID),
+			DescriptionZh:   fmt.Sprintf("转账被 %s 拒收", transferDetail.ToUserID),
+			ReferenceCode:   fmt.Sprintf("#DTRF-%s-%s-%d", nowToStringYYYYMMDD(), arg.OperatedBy, transferDetail.TransferID),
+			CreatedBy:       arg.OperatedBy,
+		})
+		if err != nil {
+			log.ZError(ctx, "while create transaction", err, "transferID", arg.TransferID)
+			return err
+		}
+		span.SetAttributes(
+			attribute.Int64("senderWalletID", senderWallet.WalletID),
+			attribute.String("amount", transferDetail.Amount.String()),
+		)
+
+		return nil
+	})
+	if errTrx != nil {
+		log.ZError(ctx, "while do trx decline transfer", errTrx)
+		err = errTrx
+		return err
+	}
+
+	return nil
+}
+
+// validateDeclineTransfer only lets the receiver turn down a transfer they could still claim.
+func validateDeclineTransfer(transfer *entity.Transfer, userID string) error {
+	if transfer.ToUserID != userID {
+		return eerrs.ErrTransferNotFound
+	}
+
+	if transfer.StatusTransfer == entity.StatusTransferClaimed {
+		return eerrs.ErrTransferAlreadyClaimed
+	}
+
+	expired := transfer.ExpiredAt != nil && !transfer.ExpiredAt.After(time.Now())
+	if transfer.StatusTransfer != entity.StatusTransferPending || !transfer.IsActive || expired {
+		return eerrs.ErrNoEligibleTransfer
+	}
+
+	return nil
+}
+
 func (s *TransferSvcImpl) GetDetailTransfer(
 	ctx context.Context, transferID int64, userID string,
 ) (tranfer *domain.Transfer, err error) {
@@ -44,12 +44,12 @@ func TestTransfer_CreateTransfer(t *testing.T) {
 			},
 			expected: expected{
 				resp: nil,
-				err:  errors.New("something wrong"),
+				err:  eerrs.ErrWalletNotFound,
 			},
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
 					Return(nil, errors.New("something wrong"))
 			},
 		},
@@ -68,13 +68,66 @@ func TestTransfer_CreateTransfer(t *testing.T) {
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "1",
 						Balance:  10,
 					}, nil).Times(1)
 			},
+			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					CountSentTransferInDay(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), nil).Times(1)
+			},
+		},
+		{
+			desc: "ErrTransferLimitExceeded",
+			arg: &domain.CreateTransferRequest{
+				FromUserID: "1",
+				ToUserID:   "2",
+				Amount:     entity.DefaultLimits.MaxSendTransferAmount + 1,
+			},
+			expected: expected{
+				err: eerrs.ErrTransferLimitExceeded,
+			},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{
+						WalletID: 1,
+						UserID:   "1",
+						Balance:  entity.NewMoney(10000),
+					}, nil).Times(1)
+			},
+		},
+		{
+			desc: "ErrInsufficientBalance_held_balance",
+			arg: &domain.CreateTransferRequest{
+				FromUserID: "1",
+				ToUserID:   "2",
+				Amount:     100,
+			},
+			expected: expected{
+				err: eerrs.ErrInsufficientBalance,
+			},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{
+						WalletID:    1,
+						UserID:      "1",
+						Balance:     150,
+						HeldBalance: 60,
+					}, nil).Times(1)
+			},
+			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					CountSentTransferInDay(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), nil).Times(1)
+			},
 		},
 		{
 			desc: "error_while_GetTargetWallet",
@@ -86,21 +139,141 @@ func TestTransfer_CreateTransfer(t *testing.T) {
 			expected: expected{
 
 				resp: nil,
-				err:  errors.New("something wrong"),
+				err:  eerrs.ErrReceiverWalletNotFound,
 			},
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "1",
 						Balance:  1000,
 					}, nil).Times(1)
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletsByUserID(gomock.Any(), "2").
 					Return(nil, errors.New("something wrong")).Times(1)
 			},
+			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					CountSentTransferInDay(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), nil).Times(1)
+			},
+		},
+		{
+			desc: "ErrInvalidCurrency",
+			arg: &domain.CreateTransferRequest{
+				FromUserID: "1",
+				ToUserID:   "2",
+				Amount:     100,
+				Currency:   "XYZ",
+			},
+			expected: expected{
+				resp: nil,
+				err:  eerrs.ErrInvalidCurrency,
+			},
+			wantError: true,
+		},
+		{
+			desc: "ErrCurrencyMismatch_receiver_has_no_wallet_in_currency",
+			arg: &domain.CreateTransferRequest{
+				FromUserID: "1",
+				ToUserID:   "2",
+				Amount:     100,
+				Currency:   "usd",
+			},
+			expected: expected{
+				resp: nil,
+				err:  eerrs.ErrCurrencyMismatch,
+			},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyUSD).
+					Return(&entity.Wallet{
+						WalletID: 3,
+						UserID:   "1",
+						Balance:  1000,
+						Currency: entity.CurrencyUSD,
+					}, nil).Times(1)
+				mock.EXPECT().
+					GetWalletsByUserID(gomock.Any(), "2").
+					Return([]*entity.Wallet{
+						{
+							WalletID: 2,
+							UserID:   "2",
+							Balance:  100,
+							Currency: entity.CurrencyCNY,
+						},
+					}, nil).Times(1)
+			},
+			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					CountSentTransferInDay(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), nil).Times(1)
+			},
+		},
+		{
+			desc: "ErrReceiverWalletLocked",
+			arg: &domain.CreateTransferRequest{
+				FromUserID: "1",
+				ToUserID:   "2",
+				Amount:     100,
+			},
+			expected: expected{
+				resp: nil,
+				err:  eerrs.ErrReceiverWalletLocked,
+			},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{
+						WalletID: 1,
+						UserID:   "1",
+						Balance:  1000,
+						Currency: entity.CurrencyCNY,
+					}, nil).Times(1)
+				mock.EXPECT().
+					GetWalletsByUserID(gomock.Any(), "2").
+					Return([]*entity.Wallet{
+						{
+							WalletID:     2,
+							UserID:       "2",
+							Currency:     entity.CurrencyCNY,
+							FreezeStatus: entity.FreezeStatusCreditFrozen,
+						},
+					}, nil).Times(1)
+			},
+			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					CountSentTransferInDay(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), nil).Times(1)
+			},
+		},
+		{
+			desc: "ErrWalletDebitFrozen",
+			arg: &domain.CreateTransferRequest{
+				FromUserID: "1",
+				ToUserID:   "2",
+				Amount:     100,
+			},
+			expected: expected{
+				resp: nil,
+				err:  eerrs.ErrWalletDebitFrozen,
+			},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{
+						WalletID:     1,
+						UserID:       "1",
+						Balance:      1000,
+						Currency:     entity.CurrencyCNY,
+						FreezeStatus: entity.FreezeStatusFrozen,
+					}, nil).Times(1)
+			},
 		},
 		{
 			desc: "Err_CreateTransfer",
@@ -117,28 +290,34 @@ func TestTransfer_CreateTransfer(t *testing.T) {
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "1",
 						Balance:  1000,
 					}, nil).Times(1)
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
-					Return(&entity.Wallet{
-						WalletID: 2,
-						UserID:   "2",
-						Balance:  100,
+					GetWalletsByUserID(gomock.Any(), "2").
+					Return([]*entity.Wallet{
+						{
+							WalletID: 2,
+							UserID:   "2",
+							Balance:  100,
+							Currency: entity.CurrencyCNY,
+						},
 					}, nil).Times(1)
 			},
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					CountSentTransferInDay(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), nil).Times(1)
 				mock.EXPECT().
 					CreateTransfer(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(int64(0), errors.New("something wrong")).Times(1)
@@ -159,28 +338,34 @@ func TestTransfer_CreateTransfer(t *testing.T) {
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "1",
 						Balance:  1000,
 					}, nil).Times(1)
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
-					Return(&entity.Wallet{
-						WalletID: 2,
-						UserID:   "2",
-						Balance:  100,
+					GetWalletsByUserID(gomock.Any(), "2").
+					Return([]*entity.Wallet{
+						{
+							WalletID: 2,
+							UserID:   "2",
+							Balance:  100,
+							Currency: entity.CurrencyCNY,
+						},
 					}, nil).Times(1)
 			},
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					CountSentTransferInDay(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), nil).Times(1)
 				mock.EXPECT().
 					CreateTransfer(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(int64(1), nil)
@@ -207,28 +392,34 @@ func TestTransfer_CreateTransfer(t *testing.T) {
 			wantError: false,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "1",
 						Balance:  1000,
 					}, nil).Times(1)
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
-					Return(&entity.Wallet{
-						WalletID: 2,
-						UserID:   "2",
-						Balance:  100,
+					GetWalletsByUserID(gomock.Any(), "2").
+					Return([]*entity.Wallet{
+						{
+							WalletID: 2,
+							UserID:   "2",
+							Balance:  100,
+							Currency: entity.CurrencyCNY,
+						},
 					}, nil).Times(1)
 			},
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					CountSentTransferInDay(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), nil).Times(1)
 				mock.EXPECT().
 					CreateTransfer(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(int64(1), nil)
@@ -239,6 +430,64 @@ func TestTransfer_CreateTransfer(t *testing.T) {
 					Return(int64(1), nil)
 			},
 		},
+		{
+			desc: "ErrExpiryRange",
+			arg: &domain.CreateTransferRequest{
+				FromUserID:       "1",
+				ToUserID:         "2",
+				Amount:           100,
+				ExpiresInMinutes: 30,
+			},
+			expected: expected{
+				err: eerrs.ErrExpiryRange(60, 72*60),
+			},
+			wantError: true,
+		},
+		{
+			desc: "SuccessCreateTransfer_custom_expiry",
+			arg: &domain.CreateTransferRequest{
+				FromUserID:       "1",
+				ToUserID:         "2",
+				Amount:           100,
+				ExpiresInMinutes: 120,
+			},
+			expected: expected{
+				resp: &domain.CreateTransferResponse{
+					TransferID: 1,
+				},
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 1, UserID: "1", Balance: 1000}, nil).Times(1)
+				mock.EXPECT().
+					GetWalletsByUserID(gomock.Any(), "2").
+					Return([]*entity.Wallet{{WalletID: 2, UserID: "2", Currency: entity.CurrencyCNY}}, nil).Times(1)
+			},
+			onMockTxRepo: func(mock *mock_tx.MockRepository) {
+				mock.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
+					})
+			},
+			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					CountSentTransferInDay(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(int64(0), nil).Times(1)
+				mock.EXPECT().
+					CreateTransfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *gorm.DB, transfer *entity.Transfer) (int64, error) {
+						assert.WithinDuration(t, time.Now().Add(2*time.Hour), *transfer.ExpiredAt, time.Minute)
+						return 1, nil
+					})
+			},
+			onMockTransactionUsecase: func(mock *mock_usecase.MockWalletTransactionSvc) {
+				mock.EXPECT().
+					CreateTransaction(gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+			},
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -270,6 +519,9 @@ func TestTransfer_CreateTransfer(t *testing.T) {
 				onMockTransferRepo,
 				onMockWalletRepo,
 				onMockTxRepo,
+				newMockDefaultLimits(ctrl),
+				newMockAllowRisk(ctrl),
+				entity.DefaultTransferExpiry,
 			)
 
 			transfer, err := svc.CreateTransfer(context.Background(), tC.arg)
@@ -284,6 +536,21 @@ func TestTransfer_CreateTransfer(t *testing.T) {
 	}
 }
 
+// refundableTransfer is a pending transfer of user 111111 that expired unclaimed.
+func refundableTransfer() *entity.Transfer {
+	expiredAt := time.Now().Add(-time.Hour)
+	return &entity.Transfer{
+		TransferID:     1,
+		FromUserID:     "111111",
+		ToUserID:       "222222",
+		Amount:         1000.0,
+		Currency:       entity.CurrencyCNY,
+		StatusTransfer: entity.StatusTransferPending,
+		ExpiredAt:      &expiredAt,
+		IsActive:       true,
+	}
+}
+
 func TestTransfer_RefundTransfer(t *testing.T) {
 	testCases := []struct {
 		desc                     string
@@ -304,9 +571,24 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), entity.CurrencyCNY).
 					Return(nil, eerrs.ErrWalletNotFound)
 			},
+			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					GetEligibleRefundTransfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(
+						&entity.Transfer{
+							TransferID:     1,
+							FromUserID:     "111111",
+							ToUserID:       "222222",
+							Amount:         1000.0,
+							Currency:       entity.CurrencyCNY,
+							StatusTransfer: "pending",
+						},
+						nil,
+					)
+			},
 		},
 		{
 			desc: "ErrNotEligibleRefundTransfer",
@@ -315,15 +597,6 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 			},
 			err:       errors.New("not eligible refund transfer"),
 			wantError: true,
-			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
-				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
-					Return(&entity.Wallet{
-						WalletID: 1,
-						UserID:   "1",
-						Balance:  1000,
-					}, nil)
-			},
 			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
 				mock.EXPECT().
 					GetEligibleRefundTransfer(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -337,12 +610,13 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 			desc: "ErrWhileUpdateTransfer",
 			arg: &domain.RefundTransferReq{
 				TransferID: 1,
+				UserID:     "111111",
 			},
 			err:       errors.New("while update transfer"),
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "1",
@@ -362,6 +636,7 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 						},
 						nil,
 					)
+				mock.EXPECT().GetTransferForUpdate(gomock.Any(), int64(1)).Return(refundableTransfer(), nil)
 				mock.EXPECT().
 					Update(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("while update transfer"))
@@ -369,8 +644,8 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 		},
@@ -378,12 +653,13 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 			desc: "ErrWhileCreateTransactions",
 			arg: &domain.RefundTransferReq{
 				TransferID: 1,
+				UserID:     "111111",
 			},
 			err:       errors.New("while create transactions"),
 			wantError: true,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "1",
@@ -403,6 +679,7 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 						},
 						nil,
 					)
+				mock.EXPECT().GetTransferForUpdate(gomock.Any(), int64(1)).Return(refundableTransfer(), nil)
 				mock.EXPECT().
 					Update(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
@@ -410,8 +687,8 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockTransactionUsecase: func(mock *mock_usecase.MockWalletTransactionSvc) {
@@ -420,16 +697,48 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 					Return(int64(0), errors.New("while create transactions"))
 			},
 		},
+		{
+			desc: "ErrNoEligibleTransferRefund_settled_under_lock",
+			arg: &domain.RefundTransferReq{
+				TransferID: 1,
+				UserID:     "111111",
+			},
+			err:       eerrs.ErrNoEligibleTransferRefund,
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&entity.Wallet{WalletID: 1, UserID: "111111", Balance: 1000}, nil)
+			},
+			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					GetEligibleRefundTransfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(refundableTransfer(), nil)
+				// a retried refund committed between the read and the lock, nothing is credited twice
+				settled := refundableTransfer()
+				settled.StatusTransfer = entity.StatusTransferRefunded
+				settled.RefundedAt = &time.Time{}
+				mock.EXPECT().GetTransferForUpdate(gomock.Any(), int64(1)).Return(settled, nil)
+			},
+			onMockTxRepo: func(mock *mock_tx.MockRepository) {
+				mock.EXPECT().
+					Do(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
+					})
+			},
+		},
 		{
 			desc: "SuccessCreateTransfer",
 			arg: &domain.RefundTransferReq{
 				TransferID: 1,
+				UserID:     "111111",
 			},
 			err:       nil,
 			wantError: false,
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(&entity.Wallet{
 						WalletID: 1,
 						UserID:   "1",
@@ -449,6 +758,7 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 						},
 						nil,
 					)
+				mock.EXPECT().GetTransferForUpdate(gomock.Any(), int64(1)).Return(refundableTransfer(), nil)
 				mock.EXPECT().
 					Update(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
@@ -456,8 +766,8 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 			onMockTxRepo: func(mock *mock_tx.MockRepository) {
 				mock.EXPECT().
 					Do(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(tx *gorm.DB) error) error {
-						return fn(&gorm.DB{})
+					DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+						return fn(ctx, &gorm.DB{})
 					})
 			},
 			onMockTransactionUsecase: func(mock *mock_usecase.MockWalletTransactionSvc) {
@@ -497,6 +807,9 @@ func TestTransfer_RefundTransfer(t *testing.T) {
 				onMockTransferRepo,
 				onMockWalletRepo,
 				onMockTxRepo,
+				nil,
+				newMockAllowRisk(ctrl),
+				entity.DefaultTransferExpiry,
 			)
 
 			err := svc.RefundTransfer(context.Background(), tC.arg)
@@ -610,7 +923,7 @@ func TestTransfer_GetDetailTransfer(t *testing.T) {
 				tC.onMockTransferRepo(onMockTransferRepo)
 			}
 
-			svc := NewTransferUseCase(nil, nil, onMockTransferRepo, nil, nil)
+			svc := NewTransferUseCase(nil, nil, onMockTransferRepo, nil, nil, nil, nil, entity.DefaultTransferExpiry)
 
 			got, err := svc.GetDetailTransfer(context.Background(), tC.arg.transferID, tC.arg.userID)
 			if !tC.wantError {
@@ -623,3 +936,337 @@ func TestTransfer_GetDetailTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestTransfer_CancelTransfer(t *testing.T) {
+	var (
+		later   = time.Now().Add(time.Hour)
+		earlier = time.Now().Add(-time.Hour)
+	)
+	pending := func() *entity.Transfer {
+		return &entity.Transfer{
+			TransferID:     1,
+			FromUserID:     "111111",
+			ToUserID:       "222222",
+			Amount:         entity.NewMoney(10),
+			Currency:       entity.CurrencyCNY,
+			StatusTransfer: entity.StatusTransferPending,
+			ExpiredAt:      &later,
+			IsActive:       true,
+		}
+	}
+	testCases := []struct {
+		desc                     string
+		transfer                 func() *entity.Transfer
+		err                      error
+		onMockWalletRepo         func(mock *mock_wallet.MockRepository)
+		onMockTransferRepo       func(mock *mock_transfer.MockRepository)
+		onMockTransactionUsecase func(mock *mock_usecase.MockWalletTransactionSvc)
+	}{
+		{
+			desc: "ErrNotSender",
+			transfer: func() *entity.Transfer {
+				transfer := pending()
+				transfer.FromUserID = "333333"
+				return transfer
+			},
+			err: eerrs.ErrTransferNotFound,
+		},
+		{
+			desc: "ErrAlreadyClaimed",
+			transfer: func() *entity.Transfer {
+				transfer := pending()
+				transfer.StatusTransfer = entity.StatusTransferClaimed
+				return transfer
+			},
+			err: eerrs.ErrTransferAlreadyClaimed,
+		},
+		{
+			desc: "ErrExpired",
+			transfer: func() *entity.Transfer {
+				transfer := pending()
+				transfer.ExpiredAt = &earlier
+				return transfer
+			},
+			err: eerrs.ErrTransferNotCancelable,
+		},
+		{
+			desc: "ErrAlreadyCanceled",
+			transfer: func() *entity.Transfer {
+				transfer := pending()
+				transfer.StatusTransfer = entity.StatusTransferCanceled
+				return transfer
+			},
+			err: eerrs.ErrTransferNotCancelable,
+		},
+		{
+			desc:     "SuccessCancelTransfer",
+			transfer: pending,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "111111", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 5, UserID: "111111"}, nil)
+			},
+			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, transfer *entity.Transfer, _ *gorm.DB) error {
+						assert.Equal(t, entity.StatusTransferCanceled, transfer.StatusTransfer)
+						assert.NotNil(t, transfer.CanceledAt)
+						assert.Nil(t, transfer.RefundedAt)
+						return nil
+					})
+			},
+			onMockTransactionUsecase: func(mock *mock_usecase.MockWalletTransactionSvc) {
+				mock.EXPECT().
+					CreateTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *domain.CreateTransactionReq) (int64, error) {
+						// booked apart from the refunds of expired transfers
+						assert.Equal(t, entity.TransactionTypeCancelTransfer.String(), req.TransactionType)
+						assert.Equal(t, entity.EntryTypeCredit.String(), req.Entrytype)
+						assert.Equal(t, int64(5), req.WalletID)
+						assert.Equal(t, entity.NewMoney(10), req.Amount)
+						return 1, nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			onMockWalletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(onMockWalletRepo)
+			}
+
+			onMockTransferRepo := mock_transfer.NewMockRepository(ctrl)
+			onMockTransferRepo.EXPECT().GetTransferForUpdate(gomock.Any(), int64(1)).Return(tC.transfer(), nil)
+			if tC.onMockTransferRepo != nil {
+				tC.onMockTransferRepo(onMockTransferRepo)
+			}
+
+			onMockTxRepo := mock_tx.NewMockRepository(ctrl)
+			onMockTxRepo.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+					return fn(ctx, &gorm.DB{})
+				})
+
+			onMockTransactionUsecase := mock_usecase.NewMockWalletTransactionSvc(ctrl)
+			if tC.onMockTransactionUsecase != nil {
+				tC.onMockTransactionUsecase(onMockTransactionUsecase)
+			}
+
+			svc := NewTransferUseCase(
+				nil,
+				onMockTransactionUsecase,
+				onMockTransferRepo,
+				onMockWalletRepo,
+				onMockTxRepo,
+				nil,
+				nil,
+				entity.DefaultTransferExpiry,
+			)
+
+			err := svc.CancelTransfer(context.Background(), &domain.CancelTransferRequest{
+				TransferID: 1,
+				UserID:     "111111",
+				OperatedBy: "111111-user",
+			})
+			if tC.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestTransfer_DeclineTransfer(t *testing.T) {
+	later := time.Now().Add(time.Hour)
+	pending := func() *entity.Transfer {
+		return &entity.Transfer{
+			TransferID:     1,
+			FromUserID:     "111111",
+			ToUserID:       "222222",
+			Amount:         entity.NewMoney(10),
+			Currency:       entity.CurrencyCNY,
+			StatusTransfer: entity.StatusTransferPending,
+			ExpiredAt:      &later,
+			IsActive:       true,
+		}
+	}
+	testCases := []struct {
+		desc                     string
+		userID                   string
+		transfer                 func() *entity.Transfer
+		err                      error
+		onMockWalletRepo         func(mock *mock_wallet.MockRepository)
+		onMockTransferRepo       func(mock *mock_transfer.MockRepository)
+		onMockTransactionUsecase func(mock *mock_usecase.MockWalletTransactionSvc)
+	}{
+		{
+			desc:     "ErrSenderCannotDecline",
+			userID:   "111111",
+			transfer: pending,
+			err:      eerrs.ErrTransferNotFound,
+		},
+		{
+			desc:   "ErrAlreadyCanceled",
+			userID: "222222",
+			transfer: func() *entity.Transfer {
+				transfer := pending()
+				transfer.StatusTransfer = entity.StatusTransferCanceled
+				return transfer
+			},
+			err: eerrs.ErrNoEligibleTransfer,
+		},
+		{
+			desc:     "SuccessDeclineTransfer",
+			userID:   "222222",
+			transfer: pending,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "111111", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 5, UserID: "111111"}, nil)
+			},
+			onMockTransferRepo: func(mock *mock_transfer.MockRepository) {
+				mock.EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, transfer *entity.Transfer, _ *gorm.DB) error {
+						assert.Equal(t, entity.StatusTransferDeclined, transfer.StatusTransfer)
+						assert.NotNil(t, transfer.DeclinedAt)
+						return nil
+					})
+			},
+			onMockTransactionUsecase: func(mock *mock_usecase.MockWalletTransactionSvc) {
+				mock.EXPECT().
+					CreateTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *domain.CreateTransactionReq) (int64, error) {
+						// the sender is credited, not the user who declined
+						assert.Equal(t, int64(5), req.WalletID)
+						assert.Equal(t, entity.TransactionTypeDeclineTransfer.String(), req.TransactionType)
+						assert.Equal(t, entity.EntryTypeCredit.String(), req.Entrytype)
+						return 1, nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			onMockWalletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(onMockWalletRepo)
+			}
+
+			onMockTransferRepo := mock_transfer.NewMockRepository(ctrl)
+			onMockTransferRepo.EXPECT().GetTransferForUpdate(gomock.Any(), int64(1)).Return(tC.transfer(), nil)
+			if tC.onMockTransferRepo != nil {
+				tC.onMockTransferRepo(onMockTransferRepo)
+			}
+
+			onMockTxRepo := mock_tx.NewMockRepository(ctrl)
+			onMockTxRepo.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+					return fn(ctx, &gorm.DB{})
+				})
+
+			onMockTransactionUsecase := mock_usecase.NewMockWalletTransactionSvc(ctrl)
+			if tC.onMockTransactionUsecase != nil {
+				tC.onMockTransactionUsecase(onMockTransactionUsecase)
+			}
+
+			svc := NewTransferUseCase(
+				nil,
+				onMockTransactionUsecase,
+				onMockTransferRepo,
+				onMockWalletRepo,
+				onMockTxRepo,
+				nil,
+				nil,
+				entity.DefaultTransferExpiry,
+			)
+
+			err := svc.DeclineTransfer(context.Background(), &domain.DeclineTransferRequest{
+				TransferID: 1,
+				UserID:     tC.userID,
+				OperatedBy: tC.userID + "-user",
+			})
+			if tC.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestTransfer_ProcessExpiredTransfers(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		locked     func() *entity.Transfer
+		wantCredit bool
+	}{
+		{
+			desc:       "refunds_pending",
+			locked:     refundableTransfer,
+			wantCredit: true,
+		},
+		{
+			desc: "claimed_before_the_lock",
+			locked: func() *entity.Transfer {
+				transfer := refundableTransfer()
+				transfer.StatusTransfer = entity.StatusTransferClaimed
+				return transfer
+			},
+		},
+		{
+			desc: "reversed_before_the_lock",
+			locked: func() *entity.Transfer {
+				transfer := refundableTransfer()
+				transfer.StatusTransfer = entity.StatusTransferReversed
+				return transfer
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			transferRepo := mock_transfer.NewMockRepository(ctrl)
+			// still pending when the message is picked up
+			transferRepo.EXPECT().FindByTransferID(gomock.Any(), int64(1)).Return(refundableTransfer(), nil)
+			transferRepo.EXPECT().GetTransferForUpdate(gomock.Any(), int64(1)).Return(tC.locked(), nil)
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			walletRepo.EXPECT().
+				GetWalletByUserID(gomock.Any(), "111111", entity.CurrencyCNY).
+				Return(&entity.Wallet{WalletID: 7, UserID: "111111"}, nil)
+
+			transactionUc := mock_usecase.NewMockWalletTransactionSvc(ctrl)
+			if tC.wantCredit {
+				transferRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, transfer *entity.Transfer, _ *gorm.DB) error {
+						assert.Equal(t, entity.StatusTransferRefunded, transfer.StatusTransfer)
+						return nil
+					})
+				transactionUc.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+			}
+
+			svc := NewTransferUseCase(
+				nil, transactionUc, transferRepo, walletRepo, newMockHoldTxRepo(ctrl), nil,
+				newMockAllowRisk(ctrl), entity.DefaultTransferExpiry,
+			)
+
+			failed, err := svc.ProcessExpiredTransfers(context.Background(), []*domain.MsgKafkaExpiredTransfer{
+				{TransferID: 1, OperatedBy: "job"},
+			})
+			require.NoError(t, err)
+			assert.Empty(t, failed)
+		})
+	}
+}
@@ -0,0 +1,451 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_envelope"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_journal"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_transfer"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet_recharge_request"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet_transaction"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/envelope"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+)
+
+// fakeLedger stands in for the database. Writes made inside a transaction are staged and
+// only become visible once the outermost transaction commits, writes made outside of
+// any transaction are visible immediately.
+type fakeLedger struct {
+	committed []string
+	staged    []string
+	begins    int
+}
+
+func (l *fakeLedger) write(ctx context.Context, explicit *gorm.DB, row string) {
+	if _, ok := tx.FromContext(ctx); ok || explicit != nil {
+		l.staged = append(l.staged, row)
+		return
+	}
+
+	l.committed = append(l.committed, row)
+}
+
+// Do mirrors tx.Run: it joins the transaction carried by ctx, otherwise it begins a new
+// one that commits or rolls back the staged writes.
+func (l *fakeLedger) Do(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+	if current, ok := tx.FromContext(ctx); ok {
+		return fn(ctx, current)
+	}
+
+	l.begins++
+	l.staged = nil
+	current := &gorm.DB{}
+	if err := fn(tx.NewContext(ctx, current), current); err != nil {
+		l.staged = nil
+		return err
+	}
+
+	l.committed = append(l.committed, l.staged...)
+	l.staged = nil
+
+	return nil
+}
+
+const (
+	stepCreateTransfer          = "transfer"
+	stepLockTransfer            = "lock_transfer"
+	stepUpdateTransfer          = "transfer_claimed"
+	stepCreateEnvelope          = "envelope"
+	stepCreateEnvelopeDetails   = "envelope_details"
+	stepCreateDeposit           = "deposit"
+	stepLockWallet              = "lock_wallet"
+	stepUpdateWallet            = "wallet"
+	stepCreateWalletTransaction = "wallet_transaction"
+	stepCreateJournalEntry      = "journal_entry"
+)
+
+var errInjected = errors.New("injected failure")
+
+// mockLedgerTransaction wires the repositories used by WalletTransactionSvc so each step
+// writes to the ledger, or fails when it is the step under test.
+func mockLedgerTransaction(
+	ledger *fakeLedger, failAt string,
+	walletRepo *mock_wallet.MockRepository, transactionRepo *mock_wallet_transaction.MockRepository,
+	journalRepo *mock_journal.MockRepository,
+) {
+	walletRepo.EXPECT().
+		GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, walletID int64) (*entity.Wallet, error) {
+			if failAt == stepLockWallet {
+				return nil, errInjected
+			}
+			return &entity.Wallet{WalletID: walletID, Balance: entity.NewMoney(1000), Currency: entity.CurrencyCNY}, nil
+		}).AnyTimes()
+	walletRepo.EXPECT().
+		UpdateBalance(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _ int64, _ entity.Money) error {
+			if failAt == stepUpdateWallet {
+				return errInjected
+			}
+			ledger.write(ctx, nil, stepUpdateWallet)
+			return nil
+		}).AnyTimes()
+	transactionRepo.EXPECT().
+		CreateTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, db *gorm.DB, _ *entity.WalletTransaction) (int64, error) {
+			if failAt == stepCreateWalletTransaction {
+				return 0, errInjected
+			}
+			ledger.write(ctx, db, stepCreateWalletTransaction)
+			return 1, nil
+		}).AnyTimes()
+	journalRepo.EXPECT().
+		CreateJournalEntry(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, db *gorm.DB, _ *entity.JournalEntry) (int64, error) {
+			if failAt == stepCreateJournalEntry {
+				return 0, errInjected
+			}
+			ledger.write(ctx, db, stepCreateJournalEntry)
+			return 1, nil
+		}).AnyTimes()
+}
+
+func TestTxPropagation_CreateTransfer(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		failAt    string
+		wantError bool
+	}{
+		{desc: "fail_create_transfer", failAt: stepCreateTransfer, wantError: true},
+		{desc: "fail_lock_wallet", failAt: stepLockWallet, wantError: true},
+		{desc: "fail_update_wallet", failAt: stepUpdateWallet, wantError: true},
+		{desc: "fail_create_wallet_transaction", failAt: stepCreateWalletTransaction, wantError: true},
+		{desc: "fail_create_journal_entry", failAt: stepCreateJournalEntry, wantError: true},
+		{desc: "success", wantError: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ledger := &fakeLedger{}
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			walletRepo.EXPECT().
+				GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+				Return(&entity.Wallet{WalletID: 1, Balance: entity.NewMoney(1000), Currency: entity.CurrencyCNY}, nil)
+			walletRepo.EXPECT().
+				GetWalletsByUserID(gomock.Any(), "2").
+				Return([]*entity.Wallet{{WalletID: 2, Currency: entity.CurrencyCNY}}, nil)
+
+			transactionRepo := mock_wallet_transaction.NewMockRepository(ctrl)
+			journalRepo := mock_journal.NewMockRepository(ctrl)
+			mockLedgerTransaction(ledger, tC.failAt, walletRepo, transactionRepo, journalRepo)
+
+			transferRepo := mock_transfer.NewMockRepository(ctrl)
+			transferRepo.EXPECT().
+				CountSentTransferInDay(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(int64(0), nil)
+			transferRepo.EXPECT().
+				CreateTransfer(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, db *gorm.DB, _ *entity.Transfer) (int64, error) {
+					if tC.failAt == stepCreateTransfer {
+						return 0, errInjected
+					}
+					ledger.write(ctx, db, stepCreateTransfer)
+					return 1, nil
+				})
+
+			svc := NewTransferUseCase(
+				nil,
+				NewWalletTransactionUseCase(transactionRepo, walletRepo, journalRepo, ledger),
+				transferRepo,
+				walletRepo,
+				ledger,
+				newMockDefaultLimits(ctrl),
+				newMockAllowRisk(ctrl),
+				entity.DefaultTransferExpiry,
+			)
+
+			_, err := svc.CreateTransfer(context.Background(), &domain.CreateTransferRequest{
+				FromUserID: "1",
+				ToUserID:   "2",
+				Amount:     entity.NewMoney(10),
+			})
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, []string{
+					stepCreateTransfer, stepUpdateWallet, stepCreateWalletTransaction, stepCreateJournalEntry,
+				}, ledger.committed)
+			} else {
+				require.ErrorIs(t, err, errInjected)
+				assert.Empty(t, ledger.committed)
+			}
+			assert.Equal(t, 1, ledger.begins)
+		})
+	}
+}
+
+func TestTxPropagation_ProcessDepositByAdmin(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		failAt    string
+		wantError bool
+	}{
+		{desc: "fail_create_deposit", failAt: stepCreateDeposit, wantError: true},
+		{desc: "fail_lock_wallet", failAt: stepLockWallet, wantError: true},
+		{desc: "fail_update_wallet", failAt: stepUpdateWallet, wantError: true},
+		{desc: "fail_create_wallet_transaction", failAt: stepCreateWalletTransaction, wantError: true},
+		{desc: "fail_create_journal_entry", failAt: stepCreateJournalEntry, wantError: true},
+		{desc: "success", wantError: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ledger := &fakeLedger{}
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			walletRepo.EXPECT().
+				GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&entity.Wallet{WalletID: 1, Currency: entity.CurrencyCNY}, nil)
+
+			transactionRepo := mock_wallet_transaction.NewMockRepository(ctrl)
+			journalRepo := mock_journal.NewMockRepository(ctrl)
+			mockLedgerTransaction(ledger, tC.failAt, walletRepo, transactionRepo, journalRepo)
+
+			depositRepo := mock_wallet_recharge_request.NewMockRepository(ctrl)
+			depositRepo.EXPECT().
+				CreateDeposit(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, db *gorm.DB, _ *entity.WalletRechargeRequest) (int64, error) {
+					if tC.failAt == stepCreateDeposit {
+						return 0, errInjected
+					}
+					ledger.write(ctx, db, stepCreateDeposit)
+					return 1, nil
+				})
+
+			svc := NewWalletRechargeRequestUseCase(
+				nil,
+				NewWalletTransactionUseCase(transactionRepo, walletRepo, journalRepo, ledger),
+				depositRepo,
+				transactionRepo,
+				walletRepo,
+				ledger,
+				nil,
+				0,
+			)
+
+			_, err := svc.ProcessDepositByAdmin(context.Background(), &domain.ProcessDepositByAdminRequest{
+				Amount:     entity.NewMoney(10),
+				UserID:     "1",
+				OperatedBy: "admin",
+			})
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, []string{
+					stepCreateDeposit, stepUpdateWallet, stepCreateWalletTransaction, stepCreateJournalEntry,
+				}, ledger.committed)
+			} else {
+				require.ErrorIs(t, err, errInjected)
+				assert.Empty(t, ledger.committed)
+			}
+			assert.Equal(t, 1, ledger.begins)
+		})
+	}
+}
+
+func TestTxPropagation_ClaimTransfer(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		failAt    string
+		wantError bool
+	}{
+		{desc: "fail_lock_transfer", failAt: stepLockTransfer, wantError: true},
+		{desc: "fail_update_transfer", failAt: stepUpdateTransfer, wantError: true},
+		{desc: "fail_lock_wallet", failAt: stepLockWallet, wantError: true},
+		{desc: "fail_update_wallet", failAt: stepUpdateWallet, wantError: true},
+		{desc: "fail_create_wallet_transaction", failAt: stepCreateWalletTransaction, wantError: true},
+		{desc: "fail_create_journal_entry", failAt: stepCreateJournalEntry, wantError: true},
+		{desc: "success", wantError: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ledger := &fakeLedger{}
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			walletRepo.EXPECT().
+				GetWalletsByUserID(gomock.Any(), "2").
+				Return([]*entity.Wallet{{WalletID: 2, Currency: entity.CurrencyCNY}}, nil)
+
+			transactionRepo := mock_wallet_transaction.NewMockRepository(ctrl)
+			journalRepo := mock_journal.NewMockRepository(ctrl)
+			mockLedgerTransaction(ledger, tC.failAt, walletRepo, transactionRepo, journalRepo)
+
+			pending := &entity.Transfer{
+				TransferID:     1,
+				FromUserID:     "1",
+				ToUserID:       "2",
+				Amount:         entity.NewMoney(10),
+				Currency:       entity.CurrencyCNY,
+				StatusTransfer: entity.StatusTransferPending,
+				IsActive:       true,
+			}
+			transferRepo := mock_transfer.NewMockRepository(ctrl)
+			transferRepo.EXPECT().
+				CountClaimedTransferInDay(gomock.Any(), "2", gomock.Any()).
+				Return(int64(0), nil)
+			transferRepo.EXPECT().
+				GetEligibleClaimTransfer(gomock.Any(), int64(1), "2").
+				Return(pending, nil)
+			transferRepo.EXPECT().
+				GetTransferForUpdate(gomock.Any(), int64(1)).
+				DoAndReturn(func(context.Context, int64) (*entity.Transfer, error) {
+					if tC.failAt == stepLockTransfer {
+						return nil, errInjected
+					}
+					locked := *pending
+					return &locked, nil
+				})
+			transferRepo.EXPECT().
+				Update(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ *entity.Transfer, db *gorm.DB) error {
+					if tC.failAt == stepUpdateTransfer {
+						return errInjected
+					}
+					ledger.write(ctx, db, stepUpdateTransfer)
+					return nil
+				}).AnyTimes()
+
+			svc := NewTransferUseCase(
+				nil,
+				NewWalletTransactionUseCase(transactionRepo, walletRepo, journalRepo, ledger),
+				transferRepo,
+				walletRepo,
+				ledger,
+				newMockDefaultLimits(ctrl),
+				newMockAllowRisk(ctrl),
+				entity.DefaultTransferExpiry,
+			)
+
+			err := svc.ClaimTransfer(context.Background(), &domain.ClaimTransferRequest{
+				TransferID:    1,
+				ClaimerUserID: "2",
+				OperateBy:     "2",
+			})
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, []string{
+					stepUpdateTransfer, stepUpdateWallet, stepCreateWalletTransaction, stepCreateJournalEntry,
+				}, ledger.committed)
+			} else {
+				require.ErrorIs(t, err, errInjected)
+				assert.Empty(t, ledger.committed)
+			}
+			assert.Equal(t, 1, ledger.begins)
+		})
+	}
+}
+
+func TestTxPropagation_CreateEnvelope(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		failAt    string
+		wantError bool
+	}{
+		{desc: "fail_create_envelope", failAt: stepCreateEnvelope, wantError: true},
+		{desc: "fail_create_envelope_details", failAt: stepCreateEnvelopeDetails, wantError: true},
+		{desc: "fail_lock_wallet", failAt: stepLockWallet, wantError: true},
+		{desc: "fail_update_wallet", failAt: stepUpdateWallet, wantError: true},
+		{desc: "fail_create_wallet_transaction", failAt: stepCreateWalletTransaction, wantError: true},
+		{desc: "fail_create_journal_entry", failAt: stepCreateJournalEntry, wantError: true},
+		{desc: "success", wantError: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ledger := &fakeLedger{}
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			walletRepo.EXPECT().
+				FindByWalletID(gomock.Any(), int64(1)).
+				Return(&entity.Wallet{WalletID: 1, UserID: "1", Balance: entity.NewMoney(1000), Currency: entity.CurrencyCNY}, nil)
+
+			transactionRepo := mock_wallet_transaction.NewMockRepository(ctrl)
+			journalRepo := mock_journal.NewMockRepository(ctrl)
+			mockLedgerTransaction(ledger, tC.failAt, walletRepo, transactionRepo, journalRepo)
+
+			envelopeRepo := mock_envelope.NewMockRepository(ctrl)
+			envelopeRepo.EXPECT().
+				CountSentEnvelope(gomock.Any(), "1", gomock.Any()).
+				Return(int64(0), nil)
+			// WithTransaction goes through tx.Run, which the ledger mirrors
+			envelopeRepo.EXPECT().
+				WithTransaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, txRepo envelope.Repository) error) error {
+					return ledger.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+						return fn(ctx, envelopeRepo)
+					})
+				})
+			envelopeRepo.EXPECT().
+				CreateEnvelope(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, env *entity.Envelope) error {
+					if tC.failAt == stepCreateEnvelope {
+						return errInjected
+					}
+					env.EnvelopeID = 1
+					ledger.write(ctx, nil, stepCreateEnvelope)
+					return nil
+				})
+			envelopeRepo.EXPECT().
+				CreateEnvelopeDetails(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ []*entity.EnvelopeDetail) error {
+					if tC.failAt == stepCreateEnvelopeDetails {
+						return errInjected
+					}
+					ledger.write(ctx, nil, stepCreateEnvelopeDetails)
+					return nil
+				}).AnyTimes()
+
+			svc := NewEnvelopeUseCase(
+				nil,
+				nil,
+				NewWalletTransactionUseCase(transactionRepo, walletRepo, journalRepo, ledger),
+				ledger,
+				envelopeRepo,
+				walletRepo,
+				newMockDefaultLimits(ctrl),
+				newMockAllowRisk(ctrl),
+				nil,
+				entity.DefaultEnvelopeExpiry,
+			)
+
+			_, err := svc.CreateEnvelope(context.Background(), &domain.EnvelopeCreateRequest{
+				UserID:       "1",
+				WalletID:     1,
+				EnvelopeType: string(entity.EnvelopeTypeFixed),
+				TotalAmount:  entity.NewMoney(10),
+				TotalClaimer: 2,
+			})
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, []string{
+					stepCreateEnvelope, stepCreateEnvelopeDetails,
+					stepUpdateWallet, stepCreateWalletTransaction, stepCreateJournalEntry,
+				}, ledger.committed)
+			} else {
+				require.ErrorIs(t, err, errInjected)
+				assert.Empty(t, ledger.committed)
+			}
+			assert.Equal(t, 1, ledger.begins)
+		})
+	}
+}
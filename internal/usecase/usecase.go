@@ -1,15 +1,32 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository"
 	"github.com/1nterdigital/aka-im-wallet/pkg/common/config"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/db/cache"
 	"github.com/1nterdigital/aka-im-wallet/pkg/common/db/kafka"
+	"github.com/1nterdigital/aka-im-wallet/pkg/common/imapi"
 )
 
 type Config struct {
 	KafkaConfig config.Kafka
+	Approval    config.Approval
+	Limits      config.Limits
+	Risk        config.Risk
+	PaymentPin  config.PaymentPin
+	// Redis counts wrong payment PINs, only the api verifies PINs and has to set it
+	Redis redis.UniversalClient
+	// IM checks group envelopes against their IM group and tells senders about skipped recurring
+	// transfer runs, only the api and the msgtransfer consumers need it
+	IM imapi.CallerInterface
 }
 
 type mapKafkaProducer struct {
@@ -25,6 +42,21 @@ type UseCase struct {
 	Transfer              TransferSvc
 	WalletMonitoring      WalletMonitoringSvc
 	BalanceAdjustment     BalanceAdjustmentSvc
+	Idempotency           IdempotencySvc
+	Ledger                LedgerSvc
+	Reconciliation        ReconciliationSvc
+	WalletFreeze          WalletFreezeSvc
+	WalletHold            WalletHoldSvc
+	TransactionReversal   TransactionReversalSvc
+	Withdrawal            WithdrawalSvc
+	Approval              ApprovalSvc
+	Limit                 LimitSvc
+	Risk                  RiskSvc
+	ScheduledTransfer     ScheduledTransferSvc
+	RecurringTransfer     RecurringTransferSvc
+	PaymentRequest        PaymentRequestSvc
+	TransferBatch         TransferBatchSvc
+	PaymentPin            PaymentPinSvc
 }
 
 func New(cfg *Config, repo repository.Repository, trx *gorm.DB) (*UseCase, error) {
@@ -33,6 +65,36 @@ func New(cfg *Config, repo repository.Repository, trx *gorm.DB) (*UseCase, error
 		return nil, err
 	}
 
+	approvalThreshold, err := parseApprovalThreshold(cfg.Approval)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := limitsFromConfig(cfg.Limits)
+	if err != nil {
+		return nil, err
+	}
+
+	transferExpiry, err := expiryFromConfig("transfer", cfg.Limits.Transfer.Expiry, entity.DefaultTransferExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	envelopeExpiry, err := expiryFromConfig("envelope", cfg.Limits.Envelope.Expiry, entity.DefaultEnvelopeExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentPinPolicy, err := paymentPinPolicyFromConfig(cfg.PaymentPin)
+	if err != nil {
+		return nil, err
+	}
+
+	riskRules, err := riskRulesFromConfig(cfg.Risk, repo.RiskDecision(), repo.Wallet())
+	if err != nil {
+		return nil, err
+	}
+
 	walletUsecase := NewWalletUseCase(
 		repo.Wallet(),
 		trx,
@@ -42,6 +104,7 @@ func New(cfg *Config, repo repository.Repository, trx *gorm.DB) (*UseCase, error
 	walletTransactionUsecase := NewWalletTransactionUseCase(
 		repo.WalletTransaction(),
 		repo.Wallet(),
+		repo.Journal(),
 		repo.TxRepo(),
 	)
 
@@ -52,6 +115,20 @@ func New(cfg *Config, repo repository.Repository, trx *gorm.DB) (*UseCase, error
 		repo.WalletTransaction(),
 		repo.Wallet(),
 		repo.TxRepo(),
+		repo.ApprovalRequest(),
+		approvalThreshold,
+	)
+
+	limitUsecase := NewLimitUseCase(
+		limits,
+		repo.LimitOverride(),
+		repo.Wallet(),
+		repo.WalletTransaction(),
+	)
+
+	riskUsecase := NewRiskUseCase(
+		riskRules,
+		repo.RiskDecision(),
 	)
 
 	envelopeUsecase := NewEnvelopeUseCase(
@@ -61,6 +138,10 @@ func New(cfg *Config, repo repository.Repository, trx *gorm.DB) (*UseCase, error
 		repo.TxRepo(),
 		repo.Envelope(),
 		repo.Wallet(),
+		limitUsecase,
+		riskUsecase,
+		cfg.IM,
+		envelopeExpiry,
 	)
 
 	transferUsecase := NewTransferUseCase(
@@ -69,10 +150,14 @@ func New(cfg *Config, repo repository.Repository, trx *gorm.DB) (*UseCase, error
 		repo.Transfer(),
 		repo.Wallet(),
 		repo.TxRepo(),
+		limitUsecase,
+		riskUsecase,
+		transferExpiry,
 	)
 
 	walletMonitoringUsecase := NewWalletMonitoringUseCase(
 		repo.WalletMonitoring(),
+		repo.RiskDecision(),
 	)
 
 	adjustmentUsecase := NewBalanceAdjustmentUseCase(
@@ -80,6 +165,92 @@ func New(cfg *Config, repo repository.Repository, trx *gorm.DB) (*UseCase, error
 		repo.BalanceAdjustment(),
 		repo.Wallet(),
 		repo.TxRepo(),
+		repo.ApprovalRequest(),
+		approvalThreshold,
+	)
+
+	idempotencyUsecase := NewIdempotencyUseCase(
+		repo.Idempotency(),
+	)
+
+	ledgerUsecase := NewLedgerUseCase(
+		repo.Journal(),
+		repo.Wallet(),
+	)
+
+	reconciliationUsecase := NewReconciliationUseCase(
+		repo.Reconciliation(),
+	)
+
+	walletFreezeUsecase := NewWalletFreezeUseCase(
+		repo.WalletFreeze(),
+		repo.Wallet(),
+		repo.TxRepo(),
+	)
+
+	walletHoldUsecase := NewWalletHoldUseCase(
+		repo.WalletHold(),
+		repo.Wallet(),
+		repo.TxRepo(),
+		walletTransactionUsecase,
+	)
+
+	transactionReversalUsecase := NewTransactionReversalUseCase(
+		repo.TransactionReversal(),
+		repo.WalletTransaction(),
+		repo.Transfer(),
+		repo.Envelope(),
+		repo.WalletRechargeRequest(),
+		repo.TxRepo(),
+		walletTransactionUsecase,
+	)
+
+	withdrawalUsecase := NewWithdrawalUseCase(
+		repo.WalletWithdrawalRequest(),
+		repo.Wallet(),
+		repo.TxRepo(),
+		walletHoldUsecase,
+	)
+
+	approvalUsecase := NewApprovalUseCase(
+		repo.ApprovalRequest(),
+		repo.TxRepo(),
+		adjustmentUsecase,
+		walletRechargeRequestUsecase,
+	)
+
+	scheduledTransferUsecase := NewScheduledTransferUseCase(
+		repo.ScheduledTransfer(),
+		repo.TxRepo(),
+		transferUsecase,
+		walletHoldUsecase,
+	)
+
+	recurringTransferUsecase := NewRecurringTransferUseCase(
+		repo.RecurringTransfer(),
+		repo.TxRepo(),
+		transferUsecase,
+		cfg.IM,
+	)
+
+	paymentRequestUsecase := NewPaymentRequestUseCase(
+		repo.PaymentRequest(),
+		repo.TxRepo(),
+		transferUsecase,
+	)
+
+	transferBatchUsecase := NewTransferBatchUseCase(
+		repo.TransferBatch(),
+		repo.Wallet(),
+		repo.TxRepo(),
+		transferUsecase,
+	)
+
+	paymentPinUsecase := NewPaymentPinUseCase(
+		repo.PaymentPin(),
+		repo.TxRepo(),
+		cache.NewPaymentPinAttempt(cfg.Redis),
+		paymentPinPolicy,
 	)
 
 	return &UseCase{
@@ -90,9 +261,126 @@ func New(cfg *Config, repo repository.Repository, trx *gorm.DB) (*UseCase, error
 		Transfer:              transferUsecase,
 		WalletMonitoring:      walletMonitoringUsecase,
 		BalanceAdjustment:     adjustmentUsecase,
+		Idempotency:           idempotencyUsecase,
+		Ledger:                ledgerUsecase,
+		Reconciliation:        reconciliationUsecase,
+		WalletFreeze:          walletFreezeUsecase,
+		WalletHold:            walletHoldUsecase,
+		TransactionReversal:   transactionReversalUsecase,
+		Withdrawal:            withdrawalUsecase,
+		Approval:              approvalUsecase,
+		Limit:                 limitUsecase,
+		Risk:                  riskUsecase,
+		ScheduledTransfer:     scheduledTransferUsecase,
+		RecurringTransfer:     recurringTransferUsecase,
+		PaymentRequest:        paymentRequestUsecase,
+		TransferBatch:         transferBatchUsecase,
+		PaymentPin:            paymentPinUsecase,
 	}, nil
 }
 
+// parseApprovalThreshold reads the maker-checker threshold, an empty one disables approval.
+func parseApprovalThreshold(cfg config.Approval) (threshold entity.Money, err error) {
+	if cfg.Threshold == "" {
+		return 0, nil
+	}
+
+	threshold, err = entity.ParseMoney(cfg.Threshold)
+	if err != nil {
+		return 0, fmt.Errorf("invalid approval threshold: %w", err)
+	}
+
+	return threshold, nil
+}
+
+// limitsFromConfig lays limits.yml over the built-in defaults, unset values keep the default.
+func limitsFromConfig(cfg config.Limits) (limits entity.Limits, err error) {
+	limits = entity.DefaultLimits
+
+	if cfg.Transfer.SendPerDay > 0 {
+		limits.TransferSendPerDay = cfg.Transfer.SendPerDay
+	}
+	if cfg.Transfer.ClaimPerDay > 0 {
+		limits.TransferClaimPerDay = cfg.Transfer.ClaimPerDay
+	}
+	if cfg.Transfer.MaxSendAmount != "" {
+		limits.MaxSendTransferAmount, err = entity.ParseMoney(cfg.Transfer.MaxSendAmount)
+		if err != nil {
+			return limits, fmt.Errorf("invalid transfer maxSendAmount: %w", err)
+		}
+	}
+	if cfg.Envelope.SendPerDay > 0 {
+		limits.EnvelopeSendPerDay = cfg.Envelope.SendPerDay
+	}
+	if cfg.Envelope.ClaimPerDay > 0 {
+		limits.EnvelopeClaimPerDay = cfg.Envelope.ClaimPerDay
+	}
+	if cfg.Envelope.MaxSendAmount != "" {
+		limits.MaxSendEnvelopeAmount, err = entity.ParseMoney(cfg.Envelope.MaxSendAmount)
+		if err != nil {
+			return limits, fmt.Errorf("invalid envelope maxSendAmount: %w", err)
+		}
+	}
+
+	for _, spend := range []struct {
+		name, value string
+		limit       *entity.Money
+	}{
+		{"perHour", cfg.Spend.PerHour, &limits.SpendPerHour},
+		{"perDay", cfg.Spend.PerDay, &limits.SpendPerDay},
+		{"per30Days", cfg.Spend.Per30Days, &limits.SpendPer30Days},
+	} {
+		if spend.value == "" {
+			continue
+		}
+		*spend.limit, err = entity.ParseMoney(spend.value)
+		if err != nil {
+			return limits, fmt.Errorf("invalid spend %s: %w", spend.name, err)
+		}
+	}
+
+	return limits, nil
+}
+
+// expiryFromConfig lays an expiry block of limits.yml over the built-in defaults, unset values
+// keep the default. The resulting bounds must still hold Min <= Default <= Max.
+func expiryFromConfig(name string, cfg config.Expiry, defaults entity.ExpiryPolicy) (policy entity.ExpiryPolicy, err error) {
+	policy = defaults
+
+	if cfg.DefaultMinutes > 0 {
+		policy.Default = time.Duration(cfg.DefaultMinutes) * time.Minute
+	}
+	if cfg.MinMinutes > 0 {
+		policy.Min = time.Duration(cfg.MinMinutes) * time.Minute
+	}
+	if cfg.MaxMinutes > 0 {
+		policy.Max = time.Duration(cfg.MaxMinutes) * time.Minute
+	}
+	if !policy.IsValid() {
+		return policy, fmt.Errorf("invalid %s expiry: default must lie between minMinutes and maxMinutes", name)
+	}
+
+	return policy, nil
+}
+
+// paymentPinPolicyFromConfig lays the paymentPin block of share.yml over the built-in lockout
+// policy, unset values keep the default.
+func paymentPinPolicyFromConfig(cfg config.PaymentPin) (policy entity.PaymentPinPolicy, err error) {
+	policy = entity.DefaultPaymentPinPolicy
+
+	if cfg.MaxAttempts < 0 || cfg.LockMinutes < 0 {
+		return policy, errors.New("invalid paymentPin: maxAttempts and lockMinutes must not be negative")
+	}
+	if cfg.MaxAttempts > 0 {
+		policy.MaxAttempts = cfg.MaxAttempts
+	}
+	if cfg.LockMinutes > 0 {
+		policy.LockDuration = time.Duration(cfg.LockMinutes) * time.Minute
+	}
+
+	return policy, nil
+}
+
 func initKafkaProducers(cfg *Config) (*mapKafkaProducer, error) {
 	kafkaConf := cfg.KafkaConfig
 	conf, err := kafka.BuildProducerConfig(kafkaConf.Build())
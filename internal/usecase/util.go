@@ -1,10 +1,13 @@
 package usecase
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/1nterdigital/aka-im-wallet/internal/domain"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
 )
 
 func dtoWalletTransactions(dbs []*entity.WalletTransaction) (transactions []*domain.WalletTransaction) {
@@ -13,6 +16,7 @@ func dtoWalletTransactions(dbs []*entity.WalletTransaction) (transactions []*dom
 			WalletTransactionID: db.WalletTransactionID,
 			WalletID:            db.WalletID,
 			Amount:              db.Amount,
+			Currency:            db.Currency.String(),
 			TransactionType:     string(db.TransactionType),
 			EntryType:           string(db.EntryType),
 			BeforeBalance:       db.BeforeBalance,
@@ -31,14 +35,76 @@ func dtoWalletTransactions(dbs []*entity.WalletTransaction) (transactions []*dom
 
 func dtoWalletDetail(dbs *entity.Wallet) *domain.Wallet {
 	return &domain.Wallet{
-		ID:        dbs.WalletID,
-		UserID:    dbs.UserID,
-		Balance:   dbs.Balance,
-		CreatedAt: dbs.CreatedAt,
-		CreatedBy: dbs.CreatedBy,
+		ID:               dbs.WalletID,
+		UserID:           dbs.UserID,
+		Currency:         dbs.Currency.String(),
+		Balance:          dbs.Balance,
+		HeldBalance:      dbs.HeldBalance,
+		AvailableBalance: dbs.AvailableBalance(),
+		FreezeStatus:     dbs.FreezeStatus.String(),
+		CreatedAt:        dbs.CreatedAt,
+		CreatedBy:        dbs.CreatedBy,
 	}
 }
 
+// validateWalletDebit rejects taking money out of a wallet frozen for outgoing payments.
+func validateWalletDebit(wallet *entity.Wallet) error {
+	if !wallet.FreezeStatus.CanDebit() {
+		return eerrs.ErrWalletDebitFrozen
+	}
+
+	return nil
+}
+
+// validateWalletCredit rejects paying into a wallet frozen for incoming payments.
+func validateWalletCredit(wallet *entity.Wallet) error {
+	if !wallet.FreezeStatus.CanCredit() {
+		return eerrs.ErrWalletCreditFrozen
+	}
+
+	return nil
+}
+
+// validateWalletFreeze applies the freeze of a wallet to a transaction about to be booked on
+// it, refunds, admin adjustments and reversals go through regardless.
+func validateWalletFreeze(wallet *entity.Wallet, req *domain.CreateTransactionReq) error {
+	if entity.TransactionType(req.TransactionType).BypassesFreeze() || req.ReversalOfID != 0 {
+		return nil
+	}
+	if req.Entrytype == entity.EntryTypeDebit.String() {
+		return validateWalletDebit(wallet)
+	}
+
+	return validateWalletCredit(wallet)
+}
+
+// walletInCurrency picks the wallet denominated in currency out of every wallet of a user.
+// A user without any wallet gets ErrWalletNotFound, a user whose wallets are all in other
+// currencies gets ErrCurrencyMismatch.
+func walletInCurrency(wallets []*entity.Wallet, currency entity.Currency) (*entity.Wallet, error) {
+	if len(wallets) == 0 {
+		return nil, eerrs.ErrWalletNotFound
+	}
+
+	for _, wallet := range wallets {
+		if wallet.Currency == currency {
+			return wallet, nil
+		}
+	}
+
+	return nil, eerrs.ErrCurrencyMismatch
+}
+
+// resolveExpiry picks the claim window a sender asked for, zero minutes takes the policy default.
+func resolveExpiry(policy entity.ExpiryPolicy, minutes int64) (time.Duration, error) {
+	expiry, ok := policy.Resolve(minutes)
+	if !ok {
+		return 0, eerrs.ErrExpiryRange(int64(policy.Min/time.Minute), int64(policy.Max/time.Minute))
+	}
+
+	return expiry, nil
+}
+
 func nowToStringYYYYMMDD() string {
 	return time.Now().Format("20060102")
 }
@@ -46,27 +112,39 @@ func nowToStringYYYYMMDD() string {
 func dtoDeposits(dbs []*entity.WalletRechargeRequest) (deposits []*domain.Deposit) {
 	for _, db := range dbs {
 		deposits = append(deposits, &domain.Deposit{
-			DepositID:     db.WalletRechargeRequestID,
-			WalletID:      db.WalletID,
-			Amount:        db.Amount,
-			StatusRequest: string(db.StatusRequest),
-			Description:   db.Description,
-			CreatedAt:     db.CreatedAt,
-			ApprovedAt:    db.ApprovedAt,
-			OperatedBy:    db.OperatedBy,
-			CreatedBy:     db.CreatedBy,
+			DepositID:      db.WalletRechargeRequestID,
+			WalletID:       db.WalletID,
+			Amount:         db.Amount,
+			Currency:       db.Currency.String(),
+			StatusRequest:  string(db.StatusRequest),
+			Description:    db.Description,
+			ProofReference: db.ProofReference,
+			RejectReason:   db.RejectReason,
+			CreatedAt:      db.CreatedAt,
+			ApprovedAt:     db.ApprovedAt,
+			OperatedBy:     db.OperatedBy,
+			CreatedBy:      db.CreatedBy,
 		})
 	}
 
 	return deposits
 }
 
+func dtoDepositResponse(db *entity.WalletRechargeRequest) *domain.DepositResponse {
+	return &domain.DepositResponse{
+		WalletRechargeRequestID: db.WalletRechargeRequestID,
+		WalletID:                db.WalletID,
+		StatusRequest:           db.StatusRequest.String(),
+	}
+}
+
 func dtoBalanceAdjustments(dbs []*entity.BalanceAdjustments) (adjustments []*domain.BalanceAdjustment) {
 	for _, db := range dbs {
 		adjustments = append(adjustments, &domain.BalanceAdjustment{
 			BalanceAdjustmentID: db.BalanceAdjustmentID,
 			WalletID:            db.WalletID,
 			Amount:              db.Amount,
+			Currency:            db.Currency.String(),
 			Reason:              db.Reason,
 			Description:         db.Description,
 			CreatedAt:           db.CreatedAt,
@@ -85,14 +163,373 @@ func dtoTransfer(db *entity.Transfer) (transfer *domain.Transfer) {
 		FromUserID:     db.FromUserID,
 		ToUserID:       db.ToUserID,
 		Amount:         db.Amount,
+		Currency:       db.Currency.String(),
 		StatusTransfer: string(db.StatusTransfer),
 		Remark:         db.Remark,
 		ExpiredAt:      db.ExpiredAt,
 		RefundedAt:     db.RefundedAt,
 		ClaimedAt:      db.ClaimedAt,
+		CanceledAt:     db.CanceledAt,
+		DeclinedAt:     db.DeclinedAt,
 		CreatedAt:      db.CreatedAt,
 		CreatedBy:      db.CreatedBy,
 		UpdatedAt:      db.UpdatedAt,
 		UpdatedBy:      db.UpdatedBy,
 	}
 }
+
+func dtoReconciliationRun(db *entity.ReconciliationRun) *domain.ReconciliationRun {
+	return &domain.ReconciliationRun{
+		ReconciliationRunID: db.ReconciliationRunID,
+		Status:              string(db.StatusReconciliation),
+		WalletsChecked:      db.WalletsChecked,
+		EnvelopesChecked:    db.EnvelopesChecked,
+		DiscrepancyCount:    db.DiscrepancyCount,
+		StartedAt:           db.StartedAt,
+		FinishedAt:          db.FinishedAt,
+	}
+}
+
+func dtoReconciliationDiscrepancies(
+	dbs []*entity.ReconciliationDiscrepancy,
+) (discrepancies []*domain.ReconciliationDiscrepancy) {
+	for _, db := range dbs {
+		discrepancies = append(discrepancies, &domain.ReconciliationDiscrepancy{
+			ReconciliationDiscrepancyID: db.ReconciliationDiscrepancyID,
+			DiscrepancyType:             string(db.DiscrepancyType),
+			WalletID:                    db.WalletID,
+			WalletTransactionID:         db.WalletTransactionID,
+			EnvelopeID:                  db.EnvelopeID,
+			Expected:                    db.Expected,
+			Actual:                      db.Actual,
+			CreatedAt:                   db.CreatedAt,
+		})
+	}
+
+	return discrepancies
+}
+
+func dtoWalletFreezeHistories(dbs []*entity.WalletFreezeHistory) (histories []*domain.WalletFreezeHistory) {
+	for _, db := range dbs {
+		histories = append(histories, &domain.WalletFreezeHistory{
+			WalletFreezeHistoryID: db.WalletFreezeHistoryID,
+			WalletID:              db.WalletID,
+			FreezeAction:          db.FreezeAction.String(),
+			FreezeType:            db.FreezeType.String(),
+			StatusBefore:          db.StatusBefore.String(),
+			StatusAfter:           db.StatusAfter.String(),
+			Reason:                db.Reason,
+			CreatedAt:             db.CreatedAt,
+			CreatedBy:             db.CreatedBy,
+		})
+	}
+
+	return histories
+}
+
+func dtoWalletHoldResponse(db *entity.WalletHold) *domain.WalletHoldResponse {
+	return &domain.WalletHoldResponse{
+		WalletHoldID:        db.WalletHoldID,
+		WalletID:            db.WalletID,
+		StatusHold:          db.StatusHold.String(),
+		WalletTransactionID: db.WalletTransactionID,
+	}
+}
+
+func dtoWalletHolds(dbs []*entity.WalletHold) (holds []*domain.WalletHold) {
+	for _, db := range dbs {
+		holds = append(holds, &domain.WalletHold{
+			WalletHoldID:        db.WalletHoldID,
+			WalletID:            db.WalletID,
+			Amount:              db.Amount,
+			Currency:            db.Currency.String(),
+			StatusHold:          db.StatusHold.String(),
+			Reason:              db.Reason,
+			WalletTransactionID: db.WalletTransactionID,
+			ReleasedAt:          db.ReleasedAt,
+			CapturedAt:          db.CapturedAt,
+			CreatedAt:           db.CreatedAt,
+			CreatedBy:           db.CreatedBy,
+		})
+	}
+
+	return holds
+}
+
+func dtoWithdrawalResponse(db *entity.WalletWithdrawalRequest) *domain.WithdrawalResponse {
+	return &domain.WithdrawalResponse{
+		WalletWithdrawalRequestID: db.WalletWithdrawalRequestID,
+		WalletID:                  db.WalletID,
+		StatusWithdrawal:          db.StatusWithdrawal.String(),
+		WalletTransactionID:       db.WalletTransactionID,
+	}
+}
+
+func dtoWithdrawals(dbs []*entity.WalletWithdrawalRequest) (withdrawals []*domain.Withdrawal) {
+	for _, db := range dbs {
+		withdrawals = append(withdrawals, &domain.Withdrawal{
+			WithdrawalID:        db.WalletWithdrawalRequestID,
+			WalletID:            db.WalletID,
+			Amount:              db.Amount,
+			Currency:            db.Currency.String(),
+			StatusWithdrawal:    db.StatusWithdrawal.String(),
+			Destination:         db.Destination,
+			Description:         db.Description,
+			RejectReason:        db.RejectReason,
+			WalletTransactionID: db.WalletTransactionID,
+			CreatedAt:           db.CreatedAt,
+			ApprovedAt:          db.ApprovedAt,
+			RejectedAt:          db.RejectedAt,
+			PaidAt:              db.PaidAt,
+			OperatedBy:          db.OperatedBy,
+			CreatedBy:           db.CreatedBy,
+		})
+	}
+
+	return withdrawals
+}
+
+func dtoApprovalResponse(db *entity.ApprovalRequest) *domain.ApprovalResponse {
+	return &domain.ApprovalResponse{
+		ApprovalRequestID: db.ApprovalRequestID,
+		Operation:         db.Operation.String(),
+		StatusApproval:    db.StatusApproval.String(),
+		OperationID:       db.OperationID,
+	}
+}
+
+func dtoApprovals(dbs []*entity.ApprovalRequest) (approvals []*domain.Approval) {
+	for _, db := range dbs {
+		approvals = append(approvals, &domain.Approval{
+			ApprovalRequestID: db.ApprovalRequestID,
+			Operation:         db.Operation.String(),
+			WalletID:          db.WalletID,
+			Amount:            db.Amount,
+			Currency:          db.Currency.String(),
+			Payload:           json.RawMessage(db.Payload),
+			StatusApproval:    db.StatusApproval.String(),
+			OperationID:       db.OperationID,
+			RejectReason:      db.RejectReason,
+			CheckedBy:         db.CheckedBy,
+			CheckedAt:         db.CheckedAt,
+			CreatedAt:         db.CreatedAt,
+			CreatedBy:         db.CreatedBy,
+		})
+	}
+
+	return approvals
+}
+
+func dtoLimits(limits entity.Limits) domain.Limits {
+	return domain.Limits{
+		TransferSendPerDay:    limits.TransferSendPerDay,
+		TransferClaimPerDay:   limits.TransferClaimPerDay,
+		MaxSendTransferAmount: limits.MaxSendTransferAmount,
+		EnvelopeSendPerDay:    limits.EnvelopeSendPerDay,
+		EnvelopeClaimPerDay:   limits.EnvelopeClaimPerDay,
+		MaxSendEnvelopeAmount: limits.MaxSendEnvelopeAmount,
+		SpendPerHour:          limits.SpendPerHour,
+		SpendPerDay:           limits.SpendPerDay,
+		SpendPer30Days:        limits.SpendPer30Days,
+	}
+}
+
+func dtoLimitOverride(db *entity.LimitOverride) *domain.LimitOverride {
+	return &domain.LimitOverride{
+		LimitOverrideID:       db.LimitOverrideID,
+		Scope:                 db.Scope.String(),
+		ScopeID:               db.ScopeID,
+		Tier:                  db.Tier,
+		TransferSendPerDay:    db.TransferSendPerDay,
+		TransferClaimPerDay:   db.TransferClaimPerDay,
+		MaxSendTransferAmount: db.MaxSendTransferAmount,
+		EnvelopeSendPerDay:    db.EnvelopeSendPerDay,
+		EnvelopeClaimPerDay:   db.EnvelopeClaimPerDay,
+		MaxSendEnvelopeAmount: db.MaxSendEnvelopeAmount,
+		SpendPerHour:          db.SpendPerHour,
+		SpendPerDay:           db.SpendPerDay,
+		SpendPer30Days:        db.SpendPer30Days,
+		UpdatedAt:             db.UpdatedAt,
+		UpdatedBy:             db.UpdatedBy,
+	}
+}
+
+func dtoRiskDecision(db *entity.RiskDecision) *domain.RiskDecision {
+	resp := &domain.RiskDecision{
+		RiskDecisionID: db.RiskDecisionID,
+		Operation:      db.Operation.String(),
+		ReferenceID:    db.ReferenceID,
+		UserID:         db.UserID,
+		CounterpartyID: db.CounterpartyID,
+		WalletID:       db.WalletID,
+		Amount:         db.Amount,
+		Currency:       db.Currency.String(),
+		DeviceID:       db.DeviceID,
+		Decision:       db.Decision.String(),
+		Rules:          []string{},
+		CreatedAt:      db.CreatedAt,
+	}
+	if db.Rules != "" {
+		resp.Rules = strings.Split(db.Rules, ",")
+	}
+
+	return resp
+}
+
+func dtoScheduledTransferResponse(db *entity.ScheduledTransfer) *domain.ScheduledTransferResponse {
+	return &domain.ScheduledTransferResponse{
+		ScheduledTransferID:     db.ScheduledTransferID,
+		StatusScheduledTransfer: db.StatusScheduledTransfer.String(),
+		WalletHoldID:            db.WalletHoldID,
+	}
+}
+
+func dtoScheduledTransfers(dbs []*entity.ScheduledTransfer) (scheduled []*domain.ScheduledTransfer) {
+	for _, db := range dbs {
+		scheduled = append(scheduled, &domain.ScheduledTransfer{
+			ScheduledTransferID:     db.ScheduledTransferID,
+			FromUserID:              db.FromUserID,
+			ToUserID:                db.ToUserID,
+			Amount:                  db.Amount,
+			Currency:                db.Currency.String(),
+			Remark:                  db.Remark,
+			ExecuteAt:               db.ExecuteAt,
+			StatusScheduledTransfer: db.StatusScheduledTransfer.String(),
+			WalletHoldID:            db.WalletHoldID,
+			TransferID:              db.TransferID,
+			FailureCode:             db.FailureCode,
+			FailureReason:           db.FailureReason,
+			ExecutedAt:              db.ExecutedAt,
+			CanceledAt:              db.CanceledAt,
+			CreatedAt:               db.CreatedAt,
+		})
+	}
+
+	return scheduled
+}
+
+func dtoRecurringTransferResponse(db *entity.RecurringTransfer) *domain.RecurringTransferResponse {
+	return &domain.RecurringTransferResponse{
+		RecurringTransferID:     db.RecurringTransferID,
+		StatusRecurringTransfer: db.StatusRecurringTransfer.String(),
+		NextRunAt:               db.NextRunAt,
+	}
+}
+
+func dtoRecurringTransfers(dbs []*entity.RecurringTransfer) (recurring []*domain.RecurringTransfer) {
+	for _, db := range dbs {
+		recurring = append(recurring, &domain.RecurringTransfer{
+			RecurringTransferID:     db.RecurringTransferID,
+			FromUserID:              db.FromUserID,
+			ToUserID:                db.ToUserID,
+			Amount:                  db.Amount,
+			Currency:                db.Currency.String(),
+			Remark:                  db.Remark,
+			Frequency:               db.Frequency.String(),
+			StartAt:                 db.StartAt,
+			EndAt:                   db.EndAt,
+			MaxOccurrences:          db.MaxOccurrences,
+			Occurrences:             db.Occurrences,
+			NextRunAt:               db.NextRunAt,
+			StatusRecurringTransfer: db.StatusRecurringTransfer.String(),
+			LastRunAt:               db.LastRunAt,
+			CanceledAt:              db.CanceledAt,
+			CreatedAt:               db.CreatedAt,
+		})
+	}
+
+	return recurring
+}
+
+func dtoRecurringTransferRuns(dbs []*entity.RecurringTransferRun) (runs []*domain.RecurringTransferRun) {
+	for _, db := range dbs {
+		runs = append(runs, &domain.RecurringTransferRun{
+			RecurringTransferRunID: db.RecurringTransferRunID,
+			Occurrence:             db.Occurrence,
+			ScheduledAt:            db.ScheduledAt,
+			StatusRun:              db.StatusRun.String(),
+			TransferID:             db.TransferID,
+			FailureCode:            db.FailureCode,
+			FailureReason:          db.FailureReason,
+			CreatedAt:              db.CreatedAt,
+		})
+	}
+
+	return runs
+}
+
+func dtoPaymentRequestResponse(db *entity.PaymentRequest) *domain.PaymentRequestResponse {
+	return &domain.PaymentRequestResponse{
+		PaymentRequestID:     db.PaymentRequestID,
+		StatusPaymentRequest: db.StatusPaymentRequest.String(),
+		TransferID:           db.TransferID,
+		ExpiredAt:            db.ExpiredAt,
+	}
+}
+
+func dtoPaymentRequests(dbs []*entity.PaymentRequest) (requests []*domain.PaymentRequest) {
+	for _, db := range dbs {
+		requests = append(requests, &domain.PaymentRequest{
+			PaymentRequestID:     db.PaymentRequestID,
+			RequesterUserID:      db.RequesterUserID,
+			PayerUserID:          db.PayerUserID,
+			Amount:               db.Amount,
+			Currency:             db.Currency.String(),
+			Remark:               db.Remark,
+			StatusPaymentRequest: db.StatusPaymentRequest.String(),
+			TransferID:           db.TransferID,
+			ExpiredAt:            db.ExpiredAt,
+			PaidAt:               db.PaidAt,
+			DeclinedAt:           db.DeclinedAt,
+			CreatedAt:            db.CreatedAt,
+		})
+	}
+
+	return requests
+}
+
+func dtoTransferBatchResponse(
+	batch *entity.TransferBatch, dbs []*entity.TransferBatchItem,
+) *domain.TransferBatchResponse {
+	items := make([]*domain.TransferBatchItem, 0, len(dbs))
+	for _, db := range dbs {
+		items = append(items, &domain.TransferBatchItem{
+			ToUserID:                db.ToUserID,
+			Amount:                  db.Amount,
+			Remark:                  db.Remark,
+			StatusTransferBatchItem: db.StatusTransferBatchItem.String(),
+			TransferID:              db.TransferID,
+			FailureCode:             db.FailureCode,
+			FailureReason:           db.FailureReason,
+		})
+	}
+
+	return &domain.TransferBatchResponse{
+		TransferBatchID:     batch.TransferBatchID,
+		Mode:                batch.Mode.String(),
+		Currency:            batch.Currency.String(),
+		TotalAmount:         batch.TotalAmount,
+		ItemCount:           batch.ItemCount,
+		SucceededCount:      batch.SucceededCount,
+		FailedCount:         batch.FailedCount,
+		StatusTransferBatch: batch.StatusTransferBatch.String(),
+		CreatedAt:           batch.CreatedAt,
+		Items:               items,
+	}
+}
+
+func dtoPaymentPinAudits(dbs []*entity.PaymentPinAudit) (audits []*domain.PaymentPinAudit) {
+	for _, db := range dbs {
+		audits = append(audits, &domain.PaymentPinAudit{
+			PaymentPinAuditID: db.PaymentPinAuditID,
+			UserID:            db.UserID,
+			Event:             db.Event.String(),
+			Endpoint:          db.Endpoint,
+			DeviceID:          db.DeviceID,
+			CreatedAt:         db.CreatedAt,
+			CreatedBy:         db.CreatedBy,
+		})
+	}
+
+	return audits
+}
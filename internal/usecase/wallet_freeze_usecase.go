@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_freeze"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	WalletFreezeSvcImpl struct {
+		repo       wallet_freeze.Repository
+		walletRepo wallet.Repository
+		txRepo     tx.Repository
+	}
+
+	WalletFreezeSvc interface {
+		// FreezeWallet blocks the side of the wallet named by the freeze type on top of
+		// whatever is already frozen, a debit freeze followed by a credit freeze leaves the
+		// wallet fully frozen.
+		FreezeWallet(ctx context.Context, req *domain.WalletFreezeRequest) (resp *domain.WalletFreezeResponse, err error)
+		// UnfreezeWallet lifts the side of the wallet named by the freeze type.
+		UnfreezeWallet(ctx context.Context, req *domain.WalletFreezeRequest) (resp *domain.WalletFreezeResponse, err error)
+		GetListFreezeHistory(
+			ctx context.Context, req *domain.GetListWalletFreezeHistoryRequest,
+		) (resp *domain.GetListWalletFreezeHistoryResponse, err error)
+	}
+)
+
+func NewWalletFreezeUseCase(
+	repo wallet_freeze.Repository,
+	walletRepo wallet.Repository,
+	txRepo tx.Repository,
+) WalletFreezeSvc {
+	return &WalletFreezeSvcImpl{
+		repo:       repo,
+		walletRepo: walletRepo,
+		txRepo:     txRepo,
+	}
+}
+
+func (s *WalletFreezeSvcImpl) FreezeWallet(
+	ctx context.Context, req *domain.WalletFreezeRequest,
+) (resp *domain.WalletFreezeResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return s.changeFreezeStatus(ctx, req, entity.FreezeActionFreeze)
+}
+
+func (s *WalletFreezeSvcImpl) UnfreezeWallet(
+	ctx context.Context, req *domain.WalletFreezeRequest,
+) (resp *domain.WalletFreezeResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return s.changeFreezeStatus(ctx, req, entity.FreezeActionUnfreeze)
+}
+
+// changeFreezeStatus moves the wallet to its new freeze status under the wallet row lock, so
+// it cannot interleave with a transaction being booked, and records the move in the history.
+func (s *WalletFreezeSvcImpl) changeFreezeStatus(
+	ctx context.Context, req *domain.WalletFreezeRequest, action entity.FreezeAction,
+) (resp *domain.WalletFreezeResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(req.Currency)
+	if err != nil {
+		return resp, err
+	}
+
+	var walletUser *entity.Wallet
+	walletUser, err = s.walletRepo.GetWalletByUserID(ctx, req.UserID, currency)
+	if err != nil {
+		log.ZError(ctx, "while get wallet user", err, "userID", req.UserID, "currency", currency)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp, eerrs.ErrWalletNotFound
+		}
+		return resp, err
+	}
+
+	freezeType := entity.FreezeType(req.FreezeType)
+	history := &entity.WalletFreezeHistory{
+		WalletID:     walletUser.WalletID,
+		FreezeAction: action,
+		FreezeType:   freezeType,
+		Reason:       req.Reason,
+		CreatedBy:    req.OperatedBy,
+	}
+	err = s.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		var locked *entity.Wallet
+		locked, err = s.walletRepo.GetWalletByWalletIDTx(ctx, tx, walletUser.WalletID)
+		if err != nil {
+			log.ZError(ctx, "while GetWalletByWalletIDTx", err, "walletID", walletUser.WalletID)
+			return err
+		}
+
+		history.StatusBefore = locked.FreezeStatus
+		history.StatusAfter = locked.FreezeStatus.Freeze(freezeType)
+		if action == entity.FreezeActionUnfreeze {
+			history.StatusAfter = locked.FreezeStatus.Unfreeze(freezeType)
+		}
+		if history.StatusAfter.CanDebit() == history.StatusBefore.CanDebit() &&
+			history.StatusAfter.CanCredit() == history.StatusBefore.CanCredit() {
+			return eerrs.ErrWalletFreezeUnchanged
+		}
+
+		err = s.walletRepo.UpdateWallet(ctx, tx, &entity.Wallet{
+			WalletID:     locked.WalletID,
+			FreezeStatus: history.StatusAfter,
+			UpdatedBy:    req.OperatedBy,
+		})
+		if err != nil {
+			log.ZError(ctx, "while update wallet freeze status", err, "walletID", locked.WalletID)
+			return err
+		}
+
+		_, err = s.repo.CreateHistory(ctx, history)
+		if err != nil {
+			log.ZError(ctx, "while create wallet freeze history", err, "walletID", locked.WalletID)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.ZError(ctx, "while change wallet freeze status", err, "request", req, "action", action)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", walletUser.WalletID),
+		attribute.String("freezeAction", action.String()),
+		attribute.String("statusBefore", history.StatusBefore.String()),
+		attribute.String("statusAfter", history.StatusAfter.String()),
+	)
+
+	return &domain.WalletFreezeResponse{
+		WalletID:              walletUser.WalletID,
+		WalletFreezeHistoryID: history.WalletFreezeHistoryID,
+		FreezeStatus:          history.StatusAfter.String(),
+	}, nil
+}
+
+func (s *WalletFreezeSvcImpl) GetListFreezeHistory(
+	ctx context.Context, req *domain.GetListWalletFreezeHistoryRequest,
+) (resp *domain.GetListWalletFreezeHistoryResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(req.Currency)
+	if err != nil {
+		return resp, err
+	}
+
+	var walletUser *entity.Wallet
+	walletUser, err = s.walletRepo.GetWalletByUserID(ctx, req.UserID, currency)
+	if err != nil {
+		log.ZError(ctx, "while get wallet user", err, "userID", req.UserID, "currency", currency)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp, eerrs.ErrWalletNotFound
+		}
+		return resp, err
+	}
+	req.WalletID = walletUser.WalletID
+
+	var (
+		histories []*entity.WalletFreezeHistory
+		total     int64
+	)
+	histories, total, err = s.repo.GetListHistory(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list wallet freeze history", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", req.WalletID),
+		attribute.Int64("total", total),
+	)
+
+	return &domain.GetListWalletFreezeHistoryResponse{
+		TotalCount: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Histories:  dtoWalletFreezeHistories(histories),
+	}, nil
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_tx"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet_freeze"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func TestWalletFreeze_FreezeWallet(t *testing.T) {
+	type expected struct {
+		resp *domain.WalletFreezeResponse
+		err  error
+	}
+	testCases := []struct {
+		desc             string
+		request          *domain.WalletFreezeRequest
+		expected         expected
+		wantError        bool
+		onMockWalletRepo func(mock *mock_wallet.MockRepository)
+		onMockRepo       func(mock *mock_wallet_freeze.MockRepository)
+	}{
+		{
+			desc:      "ErrInvalidFreezeType",
+			request:   &domain.WalletFreezeRequest{UserID: "1", FreezeType: "partial", Reason: "fraud"},
+			expected:  expected{err: eerrs.ErrInvalidFreezeType},
+			wantError: true,
+		},
+		{
+			desc:      "ErrWalletNotFound",
+			request:   &domain.WalletFreezeRequest{UserID: "1", FreezeType: "debit", Reason: "fraud"},
+			expected:  expected{err: eerrs.ErrWalletNotFound},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			desc:      "ErrWalletFreezeUnchanged",
+			request:   &domain.WalletFreezeRequest{UserID: "1", FreezeType: "debit", Reason: "fraud"},
+			expected:  expected{err: eerrs.ErrWalletFreezeUnchanged},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 7}, nil)
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, FreezeStatus: entity.FreezeStatusFrozen}, nil)
+			},
+		},
+		{
+			desc:      "error_while_CreateHistory",
+			request:   &domain.WalletFreezeRequest{UserID: "1", FreezeType: "debit", Reason: "fraud"},
+			expected:  expected{err: errors.New("something went wrong")},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 7}, nil)
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, FreezeStatus: entity.FreezeStatusNone}, nil)
+				mock.EXPECT().UpdateWallet(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			onMockRepo: func(mock *mock_wallet_freeze.MockRepository) {
+				mock.EXPECT().CreateHistory(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("something went wrong"))
+			},
+		},
+		{
+			desc:    "success_credit_on_debit_frozen",
+			request: &domain.WalletFreezeRequest{UserID: "1", FreezeType: "credit", Reason: "court order", OperatedBy: "admin"},
+			expected: expected{resp: &domain.WalletFreezeResponse{
+				WalletID:              7,
+				WalletFreezeHistoryID: 3,
+				FreezeStatus:          "frozen",
+			}},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 7}, nil)
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, FreezeStatus: entity.FreezeStatusDebitFrozen}, nil)
+				mock.EXPECT().
+					UpdateWallet(gomock.Any(), gomock.Any(), &entity.Wallet{
+						WalletID:     7,
+						FreezeStatus: entity.FreezeStatusFrozen,
+						UpdatedBy:    "admin",
+					}).
+					Return(nil)
+			},
+			onMockRepo: func(mock *mock_wallet_freeze.MockRepository) {
+				mock.EXPECT().
+					CreateHistory(gomock.Any(), &entity.WalletFreezeHistory{
+						WalletID:     7,
+						FreezeAction: entity.FreezeActionFreeze,
+						FreezeType:   entity.FreezeTypeCredit,
+						StatusBefore: entity.FreezeStatusDebitFrozen,
+						StatusAfter:  entity.FreezeStatusFrozen,
+						Reason:       "court order",
+						CreatedBy:    "admin",
+					}).
+					DoAndReturn(func(_ context.Context, history *entity.WalletFreezeHistory) (int64, error) {
+						history.WalletFreezeHistoryID = 3
+						return 3, nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(walletRepo)
+			}
+			repo := mock_wallet_freeze.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+			txRepo := mock_tx.NewMockRepository(ctrl)
+			txRepo.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+					return fn(ctx, &gorm.DB{})
+				}).AnyTimes()
+
+			svc := NewWalletFreezeUseCase(repo, walletRepo, txRepo)
+
+			got, err := svc.FreezeWallet(context.Background(), tC.request)
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestWalletFreeze_UnfreezeWallet(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		status    entity.FreezeStatus
+		request   *domain.WalletFreezeRequest
+		expected  entity.FreezeStatus
+		wantError error
+	}{
+		{
+			desc:     "debit_on_frozen",
+			status:   entity.FreezeStatusFrozen,
+			request:  &domain.WalletFreezeRequest{UserID: "1", FreezeType: "debit", Reason: "cleared"},
+			expected: entity.FreezeStatusCreditFrozen,
+		},
+		{
+			desc:     "full_on_credit_frozen",
+			status:   entity.FreezeStatusCreditFrozen,
+			request:  &domain.WalletFreezeRequest{UserID: "1", FreezeType: "full", Reason: "cleared"},
+			expected: entity.FreezeStatusNone,
+		},
+		{
+			desc:      "ErrWalletFreezeUnchanged_not_frozen",
+			status:    entity.FreezeStatusNone,
+			request:   &domain.WalletFreezeRequest{UserID: "1", FreezeType: "full", Reason: "cleared"},
+			wantError: eerrs.ErrWalletFreezeUnchanged,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			walletRepo.EXPECT().
+				GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+				Return(&entity.Wallet{WalletID: 7}, nil)
+			walletRepo.EXPECT().
+				GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+				Return(&entity.Wallet{WalletID: 7, FreezeStatus: tC.status}, nil)
+			walletRepo.EXPECT().UpdateWallet(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			repo := mock_wallet_freeze.NewMockRepository(ctrl)
+			repo.EXPECT().CreateHistory(gomock.Any(), gomock.Any()).Return(int64(1), nil).AnyTimes()
+
+			txRepo := mock_tx.NewMockRepository(ctrl)
+			txRepo.EXPECT().
+				Do(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+					return fn(ctx, &gorm.DB{})
+				})
+
+			svc := NewWalletFreezeUseCase(repo, walletRepo, txRepo)
+
+			got, err := svc.UnfreezeWallet(context.Background(), tC.request)
+			if tC.wantError == nil {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.String(), got.FreezeStatus)
+			} else {
+				require.ErrorIs(t, err, tC.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_hold"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	WalletHoldSvcImpl struct {
+		repo          wallet_hold.Repository
+		walletRepo    wallet.Repository
+		txRepo        tx.Repository
+		transactionUc WalletTransactionSvc
+	}
+
+	WalletHoldSvc interface {
+		// CreateHold reserves the amount out of the available balance of the wallet, the
+		// balance itself is left as it is.
+		CreateHold(ctx context.Context, req *domain.CreateWalletHoldRequest) (resp *domain.WalletHoldResponse, err error)
+		// ReleaseHold returns the held amount to the available balance.
+		ReleaseHold(ctx context.Context, req *domain.WalletHoldActionRequest) (resp *domain.WalletHoldResponse, err error)
+		// CaptureHold debits the held amount from the wallet, as a hold_capture against the hold
+		// settlement account unless req.Capture books it otherwise.
+		CaptureHold(ctx context.Context, req *domain.WalletHoldActionRequest) (resp *domain.WalletHoldResponse, err error)
+		GetListHold(
+			ctx context.Context, req *domain.GetListWalletHoldRequest,
+		) (resp *domain.GetListWalletHoldResponse, err error)
+	}
+)
+
+func NewWalletHoldUseCase(
+	repo wallet_hold.Repository,
+	walletRepo wallet.Repository,
+	txRepo tx.Repository,
+	transactionUc WalletTransactionSvc,
+) WalletHoldSvc {
+	return &WalletHoldSvcImpl{
+		repo:          repo,
+		walletRepo:    walletRepo,
+		txRepo:        txRepo,
+		transactionUc: transactionUc,
+	}
+}
+
+func (s *WalletHoldSvcImpl) CreateHold(
+	ctx context.Context, req *domain.CreateWalletHoldRequest,
+) (resp *domain.WalletHoldResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var walletUser *entity.Wallet
+	walletUser, err = s.getWallet(ctx, req.UserID, req.Currency)
+	if err != nil {
+		return resp, err
+	}
+
+	hold := &entity.WalletHold{
+		WalletID:   walletUser.WalletID,
+		Amount:     req.Amount,
+		Currency:   walletUser.Currency,
+		StatusHold: entity.StatusHoldActive,
+		Reason:     req.Reason,
+		CreatedBy:  req.OperatedBy,
+		UpdatedBy:  req.OperatedBy,
+	}
+	err = s.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		var locked *entity.Wallet
+		locked, err = s.walletRepo.GetWalletByWalletIDTx(ctx, tx, walletUser.WalletID)
+		if err != nil {
+			log.ZError(ctx, "while GetWalletByWalletIDTx", err, "walletID", walletUser.WalletID)
+			return err
+		}
+
+		if req.Amount > locked.AvailableBalance() {
+			log.ZError(ctx, "while validate available balance", eerrs.ErrInsufficientBalance,
+				"walletID", locked.WalletID, "availableBalance", locked.AvailableBalance())
+			return eerrs.ErrInsufficientBalance
+		}
+
+		_, err = s.repo.CreateHold(ctx, hold)
+		if err != nil {
+			log.ZError(ctx, "while create wallet hold", err, "walletID", locked.WalletID)
+			return err
+		}
+
+		err = s.walletRepo.UpdateHeldBalance(ctx, locked.WalletID, locked.HeldBalance+req.Amount)
+		if err != nil {
+			log.ZError(ctx, "while update held balance", err, "walletID", locked.WalletID)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.ZError(ctx, "while create wallet hold", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", hold.WalletID),
+		attribute.Int64("walletHoldID", hold.WalletHoldID),
+		attribute.String("amount", hold.Amount.String()),
+	)
+
+	return dtoWalletHoldResponse(hold), nil
+}
+
+func (s *WalletHoldSvcImpl) ReleaseHold(
+	ctx context.Context, req *domain.WalletHoldActionRequest,
+) (resp *domain.WalletHoldResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return s.settleHold(ctx, req, entity.StatusHoldReleased)
+}
+
+func (s *WalletHoldSvcImpl) CaptureHold(
+	ctx context.Context, req *domain.WalletHoldActionRequest,
+) (resp *domain.WalletHoldResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return s.settleHold(ctx, req, entity.StatusHoldCaptured)
+}
+
+// settleHold ends an active hold under the wallet row lock, taken before the hold row like
+// every other wallet write. The held amount leaves the held balance before a capture books
+// its debit, so the debit is checked against a balance that still includes it.
+func (s *WalletHoldSvcImpl) settleHold(
+	ctx context.Context, req *domain.WalletHoldActionRequest, status entity.StatusHold,
+) (resp *domain.WalletHoldResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var hold *entity.WalletHold
+	hold, err = s.repo.GetHold(ctx, req.WalletHoldID)
+	if err != nil {
+		log.ZError(ctx, "while get wallet hold", err, "walletHoldID", req.WalletHoldID)
+		return resp, err
+	}
+
+	err = s.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		var locked *entity.Wallet
+		locked, err = s.walletRepo.GetWalletByWalletIDTx(ctx, tx, hold.WalletID)
+		if err != nil {
+			log.ZError(ctx, "while GetWalletByWalletIDTx", err, "walletID", hold.WalletID)
+			return err
+		}
+
+		hold, err = s.repo.GetHoldForUpdate(ctx, req.WalletHoldID)
+		if err != nil {
+			log.ZError(ctx, "while lock wallet hold", err, "walletHoldID", req.WalletHoldID)
+			return err
+		}
+		if hold.StatusHold != entity.StatusHoldActive {
+			return eerrs.ErrWalletHoldNotActive
+		}
+
+		err = s.walletRepo.UpdateHeldBalance(ctx, locked.WalletID, locked.HeldBalance-hold.Amount)
+		if err != nil {
+			log.ZError(ctx, "while update held balance", err, "walletID", locked.WalletID)
+			return err
+		}
+
+		now := time.Now()
+		hold.StatusHold = status
+		hold.UpdatedBy = req.OperatedBy
+		switch status {
+		case entity.StatusHoldCaptured:
+			hold.CapturedAt = &now
+			var transactionID int64
+			transactionID, err = s.transactionUc.CreateTransaction(ctx, captureTransaction(hold, req))
+			if err != nil {
+				log.ZError(ctx, "while create transaction", err, "walletHoldID", hold.WalletHoldID)
+				return err
+			}
+			hold.WalletTransactionID = &transactionID
+		default:
+			hold.ReleasedAt = &now
+		}
+
+		err = s.repo.UpdateHold(ctx, &entity.WalletHold{
+			WalletHoldID:        hold.WalletHoldID,
+			StatusHold:          hold.StatusHold,
+			WalletTransactionID: hold.WalletTransactionID,
+			ReleasedAt:          hold.ReleasedAt,
+			CapturedAt:          hold.CapturedAt,
+			UpdatedBy:           hold.UpdatedBy,
+		})
+		if err != nil {
+			log.ZError(ctx, "while update wallet hold", err, "walletHoldID", hold.WalletHoldID)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.ZError(ctx, "while settle wallet hold", err, "request", req, "status", status)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", hold.WalletID),
+		attribute.Int64("walletHoldID", hold.WalletHoldID),
+		attribute.String("statusHold", hold.StatusHold.String()),
+	)
+
+	return dtoWalletHoldResponse(hold), nil
+}
+
+// captureTransaction builds the debit of a captured hold, the one asked for by the caller or
+// a hold_capture of the hold.
+func captureTransaction(hold *entity.WalletHold, req *domain.WalletHoldActionRequest) *domain.CreateTransactionReq {
+	capture := req.Capture
+	if capture == nil {
+		capture = &domain.CreateTransactionReq{
+			TransactionType: entity.TransactionTypeHoldCapture.String(),
+			ImpactedItem:    hold.WalletHoldID,
+			ReferenceCode:   fmt.Sprintf("#HLD-%s-%d-%d", nowToStringYYYYMMDD(), hold.WalletID, hold.WalletHoldID),
+			DescriptionEn:   "Hold Captured " + hold.Reason,
+			DescriptionZh:   "凍結資金扣劃 " + hold.Reason,
+		}
+	}
+	capture.WalletID = hold.WalletID
+	capture.Amount = -hold.Amount
+	capture.Currency = hold.Currency.String()
+	capture.Entrytype = entity.EntryTypeDebit.String()
+	capture.CreatedBy = req.OperatedBy
+
+	return capture
+}
+
+func (s *WalletHoldSvcImpl) GetListHold(
+	ctx context.Context, req *domain.GetListWalletHoldRequest,
+) (resp *domain.GetListWalletHoldResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var walletUser *entity.Wallet
+	walletUser, err = s.getWallet(ctx, req.UserID, req.Currency)
+	if err != nil {
+		return resp, err
+	}
+	req.WalletID = walletUser.WalletID
+
+	var (
+		holds []*entity.WalletHold
+		total int64
+	)
+	holds, total, err = s.repo.GetListHold(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list wallet hold", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", req.WalletID),
+		attribute.Int64("total", total),
+	)
+
+	return &domain.GetListWalletHoldResponse{
+		TotalCount: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Holds:      dtoWalletHolds(holds),
+	}, nil
+}
+
+func (s *WalletHoldSvcImpl) getWallet(
+	ctx context.Context, userID, currencyCode string,
+) (walletUser *entity.Wallet, err error) {
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	walletUser, err = s.walletRepo.GetWalletByUserID(ctx, userID, currency)
+	if err != nil {
+		log.ZError(ctx, "while get wallet user", err, "userID", userID, "currency", currency)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eerrs.ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	return walletUser, nil
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_tx"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_usecase"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet_hold"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func newMockHoldTxRepo(ctrl *gomock.Controller) *mock_tx.MockRepository {
+	txRepo := mock_tx.NewMockRepository(ctrl)
+	txRepo.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context, tx *gorm.DB) error) error {
+			return fn(ctx, &gorm.DB{})
+		}).AnyTimes()
+
+	return txRepo
+}
+
+func TestWalletHold_CreateHold(t *testing.T) {
+	type expected struct {
+		resp *domain.WalletHoldResponse
+		err  error
+	}
+	testCases := []struct {
+		desc             string
+		request          *domain.CreateWalletHoldRequest
+		expected         expected
+		wantError        bool
+		onMockWalletRepo func(mock *mock_wallet.MockRepository)
+		onMockRepo       func(mock *mock_wallet_hold.MockRepository)
+	}{
+		{
+			desc:      "ErrInvalidHoldAmount",
+			request:   &domain.CreateWalletHoldRequest{UserID: "1", Amount: entity.NewMoney(-5), Reason: "dispute"},
+			expected:  expected{err: eerrs.ErrInvalidHoldAmount},
+			wantError: true,
+		},
+		{
+			desc:      "ErrWalletNotFound",
+			request:   &domain.CreateWalletHoldRequest{UserID: "1", Amount: entity.NewMoney(5), Reason: "dispute"},
+			expected:  expected{err: eerrs.ErrWalletNotFound},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			desc:      "ErrInsufficientBalance_already_held",
+			request:   &domain.CreateWalletHoldRequest{UserID: "1", Amount: entity.NewMoney(60), Reason: "dispute"},
+			expected:  expected{err: eerrs.ErrInsufficientBalance},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 7, Currency: entity.CurrencyCNY}, nil)
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, Balance: entity.NewMoney(100), HeldBalance: entity.NewMoney(50)}, nil)
+			},
+		},
+		{
+			desc:      "error_while_UpdateHeldBalance",
+			request:   &domain.CreateWalletHoldRequest{UserID: "1", Amount: entity.NewMoney(50), Reason: "dispute"},
+			expected:  expected{err: errors.New("something went wrong")},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 7, Currency: entity.CurrencyCNY}, nil)
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, Balance: entity.NewMoney(100), HeldBalance: entity.NewMoney(50)}, nil)
+				mock.EXPECT().
+					UpdateHeldBalance(gomock.Any(), int64(7), entity.NewMoney(100)).
+					Return(errors.New("something went wrong"))
+			},
+			onMockRepo: func(mock *mock_wallet_hold.MockRepository) {
+				mock.EXPECT().CreateHold(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+			},
+		},
+		{
+			desc: "success",
+			request: &domain.CreateWalletHoldRequest{
+				UserID: "1", Currency: "usd", Amount: entity.NewMoney(50), Reason: "payout", OperatedBy: "admin",
+			},
+			expected: expected{resp: &domain.WalletHoldResponse{WalletHoldID: 3, WalletID: 7, StatusHold: "active"}},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyUSD).
+					Return(&entity.Wallet{WalletID: 7, Currency: entity.CurrencyUSD}, nil)
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, Balance: entity.NewMoney(100), HeldBalance: entity.NewMoney(20)}, nil)
+				mock.EXPECT().UpdateHeldBalance(gomock.Any(), int64(7), entity.NewMoney(70)).Return(nil)
+			},
+			onMockRepo: func(mock *mock_wallet_hold.MockRepository) {
+				mock.EXPECT().
+					CreateHold(gomock.Any(), &entity.WalletHold{
+						WalletID:   7,
+						Amount:     entity.NewMoney(50),
+						Currency:   entity.CurrencyUSD,
+						StatusHold: entity.StatusHoldActive,
+						Reason:     "payout",
+						CreatedBy:  "admin",
+						UpdatedBy:  "admin",
+					}).
+					DoAndReturn(func(_ context.Context, hold *entity.WalletHold) (int64, error) {
+						hold.WalletHoldID = 3
+						return 3, nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(walletRepo)
+			}
+			repo := mock_wallet_hold.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+
+			svc := NewWalletHoldUseCase(repo, walletRepo, newMockHoldTxRepo(ctrl), mock_usecase.NewMockWalletTransactionSvc(ctrl))
+
+			got, err := svc.CreateHold(context.Background(), tC.request)
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestWalletHold_ReleaseHold(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		hold      *entity.WalletHold
+		wantError error
+	}{
+		{
+			desc:      "ErrWalletHoldNotActive",
+			hold:      &entity.WalletHold{WalletHoldID: 3, WalletID: 7, Amount: entity.NewMoney(50), StatusHold: entity.StatusHoldCaptured},
+			wantError: eerrs.ErrWalletHoldNotActive,
+		},
+		{
+			desc: "success",
+			hold: &entity.WalletHold{WalletHoldID: 3, WalletID: 7, Amount: entity.NewMoney(50), StatusHold: entity.StatusHoldActive},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_wallet_hold.NewMockRepository(ctrl)
+			repo.EXPECT().GetHold(gomock.Any(), int64(3)).Return(&entity.WalletHold{WalletHoldID: 3, WalletID: 7}, nil)
+			repo.EXPECT().GetHoldForUpdate(gomock.Any(), int64(3)).Return(tC.hold, nil)
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			walletRepo.EXPECT().
+				GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+				Return(&entity.Wallet{WalletID: 7, Balance: entity.NewMoney(100), HeldBalance: entity.NewMoney(50)}, nil)
+
+			if tC.wantError == nil {
+				// the last hold released leaves a zero held balance, which has to be written too
+				walletRepo.EXPECT().UpdateHeldBalance(gomock.Any(), int64(7), entity.Money(0)).Return(nil)
+				repo.EXPECT().
+					UpdateHold(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, hold *entity.WalletHold) error {
+						assert.Equal(t, entity.StatusHoldReleased, hold.StatusHold)
+						assert.NotNil(t, hold.ReleasedAt)
+						assert.Nil(t, hold.CapturedAt)
+						assert.Equal(t, "admin", hold.UpdatedBy)
+						return nil
+					})
+			}
+
+			svc := NewWalletHoldUseCase(repo, walletRepo, newMockHoldTxRepo(ctrl), mock_usecase.NewMockWalletTransactionSvc(ctrl))
+
+			got, err := svc.ReleaseHold(context.Background(), &domain.WalletHoldActionRequest{WalletHoldID: 3, OperatedBy: "admin"})
+			if tC.wantError == nil {
+				require.NoError(t, err)
+				assert.Equal(t, &domain.WalletHoldResponse{WalletHoldID: 3, WalletID: 7, StatusHold: "released"}, got)
+			} else {
+				require.ErrorIs(t, err, tC.wantError)
+			}
+		})
+	}
+}
+
+func TestWalletHold_CaptureHold(t *testing.T) {
+	type expected struct {
+		resp *domain.WalletHoldResponse
+		err  error
+	}
+	testCases := []struct {
+		desc                     string
+		expected                 expected
+		wantError                bool
+		onMockRepo               func(mock *mock_wallet_hold.MockRepository)
+		onMockTransactionUsecase func(mock *mock_usecase.MockWalletTransactionSvc)
+	}{
+		{
+			desc:      "ErrWalletHoldNotFound",
+			expected:  expected{err: eerrs.ErrWalletHoldNotFound},
+			wantError: true,
+			onMockRepo: func(mock *mock_wallet_hold.MockRepository) {
+				mock.EXPECT().GetHold(gomock.Any(), int64(3)).Return(nil, eerrs.ErrWalletHoldNotFound)
+			},
+		},
+		{
+			desc:      "error_while_CreateTransaction",
+			expected:  expected{err: errors.New("something went wrong")},
+			wantError: true,
+			onMockRepo: func(mock *mock_wallet_hold.MockRepository) {
+				mock.EXPECT().GetHold(gomock.Any(), int64(3)).Return(&entity.WalletHold{WalletHoldID: 3, WalletID: 7}, nil)
+				mock.EXPECT().GetHoldForUpdate(gomock.Any(), int64(3)).Return(&entity.WalletHold{
+					WalletHoldID: 3, WalletID: 7, Amount: entity.NewMoney(30), Currency: entity.CurrencyCNY,
+					StatusHold: entity.StatusHoldActive,
+				}, nil)
+			},
+			onMockTransactionUsecase: func(mock *mock_usecase.MockWalletTransactionSvc) {
+				mock.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("something went wrong"))
+			},
+		},
+		{
+			desc: "success",
+			expected: expected{resp: &domain.WalletHoldResponse{
+				WalletHoldID: 3, WalletID: 7, StatusHold: "captured", WalletTransactionID: int64Ptr(11),
+			}},
+			onMockRepo: func(mock *mock_wallet_hold.MockRepository) {
+				mock.EXPECT().GetHold(gomock.Any(), int64(3)).Return(&entity.WalletHold{WalletHoldID: 3, WalletID: 7}, nil)
+				mock.EXPECT().GetHoldForUpdate(gomock.Any(), int64(3)).Return(&entity.WalletHold{
+					WalletHoldID: 3, WalletID: 7, Amount: entity.NewMoney(30), Currency: entity.CurrencyCNY,
+					StatusHold: entity.StatusHoldActive, Reason: "chargeback",
+				}, nil)
+				mock.EXPECT().
+					UpdateHold(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, hold *entity.WalletHold) error {
+						if hold.StatusHold != entity.StatusHoldCaptured || hold.CapturedAt == nil ||
+							hold.WalletTransactionID == nil || *hold.WalletTransactionID != 11 {
+							return errors.New("unexpected hold update")
+						}
+						return nil
+					})
+			},
+			onMockTransactionUsecase: func(mock *mock_usecase.MockWalletTransactionSvc) {
+				mock.EXPECT().
+					CreateTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *domain.CreateTransactionReq) (int64, error) {
+						if req.WalletID != 7 || req.Amount != entity.NewMoney(-30) || req.Currency != "CNY" ||
+							req.TransactionType != "hold_capture" || req.Entrytype != "debit" || req.ImpactedItem != 3 {
+							return 0, errors.New("unexpected capture transaction")
+						}
+						return 11, nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mock_wallet_hold.NewMockRepository(ctrl)
+			tC.onMockRepo(repo)
+			transactionUc := mock_usecase.NewMockWalletTransactionSvc(ctrl)
+			if tC.onMockTransactionUsecase != nil {
+				tC.onMockTransactionUsecase(transactionUc)
+			}
+
+			// the held amount leaves the held balance before the capture debit is checked
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			walletRepo.EXPECT().
+				GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+				Return(&entity.Wallet{WalletID: 7, Balance: entity.NewMoney(30), HeldBalance: entity.NewMoney(30)}, nil).
+				AnyTimes()
+			walletRepo.EXPECT().UpdateHeldBalance(gomock.Any(), int64(7), entity.Money(0)).Return(nil).AnyTimes()
+
+			svc := NewWalletHoldUseCase(repo, walletRepo, newMockHoldTxRepo(ctrl), transactionUc)
+
+			got, err := svc.CaptureHold(context.Background(), &domain.WalletHoldActionRequest{WalletHoldID: 3, OperatedBy: "admin"})
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
@@ -18,12 +18,14 @@ import (
 	"github.com/1nterdigital/aka-im-tools/tracer"
 	"github.com/1nterdigital/aka-im-wallet/internal/domain"
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/risk_decision"
 	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_monitoring"
 )
 
 type (
 	WalletMonitoringSvcImpl struct {
-		repo wallet_monitoring.WalletMonitoringRepository
+		repo     wallet_monitoring.WalletMonitoringRepository
+		riskRepo risk_decision.Repository
 	}
 
 	WalletMonitoringSvc interface {
@@ -53,12 +55,49 @@ type (
 	}
 )
 
-func NewWalletMonitoringUseCase(repo wallet_monitoring.WalletMonitoringRepository) WalletMonitoringSvc {
+func NewWalletMonitoringUseCase(
+	repo wallet_monitoring.WalletMonitoringRepository,
+	riskRepo risk_decision.Repository,
+) WalletMonitoringSvc {
 	return &WalletMonitoringSvcImpl{
-		repo: repo,
+		repo:     repo,
+		riskRepo: riskRepo,
 	}
 }
 
+var (
+	riskEnvelopeOperations = []entity.RiskOperation{entity.RiskOperationEnvelopeCreate, entity.RiskOperationEnvelopeClaim}
+	riskTransferOperations = []entity.RiskOperation{entity.RiskOperationTransferCreate, entity.RiskOperationTransferClaim}
+)
+
+// getRiskDecisions folds the risk decisions on each transfer or envelope into the most severe
+// one, references the rules allowed every time are left out.
+func (u *WalletMonitoringSvcImpl) getRiskDecisions(
+	ctx context.Context, operations []entity.RiskOperation, referenceIDs []int64,
+) (decisions map[int64]string, err error) {
+	var rows []*entity.RiskDecision
+	rows, err = u.riskRepo.GetRiskDecisionsByReference(ctx, operations, referenceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	worst := make(map[int64]entity.RiskDecisionType, len(rows))
+	for _, row := range rows {
+		if row.Decision.MoreSevere(worst[row.ReferenceID]) {
+			worst[row.ReferenceID] = row.Decision
+		}
+	}
+
+	decisions = make(map[int64]string, len(worst))
+	for referenceID, decision := range worst {
+		if decision != entity.RiskDecisionAllow {
+			decisions[referenceID] = decision.String()
+		}
+	}
+
+	return decisions, nil
+}
+
 func (u *WalletMonitoringSvcImpl) GetDashboardTransactionVolume(
 	ctx context.Context,
 ) (resp *domain.DashboardTransactionVolumeResponse, err error) {
@@ -129,8 +168,8 @@ func (u *WalletMonitoringSvcImpl) GetListTransactionMonitoring(
 		attribute.String("transactionTypeReq", req.TransactionType),
 		attribute.String("entryTypeReq", req.EntryType),
 		attribute.String("referenceCodeReq", req.ReferenceCode),
-		attribute.Float64("fromAmountReq", req.FromAmount),
-		attribute.Float64("toAmountReq", req.ToAmount),
+		attribute.String("fromAmountReq", req.FromAmount.String()),
+		attribute.String("toAmountReq", req.ToAmount.String()),
 		attribute.Int64("walletIDReq", req.WalletID),
 	)
 
@@ -196,8 +235,8 @@ func (u *WalletMonitoringSvcImpl) GetListEnvelope(
 	span.SetAttributes(
 		attribute.Int("page", int(req.Page)),
 		attribute.Int("limit", int(req.Limit)),
-		attribute.Float64("fromTotalAmount", req.FromTotalAmount),
-		attribute.Float64("toTotalAmount", req.ToTotalAmount),
+		attribute.String("fromTotalAmount", req.FromTotalAmount.String()),
+		attribute.String("toTotalAmount", req.ToTotalAmount.String()),
 		attribute.String("userID", req.UserID),
 		attribute.String("envelopeType", req.EnvelopeType),
 		attribute.Int("maxNumReceived", int(req.MaxNumReceived)),
@@ -216,10 +255,22 @@ func (u *WalletMonitoringSvcImpl) GetListEnvelope(
 		return resp, errs.ErrArgs.WithDetail(fmt.Sprintf("failed to get envelope list: %v", err)).Wrap()
 	}
 
+	envelopeIDs := make([]int64, 0, len(envelopes))
+	for _, env := range envelopes {
+		envelopeIDs = append(envelopeIDs, env.EnvelopeID)
+	}
+
+	var riskDecisions map[int64]string
+	riskDecisions, err = u.getRiskDecisions(ctx, riskEnvelopeOperations, envelopeIDs)
+	if err != nil {
+		return resp, errs.ErrArgs.WithDetail(fmt.Sprintf("failed to get risk decisions: %v", err)).Wrap()
+	}
+
 	result := make([]*domain.EnvelopeDTO, 0, len(envelopes))
 
 	for _, env := range envelopes {
 		dto := convertEnvelopeToDTO(env)
+		dto.RiskDecision = riskDecisions[env.EnvelopeID]
 		result = append(result, dto)
 	}
 
@@ -306,6 +357,13 @@ func (u *WalletMonitoringSvcImpl) GetEnvelopeDetail(
 
 	enrichEnvelopeDetailResponse(response)
 
+	var riskDecisions map[int64]string
+	riskDecisions, err = u.getRiskDecisions(ctx, riskEnvelopeOperations, []int64{envelope.EnvelopeID})
+	if err != nil {
+		return resp, errs.ErrArgs.WithDetail(fmt.Sprintf("failed to get risk decisions: %v", err)).Wrap()
+	}
+	response.RiskDecision = riskDecisions[envelope.EnvelopeID]
+
 	return response, nil
 }
 
@@ -336,8 +394,8 @@ func (u *WalletMonitoringSvcImpl) GetTransferHistory(
 		attribute.String("fromUserIDReq", req.FromUserID),
 		attribute.String("toUserIDReq", req.ToUserID),
 		attribute.String("statusTransferReq", req.StatusTransfer),
-		attribute.Float64("fromAmountReq", req.FromAmount),
-		attribute.Float64("toAmountReq", req.ToAmount),
+		attribute.String("fromAmountReq", req.FromAmount.String()),
+		attribute.String("toAmountReq", req.ToAmount.String()),
 		attribute.Int64("fromExpiredAtReq", req.FromExpiredAt),
 		attribute.Int64("toExpiredAtReq", req.ToExpiredAt),
 		attribute.Int64("fromClaimedAtReq", req.FromClaimedAt),
@@ -353,10 +411,22 @@ func (u *WalletMonitoringSvcImpl) GetTransferHistory(
 		return resp, errs.ErrArgs.WithDetail(fmt.Sprintf("failed to get transfer history: %v", err)).Wrap()
 	}
 
+	transferIDs := make([]int64, 0, len(transfers))
+	for _, transfer := range transfers {
+		transferIDs = append(transferIDs, transfer.TransferID)
+	}
+
+	var riskDecisions map[int64]string
+	riskDecisions, err = u.getRiskDecisions(ctx, riskTransferOperations, transferIDs)
+	if err != nil {
+		return resp, errs.ErrArgs.WithDetail(fmt.Sprintf("failed to get risk decisions: %v", err)).Wrap()
+	}
+
 	result := make([]*domain.TransferDTO, 0, len(transfers))
 
 	for _, transfer := range transfers {
 		dto := convertTransferToDTO(transfer)
+		dto.RiskDecision = riskDecisions[transfer.TransferID]
 		result = append(result, dto)
 	}
 
@@ -442,6 +512,9 @@ func setTransactionTypeCount(ttc *domain.TransactionTypeCount, transactionType s
 		entity.TransactionTypeEnvelopeSingle.String(): func(t *domain.TransactionTypeCount, c int64) {
 			t.EnvelopeSingle = c
 		},
+		entity.TransactionTypeEnvelopeExclusive.String(): func(t *domain.TransactionTypeCount, c int64) {
+			t.EnvelopeExclusive = c
+		},
 		entity.TransactionTypeRefundEnvelope.String(): func(t *domain.TransactionTypeCount, c int64) {
 			t.RefundEnvelope = c
 		},
@@ -454,6 +527,12 @@ func setTransactionTypeCount(ttc *domain.TransactionTypeCount, transactionType s
 		entity.TransactionTypeDeposit.String(): func(t *domain.TransactionTypeCount, c int64) {
 			t.Deposit = c
 		},
+		entity.TransactionTypeDeclineTransfer.String(): func(t *domain.TransactionTypeCount, c int64) {
+			t.DeclineTransfer = c
+		},
+		entity.TransactionTypeCancelTransfer.String(): func(t *domain.TransactionTypeCount, c int64) {
+			t.CancelTransfer = c
+		},
 	}
 
 	if setter, exists := transactionTypeSetters[transactionType]; exists {
@@ -594,7 +673,7 @@ func calculateCompletionPercentage(response *domain.GetEnvelopeDetailResponse) (
 	if response.TotalAmount == 0 {
 		return 0
 	}
-	return (response.TotalAmountClaimed / response.TotalAmount) * percentageFactor
+	return (response.TotalAmountClaimed.Float64() / response.TotalAmount.Float64()) * percentageFactor
 }
 
 // calculateEnvelopeStatistics calculates statistics from envelope details
@@ -641,6 +720,8 @@ func convertTransferToDTO(transfer *entity.Transfer) (resp *domain.TransferDTO)
 		ExpiredAt:      transfer.ExpiredAt,
 		RefundedAt:     transfer.RefundedAt,
 		ClaimedAt:      transfer.ClaimedAt,
+		CanceledAt:     transfer.CanceledAt,
+		DeclinedAt:     transfer.DeclinedAt,
 		CreatedAt:      transfer.CreatedAt,
 	}
 
@@ -668,6 +749,12 @@ func convertTransferToDTO(transfer *entity.Transfer) (resp *domain.TransferDTO)
 	} else if transfer.RefundedAt != nil {
 		processingTime := transfer.RefundedAt.Sub(transfer.CreatedAt)
 		dto.ProcessingTime = &processingTime
+	} else if transfer.CanceledAt != nil {
+		processingTime := transfer.CanceledAt.Sub(transfer.CreatedAt)
+		dto.ProcessingTime = &processingTime
+	} else if transfer.DeclinedAt != nil {
+		processingTime := transfer.DeclinedAt.Sub(transfer.CreatedAt)
+		dto.ProcessingTime = &processingTime
 	}
 
 	return dto
@@ -697,6 +784,12 @@ func calculateTransferStatistics(transfers []*domain.TransferDTO) (resp *domain.
 		case entity.StatusTransferRefunded.String():
 			stats.RefundedCount++
 			stats.RefundedAmount += transfer.Amount
+		case entity.StatusTransferCanceled.String():
+			stats.CanceledCount++
+			stats.CanceledAmount += transfer.Amount
+		case entity.StatusTransferDeclined.String():
+			stats.DeclinedCount++
+			stats.DeclinedAmount += transfer.Amount
 		}
 	}
 
@@ -704,7 +797,7 @@ func calculateTransferStatistics(transfers []*domain.TransferDTO) (resp *domain.
 	stats.UniqueReceiversCount = len(uniqueReceivers)
 
 	if stats.TotalTransfers > 0 {
-		stats.AverageAmount = stats.TotalAmount / float64(stats.TotalTransfers)
+		stats.AverageAmount = stats.TotalAmount / entity.Money(stats.TotalTransfers)
 	}
 
 	return stats
@@ -722,25 +815,31 @@ func transformToHighFrequencyUserDTOs(
 			WalletID:    result.WalletID,
 			TotalCredit: result.TotalCredit,
 			Credit: domain.TransactionTypeCount{
-				Transfer:         result.CreditTransfer,
-				EnvelopeFixed:    result.CreditEnvelopeFixed,
-				EnvelopeLucky:    result.CreditEnvelopeLucky,
-				EnvelopeSingle:   result.CreditEnvelopeSingle,
-				Deposit:          result.DebitDeposit,
-				RefundEnvelope:   result.CreditRefundEnvelope,
-				RefundTransfer:   result.CreditRefundTransfer,
-				SystemAdjustment: result.CreditSystemAdjustment,
+				Transfer:          result.CreditTransfer,
+				EnvelopeFixed:     result.CreditEnvelopeFixed,
+				EnvelopeLucky:     result.CreditEnvelopeLucky,
+				EnvelopeSingle:    result.CreditEnvelopeSingle,
+				EnvelopeExclusive: result.CreditEnvelopeExclusive,
+				Deposit:           result.DebitDeposit,
+				RefundEnvelope:    result.CreditRefundEnvelope,
+				RefundTransfer:    result.CreditRefundTransfer,
+				SystemAdjustment:  result.CreditSystemAdjustment,
+				DeclineTransfer:   result.CreditDeclineTransfer,
+				CancelTransfer:    result.CreditCancelTransfer,
 			},
 			TotalDebit: result.TotalDebit,
 			Debit: domain.TransactionTypeCount{
-				Transfer:         result.DebitTransfer,
-				EnvelopeFixed:    result.DebitEnvelopeFixed,
-				EnvelopeLucky:    result.DebitEnvelopeLucky,
-				EnvelopeSingle:   result.DebitEnvelopeSingle,
-				Deposit:          result.DebitDeposit,
-				RefundEnvelope:   result.DebitRefundEnvelope,
-				RefundTransfer:   result.DebitRefundTransfer,
-				SystemAdjustment: result.DebitSystemAdjustment,
+				Transfer:          result.DebitTransfer,
+				EnvelopeFixed:     result.DebitEnvelopeFixed,
+				EnvelopeLucky:     result.DebitEnvelopeLucky,
+				EnvelopeSingle:    result.DebitEnvelopeSingle,
+				EnvelopeExclusive: result.DebitEnvelopeExclusive,
+				Deposit:           result.DebitDeposit,
+				RefundEnvelope:    result.DebitRefundEnvelope,
+				RefundTransfer:    result.DebitRefundTransfer,
+				SystemAdjustment:  result.DebitSystemAdjustment,
+				DeclineTransfer:   result.DebitDeclineTransfer,
+				CancelTransfer:    result.DebitCancelTransfer,
 			},
 			Position: i + 1,
 		}
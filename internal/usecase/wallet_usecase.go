@@ -26,10 +26,13 @@ type (
 	}
 
 	WalletSvc interface {
-		GetWalletByUserID(userID string) (resp *domain.WalletDomain, err error)
-		GetWalletDetail(ctx context.Context, userID string) (wallet *domain.Wallet, err error)
+		GetWalletByUserID(
+			ctx context.Context, userID string, currency entity.Currency,
+		) (resp *domain.WalletDomain, err error)
+		// GetWalletDetail returns every wallet of the user, one per currency.
+		GetWalletDetail(ctx context.Context, userID string) (detail *domain.WalletDetail, err error)
 		CreateWallet(
-			ctx context.Context, userID, createdBy string,
+			ctx context.Context, userID, currency, createdBy string,
 		) (wallet *domain.Wallet, err error)
 	}
 )
@@ -46,8 +49,10 @@ func NewWalletUseCase(
 	}
 }
 
-func (s *WalletSvcImpl) GetWalletByUserID(userID string) (resp *domain.WalletDomain, err error) {
-	walletDB, err := s.repo.FindByUserID(userID)
+func (s *WalletSvcImpl) GetWalletByUserID(
+	ctx context.Context, userID string, currency entity.Currency,
+) (resp *domain.WalletDomain, err error) {
+	walletDB, err := s.repo.FindByUserID(ctx, userID, currency)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +63,7 @@ func (s *WalletSvcImpl) GetWalletByUserID(userID string) (resp *domain.WalletDom
 func toDomainWallet(walletDB *entity.Wallet) (resp *domain.WalletDomain) {
 	return &domain.WalletDomain{
 		WalletID:  walletDB.WalletID,
+		Currency:  walletDB.Currency.String(),
 		Balance:   walletDB.Balance,
 		IsActive:  walletDB.IsActive,
 		CreatedAt: walletDB.CreatedAt,
@@ -66,7 +72,7 @@ func toDomainWallet(walletDB *entity.Wallet) (resp *domain.WalletDomain) {
 
 func (u *WalletSvcImpl) GetWalletDetail(
 	ctx context.Context, userID string,
-) (walletDetail *domain.Wallet, err error) {
+) (walletDetail *domain.WalletDetail, err error) {
 	var (
 		funcName = tracer.GetFullFunctionPath()
 		t        = otel.Tracer(tracer.LevelUsecase)
@@ -81,26 +87,35 @@ func (u *WalletSvcImpl) GetWalletDetail(
 		span.End()
 	}()
 
-	var resp *entity.Wallet
-	resp, err = u.repo.GetWalletByUserID(ctx, userID)
+	var wallets []*entity.Wallet
+	wallets, err = u.repo.GetWalletsByUserID(ctx, userID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
-		log.ZError(ctx, "while repo.GetWalletByUserID", err, "userID", userID)
+		log.ZError(ctx, "while repo.GetWalletsByUserID", err, "userID", userID)
 		return nil, err
 	}
 
 	span.SetAttributes(
 		attribute.String("userIDReq", userID),
-		attribute.Int64("walletIDResp", resp.WalletID),
+		attribute.Int("totalWalletResp", len(wallets)),
 	)
 
-	return dtoWalletDetail(resp), nil
+	if len(wallets) == 0 {
+		return nil, nil
+	}
+
+	walletDetail = &domain.WalletDetail{
+		UserID:  userID,
+		Wallets: make([]*domain.Wallet, 0, len(wallets)),
+	}
+	for _, wallet := range wallets {
+		walletDetail.Wallets = append(walletDetail.Wallets, dtoWalletDetail(wallet))
+	}
+
+	return walletDetail, nil
 }
 
 func (u *WalletSvcImpl) CreateWallet(
-	ctx context.Context, userID string, createdBy string,
+	ctx context.Context, userID, currencyCode, createdBy string,
 ) (resp *domain.Wallet, err error) {
 	var (
 		funcName = tracer.GetFullFunctionPath()
@@ -116,24 +131,32 @@ func (u *WalletSvcImpl) CreateWallet(
 		span.End()
 	}()
 
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
 	var walletUser *entity.Wallet
-	walletUser, err = u.repo.GetWalletByUserID(ctx, userID)
+	walletUser, err = u.repo.GetWalletByUserID(ctx, userID, currency)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		log.ZError(ctx, "while repo.GetWalletByUserID", err, "userID", userID)
 		return nil, err
 	}
 
 	if walletUser != nil && walletUser.WalletID > 0 {
-		log.ZError(ctx, "validate wallet existed", err, "userID", userID)
+		log.ZError(ctx, "validate wallet existed", err, "userID", userID, "currency", currency)
 		return nil, eerrs.ErrWalletExisted
 	}
 
 	var walletID int64
 	walletID, err = u.repo.CreateWallet(ctx, &entity.Wallet{
-		UserID:    userID,
-		IsActive:  true,
-		CreatedBy: createdBy,
-		UpdatedBy: createdBy,
+		UserID:       userID,
+		Currency:     currency,
+		IsActive:     true,
+		FreezeStatus: entity.FreezeStatusNone,
+		CreatedBy:    createdBy,
+		UpdatedBy:    createdBy,
 	})
 	if err != nil {
 		log.ZError(ctx, "while repo.CreateWallet", err, "userID", userID)
@@ -142,13 +165,16 @@ func (u *WalletSvcImpl) CreateWallet(
 
 	span.SetAttributes(
 		attribute.String("userIDReq", userID),
+		attribute.String("currencyReq", currency.String()),
 		attribute.Int64("walletIDResp", walletID),
 		attribute.String("createdBy", createdBy),
 	)
 
 	return &domain.Wallet{
-		ID:        walletID,
-		UserID:    userID,
-		CreatedBy: createdBy,
+		ID:           walletID,
+		UserID:       userID,
+		Currency:     currency.String(),
+		FreezeStatus: entity.FreezeStatusNone.String(),
+		CreatedBy:    createdBy,
 	}, nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"testing"
-	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +18,7 @@ import (
 
 func TestWallet_GetWalletDetail(t *testing.T) {
 	type expected struct {
-		wallet *domain.Wallet
+		detail *domain.WalletDetail
 		err    error
 	}
 
@@ -31,42 +30,54 @@ func TestWallet_GetWalletDetail(t *testing.T) {
 		onMockWalletRepo func(mock *mock_wallet.MockRepository)
 	}{
 		{
-			desc:   "error_while_GetWalletByUserID",
+			desc:   "error_while_GetWalletsByUserID",
 			userID: "1123453",
 			expected: expected{
-				wallet: &domain.Wallet{},
-				err:    errors.New("something went wrong"),
+				err: errors.New("something went wrong"),
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletsByUserID(gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("something went wrong"))
 			},
 			wantError: true,
 		},
 		{
-			desc:   "success_get_existing_wallet",
+			desc:   "success_no_wallet",
+			userID: "1123453",
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletsByUserID(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+			},
+			wantError: false,
+		},
+		{
+			desc:   "success_get_every_currency",
 			userID: "1123453",
 			expected: expected{
-				wallet: &domain.Wallet{
-					ID:        1,
-					UserID:    "1123453",
-					Balance:   0,
-					CreatedAt: time.Now(),
-					CreatedBy: "system",
+				detail: &domain.WalletDetail{
+					UserID: "1123453",
+					Wallets: []*domain.Wallet{
+						{ID: 1, UserID: "1123453", Currency: "CNY", Balance: 0, CreatedBy: "system"},
+						{
+							ID: 2, UserID: "1123453", Currency: "USD", Balance: 150, HeldBalance: 50, AvailableBalance: 100,
+							CreatedBy: "system",
+						},
+					},
 				},
 				err: nil,
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletsByUserID(gomock.Any(), gomock.Any()).
 					Return(
-						&entity.Wallet{
-							WalletID:  1,
-							UserID:    "1123453",
-							Balance:   0,
-							CreatedAt: time.Now(),
-							CreatedBy: "system",
+						[]*entity.Wallet{
+							{WalletID: 1, UserID: "1123453", Currency: entity.CurrencyCNY, Balance: 0, CreatedBy: "system"},
+							{
+								WalletID: 2, UserID: "1123453", Currency: entity.CurrencyUSD, Balance: 150, HeldBalance: 50,
+								CreatedBy: "system",
+							},
 						}, nil,
 					)
 			},
@@ -88,9 +99,7 @@ func TestWallet_GetWalletDetail(t *testing.T) {
 			got, err := svc.GetWalletDetail(context.Background(), tC.userID)
 			if !tC.wantError {
 				require.NoError(t, err)
-				assert.Equal(t, got.ID, tC.expected.wallet.ID)
-				assert.Equal(t, got.UserID, tC.expected.wallet.UserID)
-				assert.InDelta(t, got.Balance, tC.expected.wallet.Balance, 0.01)
+				assert.Equal(t, tC.expected.detail, got)
 			} else {
 				require.Error(t, err)
 				assert.Equal(t, err.Error(), tC.expected.err.Error())
@@ -108,6 +117,7 @@ func TestWallet_CreateWallet(t *testing.T) {
 	testCases := []struct {
 		desc             string
 		userID           string
+		currency         string
 		createdBy        string
 		expected         expected
 		wantError        bool
@@ -123,7 +133,7 @@ func TestWallet_CreateWallet(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("something went wrong"))
 			},
 			wantError: true,
@@ -138,7 +148,7 @@ func TestWallet_CreateWallet(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(
 						&entity.Wallet{
 							WalletID:  1,
@@ -160,7 +170,7 @@ func TestWallet_CreateWallet(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, gorm.ErrRecordNotFound)
 				mock.EXPECT().
 					CreateWallet(gomock.Any(), gomock.Any()).
@@ -168,6 +178,42 @@ func TestWallet_CreateWallet(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			desc:      "ErrInvalidCurrency",
+			userID:    "1123453",
+			currency:  "ABC",
+			createdBy: "system",
+			expected: expected{
+				wallet: &domain.Wallet{},
+				err:    eerrs.ErrInvalidCurrency,
+			},
+			wantError: true,
+		},
+		{
+			desc:      "success_create_wallet_in_second_currency",
+			userID:    "1123453",
+			currency:  "usd",
+			createdBy: "system",
+			expected: expected{
+				wallet: &domain.Wallet{
+					ID:        2,
+					UserID:    "1123453",
+					Currency:  "USD",
+					Balance:   0,
+					CreatedBy: "system",
+				},
+				err: nil,
+			},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1123453", entity.CurrencyUSD).
+					Return(nil, gorm.ErrRecordNotFound)
+				mock.EXPECT().
+					CreateWallet(gomock.Any(), gomock.Any()).
+					Return(int64(2), nil)
+			},
+			wantError: false,
+		},
 		{
 			desc:      "success_create_wallet",
 			userID:    "1123453",
@@ -183,7 +229,7 @@ func TestWallet_CreateWallet(t *testing.T) {
 			},
 			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
 				mock.EXPECT().
-					GetWalletByUserID(gomock.Any(), gomock.Any()).
+					GetWalletByUserID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, gorm.ErrRecordNotFound)
 				mock.EXPECT().
 					CreateWallet(gomock.Any(), gomock.Any()).
@@ -204,12 +250,15 @@ func TestWallet_CreateWallet(t *testing.T) {
 
 			svc := NewWalletUseCase(onMockWalletRepo, nil, nil)
 
-			got, err := svc.CreateWallet(context.Background(), tC.userID, tC.createdBy)
+			got, err := svc.CreateWallet(context.Background(), tC.userID, tC.currency, tC.createdBy)
 			if !tC.wantError {
 				require.NoError(t, err)
 				assert.Equal(t, got.ID, tC.expected.wallet.ID)
 				assert.Equal(t, got.UserID, tC.expected.wallet.UserID)
-				assert.InDelta(t, got.Balance, tC.expected.wallet.Balance, 0.01)
+				assert.Equal(t, got.Balance, tC.expected.wallet.Balance)
+				if tC.expected.wallet.Currency != "" {
+					assert.Equal(t, tC.expected.wallet.Currency, got.Currency)
+				}
 			} else {
 				require.Error(t, err)
 				assert.Equal(t, err.Error(), tC.expected.err.Error())
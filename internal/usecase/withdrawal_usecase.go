@@ -0,0 +1,445 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-tools/log"
+	"github.com/1nterdigital/aka-im-tools/tracer"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/tx"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet"
+	"github.com/1nterdigital/aka-im-wallet/internal/repository/wallet_withdrawal_request"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+type (
+	WithdrawalSvcImpl struct {
+		repo       wallet_withdrawal_request.Repository
+		walletRepo wallet.Repository
+		txRepo     tx.Repository
+		holdUc     WalletHoldSvc
+	}
+
+	WithdrawalSvc interface {
+		// RequestWithdrawal records a cash-out of the user and holds its amount on the wallet
+		// until an admin rejects the request or marks it paid.
+		RequestWithdrawal(
+			ctx context.Context, req *domain.CreateWithdrawalRequest,
+		) (resp *domain.WithdrawalResponse, err error)
+		ApproveWithdrawal(
+			ctx context.Context, req *domain.ProcessWithdrawalRequest,
+		) (resp *domain.WithdrawalResponse, err error)
+		// RejectWithdrawal releases the held amount back to the wallet, a requested or approved
+		// withdrawal can be rejected.
+		RejectWithdrawal(
+			ctx context.Context, req *domain.ProcessWithdrawalRequest,
+		) (resp *domain.WithdrawalResponse, err error)
+		// MarkWithdrawalPaid captures the hold of an approved withdrawal once the money has been
+		// paid out, booking a withdrawal debit on the wallet.
+		MarkWithdrawalPaid(
+			ctx context.Context, req *domain.ProcessWithdrawalRequest,
+		) (resp *domain.WithdrawalResponse, err error)
+		GetListWithdrawal(
+			ctx context.Context, req *domain.GetListWithdrawalRequest,
+		) (resp *domain.GetListWithdrawalResponse, err error)
+	}
+)
+
+func NewWithdrawalUseCase(
+	repo wallet_withdrawal_request.Repository,
+	walletRepo wallet.Repository,
+	txRepo tx.Repository,
+	holdUc WalletHoldSvc,
+) WithdrawalSvc {
+	return &WithdrawalSvcImpl{
+		repo:       repo,
+		walletRepo: walletRepo,
+		txRepo:     txRepo,
+		holdUc:     holdUc,
+	}
+}
+
+func (s *WithdrawalSvcImpl) RequestWithdrawal(
+	ctx context.Context, req *domain.CreateWithdrawalRequest,
+) (resp *domain.WithdrawalResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var currency entity.Currency
+	currency, err = entity.ParseCurrency(req.Currency)
+	if err != nil {
+		return resp, err
+	}
+
+	var walletUser *entity.Wallet
+	walletUser, err = s.walletRepo.GetWalletByUserID(ctx, req.UserID, currency)
+	if err != nil {
+		log.ZError(ctx, "while get wallet user", err, "userID", req.UserID, "currency", currency)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp, eerrs.ErrWalletNotFound
+		}
+		return resp, err
+	}
+
+	if err = validateWalletDebit(walletUser); err != nil {
+		log.ZError(ctx, "while validate wallet user", err, "walletID", walletUser.WalletID)
+		return resp, err
+	}
+
+	withdrawal := &entity.WalletWithdrawalRequest{
+		WalletID:         walletUser.WalletID,
+		Amount:           req.Amount,
+		Currency:         currency,
+		StatusWithdrawal: entity.StatusWithdrawalRequested,
+		Destination:      req.Destination,
+		Description:      req.Description,
+		IsActive:         true,
+		CreatedBy:        req.UserID,
+		UpdatedBy:        req.UserID,
+	}
+	err = s.txRepo.Do(ctx, func(ctx context.Context, _ *gorm.DB) error {
+		var hold *domain.WalletHoldResponse
+		hold, err = s.holdUc.CreateHold(ctx, &domain.CreateWalletHoldRequest{
+			UserID:     req.UserID,
+			Currency:   currency.String(),
+			Amount:     req.Amount,
+			Reason:     "Withdrawal to " + req.Destination,
+			OperatedBy: req.UserID,
+		})
+		if err != nil {
+			log.ZError(ctx, "while hold withdrawal amount", err, "walletID", walletUser.WalletID)
+			return err
+		}
+		withdrawal.WalletHoldID = hold.WalletHoldID
+
+		_, err = s.repo.CreateWithdrawal(ctx, withdrawal)
+		if err != nil {
+			log.ZError(ctx, "while create withdrawal", err, "walletID", walletUser.WalletID)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.ZError(ctx, "while request withdrawal", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", withdrawal.WalletID),
+		attribute.Int64("walletWithdrawalRequestID", withdrawal.WalletWithdrawalRequestID),
+		attribute.String("amount", withdrawal.Amount.String()),
+	)
+
+	return dtoWithdrawalResponse(withdrawal), nil
+}
+
+func (s *WithdrawalSvcImpl) ApproveWithdrawal(
+	ctx context.Context, req *domain.ProcessWithdrawalRequest,
+) (resp *domain.WithdrawalResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return s.processWithdrawal(ctx, req, entity.StatusWithdrawalApproved)
+}
+
+func (s *WithdrawalSvcImpl) RejectWithdrawal(
+	ctx context.Context, req *domain.ProcessWithdrawalRequest,
+) (resp *domain.WithdrawalResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if req.Reason == "" {
+		return resp, errors.New("reason is required")
+	}
+
+	return s.processWithdrawal(ctx, req, entity.StatusWithdrawalRejected)
+}
+
+func (s *WithdrawalSvcImpl) MarkWithdrawalPaid(
+	ctx context.Context, req *domain.ProcessWithdrawalRequest,
+) (resp *domain.WithdrawalResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return s.processWithdrawal(ctx, req, entity.StatusWithdrawalPaid)
+}
+
+// processWithdrawal moves a withdrawal to status under its row lock, taken before the wallet
+// and hold rows the hold usecase locks.
+func (s *WithdrawalSvcImpl) processWithdrawal(
+	ctx context.Context, req *domain.ProcessWithdrawalRequest, status entity.StatusWithdrawal,
+) (resp *domain.WithdrawalResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		return resp, err
+	}
+
+	var withdrawal *entity.WalletWithdrawalRequest
+	err = s.txRepo.Do(ctx, func(ctx context.Context, tx *gorm.DB) error {
+		withdrawal, err = s.repo.GetWithdrawalForUpdate(ctx, req.WalletWithdrawalRequestID)
+		if err != nil {
+			log.ZError(ctx, "while lock withdrawal", err, "walletWithdrawalRequestID", req.WalletWithdrawalRequestID)
+			return err
+		}
+
+		now := time.Now()
+		switch status {
+		case entity.StatusWithdrawalApproved:
+			err = s.approve(ctx, tx, withdrawal, now)
+		case entity.StatusWithdrawalRejected:
+			err = s.reject(ctx, withdrawal, req, now)
+		default:
+			err = s.markPaid(ctx, withdrawal, req, now)
+		}
+		if err != nil {
+			return err
+		}
+
+		withdrawal.OperatedBy = &req.OperatedBy
+		withdrawal.UpdatedBy = req.OperatedBy
+		err = s.repo.UpdateWithdrawal(ctx, &entity.WalletWithdrawalRequest{
+			WalletWithdrawalRequestID: withdrawal.WalletWithdrawalRequestID,
+			StatusWithdrawal:          withdrawal.StatusWithdrawal,
+			RejectReason:              withdrawal.RejectReason,
+			WalletTransactionID:       withdrawal.WalletTransactionID,
+			ApprovedAt:                withdrawal.ApprovedAt,
+			RejectedAt:                withdrawal.RejectedAt,
+			PaidAt:                    withdrawal.PaidAt,
+			OperatedBy:                withdrawal.OperatedBy,
+			UpdatedBy:                 withdrawal.UpdatedBy,
+		})
+		if err != nil {
+			log.ZError(ctx, "while update withdrawal", err, "walletWithdrawalRequestID", withdrawal.WalletWithdrawalRequestID)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.ZError(ctx, "while process withdrawal", err, "request", req, "status", status)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.Int64("walletID", withdrawal.WalletID),
+		attribute.Int64("walletWithdrawalRequestID", withdrawal.WalletWithdrawalRequestID),
+		attribute.String("statusWithdrawal", withdrawal.StatusWithdrawal.String()),
+	)
+
+	return dtoWithdrawalResponse(withdrawal), nil
+}
+
+// approve accepts a requested withdrawal, the wallet must still allow money out of it.
+func (s *WithdrawalSvcImpl) approve(
+	ctx context.Context, tx *gorm.DB, withdrawal *entity.WalletWithdrawalRequest, now time.Time,
+) (err error) {
+	if withdrawal.StatusWithdrawal != entity.StatusWithdrawalRequested {
+		return eerrs.ErrWithdrawalStatusConflict
+	}
+
+	var walletUser *entity.Wallet
+	walletUser, err = s.walletRepo.GetWalletByWalletIDTx(ctx, tx, withdrawal.WalletID)
+	if err != nil {
+		log.ZError(ctx, "while GetWalletByWalletIDTx", err, "walletID", withdrawal.WalletID)
+		return err
+	}
+	if err = validateWalletDebit(walletUser); err != nil {
+		return err
+	}
+
+	withdrawal.StatusWithdrawal = entity.StatusWithdrawalApproved
+	withdrawal.ApprovedAt = &now
+
+	return nil
+}
+
+func (s *WithdrawalSvcImpl) reject(
+	ctx context.Context, withdrawal *entity.WalletWithdrawalRequest, req *domain.ProcessWithdrawalRequest, now time.Time,
+) (err error) {
+	if withdrawal.StatusWithdrawal != entity.StatusWithdrawalRequested &&
+		withdrawal.StatusWithdrawal != entity.StatusWithdrawalApproved {
+		return eerrs.ErrWithdrawalStatusConflict
+	}
+
+	_, err = s.holdUc.ReleaseHold(ctx, &domain.WalletHoldActionRequest{
+		WalletHoldID: withdrawal.WalletHoldID,
+		OperatedBy:   req.OperatedBy,
+	})
+	if err != nil {
+		log.ZError(ctx, "while release withdrawal hold", err, "walletHoldID", withdrawal.WalletHoldID)
+		return err
+	}
+
+	withdrawal.StatusWithdrawal = entity.StatusWithdrawalRejected
+	withdrawal.RejectReason = req.Reason
+	withdrawal.RejectedAt = &now
+
+	return nil
+}
+
+func (s *WithdrawalSvcImpl) markPaid(
+	ctx context.Context, withdrawal *entity.WalletWithdrawalRequest, req *domain.ProcessWithdrawalRequest, now time.Time,
+) (err error) {
+	if withdrawal.StatusWithdrawal != entity.StatusWithdrawalApproved {
+		return eerrs.ErrWithdrawalStatusConflict
+	}
+
+	var hold *domain.WalletHoldResponse
+	hold, err = s.holdUc.CaptureHold(ctx, &domain.WalletHoldActionRequest{
+		WalletHoldID: withdrawal.WalletHoldID,
+		OperatedBy:   req.OperatedBy,
+		Capture: &domain.CreateTransactionReq{
+			TransactionType: entity.TransactionTypeWithdrawal.String(),
+			ImpactedItem:    withdrawal.WalletWithdrawalRequestID,
+			ReferenceCode: fmt.Sprintf(
+				"#WDR-%s-%d-%d", nowToStringYYYYMMDD(), withdrawal.WalletID, withdrawal.WalletWithdrawalRequestID,
+			),
+			DescriptionEn: "Withdrawal to " + withdrawal.Destination,
+			DescriptionZh: "提現至 " + withdrawal.Destination,
+		},
+	})
+	if err != nil {
+		log.ZError(ctx, "while capture withdrawal hold", err, "walletHoldID", withdrawal.WalletHoldID)
+		return err
+	}
+
+	withdrawal.StatusWithdrawal = entity.StatusWithdrawalPaid
+	withdrawal.WalletTransactionID = hold.WalletTransactionID
+	withdrawal.PaidAt = &now
+
+	return nil
+}
+
+func (s *WithdrawalSvcImpl) GetListWithdrawal(
+	ctx context.Context, req *domain.GetListWithdrawalRequest,
+) (resp *domain.GetListWithdrawalResponse, err error) {
+	var (
+		funcName = tracer.GetFullFunctionPath()
+		t        = otel.Tracer(tracer.LevelUsecase)
+	)
+
+	ctx, span := t.Start(ctx, funcName)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = req.Validate(); err != nil {
+		log.ZError(ctx, "while validate request", err, "request", req)
+		return resp, err
+	}
+
+	if req.UserID != "" {
+		var currency entity.Currency
+		currency, err = entity.ParseCurrency(req.Currency)
+		if err != nil {
+			return resp, err
+		}
+
+		var walletUser *entity.Wallet
+		walletUser, err = s.walletRepo.GetWalletByUserID(ctx, req.UserID, currency)
+		if err != nil {
+			log.ZError(ctx, "while get wallet target user", err, "UserID", req.UserID)
+			return resp, err
+		}
+
+		req.WalletID = walletUser.WalletID
+	}
+
+	var (
+		withdrawals []*entity.WalletWithdrawalRequest
+		total       int64
+	)
+	withdrawals, total, err = s.repo.GetListWithdrawal(ctx, req)
+	if err != nil {
+		log.ZError(ctx, "while get list withdrawal", err, "request", req)
+		return resp, err
+	}
+
+	span.SetAttributes(
+		attribute.String("userID", req.UserID),
+		attribute.Int64("walletID", req.WalletID),
+		attribute.Int64("total", total),
+	)
+
+	return &domain.GetListWithdrawalResponse{
+		TotalCount:  total,
+		Page:        req.Page,
+		Limit:       req.Limit,
+		Withdrawals: dtoWithdrawals(withdrawals),
+	}, nil
+}
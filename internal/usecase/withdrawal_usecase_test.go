@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_usecase"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet"
+	"github.com/1nterdigital/aka-im-wallet/generated/mock/mock_wallet_withdrawal_request"
+	"github.com/1nterdigital/aka-im-wallet/internal/domain"
+	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
+	"github.com/1nterdigital/aka-im-wallet/pkg/eerrs"
+)
+
+func TestWithdrawal_RequestWithdrawal(t *testing.T) {
+	type expected struct {
+		resp *domain.WithdrawalResponse
+		err  error
+	}
+	testCases := []struct {
+		desc             string
+		request          *domain.CreateWithdrawalRequest
+		expected         expected
+		wantError        bool
+		onMockWalletRepo func(mock *mock_wallet.MockRepository)
+		onMockHoldUc     func(mock *mock_usecase.MockWalletHoldSvc)
+		onMockRepo       func(mock *mock_wallet_withdrawal_request.MockRepository)
+	}{
+		{
+			desc:      "destination_required",
+			request:   &domain.CreateWithdrawalRequest{UserID: "1", Amount: entity.NewMoney(50)},
+			expected:  expected{err: errors.New("destination is required")},
+			wantError: true,
+		},
+		{
+			desc:      "ErrWalletNotFound",
+			request:   &domain.CreateWithdrawalRequest{UserID: "1", Amount: entity.NewMoney(50), Destination: "bank 123"},
+			expected:  expected{err: eerrs.ErrWalletNotFound},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			desc:      "ErrWalletDebitFrozen",
+			request:   &domain.CreateWithdrawalRequest{UserID: "1", Amount: entity.NewMoney(50), Destination: "bank 123"},
+			expected:  expected{err: eerrs.ErrWalletDebitFrozen},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 7, FreezeStatus: entity.FreezeStatusDebitFrozen}, nil)
+			},
+		},
+		{
+			desc:      "ErrInsufficientBalance",
+			request:   &domain.CreateWithdrawalRequest{UserID: "1", Amount: entity.NewMoney(50), Destination: "bank 123"},
+			expected:  expected{err: eerrs.ErrInsufficientBalance},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyCNY).
+					Return(&entity.Wallet{WalletID: 7, FreezeStatus: entity.FreezeStatusNone}, nil)
+			},
+			onMockHoldUc: func(mock *mock_usecase.MockWalletHoldSvc) {
+				mock.EXPECT().CreateHold(gomock.Any(), gomock.Any()).Return(nil, eerrs.ErrInsufficientBalance)
+			},
+		},
+		{
+			desc: "success",
+			request: &domain.CreateWithdrawalRequest{
+				UserID: "1", Currency: "usd", Amount: entity.NewMoney(50), Destination: "bank 123",
+			},
+			expected: expected{resp: &domain.WithdrawalResponse{
+				WalletWithdrawalRequestID: 4,
+				WalletID:                  7,
+				StatusWithdrawal:          "requested",
+			}},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByUserID(gomock.Any(), "1", entity.CurrencyUSD).
+					Return(&entity.Wallet{WalletID: 7, Currency: entity.CurrencyUSD, FreezeStatus: entity.FreezeStatusNone}, nil)
+			},
+			onMockHoldUc: func(mock *mock_usecase.MockWalletHoldSvc) {
+				mock.EXPECT().
+					CreateHold(gomock.Any(), &domain.CreateWalletHoldRequest{
+						UserID:     "1",
+						Currency:   "USD",
+						Amount:     entity.NewMoney(50),
+						Reason:     "Withdrawal to bank 123",
+						OperatedBy: "1",
+					}).
+					Return(&domain.WalletHoldResponse{WalletHoldID: 3, WalletID: 7, StatusHold: "active"}, nil)
+			},
+			onMockRepo: func(mock *mock_wallet_withdrawal_request.MockRepository) {
+				mock.EXPECT().
+					CreateWithdrawal(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, withdrawal *entity.WalletWithdrawalRequest) (int64, error) {
+						if withdrawal.WalletHoldID != 3 || withdrawal.StatusWithdrawal != entity.StatusWithdrawalRequested {
+							return 0, errors.New("unexpected withdrawal")
+						}
+						withdrawal.WalletWithdrawalRequestID = 4
+						return 4, nil
+					})
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(walletRepo)
+			}
+			holdUc := mock_usecase.NewMockWalletHoldSvc(ctrl)
+			if tC.onMockHoldUc != nil {
+				tC.onMockHoldUc(holdUc)
+			}
+			repo := mock_wallet_withdrawal_request.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+
+			svc := NewWithdrawalUseCase(repo, walletRepo, newMockHoldTxRepo(ctrl), holdUc)
+
+			got, err := svc.RequestWithdrawal(context.Background(), tC.request)
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
+
+func TestWithdrawal_ProcessWithdrawal(t *testing.T) {
+	type expected struct {
+		resp *domain.WithdrawalResponse
+		err  error
+	}
+	withdrawalIn := func(status entity.StatusWithdrawal) *entity.WalletWithdrawalRequest {
+		return &entity.WalletWithdrawalRequest{
+			WalletWithdrawalRequestID: 4,
+			WalletID:                  7,
+			WalletHoldID:              3,
+			Amount:                    entity.NewMoney(50),
+			StatusWithdrawal:          status,
+			Destination:               "bank 123",
+		}
+	}
+	testCases := []struct {
+		desc             string
+		action           entity.StatusWithdrawal
+		request          *domain.ProcessWithdrawalRequest
+		expected         expected
+		wantError        bool
+		onMockWalletRepo func(mock *mock_wallet.MockRepository)
+		onMockHoldUc     func(mock *mock_usecase.MockWalletHoldSvc)
+		onMockRepo       func(mock *mock_wallet_withdrawal_request.MockRepository)
+	}{
+		{
+			desc:      "ErrWithdrawalNotFound",
+			action:    entity.StatusWithdrawalApproved,
+			request:   &domain.ProcessWithdrawalRequest{WalletWithdrawalRequestID: 4},
+			expected:  expected{err: eerrs.ErrWithdrawalNotFound},
+			wantError: true,
+			onMockRepo: func(mock *mock_wallet_withdrawal_request.MockRepository) {
+				mock.EXPECT().GetWithdrawalForUpdate(gomock.Any(), int64(4)).Return(nil, eerrs.ErrWithdrawalNotFound)
+			},
+		},
+		{
+			desc:      "approve_ErrWithdrawalStatusConflict",
+			action:    entity.StatusWithdrawalApproved,
+			request:   &domain.ProcessWithdrawalRequest{WalletWithdrawalRequestID: 4},
+			expected:  expected{err: eerrs.ErrWithdrawalStatusConflict},
+			wantError: true,
+			onMockRepo: func(mock *mock_wallet_withdrawal_request.MockRepository) {
+				mock.EXPECT().
+					GetWithdrawalForUpdate(gomock.Any(), int64(4)).
+					Return(withdrawalIn(entity.StatusWithdrawalRejected), nil)
+			},
+		},
+		{
+			desc:      "approve_ErrWalletDebitFrozen",
+			action:    entity.StatusWithdrawalApproved,
+			request:   &domain.ProcessWithdrawalRequest{WalletWithdrawalRequestID: 4},
+			expected:  expected{err: eerrs.ErrWalletDebitFrozen},
+			wantError: true,
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, FreezeStatus: entity.FreezeStatusFrozen}, nil)
+			},
+			onMockRepo: func(mock *mock_wallet_withdrawal_request.MockRepository) {
+				mock.EXPECT().
+					GetWithdrawalForUpdate(gomock.Any(), int64(4)).
+					Return(withdrawalIn(entity.StatusWithdrawalRequested), nil)
+			},
+		},
+		{
+			desc:     "approve_success",
+			action:   entity.StatusWithdrawalApproved,
+			request:  &domain.ProcessWithdrawalRequest{WalletWithdrawalRequestID: 4, OperatedBy: "admin"},
+			expected: expected{resp: &domain.WithdrawalResponse{WalletWithdrawalRequestID: 4, WalletID: 7, StatusWithdrawal: "approved"}},
+			onMockWalletRepo: func(mock *mock_wallet.MockRepository) {
+				mock.EXPECT().
+					GetWalletByWalletIDTx(gomock.Any(), gomock.Any(), int64(7)).
+					Return(&entity.Wallet{WalletID: 7, FreezeStatus: entity.FreezeStatusNone}, nil)
+			},
+			onMockRepo: func(mock *mock_wallet_withdrawal_request.MockRepository) {
+				mock.EXPECT().
+					GetWithdrawalForUpdate(gomock.Any(), int64(4)).
+					Return(withdrawalIn(entity.StatusWithdrawalRequested), nil)
+				mock.EXPECT().UpdateWithdrawal(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			desc:      "reject_reason_required",
+			action:    entity.StatusWithdrawalRejected,
+			request:   &domain.ProcessWithdrawalRequest{WalletWithdrawalRequestID: 4},
+			expected:  expected{err: errors.New("reason is required")},
+			wantError: true,
+		},
+		{
+			desc:      "reject_ErrWithdrawalStatusConflict_paid",
+			action:    entity.StatusWithdrawalRejected,
+			request:   &domain.ProcessWithdrawalRequest{WalletWithdrawalRequestID: 4, Reason: "wrong account"},
+			expected:  expected{err: eerrs.ErrWithdrawalStatusConflict},
+			wantError: true,
+			onMockRepo: func(mock *mock_wallet_withdrawal_request.MockRepository) {
+				mock.EXPECT().
+					GetWithdrawalForUpdate(gomock.Any(), int64(4)).
+					Return(withdrawalIn(entity.StatusWithdrawalPaid), nil)
+			},
+		},
+		{
+			desc:     "reject_success",
+			action:   entity.StatusWithdrawalRejected,
+			request:  &domain.ProcessWithdrawalRequest{WalletWithdrawalRequestID: 4, Reason: "wrong account", OperatedBy: "admin"},
+			expected: expected{resp: &domain.WithdrawalResponse{WalletWithdrawalRequestID: 4, WalletID: 7, StatusWithdrawal: "rejected"}},
+			onMockHoldUc: func(mock *mock_usecase.MockWalletHoldSvc) {
+				mock.EXPECT().
+					ReleaseHold(gomock.Any(), &domain.WalletHoldActionRequest{WalletHoldID: 3, OperatedBy: "admin"}).
+					Return(&domain.WalletHoldResponse{WalletHoldID: 3, WalletID: 7, StatusHold: "released"}, nil)
+			},
+			onMockRepo: func(mock *mock_wallet_withdrawal_request.MockRepository) {
+				mock.EXPECT().
+					GetWithdrawalForUpdate(gomock.Any(), int64(4)).
+					Return(withdrawalIn(entity.StatusWithdrawalApproved), nil)
+				mock.EXPECT().
+					UpdateWithdrawal(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, withdrawal *entity.WalletWithdrawalRequest) error {
+						if withdrawal.RejectReason != "wrong account" || withdrawal.RejectedAt == nil {
+							return errors.New("unexpected withdrawal update")
+						}
+						return nil
+					})
+			},
+		},
+		{
+			desc:      "mark_paid_ErrWithdrawalStatusConflict_requested",
+			action:    entity.StatusWithdrawalPaid,
+			request:   &domain.ProcessWithdrawalRequest{WalletWithdrawalRequestID: 4},
+			expected:  expected{err: eerrs.ErrWithdrawalStatusConflict},
+			wantError: true,
+			onMockRepo: func(mock *mock_wallet_withdrawal_request.MockRepository) {
+				mock.EXPECT().
+					GetWithdrawalForUpdate(gomock.Any(), int64(4)).
+					Return(withdrawalIn(entity.StatusWithdrawalRequested), nil)
+			},
+		},
+		{
+			desc:    "mark_paid_success",
+			action:  entity.StatusWithdrawalPaid,
+			request: &domain.ProcessWithdrawalRequest{WalletWithdrawalRequestID: 4, OperatedBy: "admin"},
+			expected: expected{resp: &domain.WithdrawalResponse{
+				WalletWithdrawalRequestID: 4,
+				WalletID:                  7,
+				StatusWithdrawal:          "paid",
+				WalletTransactionID:       int64Ptr(11),
+			}},
+			onMockHoldUc: func(mock *mock_usecase.MockWalletHoldSvc) {
+				mock.EXPECT().
+					CaptureHold(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *domain.WalletHoldActionRequest) (*domain.WalletHoldResponse, error) {
+						if req.WalletHoldID != 3 || req.Capture == nil ||
+							req.Capture.TransactionType != "withdrawal" || req.Capture.ImpactedItem != 4 {
+							return nil, errors.New("unexpected capture")
+						}
+						return &domain.WalletHoldResponse{
+							WalletHoldID: 3, WalletID: 7, StatusHold: "captured", WalletTransactionID: int64Ptr(11),
+						}, nil
+					})
+			},
+			onMockRepo: func(mock *mock_wallet_withdrawal_request.MockRepository) {
+				mock.EXPECT().
+					GetWithdrawalForUpdate(gomock.Any(), int64(4)).
+					Return(withdrawalIn(entity.StatusWithdrawalApproved), nil)
+				mock.EXPECT().UpdateWithdrawal(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			walletRepo := mock_wallet.NewMockRepository(ctrl)
+			if tC.onMockWalletRepo != nil {
+				tC.onMockWalletRepo(walletRepo)
+			}
+			holdUc := mock_usecase.NewMockWalletHoldSvc(ctrl)
+			if tC.onMockHoldUc != nil {
+				tC.onMockHoldUc(holdUc)
+			}
+			repo := mock_wallet_withdrawal_request.NewMockRepository(ctrl)
+			if tC.onMockRepo != nil {
+				tC.onMockRepo(repo)
+			}
+
+			svc := NewWithdrawalUseCase(repo, walletRepo, newMockHoldTxRepo(ctrl), holdUc)
+
+			var (
+				got *domain.WithdrawalResponse
+				err error
+			)
+			switch tC.action {
+			case entity.StatusWithdrawalApproved:
+				got, err = svc.ApproveWithdrawal(context.Background(), tC.request)
+			case entity.StatusWithdrawalRejected:
+				got, err = svc.RejectWithdrawal(context.Background(), tC.request)
+			default:
+				got, err = svc.MarkWithdrawalPaid(context.Background(), tC.request)
+			}
+			if !tC.wantError {
+				require.NoError(t, err)
+				assert.Equal(t, tC.expected.resp, got)
+			} else {
+				require.Error(t, err)
+				assert.Equal(t, tC.expected.err.Error(), err.Error())
+			}
+		})
+	}
+}
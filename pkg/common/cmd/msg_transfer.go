@@ -29,6 +29,8 @@ func NewMsgTransferCmd() *MsgTransferCmd {
 		config.MysqlConfigFileName:     &msgTransferConfig.MysqlConfig,
 		config.ShareFileName:           &msgTransferConfig.Share,
 		config.DiscoveryConfigFileName: &msgTransferConfig.Discovery,
+		config.LimitsCfgFileName:       &msgTransferConfig.LimitsConfig,
+		config.RiskCfgFileName:         &msgTransferConfig.RiskConfig,
 	}
 	ret.RootCmd = NewRootCmd(program.GetProcessName(), WithConfigMap(ret.configMap))
 	ret.ctx = context.WithValue(context.Background(), constant.ContextKeyVersion, version.Version)
@@ -35,7 +35,7 @@ func NewPublisherCmd() *PublisherCmd {
 		(*string)(&publisherConfig.Key),
 		"key",
 		"",
-		"publisher key (e.g. refundTransferEnvelope)",
+		"publisher key (e.g. refundTransferEnvelope, reconciliation, scheduledTransfer)",
 	)
 
 	ret.Command.RunE = func(_ *cobra.Command, _ []string) error {
@@ -32,6 +32,8 @@ func NewWalletApiCmd() *WalletApiCmd {
 		config.MysqlConfigFileName: &ret.apiConfig.MysqlConfig,
 		config.KafkaConfigFileName: &ret.apiConfig.KafkaConfig,
 		config.TracerCfgFileName:   &ret.apiConfig.TracerConfig,
+		config.LimitsCfgFileName:   &ret.apiConfig.LimitsConfig,
+		config.RiskCfgFileName:     &ret.apiConfig.RiskConfig,
 	}
 	ret.RootCmd = NewRootCmd(program.GetProcessName(), WithConfigMap(ret.configMap))
 	ret.ctx = context.WithValue(context.Background(), constant.ContextKeyVersion, config.Version)
@@ -154,10 +154,27 @@ type Share struct {
 		Secret      string `mapstructure:"secret"`
 		AdminUserID string `mapstructure:"adminUserID"`
 	} `mapstructure:"AkaIM"`
-	WalletAdmin []string `mapstructure:"walletAdmin"`
-	ProxyHeader string   `mapstructure:"proxyHeader"`
-	DBOption    string   `mapstructure:"dbOption"`
+	WalletAdmin []string   `mapstructure:"walletAdmin"`
+	ProxyHeader string     `mapstructure:"proxyHeader"`
+	DBOption    string     `mapstructure:"dbOption"`
+	Approval    Approval   `mapstructure:"approval"`
+	PaymentPin  PaymentPin `mapstructure:"paymentPin"`
 }
+
+// Approval configures maker-checker approval of balance adjustments and admin deposits.
+type Approval struct {
+	// Threshold: amount in major units above which a second admin has to approve, empty disables it
+	Threshold string `mapstructure:"threshold"`
+}
+
+// PaymentPin configures the lockout of the payment PIN, unset values keep the built-in policy.
+type PaymentPin struct {
+	// MaxAttempts: wrong PINs in a row before the PIN locks
+	MaxAttempts int64 `mapstructure:"maxAttempts"`
+	// LockMinutes: how long a locked PIN stays locked, also the window the wrong PINs are counted in
+	LockMinutes int64 `mapstructure:"lockMinutes"`
+}
+
 type Admin struct {
 	TokenPolicy struct {
 		Expire int `mapstructure:"expire"`
@@ -179,16 +196,78 @@ type Publisher struct {
 	} `mapstructure:"prometheus"`
 }
 
+// Limits are the default wallet limits, per-tier and per-user overrides are kept in the database.
+// Amounts are in major units, a zero or empty value keeps the built-in default.
+type Limits struct {
+	Transfer struct {
+		SendPerDay    int64  `mapstructure:"sendPerDay"`
+		ClaimPerDay   int64  `mapstructure:"claimPerDay"`
+		MaxSendAmount string `mapstructure:"maxSendAmount"`
+		Expiry        Expiry `mapstructure:"expiry"`
+	} `mapstructure:"transfer"`
+	Envelope struct {
+		SendPerDay    int64  `mapstructure:"sendPerDay"`
+		ClaimPerDay   int64  `mapstructure:"claimPerDay"`
+		MaxSendAmount string `mapstructure:"maxSendAmount"`
+		Expiry        Expiry `mapstructure:"expiry"`
+	} `mapstructure:"envelope"`
+	Spend struct {
+		PerHour   string `mapstructure:"perHour"`
+		PerDay    string `mapstructure:"perDay"`
+		Per30Days string `mapstructure:"per30Days"`
+	} `mapstructure:"spend"`
+}
+
+// Expiry bounds the claim window a sender may pick, in minutes. DefaultMinutes applies when
+// the sender picks none.
+type Expiry struct {
+	DefaultMinutes int64 `mapstructure:"defaultMinutes"`
+	MinMinutes     int64 `mapstructure:"minMinutes"`
+	MaxMinutes     int64 `mapstructure:"maxMinutes"`
+}
+
+type Risk struct {
+	Enabled  bool `mapstructure:"enabled"`
+	Velocity struct {
+		Enabled       bool   `mapstructure:"enabled"`
+		WindowMinutes int64  `mapstructure:"windowMinutes"`
+		MaxCount      int64  `mapstructure:"maxCount"`
+		Decision      string `mapstructure:"decision"`
+	} `mapstructure:"velocity"`
+	NewWalletLargeAmount struct {
+		Enabled           bool   `mapstructure:"enabled"`
+		MaxWalletAgeHours int64  `mapstructure:"maxWalletAgeHours"`
+		Amount            string `mapstructure:"amount"`
+		Decision          string `mapstructure:"decision"`
+	} `mapstructure:"newWalletLargeAmount"`
+	CircularTransfer struct {
+		Enabled          bool   `mapstructure:"enabled"`
+		WindowMinutes    int64  `mapstructure:"windowMinutes"`
+		ReverseTransfers int64  `mapstructure:"reverseTransfers"`
+		Decision         string `mapstructure:"decision"`
+	} `mapstructure:"circularTransfer"`
+	ClaimCluster struct {
+		Enabled       bool   `mapstructure:"enabled"`
+		WindowMinutes int64  `mapstructure:"windowMinutes"`
+		MaxAccounts   int64  `mapstructure:"maxAccounts"`
+		Decision      string `mapstructure:"decision"`
+	} `mapstructure:"claimCluster"`
+}
+
 type Kafka struct {
-	Username                 string   `mapstructure:"username"`
-	Password                 string   `mapstructure:"password"`
-	ProducerAck              string   `mapstructure:"producerAck"`
-	CompressType             string   `mapstructure:"compressType"`
-	Address                  []string `mapstructure:"address"`
-	ToExpiredTransferTopic   string   `mapstructure:"toExpiredTransferTopic"`
-	ToExpiredEnvelopeTopic   string   `mapstructure:"toExpiredEnvelopeTopic"`
-	ToExpiredTransferGroupID string   `mapstructure:"toExpiredTransferGroupID"`
-	ToExpiredEnvelopeGroupID string   `mapstructure:"toExpiredEnvelopeGroupID"`
+	Username                   string   `mapstructure:"username"`
+	Password                   string   `mapstructure:"password"`
+	ProducerAck                string   `mapstructure:"producerAck"`
+	CompressType               string   `mapstructure:"compressType"`
+	Address                    []string `mapstructure:"address"`
+	ToExpiredTransferTopic     string   `mapstructure:"toExpiredTransferTopic"`
+	ToExpiredEnvelopeTopic     string   `mapstructure:"toExpiredEnvelopeTopic"`
+	ToExpiredTransferGroupID   string   `mapstructure:"toExpiredTransferGroupID"`
+	ToExpiredEnvelopeGroupID   string   `mapstructure:"toExpiredEnvelopeGroupID"`
+	ToScheduledTransferTopic   string   `mapstructure:"toScheduledTransferTopic"`
+	ToScheduledTransferGroupID string   `mapstructure:"toScheduledTransferGroupID"`
+	ToRecurringTransferTopic   string   `mapstructure:"toRecurringTransferTopic"`
+	ToRecurringTransferGroupID string   `mapstructure:"toRecurringTransferGroupID"`
 
 	Tls TLSConfig `mapstructure:"tls"`
 }
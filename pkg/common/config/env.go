@@ -15,6 +15,8 @@ var (
 	MsgTransferCfgFileName     = "msgtransfer.yml"
 	PublisherCfgFileName       = "publisher.yml"
 	TracerCfgFileName          = "tracer.yml"
+	LimitsCfgFileName          = "limits.yml"
+	RiskCfgFileName            = "risk.yml"
 	Publisss                   = "her.yml"
 )
 
@@ -34,6 +36,8 @@ func init() {
 		MsgTransferCfgFileName,
 		PublisherCfgFileName,
 		TracerCfgFileName,
+		LimitsCfgFileName,
+		RiskCfgFileName,
 		Publisss,
 	}
 
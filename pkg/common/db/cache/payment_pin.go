@@ -0,0 +1,88 @@
+//go:generate mockgen -source=$GOFILE -destination=$PROJECT_DIR/generated/mock/mock_$GOPACKAGE/$GOFILE
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/1nterdigital/aka-im-tools/errs"
+)
+
+const CacheKeyPaymentPinFailures = "WALLET_PAYMENT_PIN_FAILURES:"
+
+// PaymentPinAttemptInterface counts the wrong payment PINs a user entered in a row.
+type PaymentPinAttemptInterface interface {
+	// GetFailures returns the wrong PINs counted so far and how long they are still counted.
+	GetFailures(ctx context.Context, userID string) (failures int64, ttl time.Duration, err error)
+	// IncrFailures counts one more attempt before the PIN is checked, a right PIN resets the
+	// count and otherwise it is forgotten window after the last one.
+	IncrFailures(ctx context.Context, userID string, window time.Duration) (failures int64, err error)
+	ResetFailures(ctx context.Context, userID string) error
+}
+
+type PaymentPinAttemptRedis struct {
+	rdb redis.UniversalClient
+}
+
+func NewPaymentPinAttempt(rdb redis.UniversalClient) *PaymentPinAttemptRedis {
+	return &PaymentPinAttemptRedis{rdb: rdb}
+}
+
+func (p *PaymentPinAttemptRedis) GetFailures(
+	ctx context.Context, userID string,
+) (failures int64, ttl time.Duration, err error) {
+	key := CacheKeyPaymentPinFailures + userID
+
+	var (
+		get    *redis.StringCmd
+		getTTL *redis.DurationCmd
+	)
+	_, err = p.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		getTTL = pipe.TTL(ctx, key)
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, 0, errs.Wrap(err)
+	}
+
+	failures, err = get.Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, errs.Wrap(err)
+	}
+
+	return failures, getTTL.Val(), nil
+}
+
+func (p *PaymentPinAttemptRedis) IncrFailures(
+	ctx context.Context, userID string, window time.Duration,
+) (failures int64, err error) {
+	key := CacheKeyPaymentPinFailures + userID
+
+	var incr *redis.IntCmd
+	_, err = p.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+
+	return incr.Val(), nil
+}
+
+func (p *PaymentPinAttemptRedis) ResetFailures(ctx context.Context, userID string) error {
+	if err := p.rdb.Del(ctx, CacheKeyPaymentPinFailures+userID).Err(); err != nil {
+		return errs.Wrap(err)
+	}
+
+	return nil
+}
@@ -2,8 +2,12 @@ package db
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 
 	entity "github.com/1nterdigital/aka-im-wallet/internal/model"
 )
@@ -17,16 +21,255 @@ func InitiateTable(gormDB *gorm.DB) error {
 		&entity.WalletRechargeRequest{},
 		&entity.Transfer{},
 		&entity.BalanceAdjustments{},
+		&entity.IdempotencyKey{},
+		&entity.JournalEntry{},
+		&entity.ReconciliationRun{},
+		&entity.ReconciliationDiscrepancy{},
+		&entity.WalletFreezeHistory{},
+		&entity.WalletHold{},
+		&entity.TransactionReversal{},
+		&entity.WalletWithdrawalRequest{},
+		&entity.ApprovalRequest{},
+		&entity.LimitOverride{},
+		&entity.RiskDecision{},
+		&entity.ScheduledTransfer{},
+		&entity.RecurringTransfer{},
+		&entity.RecurringTransferRun{},
+		&entity.PaymentRequest{},
+		&entity.TransferBatch{},
+		&entity.TransferBatchItem{},
+		&entity.PaymentPin{},
+		&entity.PaymentPinAudit{},
 	}
 
+	hasJournal := gormDB.Migrator().HasTable(&entity.JournalEntry{})
+
 	for _, model := range models {
 		if !gormDB.Migrator().HasTable(model) {
 			err := gormDB.AutoMigrate(model)
 			if err != nil {
 				return fmt.Errorf("failed to migrate database: %w", err)
 			}
+
+			continue
+		}
+
+		err := MigrateMoneyColumns(gormDB, model)
+		if err != nil {
+			return fmt.Errorf("failed to migrate money columns: %w", err)
+		}
+
+		err = MigrateAddedColumns(gormDB, model)
+		if err != nil {
+			return fmt.Errorf("failed to migrate added columns: %w", err)
+		}
+
+		err = MigrateEnumColumns(gormDB, model)
+		if err != nil {
+			return fmt.Errorf("failed to migrate enum columns: %w", err)
+		}
+	}
+
+	if err := MigrateWalletUniqueIndex(gormDB); err != nil {
+		return fmt.Errorf("failed to migrate wallet unique index: %w", err)
+	}
+
+	if !hasJournal {
+		if err := BackfillJournalEntries(gormDB); err != nil {
+			return fmt.Errorf("failed to backfill journal entries: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// BackfillJournalEntries books the counter-entry of every wallet transaction written
+// before the journal existed, so the ledger balances from the first day on.
+func BackfillJournalEntries(gormDB *gorm.DB) error {
+	counterAccounts := entity.CounterAccounts()
+	transactionTypes := make([]string, 0, len(counterAccounts))
+	for transactionType := range counterAccounts {
+		transactionTypes = append(transactionTypes, transactionType.String())
+	}
+	sort.Strings(transactionTypes)
+
+	var (
+		caseAccount strings.Builder
+		args        []interface{}
+	)
+	caseAccount.WriteString("CASE wt.transaction_type")
+	for _, transactionType := range transactionTypes {
+		caseAccount.WriteString(" WHEN ? THEN ?")
+		args = append(args, transactionType, counterAccounts[entity.TransactionType(transactionType)].String())
+	}
+	caseAccount.WriteString(" END")
+
+	return gormDB.Exec(`
+		INSERT INTO journal_entries (wallet_transaction_id, system_account, currency, amount, created_at, created_by)
+		SELECT wt.wallet_transaction_id, `+caseAccount.String()+`, wt.currency, -wt.amount, wt.created_at, 'migration'
+		FROM wallet_transactions wt
+		LEFT JOIN journal_entries je ON je.wallet_transaction_id = wt.wallet_transaction_id
+		WHERE je.journal_entry_id IS NULL AND wt.deleted_at IS NULL`,
+		args...,
+	).Error
+}
+
+// MigrateMoneyColumns converts the columns of an existing table that hold entity.Money
+// values to entity.MoneyColumnType. Tables created before amounts were stored as exact
+// decimals use DOUBLE columns, MySQL rounds every existing value to the nearest cent
+// while altering the column, so no row has to be rewritten by hand.
+func MigrateMoneyColumns(gormDB *gorm.DB, model interface{}) error {
+	stmt := &gorm.Statement{DB: gormDB}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+
+	columnTypes, err := gormDB.Migrator().ColumnTypes(model)
+	if err != nil {
+		return err
+	}
+
+	currentTypes := make(map[string]string, len(columnTypes))
+	for _, columnType := range columnTypes {
+		currentType, _ := columnType.ColumnType()
+		currentTypes[columnType.Name()] = strings.ToLower(currentType)
+	}
+
+	moneyType := reflect.TypeOf(entity.Money(0))
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" || field.FieldType != moneyType {
+			continue
+		}
+
+		currentType, exist := currentTypes[field.DBName]
+		if !exist || currentType == entity.MoneyColumnType {
+			continue
+		}
+
+		err = gormDB.Migrator().AlterColumn(model, field.Name)
+		if err != nil {
+			return fmt.Errorf("alter column %s.%s: %w", stmt.Schema.Table, field.DBName, err)
 		}
 	}
 
 	return nil
 }
+
+// MigrateAddedColumns adds the columns a model gained after its table was created, such as
+// the currency of every money-moving table or the freeze status of a wallet, together with
+// the indexes declared on them. Existing rows take the column default, so every added column
+// needs one or has to be nullable.
+func MigrateAddedColumns(gormDB *gorm.DB, model interface{}) error {
+	stmt := &gorm.Statement{DB: gormDB}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+
+	added := make(map[string]bool)
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" || gormDB.Migrator().HasColumn(model, field.DBName) {
+			continue
+		}
+
+		if err := gormDB.Migrator().AddColumn(model, field.Name); err != nil {
+			return fmt.Errorf("add column %s.%s: %w", stmt.Schema.Table, field.DBName, err)
+		}
+		added[field.DBName] = true
+	}
+
+	for _, index := range stmt.Schema.ParseIndexes() {
+		if !indexCoversAny(index, added) || gormDB.Migrator().HasIndex(model, index.Name) {
+			continue
+		}
+
+		if err := gormDB.Migrator().CreateIndex(model, index.Name); err != nil {
+			return fmt.Errorf("create index %s.%s: %w", stmt.Schema.Table, index.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func indexCoversAny(index *schema.Index, columns map[string]bool) bool {
+	for _, option := range index.Fields {
+		if option.Field != nil && columns[option.DBName] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MigrateEnumColumns widens the enum columns of an existing table to the values its model
+// declares, such as a transaction type or system account added later. MySQL reports enum
+// types without the spaces the GORM tags use, so both sides are compared without them.
+func MigrateEnumColumns(gormDB *gorm.DB, model interface{}) error {
+	stmt := &gorm.Statement{DB: gormDB}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+
+	columnTypes, err := gormDB.Migrator().ColumnTypes(model)
+	if err != nil {
+		return err
+	}
+
+	currentTypes := make(map[string]string, len(columnTypes))
+	for _, columnType := range columnTypes {
+		currentType, _ := columnType.ColumnType()
+		currentTypes[columnType.Name()] = normalizeColumnType(currentType)
+	}
+
+	for _, field := range stmt.Schema.Fields {
+		declaredType := normalizeColumnType(string(field.DataType))
+		if field.DBName == "" || !strings.HasPrefix(declaredType, "enum(") {
+			continue
+		}
+
+		currentType, exist := currentTypes[field.DBName]
+		if !exist || currentType == declaredType {
+			continue
+		}
+
+		err = gormDB.Migrator().AlterColumn(model, field.Name)
+		if err != nil {
+			return fmt.Errorf("alter column %s.%s: %w", stmt.Schema.Table, field.DBName, err)
+		}
+	}
+
+	return nil
+}
+
+func normalizeColumnType(columnType string) string {
+	return strings.ToLower(strings.ReplaceAll(columnType, " ", ""))
+}
+
+// MigrateWalletUniqueIndex replaces the unique constraint on wallets.user_id, from when a
+// user had a single wallet, with the unique index on (user_id, currency).
+func MigrateWalletUniqueIndex(gormDB *gorm.DB) error {
+	const uniqueIndex = "uq_wallet_user_currency"
+
+	wallet := &entity.Wallet{}
+	if gormDB.Migrator().HasIndex(wallet, uniqueIndex) {
+		return nil
+	}
+
+	var userIDIndexes []string
+	err := gormDB.Raw(`
+		SELECT index_name FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = 'wallets' AND non_unique = 0
+		GROUP BY index_name
+		HAVING COUNT(*) = 1 AND MAX(column_name) = 'user_id'`,
+	).Scan(&userIDIndexes).Error
+	if err != nil {
+		return err
+	}
+
+	for _, index := range userIDIndexes {
+		if err = gormDB.Migrator().DropIndex(wallet, index); err != nil {
+			return fmt.Errorf("drop index wallets.%s: %w", index, err)
+		}
+	}
+
+	return gormDB.Migrator().CreateIndex(wallet, uniqueIndex)
+}
@@ -0,0 +1,153 @@
+package imapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/1nterdigital/aka-im-tools/mcontext"
+)
+
+const (
+	getAdminTokenPath       = "/auth/get_admin_token"
+	getGroupsInfoPath       = "/group/get_groups_info"
+	getGroupMembersInfoPath = "/group/get_group_members_info"
+	sendMsgPath             = "/msg/send_msg"
+
+	callTimeout        = 10 * time.Second
+	tokenRefreshMargin = 5 * time.Minute
+
+	// groupStatusDismissed is the status the IM server gives a group after its owner dismissed it
+	groupStatusDismissed = 2
+
+	// a plain text message in a one to one chat, sent from the admin platform
+	contentTypeText       = 101
+	sessionTypeSingleChat = 1
+	platformIDAdmin       = 10
+)
+
+var ErrGroupNotFound = errors.New("im group not found")
+
+type (
+	apiResponse struct {
+		ErrCode int             `json:"errCode"`
+		ErrMsg  string          `json:"errMsg"`
+		ErrDlt  string          `json:"errDlt"`
+		Data    json.RawMessage `json:"data"`
+	}
+
+	getAdminTokenReq struct {
+		Secret string `json:"secret"`
+		UserID string `json:"userID"`
+	}
+
+	getAdminTokenResp struct {
+		Token             string `json:"token"`
+		ExpireTimeSeconds int64  `json:"expireTimeSeconds"`
+	}
+
+	getGroupsInfoReq struct {
+		GroupIDs []string `json:"groupIDs"`
+	}
+
+	getGroupsInfoResp struct {
+		GroupInfos []struct {
+			GroupID     string `json:"groupID"`
+			MemberCount int64  `json:"memberCount"`
+			Status      int32  `json:"status"`
+		} `json:"groupInfos"`
+	}
+
+	getGroupMembersInfoReq struct {
+		GroupID string   `json:"groupID"`
+		UserIDs []string `json:"userIDs"`
+	}
+
+	getGroupMembersInfoResp struct {
+		Members []struct {
+			UserID string `json:"userID"`
+		} `json:"members"`
+	}
+
+	sendMsgReq struct {
+		SendID           string            `json:"sendID"`
+		RecvID           string            `json:"recvID"`
+		SenderPlatformID int32             `json:"senderPlatformID"`
+		Content          map[string]string `json:"content"`
+		ContentType      int32             `json:"contentType"`
+		SessionType      int32             `json:"sessionType"`
+	}
+)
+
+// call posts req to an admin endpoint of the IM API.
+func call[Resp, Req any](ctx context.Context, c *Caller, path string, req *Req) (resp *Resp, err error) {
+	token, err := c.adminToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return post[Resp](ctx, c, path, token, req)
+}
+
+func post[Resp, Req any](ctx context.Context, c *Caller, path, token string, req *Req) (resp *Resp, err error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.imApi+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("operationID", operationID(ctx))
+	if token != "" {
+		httpReq.Header.Set("token", token)
+	}
+
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("im api %s: %w", path, err)
+	}
+	defer httpResp.Body.Close()
+
+	raw, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("im api %s: %w", path, err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("im api %s: status %d", path, httpResp.StatusCode)
+	}
+
+	var apiResp apiResponse
+	if err = json.Unmarshal(raw, &apiResp); err != nil {
+		return nil, fmt.Errorf("im api %s: %w", path, err)
+	}
+	if apiResp.ErrCode != 0 {
+		return nil, fmt.Errorf("im api %s: %d %s %s", path, apiResp.ErrCode, apiResp.ErrMsg, apiResp.ErrDlt)
+	}
+
+	resp = new(Resp)
+	if len(apiResp.Data) > 0 {
+		if err = json.Unmarshal(apiResp.Data, resp); err != nil {
+			return nil, fmt.Errorf("im api %s: %w", path, err)
+		}
+	}
+
+	return resp, nil
+}
+
+// operationID carries the operation of the wallet request over to the IM server, which
+// rejects calls without one.
+func operationID(ctx context.Context) string {
+	if opid := mcontext.GetOperationID(ctx); opid != "" {
+		return opid
+	}
+
+	return fmt.Sprintf("wallet-%d", time.Now().UnixNano())
+}
@@ -1,16 +1,32 @@
 package imapi
 
 import (
+	"context"
+	"net/http"
 	"sync"
+	"time"
 )
 
-type CallerInterface interface{}
+// CallerInterface is what the wallet asks the IM server about its users.
+type CallerInterface interface {
+	// GetGroupMemberCount returns how many members an IM group has, ErrGroupNotFound when the
+	// group does not exist or was dismissed.
+	GetGroupMemberCount(ctx context.Context, groupID string) (count int64, err error)
+	IsGroupMember(ctx context.Context, groupID, userID string) (ok bool, err error)
+	// FilterGroupMembers returns those of userIDs that are members of the IM group.
+	FilterGroupMembers(ctx context.Context, groupID string, userIDs []string) (memberIDs []string, err error)
+	// SendTextMessage sends text to the user in a one to one chat from the admin user.
+	SendTextMessage(ctx context.Context, recvID, text string) (err error)
+}
 
 type Caller struct {
 	imApi           string
 	imSecret        string
 	defaultIMUserID string
+	client          *http.Client
 	lock            sync.RWMutex
+	token           string
+	tokenExpireAt   time.Time
 }
 
 func New(imApi, imSecret, defaultIMUserID string) (caller CallerInterface) {
@@ -18,6 +34,96 @@ func New(imApi, imSecret, defaultIMUserID string) (caller CallerInterface) {
 		imApi:           imApi,
 		imSecret:        imSecret,
 		defaultIMUserID: defaultIMUserID,
+		client:          &http.Client{Timeout: callTimeout},
 		lock:            sync.RWMutex{},
 	}
 }
+
+func (c *Caller) GetGroupMemberCount(ctx context.Context, groupID string) (count int64, err error) {
+	resp, err := call[getGroupsInfoResp](ctx, c, getGroupsInfoPath, &getGroupsInfoReq{GroupIDs: []string{groupID}})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, group := range resp.GroupInfos {
+		if group.GroupID == groupID && group.Status != groupStatusDismissed {
+			return group.MemberCount, nil
+		}
+	}
+
+	return 0, ErrGroupNotFound
+}
+
+func (c *Caller) IsGroupMember(ctx context.Context, groupID, userID string) (ok bool, err error) {
+	memberIDs, err := c.FilterGroupMembers(ctx, groupID, []string{userID})
+	if err != nil {
+		return false, err
+	}
+
+	return len(memberIDs) == 1 && memberIDs[0] == userID, nil
+}
+
+func (c *Caller) FilterGroupMembers(ctx context.Context, groupID string, userIDs []string) (memberIDs []string, err error) {
+	resp, err := call[getGroupMembersInfoResp](ctx, c, getGroupMembersInfoPath, &getGroupMembersInfoReq{
+		GroupID: groupID,
+		UserIDs: userIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	asked := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		asked[userID] = true
+	}
+	for _, member := range resp.Members {
+		if asked[member.UserID] {
+			memberIDs = append(memberIDs, member.UserID)
+		}
+	}
+
+	return memberIDs, nil
+}
+
+func (c *Caller) SendTextMessage(ctx context.Context, recvID, text string) (err error) {
+	_, err = call[struct{}](ctx, c, sendMsgPath, &sendMsgReq{
+		SendID:           c.defaultIMUserID,
+		RecvID:           recvID,
+		SenderPlatformID: platformIDAdmin,
+		Content:          map[string]string{"content": text},
+		ContentType:      contentTypeText,
+		SessionType:      sessionTypeSingleChat,
+	})
+
+	return err
+}
+
+// adminToken returns the cached admin token of defaultIMUserID, a new one is fetched shortly
+// before the cached one expires.
+func (c *Caller) adminToken(ctx context.Context) (token string, err error) {
+	c.lock.RLock()
+	token, expireAt := c.token, c.tokenExpireAt
+	c.lock.RUnlock()
+	if token != "" && time.Now().Before(expireAt) {
+		return token, nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.token != "" && time.Now().Before(c.tokenExpireAt) {
+		return c.token, nil
+	}
+
+	resp, err := post[getAdminTokenResp](ctx, c, getAdminTokenPath, "", &getAdminTokenReq{
+		Secret: c.imSecret,
+		UserID: c.defaultIMUserID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	c.token = resp.Token
+	c.tokenExpireAt = time.Now().Add(time.Duration(resp.ExpireTimeSeconds)*time.Second - tokenRefreshMargin)
+
+	return c.token, nil
+}
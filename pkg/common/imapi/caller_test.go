@@ -0,0 +1,128 @@
+package imapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStubIMServer answers the IM API calls of the caller for group "g1" with members "1" and "2"
+// and the dismissed group "g2", counting the admin tokens it hands out. Only users "1" and "2"
+// can be sent messages.
+func newStubIMServer(t *testing.T, tokens *int) *httptest.Server {
+	reply := func(w http.ResponseWriter, data any) {
+		raw, err := json.Marshal(data)
+		require.NoError(t, err)
+		require.NoError(t, json.NewEncoder(w).Encode(apiResponse{Data: raw}))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(getAdminTokenPath, func(w http.ResponseWriter, r *http.Request) {
+		var req getAdminTokenReq
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.Secret != "secret" {
+			require.NoError(t, json.NewEncoder(w).Encode(apiResponse{ErrCode: 1001, ErrMsg: "ArgsError"}))
+			return
+		}
+		*tokens++
+		reply(w, getAdminTokenResp{Token: "admin-token", ExpireTimeSeconds: 3600})
+	})
+	mux.HandleFunc(getGroupsInfoPath, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "admin-token", r.Header.Get("token"))
+		assert.NotEmpty(t, r.Header.Get("operationID"))
+		reply(w, map[string]any{"groupInfos": []map[string]any{
+			{"groupID": "g1", "memberCount": 2, "status": 0},
+			{"groupID": "g2", "memberCount": 5, "status": groupStatusDismissed},
+		}})
+	})
+	mux.HandleFunc(getGroupMembersInfoPath, func(w http.ResponseWriter, r *http.Request) {
+		var req getGroupMembersInfoReq
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		members := []map[string]any{}
+		for _, userID := range req.UserIDs {
+			if req.GroupID == "g1" && (userID == "1" || userID == "2") {
+				members = append(members, map[string]any{"userID": userID})
+			}
+		}
+		reply(w, map[string]any{"members": members})
+	})
+
+	mux.HandleFunc(sendMsgPath, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "admin-token", r.Header.Get("token"))
+		var req sendMsgReq
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "imAdmin", req.SendID)
+		assert.Equal(t, int32(contentTypeText), req.ContentType)
+		assert.Equal(t, int32(sessionTypeSingleChat), req.SessionType)
+		assert.NotEmpty(t, req.Content["content"])
+		if req.RecvID != "1" && req.RecvID != "2" {
+			require.NoError(t, json.NewEncoder(w).Encode(apiResponse{ErrCode: 1004, ErrMsg: "RecordNotFoundError"}))
+			return
+		}
+		reply(w, map[string]any{"serverMsgID": "m1", "clientMsgID": "c1", "sendTime": 1})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestCaller_GetGroupMemberCount(t *testing.T) {
+	var tokens int
+	caller := New(newStubIMServer(t, &tokens).URL, "secret", "imAdmin")
+
+	count, err := caller.GetGroupMemberCount(context.Background(), "g1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	_, err = caller.GetGroupMemberCount(context.Background(), "g2")
+	assert.ErrorIs(t, err, ErrGroupNotFound)
+
+	_, err = caller.GetGroupMemberCount(context.Background(), "g3")
+	assert.ErrorIs(t, err, ErrGroupNotFound)
+
+	assert.Equal(t, 1, tokens, "the admin token is reused until it expires")
+}
+
+func TestCaller_IsGroupMember(t *testing.T) {
+	var tokens int
+	caller := New(newStubIMServer(t, &tokens).URL, "secret", "imAdmin")
+
+	ok, err := caller.IsGroupMember(context.Background(), "g1", "2")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = caller.IsGroupMember(context.Background(), "g1", "3")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	memberIDs, err := caller.FilterGroupMembers(context.Background(), "g1", []string{"1", "3", "2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, memberIDs)
+}
+
+func TestCaller_SendTextMessage(t *testing.T) {
+	var tokens int
+	caller := New(newStubIMServer(t, &tokens).URL, "secret", "imAdmin")
+
+	require.NoError(t, caller.SendTextMessage(context.Background(), "1", "hello"))
+
+	err := caller.SendTextMessage(context.Background(), "3", "hello")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1004 RecordNotFoundError")
+}
+
+func TestCaller_ErrCode(t *testing.T) {
+	var tokens int
+	caller := New(newStubIMServer(t, &tokens).URL, "wrong", "imAdmin")
+
+	_, err := caller.IsGroupMember(context.Background(), "g1", "1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1001 ArgsError")
+}
@@ -39,6 +39,7 @@ const (
 	ErrorCodeCurrencyMismatch
 	ErrCodeExceedGreetingLength
 	ErrCodeRedEnvelopeAmountRange
+	ErrorCodeInvalidCurrency
 )
 
 const (
@@ -58,6 +59,7 @@ const (
 	ErrorCodeUserAlreadyClaimedThisEnvelope
 	ErrorCodeNoRemainingAmountToRefund
 	ErrorCodeEnvelopeIDNotFoundParam
+	ErrorCodeAmountTooSmallToSplit
 )
 
 const (
@@ -78,4 +80,141 @@ const (
 	ErrorCodeNoEligibleTransfer
 	ErrorCodeSendingTransferDailyLimit
 	ErrorCodeClaimTransferDailyLimit
+	ErrorCodeTransferNotCancelable
+)
+
+const (
+	// Idempotency
+	ErrorCodeInvalidIdempotencyKey = 33001 + iota
+	ErrorCodeIdempotencyKeyConflict
+	ErrorCodeIdempotencyKeyInProgress
+)
+
+const (
+	// Reconciliation
+	ErrorCodeReconciliationRunNotFound = 34001 + iota
+	ErrorCodeInvalidDiscrepancyType
+)
+
+const (
+	// Wallet freeze
+	ErrorCodeWalletDebitFrozen = 35001 + iota
+	ErrorCodeWalletCreditFrozen
+	ErrorCodeInvalidFreezeType
+	ErrorCodeWalletFreezeUnchanged
+)
+
+const (
+	// Wallet hold
+	ErrorCodeWalletHoldNotFound = 36001 + iota
+	ErrorCodeWalletHoldNotActive
+	ErrorCodeInvalidHoldAmount
+)
+
+const (
+	// Transaction reversal
+	ErrorCodeTransactionNotFound = 37001 + iota
+	ErrorCodeTransactionAlreadyReversed
+	ErrorCodeTransactionNotReversible
+)
+
+const (
+	// Withdrawal
+	ErrorCodeWithdrawalNotFound = 38001 + iota
+	ErrorCodeInvalidStatusWithdrawal
+	ErrorCodeWithdrawalStatusConflict
+)
+
+const (
+	// Deposit request
+	ErrorCodeDepositNotFound = 39001 + iota
+	ErrorCodeDepositStatusConflict
+)
+
+const (
+	// Maker-checker approval
+	ErrorCodeApprovalRequestNotFound = 40001 + iota
+	ErrorCodeApprovalStatusConflict
+	ErrorCodeMakerCannotApprove
+	ErrorCodeInvalidStatusApproval
+	ErrorCodeInvalidApprovalOperation
+)
+
+const (
+	// Limits
+	ErrorCodeLimitOverrideNotFound = 41001 + iota
+	ErrorCodeInvalidLimitScope
+	ErrorCodeHourlySpendLimit
+	ErrorCodeDailySpendLimit
+	ErrorCode30DaySpendLimit
+)
+
+const (
+	// Risk rules
+	ErrorCodeRiskDenied = 42001 + iota
+	ErrorCodeInvalidRiskDecision
+	ErrorCodeInvalidRiskOperation
+)
+
+const (
+	// Scheduled transfer
+	ErrorCodeScheduledTransferNotFound = 43001 + iota
+	ErrorCodeScheduledTransferNotCancelable
+	ErrorCodeInvalidScheduleTime
+	ErrorCodeInvalidStatusScheduledTransfer
+)
+
+const (
+	// Recurring transfer
+	ErrorCodeRecurringTransferNotFound = 44001 + iota
+	ErrorCodeRecurringTransferStatusConflict
+	ErrorCodeInvalidRecurringFrequency
+	ErrorCodeInvalidRecurringSchedule
+	ErrorCodeInvalidStatusRecurringTransfer
+)
+
+const (
+	// Payment request
+	ErrorCodePaymentRequestNotFound = 45001 + iota
+	ErrorCodePaymentRequestNotPending
+	ErrorCodePaymentRequestExpired
+	ErrorCodeInvalidPaymentRequestExpiry
+	ErrorCodeInvalidStatusPaymentRequest
+)
+
+const (
+	// Expiry
+	ErrorCodeExpiryRange = 46001 + iota
+)
+
+const (
+	// Transfer batch
+	ErrorCodeTransferBatchNotFound = 47001 + iota
+	ErrorCodeInvalidTransferBatchMode
+	ErrorCodeTransferBatchSize
+	ErrorCodeBatchRecipientWalletNotFound
+	ErrorCodeInvalidTransferBatchID
+)
+
+const (
+	// Payment PIN
+	ErrorCodePaymentPinNotSet = 48001 + iota
+	ErrorCodePaymentPinAlreadySet
+	ErrorCodePaymentPinMismatch
+	ErrorCodePaymentPinLocked
+	ErrorCodeInvalidPaymentPin
+	ErrorCodeWeakPaymentPin
+)
+
+const (
+	// Group envelope
+	ErrorCodeEnvelopeGroupNotFound = 49001 + iota
+	ErrorCodeNotEnvelopeGroupMember
+	ErrorCodeTotalClaimerExceedsGroup
+)
+
+const (
+	// Exclusive envelope
+	ErrorCodeExclusiveRecipients = 50001 + iota
+	ErrorCodeInvalidEnvelopeSplitType
 )
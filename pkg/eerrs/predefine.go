@@ -2,6 +2,8 @@ package eerrs
 
 import (
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/1nterdigital/aka-im-tools/errs"
 )
@@ -39,6 +41,7 @@ var (
 	ErrWalletExisted          = errs.NewCodeError(ErrorCodeWalletExisted, "wallet already existed")
 	ErrInvalidStatusRequest   = errs.NewCodeError(ErrorCodeInvalidStatusRequest, "invalid status request deposit")
 	ErrCurrencyMismatch       = errs.NewCodeError(ErrorCodeCurrencyMismatch, "currency mismatch")
+	ErrInvalidCurrency        = errs.NewCodeError(ErrorCodeInvalidCurrency, "invalid currency")
 
 	// Envelope
 	ErrEnvelopeNotFound                = errs.NewCodeError(ErrorCodeEnvelopeNotFound, "envelope not found")
@@ -56,6 +59,7 @@ var (
 	ErrUserAlreadyClaimedThisEnvelope  = errs.NewCodeError(ErrorCodeUserAlreadyClaimedThisEnvelope, "user has already claimed this envelope")
 	ErrNoRemainingAmountToRefund       = errs.NewCodeError(ErrorCodeNoRemainingAmountToRefund, "no remaining amount to refund")
 	ErrEnvelopeIDNotFoundParam         = errs.NewCodeError(ErrorCodeEnvelopeIDNotFoundParam, "envelope ID not found in path param")
+	ErrAmountTooSmallToSplit           = errs.NewCodeError(ErrorCodeAmountTooSmallToSplit, "total amount is too small to split between claimers")
 
 	// transfer
 	ErrNoEligibleTransferRefund  = errs.NewCodeError(ErrorCodeNoEligibleTransferRefund, "transfer not eligible to refund")
@@ -74,16 +78,128 @@ var (
 	ErrNoEligibleTransfer        = errs.NewCodeError(ErrorCodeNoEligibleTransfer, "transfer not eligible")
 	ErrSendingTransferDailyLimit = errs.NewCodeError(ErrorCodeSendingTransferDailyLimit, "you have reached the daily transfer sending limit")
 	ErrClaimTransferDailyLimit   = errs.NewCodeError(ErrorCodeClaimTransferDailyLimit, "you have reached the daily transfer claiming limit")
+	ErrTransferNotCancelable     = errs.NewCodeError(ErrorCodeTransferNotCancelable, "transfer can no longer be canceled")
+
+	// idempotency
+	ErrInvalidIdempotencyKey    = errs.NewCodeError(ErrorCodeInvalidIdempotencyKey, "idempotency key is invalid")
+	ErrIdempotencyKeyConflict   = errs.NewCodeError(ErrorCodeIdempotencyKeyConflict, "idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errs.NewCodeError(ErrorCodeIdempotencyKeyInProgress, "a request with this idempotency key is still in progress")
+
+	// reconciliation
+	ErrReconciliationRunNotFound = errs.NewCodeError(ErrorCodeReconciliationRunNotFound, "reconciliation run not found")
+	ErrInvalidDiscrepancyType    = errs.NewCodeError(ErrorCodeInvalidDiscrepancyType, "invalid discrepancy type")
+
+	// wallet freeze
+	ErrWalletDebitFrozen     = errs.NewCodeError(ErrorCodeWalletDebitFrozen, "wallet is frozen for outgoing payments")
+	ErrWalletCreditFrozen    = errs.NewCodeError(ErrorCodeWalletCreditFrozen, "wallet is frozen for incoming payments")
+	ErrInvalidFreezeType     = errs.NewCodeError(ErrorCodeInvalidFreezeType, "invalid freeze type")
+	ErrWalletFreezeUnchanged = errs.NewCodeError(ErrorCodeWalletFreezeUnchanged, "wallet freeze status is unchanged")
+
+	// wallet hold
+	ErrWalletHoldNotFound  = errs.NewCodeError(ErrorCodeWalletHoldNotFound, "wallet hold not found")
+	ErrWalletHoldNotActive = errs.NewCodeError(ErrorCodeWalletHoldNotActive, "wallet hold is already released or captured")
+	ErrInvalidHoldAmount   = errs.NewCodeError(ErrorCodeInvalidHoldAmount, "hold amount must be positive")
+
+	// transaction reversal
+	ErrTransactionNotFound        = errs.NewCodeError(ErrorCodeTransactionNotFound, "wallet transaction not found")
+	ErrTransactionAlreadyReversed = errs.NewCodeError(ErrorCodeTransactionAlreadyReversed, "wallet transaction is already reversed")
+	ErrTransactionNotReversible   = errs.NewCodeError(ErrorCodeTransactionNotReversible, "wallet transaction cannot be reversed")
+
+	// withdrawal
+	ErrWithdrawalNotFound       = errs.NewCodeError(ErrorCodeWithdrawalNotFound, "withdrawal request not found")
+	ErrInvalidStatusWithdrawal  = errs.NewCodeError(ErrorCodeInvalidStatusWithdrawal, "invalid withdrawal status")
+	ErrWithdrawalStatusConflict = errs.NewCodeError(ErrorCodeWithdrawalStatusConflict, "withdrawal request is not in a status that allows this action")
+
+	// deposit request
+	ErrDepositNotFound       = errs.NewCodeError(ErrorCodeDepositNotFound, "deposit request not found")
+	ErrDepositStatusConflict = errs.NewCodeError(ErrorCodeDepositStatusConflict, "deposit request is no longer awaiting approval")
+
+	// maker-checker approval
+	ErrApprovalRequestNotFound  = errs.NewCodeError(ErrorCodeApprovalRequestNotFound, "approval request not found")
+	ErrApprovalStatusConflict   = errs.NewCodeError(ErrorCodeApprovalStatusConflict, "approval request is no longer pending")
+	ErrMakerCannotApprove       = errs.NewCodeError(ErrorCodeMakerCannotApprove, "an admin cannot approve their own request")
+	ErrInvalidStatusApproval    = errs.NewCodeError(ErrorCodeInvalidStatusApproval, "invalid approval status")
+	ErrInvalidApprovalOperation = errs.NewCodeError(ErrorCodeInvalidApprovalOperation, "invalid approval operation")
+
+	// limits
+	ErrLimitOverrideNotFound = errs.NewCodeError(ErrorCodeLimitOverrideNotFound, "limit override not found")
+	ErrInvalidLimitScope     = errs.NewCodeError(ErrorCodeInvalidLimitScope, "invalid limit scope")
+
+	// risk rules
+	ErrRiskDenied           = errs.NewCodeError(ErrorCodeRiskDenied, "the operation was declined by the risk rules")
+	ErrInvalidRiskDecision  = errs.NewCodeError(ErrorCodeInvalidRiskDecision, "invalid risk decision")
+	ErrInvalidRiskOperation = errs.NewCodeError(ErrorCodeInvalidRiskOperation, "invalid risk operation")
+
+	// scheduled transfer
+	ErrScheduledTransferNotFound      = errs.NewCodeError(ErrorCodeScheduledTransferNotFound, "scheduled transfer not found")
+	ErrScheduledTransferNotCancelable = errs.NewCodeError(ErrorCodeScheduledTransferNotCancelable, "scheduled transfer has already run")
+	ErrInvalidScheduleTime            = errs.NewCodeError(ErrorCodeInvalidScheduleTime, "execute time must be in the future")
+	ErrInvalidStatusScheduledTransfer = errs.NewCodeError(ErrorCodeInvalidStatusScheduledTransfer, "invalid scheduled transfer status")
+
+	// recurring transfer
+	ErrRecurringTransferNotFound       = errs.NewCodeError(ErrorCodeRecurringTransferNotFound, "recurring transfer not found")
+	ErrRecurringTransferStatusConflict = errs.NewCodeError(ErrorCodeRecurringTransferStatusConflict, "not allowed in the current status")
+	ErrInvalidRecurringFrequency       = errs.NewCodeError(ErrorCodeInvalidRecurringFrequency, "invalid recurring frequency")
+	ErrInvalidRecurringSchedule        = errs.NewCodeError(ErrorCodeInvalidRecurringSchedule, "invalid start or end time")
+	ErrInvalidStatusRecurringTransfer  = errs.NewCodeError(ErrorCodeInvalidStatusRecurringTransfer, "invalid recurring transfer status")
+
+	// payment request
+	ErrPaymentRequestNotFound      = errs.NewCodeError(ErrorCodePaymentRequestNotFound, "payment request not found")
+	ErrPaymentRequestNotPending    = errs.NewCodeError(ErrorCodePaymentRequestNotPending, "payment request was already paid or declined")
+	ErrPaymentRequestExpired       = errs.NewCodeError(ErrorCodePaymentRequestExpired, "payment request has expired")
+	ErrInvalidPaymentRequestExpiry = errs.NewCodeError(ErrorCodeInvalidPaymentRequestExpiry, "invalid payment request expiry")
+	ErrInvalidStatusPaymentRequest = errs.NewCodeError(ErrorCodeInvalidStatusPaymentRequest, "invalid payment request status")
+
+	// transfer batch
+	ErrTransferBatchNotFound    = errs.NewCodeError(ErrorCodeTransferBatchNotFound, "transfer batch not found")
+	ErrInvalidTransferBatchMode = errs.NewCodeError(ErrorCodeInvalidTransferBatchMode, "invalid transfer batch mode")
+	ErrInvalidTransferBatchID   = errs.NewCodeError(ErrorCodeInvalidTransferBatchID, "transfer batch id is invalid")
+
+	// payment pin
+	ErrPaymentPinNotSet     = errs.NewCodeError(ErrorCodePaymentPinNotSet, "payment pin is not set")
+	ErrPaymentPinAlreadySet = errs.NewCodeError(ErrorCodePaymentPinAlreadySet, "payment pin is already set")
+	ErrInvalidPaymentPin    = errs.NewCodeError(ErrorCodeInvalidPaymentPin, "payment pin must be 6 digits")
+	ErrWeakPaymentPin       = errs.NewCodeError(ErrorCodeWeakPaymentPin, "payment pin is too easy to guess")
+
+	// group envelope
+	ErrEnvelopeGroupNotFound  = errs.NewCodeError(ErrorCodeEnvelopeGroupNotFound, "group of the envelope not found")
+	ErrNotEnvelopeGroupMember = errs.NewCodeError(ErrorCodeNotEnvelopeGroupMember, "user is not a member of the envelope's group")
+
+	// exclusive envelope
+	ErrInvalidEnvelopeSplitType = errs.NewCodeError(ErrorCodeInvalidEnvelopeSplitType, "split type must be fixed or lucky")
 )
 
 func ErrUnsupportedAction(action string) (err error) {
 	return fmt.Errorf("unsupported description action: %s", action)
 }
 
-func ErrAmountRange(minAmount, maxAmount float64) (err error) {
+func ErrAmountRange(minAmount, maxAmount fmt.Stringer) (err error) {
 	return errs.NewCodeError(
 		ErrCodeRedEnvelopeAmountRange,
-		fmt.Sprintf("amount must be between %.2f and %.2f", minAmount, maxAmount),
+		fmt.Sprintf("amount must be between %s and %s", minAmount, maxAmount),
+	)
+}
+
+// ErrHourlySpendLimit, ErrDailySpendLimit and Err30DaySpendLimit report the amount the user may
+// still send before the rolling window frees up.
+func ErrHourlySpendLimit(remaining fmt.Stringer) (err error) {
+	return errs.NewCodeError(
+		ErrorCodeHourlySpendLimit,
+		fmt.Sprintf("hourly spending limit exceeded, remaining allowance: %s", remaining),
+	)
+}
+
+func ErrDailySpendLimit(remaining fmt.Stringer) (err error) {
+	return errs.NewCodeError(
+		ErrorCodeDailySpendLimit,
+		fmt.Sprintf("daily spending limit exceeded, remaining allowance: %s", remaining),
+	)
+}
+
+func Err30DaySpendLimit(remaining fmt.Stringer) (err error) {
+	return errs.NewCodeError(
+		ErrorCode30DaySpendLimit,
+		fmt.Sprintf("30-day spending limit exceeded, remaining allowance: %s", remaining),
 	)
 }
 
@@ -101,3 +217,57 @@ func ErrDeactivateDetails(inErr error) (err error) {
 func ErrRefund(envID int64, userID string, inErr error) (err error) {
 	return fmt.Errorf("[Refund] Failed refund. EnvelopeID=%d UserID=%s Error=%w", envID, userID, inErr)
 }
+
+// ErrExpiryRange reports the claim window, in minutes, a transfer or envelope may be given.
+func ErrExpiryRange(minMinutes, maxMinutes int64) (err error) {
+	return errs.NewCodeError(
+		ErrorCodeExpiryRange,
+		fmt.Sprintf("expiry must be between %d and %d minutes", minMinutes, maxMinutes),
+	)
+}
+
+func ErrTransferBatchSize(maxItems int) (err error) {
+	return errs.NewCodeError(
+		ErrorCodeTransferBatchSize,
+		fmt.Sprintf("a transfer batch takes between 1 and %d recipients", maxItems),
+	)
+}
+
+// ErrBatchRecipientWalletNotFound names the recipient of a batch without a wallet in the batch currency.
+func ErrBatchRecipientWalletNotFound(toUserID string) (err error) {
+	return errs.NewCodeError(
+		ErrorCodeBatchRecipientWalletNotFound,
+		fmt.Sprintf("recipient %s has no wallet in the batch currency", toUserID),
+	)
+}
+
+// ErrPaymentPinMismatch reports how many wrong PINs are left before the PIN is locked.
+func ErrPaymentPinMismatch(remaining int64) (err error) {
+	return errs.NewCodeError(
+		ErrorCodePaymentPinMismatch,
+		fmt.Sprintf("wrong payment pin, %d attempts left", remaining),
+	)
+}
+
+func ErrPaymentPinLocked(retryAfter time.Duration) (err error) {
+	return errs.NewCodeError(
+		ErrorCodePaymentPinLocked,
+		fmt.Sprintf("payment pin is locked, try again in %d minutes", int64(math.Ceil(retryAfter.Minutes()))),
+	)
+}
+
+// ErrTotalClaimerExceedsGroup rejects a group envelope split among more claimers than the group has members.
+func ErrTotalClaimerExceedsGroup(members int64) (err error) {
+	return errs.NewCodeError(
+		ErrorCodeTotalClaimerExceedsGroup,
+		fmt.Sprintf("total claimer must not exceed the %d members of the group", members),
+	)
+}
+
+// ErrExclusiveRecipients rejects an exclusive envelope without a usable list of recipients.
+func ErrExclusiveRecipients(maxRecipients int) (err error) {
+	return errs.NewCodeError(
+		ErrorCodeExclusiveRecipients,
+		fmt.Sprintf("an exclusive envelope takes between 1 and %d distinct recipients other than the sender", maxRecipients),
+	)
+}